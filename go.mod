@@ -1,11 +1,19 @@
 module github.com/Msksgm/go-IDDD-05-entity
 
-go 1.17
+go 1.22
 
 require (
-	github.com/google/go-cmp v0.5.7
-	github.com/google/uuid v1.3.0
-	golang.org/x/crypto v0.0.0-20220214200702-86341886e292
+	github.com/google/go-cmp v0.6.0
+	github.com/google/uuid v1.6.0
+	github.com/mattn/go-sqlite3 v1.14.24
+	golang.org/x/crypto v0.30.0
+	golang.org/x/text v0.21.0
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20241202173237-19429a94021a
+	google.golang.org/grpc v1.70.0
+	google.golang.org/protobuf v1.36.5
 )
 
-require golang.org/x/xerrors v0.0.0-20191204190536-9bdfabe68543 // indirect
+require (
+	golang.org/x/net v0.32.0 // indirect
+	golang.org/x/sys v0.28.0 // indirect
+)
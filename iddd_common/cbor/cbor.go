@@ -0,0 +1,231 @@
+package cbor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+const (
+	MAJOR_TYPE_UNSIGNED_INTEGER = 0
+	MAJOR_TYPE_NEGATIVE_INTEGER = 1
+	MAJOR_TYPE_BYTE_STRING      = 2
+	MAJOR_TYPE_TEXT_STRING      = 3
+	MAJOR_TYPE_ARRAY            = 4
+	MAJOR_TYPE_MAP              = 5
+	MAJOR_TYPE_SIMPLE           = 7
+)
+
+const maximumDepth = 16
+
+var ErrMalformed = errors.New("The CBOR data is malformed.")
+
+// Decode returns the first data item and the bytes following it. Only the definite-length subset of RFC 8949 that WebAuthn uses is supported;
+// integers decode to int64, maps to map[interface{}]interface{} and arrays to []interface{}.
+func Decode(aData []byte) (interface{}, []byte, error) {
+	return decode(aData, 0)
+}
+
+func decode(aData []byte, aDepth int) (interface{}, []byte, error) {
+	if aDepth > maximumDepth || len(aData) == 0 {
+		return nil, nil, ErrMalformed
+	}
+
+	majorType := aData[0] >> 5
+	argument, rest, err := decodeArgument(aData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch majorType {
+	case MAJOR_TYPE_UNSIGNED_INTEGER:
+		if argument > 1<<63-1 {
+			return nil, nil, ErrMalformed
+		}
+		return int64(argument), rest, nil
+	case MAJOR_TYPE_NEGATIVE_INTEGER:
+		if argument > 1<<63-1 {
+			return nil, nil, ErrMalformed
+		}
+		return -1 - int64(argument), rest, nil
+	case MAJOR_TYPE_BYTE_STRING, MAJOR_TYPE_TEXT_STRING:
+		if argument > uint64(len(rest)) {
+			return nil, nil, ErrMalformed
+		}
+		value := make([]byte, argument)
+		copy(value, rest[:argument])
+		if majorType == MAJOR_TYPE_TEXT_STRING {
+			return string(value), rest[argument:], nil
+		}
+		return value, rest[argument:], nil
+	case MAJOR_TYPE_ARRAY:
+		if argument > uint64(len(rest)) {
+			return nil, nil, ErrMalformed
+		}
+		array := make([]interface{}, 0, argument)
+		for i := uint64(0); i < argument; i++ {
+			var item interface{}
+			item, rest, err = decode(rest, aDepth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			array = append(array, item)
+		}
+		return array, rest, nil
+	case MAJOR_TYPE_MAP:
+		if argument > uint64(len(rest)) {
+			return nil, nil, ErrMalformed
+		}
+		m := make(map[interface{}]interface{}, argument)
+		for i := uint64(0); i < argument; i++ {
+			var key, value interface{}
+			key, rest, err = decode(rest, aDepth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			switch key.(type) {
+			case int64, string:
+			default:
+				return nil, nil, ErrMalformed
+			}
+			if _, ok := m[key]; ok {
+				return nil, nil, ErrMalformed
+			}
+			value, rest, err = decode(rest, aDepth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			m[key] = value
+		}
+		return m, rest, nil
+	case MAJOR_TYPE_SIMPLE:
+		switch aData[0] & 0x1f {
+		case 20:
+			return false, rest, nil
+		case 21:
+			return true, rest, nil
+		case 22:
+			return nil, rest, nil
+		}
+	}
+	return nil, nil, ErrMalformed
+}
+
+func decodeArgument(aData []byte) (uint64, []byte, error) {
+	additional := aData[0] & 0x1f
+	rest := aData[1:]
+
+	switch {
+	case additional < 24:
+		return uint64(additional), rest, nil
+	case additional == 24 && len(rest) >= 1:
+		return uint64(rest[0]), rest[1:], nil
+	case additional == 25 && len(rest) >= 2:
+		return uint64(binary.BigEndian.Uint16(rest)), rest[2:], nil
+	case additional == 26 && len(rest) >= 4:
+		return uint64(binary.BigEndian.Uint32(rest)), rest[4:], nil
+	case additional == 27 && len(rest) >= 8:
+		return binary.BigEndian.Uint64(rest), rest[8:], nil
+	}
+	return 0, nil, ErrMalformed
+}
+
+// Encode writes maps with their keys in bytewise lexical order, the CTAP2 canonical form.
+func Encode(aValue interface{}) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	if err := encode(buffer, aValue); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func encode(aBuffer *bytes.Buffer, aValue interface{}) error {
+	switch value := aValue.(type) {
+	case int:
+		encodeInteger(aBuffer, int64(value))
+	case int64:
+		encodeInteger(aBuffer, value)
+	case []byte:
+		encodeHead(aBuffer, MAJOR_TYPE_BYTE_STRING, uint64(len(value)))
+		aBuffer.Write(value)
+	case string:
+		encodeHead(aBuffer, MAJOR_TYPE_TEXT_STRING, uint64(len(value)))
+		aBuffer.WriteString(value)
+	case []interface{}:
+		encodeHead(aBuffer, MAJOR_TYPE_ARRAY, uint64(len(value)))
+		for _, item := range value {
+			if err := encode(aBuffer, item); err != nil {
+				return err
+			}
+		}
+	case map[interface{}]interface{}:
+		return encodeMap(aBuffer, value)
+	case bool:
+		if value {
+			aBuffer.WriteByte(MAJOR_TYPE_SIMPLE<<5 | 21)
+		} else {
+			aBuffer.WriteByte(MAJOR_TYPE_SIMPLE<<5 | 20)
+		}
+	case nil:
+		aBuffer.WriteByte(MAJOR_TYPE_SIMPLE<<5 | 22)
+	default:
+		return fmt.Errorf("cbor.Encode(): The type %T is not supported.", aValue)
+	}
+	return nil
+}
+
+func encodeMap(aBuffer *bytes.Buffer, aMap map[interface{}]interface{}) error {
+	type entry struct {
+		key   []byte
+		value interface{}
+	}
+	entries := make([]entry, 0, len(aMap))
+	for key, value := range aMap {
+		encodedKey, err := Encode(key)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry{key: encodedKey, value: value})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].key, entries[j].key) < 0
+	})
+
+	encodeHead(aBuffer, MAJOR_TYPE_MAP, uint64(len(entries)))
+	for _, entry := range entries {
+		aBuffer.Write(entry.key)
+		if err := encode(aBuffer, entry.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func encodeInteger(aBuffer *bytes.Buffer, aValue int64) {
+	if aValue < 0 {
+		encodeHead(aBuffer, MAJOR_TYPE_NEGATIVE_INTEGER, uint64(-1-aValue))
+		return
+	}
+	encodeHead(aBuffer, MAJOR_TYPE_UNSIGNED_INTEGER, uint64(aValue))
+}
+
+func encodeHead(aBuffer *bytes.Buffer, aMajorType byte, anArgument uint64) {
+	head := aMajorType << 5
+	switch {
+	case anArgument < 24:
+		aBuffer.WriteByte(head | byte(anArgument))
+	case anArgument <= 0xff:
+		aBuffer.Write([]byte{head | 24, byte(anArgument)})
+	case anArgument <= 0xffff:
+		aBuffer.WriteByte(head | 25)
+		binary.Write(aBuffer, binary.BigEndian, uint16(anArgument))
+	case anArgument <= 0xffffffff:
+		aBuffer.WriteByte(head | 26)
+		binary.Write(aBuffer, binary.BigEndian, uint32(anArgument))
+	default:
+		aBuffer.WriteByte(head | 27)
+		binary.Write(aBuffer, binary.BigEndian, anArgument)
+	}
+}
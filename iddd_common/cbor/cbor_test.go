@@ -0,0 +1,95 @@
+package cbor
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// examples of RFC 8949 appendix A
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		data string
+		want interface{}
+	}{
+		{data: "00", want: int64(0)},
+		{data: "17", want: int64(23)},
+		{data: "1818", want: int64(24)},
+		{data: "1903e8", want: int64(1000)},
+		{data: "1a000f4240", want: int64(1000000)},
+		{data: "20", want: int64(-1)},
+		{data: "3863", want: int64(-100)},
+		{data: "4401020304", want: []byte{1, 2, 3, 4}},
+		{data: "6449455446", want: "IETF"},
+		{data: "83010203", want: []interface{}{int64(1), int64(2), int64(3)}},
+		{data: "a201020304", want: map[interface{}]interface{}{int64(1): int64(2), int64(3): int64(4)}},
+		{data: "a26161016162820203", want: map[interface{}]interface{}{"a": int64(1), "b": []interface{}{int64(2), int64(3)}}},
+		{data: "f4", want: false},
+		{data: "f5", want: true},
+		{data: "f6", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			data, err := hex.DecodeString(tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, rest, err := Decode(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mismatch (-want, +got):\n%s", diff)
+			}
+			if len(rest) != 0 {
+				t.Errorf("got %d bytes rest, want 0", len(rest))
+			}
+
+			encoded, err := Encode(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if hex.EncodeToString(encoded) != tt.data {
+				t.Errorf("got %x, want %s", encoded, tt.data)
+			}
+		})
+	}
+}
+
+func TestDecodeRest(t *testing.T) {
+	got, rest, err := Decode([]byte{0x01, 0x02})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != int64(1) || len(rest) != 1 || rest[0] != 0x02 {
+		t.Errorf("got %v and rest %v", got, rest)
+	}
+}
+
+func TestDecodeMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "empty", data: ""},
+		{name: "truncated byte string", data: "4401"},
+		{name: "truncated array", data: "8301"},
+		{name: "array longer than data", data: "9bffffffffffffffff"},
+		{name: "duplicate map key", data: "a201020103"},
+		{name: "indefinite length", data: "5f"},
+		{name: "float", data: "f93c00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := hex.DecodeString(tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, _, err := Decode(data); err != ErrMalformed {
+				t.Errorf("got %v, want %v", err, ErrMalformed)
+			}
+		})
+	}
+}
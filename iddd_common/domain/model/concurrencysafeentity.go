@@ -0,0 +1,28 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrConcurrencyViolation = errors.New("Concurrency Violation: Stale data detected. Entity was already modified.")
+
+// ConcurrencySafeEntity carries the version a repository advances whenever it stores the entity.
+type ConcurrencySafeEntity struct {
+	concurrencyVersion int
+}
+
+func (concurrencySafeEntity *ConcurrencySafeEntity) ConcurrencyVersion() int {
+	return concurrencySafeEntity.concurrencyVersion
+}
+
+func (concurrencySafeEntity *ConcurrencySafeEntity) SetConcurrencyVersion(aVersion int) {
+	concurrencySafeEntity.concurrencyVersion = aVersion
+}
+
+func (concurrencySafeEntity *ConcurrencySafeEntity) FailWhenConcurrencyViolation(aVersion int) error {
+	if aVersion != concurrencySafeEntity.concurrencyVersion {
+		return fmt.Errorf("%w (version %d, expected %d)", ErrConcurrencyViolation, concurrencySafeEntity.concurrencyVersion, aVersion)
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package model
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConcurrencySafeEntity(t *testing.T) {
+	concurrencySafeEntity := &ConcurrencySafeEntity{}
+	concurrencySafeEntity.SetConcurrencyVersion(concurrencySafeEntity.ConcurrencyVersion() + 1)
+
+	if got := concurrencySafeEntity.ConcurrencyVersion(); got != 1 {
+		t.Errorf("got %d, want 1", got)
+	}
+	if err := concurrencySafeEntity.FailWhenConcurrencyViolation(1); err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+	if err := concurrencySafeEntity.FailWhenConcurrencyViolation(0); !errors.Is(err, ErrConcurrencyViolation) {
+		t.Errorf("got %v, want %v", err, ErrConcurrencyViolation)
+	}
+}
@@ -0,0 +1,8 @@
+package model
+
+import "time"
+
+type DomainEvent interface {
+	EventVersion() int
+	OccurredOn() time.Time
+}
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"errors"
+	"sync"
+)
+
+type DomainEventSubscriber interface {
+	HandleEvent(aDomainEvent DomainEvent) error
+}
+
+type DomainEventSubscriberFunc func(aDomainEvent DomainEvent) error
+
+func (domainEventSubscriberFunc DomainEventSubscriberFunc) HandleEvent(aDomainEvent DomainEvent) error {
+	return domainEventSubscriberFunc(aDomainEvent)
+}
+
+type DomainEventPublisher struct {
+	mu          sync.RWMutex
+	subscribers []DomainEventSubscriber
+}
+
+var domainEventPublisher = NewDomainEventPublisher()
+
+func DomainEventPublisherInstance() *DomainEventPublisher {
+	return domainEventPublisher
+}
+
+func NewDomainEventPublisher() *DomainEventPublisher {
+	return &DomainEventPublisher{subscribers: []DomainEventSubscriber{}}
+}
+
+func (domainEventPublisher *DomainEventPublisher) Subscribe(aSubscriber DomainEventSubscriber) {
+	domainEventPublisher.mu.Lock()
+	defer domainEventPublisher.mu.Unlock()
+
+	domainEventPublisher.subscribers = append(domainEventPublisher.subscribers, aSubscriber)
+}
+
+// Publish hands aDomainEvent to every subscriber, even after one fails, and joins their errors.
+func (domainEventPublisher *DomainEventPublisher) Publish(aDomainEvent DomainEvent) error {
+	domainEventPublisher.mu.RLock()
+	subscribers := make([]DomainEventSubscriber, len(domainEventPublisher.subscribers))
+	copy(subscribers, domainEventPublisher.subscribers)
+	domainEventPublisher.mu.RUnlock()
+
+	errs := []error{}
+	for _, subscriber := range subscribers {
+		errs = append(errs, subscriber.HandleEvent(aDomainEvent))
+	}
+	return errors.Join(errs...)
+}
+
+func (domainEventPublisher *DomainEventPublisher) Reset() {
+	domainEventPublisher.mu.Lock()
+	defer domainEventPublisher.mu.Unlock()
+
+	domainEventPublisher.subscribers = []DomainEventSubscriber{}
+}
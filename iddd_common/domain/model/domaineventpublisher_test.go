@@ -0,0 +1,80 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type testableDomainEvent struct {
+	occurredOn time.Time
+}
+
+func (testableDomainEvent testableDomainEvent) EventVersion() int {
+	return 1
+}
+
+func (testableDomainEvent testableDomainEvent) OccurredOn() time.Time {
+	return testableDomainEvent.occurredOn
+}
+
+func TestDomainEventPublisher(t *testing.T) {
+	t.Run("publish", func(t *testing.T) {
+		domainEventPublisher := NewDomainEventPublisher()
+		handled := []DomainEvent{}
+		domainEventPublisher.Subscribe(DomainEventSubscriberFunc(func(aDomainEvent DomainEvent) error {
+			handled = append(handled, aDomainEvent)
+			return nil
+		}))
+
+		domainEvent := testableDomainEvent{occurredOn: time.Now()}
+		if err := domainEventPublisher.Publish(domainEvent); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(handled) != 1 || handled[0] != domainEvent {
+			t.Errorf("got %v, want [%v]", handled, domainEvent)
+		}
+	})
+	t.Run("fail subscriber", func(t *testing.T) {
+		domainEventPublisher := NewDomainEventPublisher()
+		subscriberError := errors.New("unavailable")
+		handled := 0
+		domainEventPublisher.Subscribe(DomainEventSubscriberFunc(func(aDomainEvent DomainEvent) error {
+			return subscriberError
+		}))
+		domainEventPublisher.Subscribe(DomainEventSubscriberFunc(func(aDomainEvent DomainEvent) error {
+			handled++
+			return nil
+		}))
+
+		if err := domainEventPublisher.Publish(testableDomainEvent{occurredOn: time.Now()}); !errors.Is(err, subscriberError) {
+			t.Errorf("got %v, want %v", err, subscriberError)
+		}
+		if handled != 1 {
+			t.Errorf("got %d handled events, want 1", handled)
+		}
+	})
+	t.Run("reset", func(t *testing.T) {
+		domainEventPublisher := NewDomainEventPublisher()
+		handled := 0
+		domainEventPublisher.Subscribe(DomainEventSubscriberFunc(func(aDomainEvent DomainEvent) error {
+			handled++
+			return nil
+		}))
+		domainEventPublisher.Reset()
+
+		if err := domainEventPublisher.Publish(testableDomainEvent{occurredOn: time.Now()}); err != nil {
+			t.Fatal(err)
+		}
+
+		if handled != 0 {
+			t.Errorf("got %d handled events, want 0", handled)
+		}
+	})
+	t.Run("instance", func(t *testing.T) {
+		if DomainEventPublisherInstance() != DomainEventPublisherInstance() {
+			t.Errorf("DomainEventPublisherInstance must return the same publisher")
+		}
+	})
+}
@@ -0,0 +1,120 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// IdentityKind tells the identities of one kind apart from those of every other, so that one kind of identity cannot be
+// passed where another is expected. A kind is an empty struct; Identity only ever uses its zero value.
+type IdentityKind interface {
+	IdentityName() string
+	ValidateIdentity(anId string) error
+}
+
+// IdentityStrategy generates the values of new identities.
+type IdentityStrategy interface {
+	NextIdentity() (string, error)
+}
+
+type IdentityStrategyFunc func() (string, error)
+
+func (identityStrategyFunc IdentityStrategyFunc) NextIdentity() (string, error) {
+	return identityStrategyFunc()
+}
+
+// Identity is the value of an identity of kind K. The zero value is the absent identity; every other value has been validated by K.
+type Identity[K IdentityKind] struct {
+	id string
+}
+
+// NewIdentity returns the error of K as it is, so that the constructor of each concrete identity wraps it under its own name.
+func NewIdentity[K IdentityKind](anId string) (Identity[K], error) {
+	var kind K
+	if err := kind.ValidateIdentity(anId); err != nil {
+		return Identity[K]{}, err
+	}
+	return Identity[K]{id: anId}, nil
+}
+
+// NextIdentity validates what aStrategy generates, so that a strategy cannot produce an identity K rejects.
+func NextIdentity[K IdentityKind](aStrategy IdentityStrategy) (Identity[K], error) {
+	anId, err := aStrategy.NextIdentity()
+	if err != nil {
+		return Identity[K]{}, err
+	}
+	return NewIdentity[K](anId)
+}
+
+func (identity Identity[K]) Id() string {
+	return identity.id
+}
+
+func (identity Identity[K]) IsZero() bool {
+	return identity.id == ""
+}
+
+func (identity Identity[K]) Equals(anOther *Identity[K]) bool {
+	return anOther != nil && identity.id == anOther.id
+}
+
+func (identity Identity[K]) String() string {
+	var kind K
+	return fmt.Sprintf("%s [id= %s ]", kind.IdentityName(), identity.id)
+}
+
+func (identity Identity[K]) MarshalText() ([]byte, error) {
+	return []byte(identity.id), nil
+}
+
+func (identity *Identity[K]) UnmarshalText(aText []byte) error {
+	unmarshaled, err := NewIdentity[K](string(aText))
+	if err != nil {
+		return err
+	}
+	*identity = unmarshaled
+	return nil
+}
+
+// MarshalJSON writes the absent identity as null rather than as an empty string, which would not unmarshal.
+func (identity Identity[K]) MarshalJSON() ([]byte, error) {
+	if identity.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(identity.id)
+}
+
+func (identity *Identity[K]) UnmarshalJSON(aData []byte) error {
+	if string(aData) == "null" {
+		*identity = Identity[K]{}
+		return nil
+	}
+	var anId string
+	if err := json.Unmarshal(aData, &anId); err != nil {
+		return err
+	}
+	return identity.UnmarshalText([]byte(anId))
+}
+
+// Value stores the absent identity as NULL.
+func (identity Identity[K]) Value() (driver.Value, error) {
+	if identity.IsZero() {
+		return nil, nil
+	}
+	return identity.id, nil
+}
+
+func (identity *Identity[K]) Scan(aSource interface{}) error {
+	switch source := aSource.(type) {
+	case nil:
+		*identity = Identity[K]{}
+		return nil
+	case string:
+		return identity.UnmarshalText([]byte(source))
+	case []byte:
+		return identity.UnmarshalText(source)
+	}
+	var kind K
+	return fmt.Errorf("Cannot scan %T into %s.", aSource, kind.IdentityName())
+}
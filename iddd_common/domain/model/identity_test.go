@@ -0,0 +1,198 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type testIdKind struct{}
+
+func (kind testIdKind) IdentityName() string {
+	return "TestId"
+}
+
+func (kind testIdKind) ValidateIdentity(anId string) error {
+	return UUIDVersions{}.Validate(anId)
+}
+
+type testId = Identity[testIdKind]
+
+const testUuid = "5b0dfa6a-4a24-4b41-9b2e-6e5d2c4f43d1"
+
+func TestNewIdentity(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		got, err := NewIdentity[testIdKind](testUuid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Id() != testUuid || got.IsZero() {
+			t.Errorf("got %v, want %s", got, testUuid)
+		}
+		if want := "TestId [id= " + testUuid + " ]"; got.String() != want {
+			t.Errorf("got %s, want %s", got.String(), want)
+		}
+	})
+	t.Run("fail invalid UUID", func(t *testing.T) {
+		if _, err := NewIdentity[testIdKind]("UUID"); err == nil {
+			t.Error("got nil, want an error")
+		}
+	})
+}
+
+func TestNextIdentity(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		strategy := NewUUIDv7Generator()
+		got, err := NextIdentity[testIdKind](strategy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		other, err := NextIdentity[testIdKind](strategy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Equals(&other) {
+			t.Errorf("got %v twice", got)
+		}
+	})
+	t.Run("fail strategy generates invalid identity", func(t *testing.T) {
+		strategy := IdentityStrategyFunc(func() (string, error) { return "not-a-uuid", nil })
+		if _, err := NextIdentity[testIdKind](strategy); err == nil {
+			t.Error("got nil, want an error")
+		}
+	})
+	t.Run("fail strategy error", func(t *testing.T) {
+		strategyError := errors.New("exhausted")
+		strategy := IdentityStrategyFunc(func() (string, error) { return "", strategyError })
+		if _, err := NextIdentity[testIdKind](strategy); !errors.Is(err, strategyError) {
+			t.Errorf("got %v, want %v", err, strategyError)
+		}
+	})
+}
+
+func TestIdentityEquals(t *testing.T) {
+	identity, err := NewIdentity[testIdKind](testUuid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewIdentity[testIdKind](testUuid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !identity.Equals(&other) {
+		t.Errorf("identity: %v must be equal to other: %v", identity, other)
+	}
+	if identity.Equals(nil) {
+		t.Errorf("identity: %v must not be equal to nil", identity)
+	}
+}
+
+func TestIdentityJSON(t *testing.T) {
+	type holder struct {
+		Id       testId   `json:"id"`
+		ParentId testId   `json:"parentId"`
+		Ids      []testId `json:"ids"`
+	}
+	identity, err := NewIdentity[testIdKind](testUuid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(holder{Id: identity, Ids: []testId{identity}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"id":"` + testUuid + `","parentId":null,"ids":["` + testUuid + `"]}`
+	if string(data) != want {
+		t.Errorf("got %s, want %s", data, want)
+	}
+
+	var got holder
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(holder{Id: identity, Ids: []testId{identity}}, got, cmp.AllowUnexported(testId{})); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+
+	if err := json.Unmarshal([]byte(`{"id":"UUID"}`), &got); err == nil {
+		t.Error("got nil, want an error")
+	}
+}
+
+func TestIdentityText(t *testing.T) {
+	identity, err := NewIdentity[testIdKind](testUuid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	text, err := identity.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got testId
+	if err := got.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equals(&identity) {
+		t.Errorf("got %v, want %v", got, identity)
+	}
+	if err := got.UnmarshalText([]byte("UUID")); err == nil {
+		t.Error("got nil, want an error")
+	}
+	if !got.Equals(&identity) {
+		t.Errorf("got %v, want it unchanged by a failed unmarshal", got)
+	}
+}
+
+func TestIdentitySQL(t *testing.T) {
+	identity, err := NewIdentity[testIdKind](testUuid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		source  interface{}
+		want    testId
+		wantErr bool
+	}{
+		{name: "string", source: testUuid, want: identity},
+		{name: "bytes", source: []byte(testUuid), want: identity},
+		{name: "null", source: nil, want: testId{}},
+		{name: "fail invalid UUID", source: "UUID", wantErr: true},
+		{name: "fail unsupported type", source: 42, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got testId
+			err := got.Scan(tt.source)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got %v, want error: %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(testId{})); diff != "" {
+				t.Errorf("mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+
+	for _, tt := range []struct {
+		identity testId
+		want     driver.Value
+	}{
+		{identity: identity, want: testUuid},
+		{identity: testId{}, want: nil},
+	} {
+		got, err := tt.identity.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("got %v, want %v", got, tt.want)
+		}
+	}
+}
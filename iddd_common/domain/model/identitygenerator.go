@@ -0,0 +1,138 @@
+package model
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNilUUID                = errors.New("The nil UUID is not an identity.")
+	ErrUUIDVersionNotAccepted = errors.New("The UUID version is not accepted.")
+)
+
+const (
+	crockfordBase32   = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	uuidV7EntropyBits = 74
+	ulidEntropyBits   = 80
+)
+
+// UUIDVersions accepts the UUIDs of the listed versions only, or any UUID but the nil UUID when it lists none.
+type UUIDVersions []uuid.Version
+
+func (uuidVersions UUIDVersions) Validate(anId string) error {
+	uuId, err := uuid.Parse(anId)
+	if err != nil {
+		return err
+	}
+	if uuId == uuid.Nil {
+		return ErrNilUUID
+	}
+	if len(uuidVersions) == 0 {
+		return nil
+	}
+	for _, version := range uuidVersions {
+		if uuId.Version() == version {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w (version %d, expected %v)", ErrUUIDVersionNotAccepted, uuId.Version(), []uuid.Version(uuidVersions))
+}
+
+// IdentityGenerator is an IdentityStrategy whose identities sort in the order they were generated, so that indexes stay
+// compact and events read in order. Within a millisecond it increments the random part of the previous identity rather
+// than drawing a new one; when that overflows, it moves on to the next millisecond.
+type IdentityGenerator struct {
+	mutex        sync.Mutex
+	entropyBits  int
+	encode       func(aMilliseconds int64, anEntropyHigh uint16, anEntropyLow uint64) string
+	clock        func() time.Time
+	random       io.Reader
+	milliseconds int64
+	entropyHigh  uint16
+	entropyLow   uint64
+}
+
+// NewUUIDv7Generator generates version 7 UUIDs: a millisecond timestamp followed by 74 random bits.
+func NewUUIDv7Generator() *IdentityGenerator {
+	return &IdentityGenerator{entropyBits: uuidV7EntropyBits, encode: encodeUUIDv7, clock: time.Now, random: rand.Reader}
+}
+
+// NewULIDGenerator generates ULIDs: a millisecond timestamp followed by 80 random bits, in 26 characters of Crockford's base32.
+func NewULIDGenerator() *IdentityGenerator {
+	return &IdentityGenerator{entropyBits: ulidEntropyBits, encode: encodeULID, clock: time.Now, random: rand.Reader}
+}
+
+func (identityGenerator *IdentityGenerator) NextIdentity() (string, error) {
+	identityGenerator.mutex.Lock()
+	defer identityGenerator.mutex.Unlock()
+
+	// a clock that went backwards is treated as the same millisecond, so that the order holds
+	if milliseconds := identityGenerator.clock().UnixMilli(); milliseconds > identityGenerator.milliseconds {
+		identityGenerator.milliseconds = milliseconds
+		if err := identityGenerator.drawEntropy(); err != nil {
+			return "", err
+		}
+	} else if !identityGenerator.incrementEntropy() {
+		identityGenerator.milliseconds++
+		if err := identityGenerator.drawEntropy(); err != nil {
+			return "", err
+		}
+	}
+
+	return identityGenerator.encode(identityGenerator.milliseconds, identityGenerator.entropyHigh, identityGenerator.entropyLow), nil
+}
+
+func (identityGenerator *IdentityGenerator) drawEntropy() error {
+	var entropy [10]byte
+	if _, err := io.ReadFull(identityGenerator.random, entropy[:]); err != nil {
+		return err
+	}
+	identityGenerator.entropyHigh = binary.BigEndian.Uint16(entropy[:2]) & identityGenerator.maximumEntropyHigh()
+	identityGenerator.entropyLow = binary.BigEndian.Uint64(entropy[2:])
+	return nil
+}
+
+// incrementEntropy reports false, leaving the entropy as it is, when the entropy is already at its maximum.
+func (identityGenerator *IdentityGenerator) incrementEntropy() bool {
+	if identityGenerator.entropyLow == ^uint64(0) {
+		if identityGenerator.entropyHigh == identityGenerator.maximumEntropyHigh() {
+			return false
+		}
+		identityGenerator.entropyHigh++
+	}
+	identityGenerator.entropyLow++
+	return true
+}
+
+func (identityGenerator *IdentityGenerator) maximumEntropyHigh() uint16 {
+	return uint16(1<<(identityGenerator.entropyBits-64) - 1)
+}
+
+func encodeUUIDv7(aMilliseconds int64, anEntropyHigh uint16, anEntropyLow uint64) string {
+	var uuId uuid.UUID
+	binary.BigEndian.PutUint64(uuId[:8], uint64(aMilliseconds)<<16)
+	randA := anEntropyHigh<<2 | uint16(anEntropyLow>>62)
+	binary.BigEndian.PutUint16(uuId[6:8], 0x7000|randA&0x0fff)
+	binary.BigEndian.PutUint64(uuId[8:], 0x8000000000000000|anEntropyLow&0x3fffffffffffffff)
+	return uuId.String()
+}
+
+func encodeULID(aMilliseconds int64, anEntropyHigh uint16, anEntropyLow uint64) string {
+	// the 128 bits are encoded from the least significant end, five at a time, into 26 characters
+	high := uint64(aMilliseconds)<<16 | uint64(anEntropyHigh)
+	low := anEntropyLow
+	ulid := make([]byte, 26)
+	for i := len(ulid) - 1; i >= 0; i-- {
+		ulid[i] = crockfordBase32[low&0x1f]
+		low = low>>5 | high<<59
+		high >>= 5
+	}
+	return string(ulid)
+}
@@ -0,0 +1,182 @@
+package model
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestUUIDVersionsValidate(t *testing.T) {
+	tests := []struct {
+		name         string
+		uuidVersions UUIDVersions
+		id           string
+		wantErr      error
+	}{
+		{name: "success v4", uuidVersions: UUIDVersions{4, 7}, id: uuid.NewString()},
+		{name: "success v7", uuidVersions: UUIDVersions{4, 7}, id: uuid.Must(uuid.NewV7()).String()},
+		{name: "success any version", uuidVersions: UUIDVersions{}, id: uuid.NewMD5(uuid.NameSpaceURL, []byte("iddd")).String()},
+		{name: "fail v3", uuidVersions: UUIDVersions{4, 7}, id: uuid.NewMD5(uuid.NameSpaceURL, []byte("iddd")).String(), wantErr: ErrUUIDVersionNotAccepted},
+		{name: "fail nil UUID", uuidVersions: UUIDVersions{4, 7}, id: uuid.Nil.String(), wantErr: ErrNilUUID},
+		{name: "fail nil UUID of any version", uuidVersions: UUIDVersions{}, id: uuid.Nil.String(), wantErr: ErrNilUUID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.uuidVersions.Validate(tt.id); !errors.Is(err, tt.wantErr) {
+				t.Errorf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+	t.Run("fail malformed", func(t *testing.T) {
+		if err := (UUIDVersions{}).Validate("UUID"); err == nil {
+			t.Error("got nil, want an error")
+		}
+	})
+}
+
+func fixedClock(aTime time.Time) func() time.Time {
+	return func() time.Time { return aTime }
+}
+
+func TestUUIDv7Generator(t *testing.T) {
+	now := time.UnixMilli(1700000000123)
+	identityGenerator := NewUUIDv7Generator()
+	identityGenerator.clock = fixedClock(now)
+
+	ids := make([]string, 1000)
+	for i := range ids {
+		id, err := identityGenerator.NextIdentity()
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[i] = id
+	}
+
+	if !sort.StringsAreSorted(ids) {
+		t.Errorf("got %v, want them in the order they were generated", ids)
+	}
+	for _, id := range ids {
+		uuId, err := uuid.Parse(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if uuId.Version() != 7 || uuId.Variant() != uuid.RFC4122 {
+			t.Errorf("got version %v and variant %v of %s, want 7 and RFC4122", uuId.Version(), uuId.Variant(), id)
+		}
+		if sec, nsec := uuId.Time().UnixTime(); time.Unix(sec, nsec).UnixMilli() != now.UnixMilli() {
+			t.Errorf("got %v of %s, want %v", time.Unix(sec, nsec), id, now)
+		}
+	}
+	if ids[0] == ids[1] {
+		t.Errorf("got %s twice", ids[0])
+	}
+}
+
+func TestULIDGenerator(t *testing.T) {
+	identityGenerator := NewULIDGenerator()
+	identityGenerator.clock = fixedClock(time.UnixMilli(1700000000123))
+
+	ids := make([]string, 1000)
+	for i := range ids {
+		id, err := identityGenerator.NextIdentity()
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[i] = id
+	}
+
+	if !sort.StringsAreSorted(ids) {
+		t.Errorf("got %v, want them in the order they were generated", ids)
+	}
+	for _, id := range ids {
+		if len(id) != 26 || strings.Trim(id, crockfordBase32) != "" {
+			t.Errorf("got %s, want 26 characters of Crockford's base32", id)
+		}
+		if !strings.HasPrefix(id, "01HF7YAT") {
+			t.Errorf("got %s, want the timestamp 01HF7YAT...", id)
+		}
+	}
+}
+
+func TestIdentityGeneratorEncoding(t *testing.T) {
+	identityGenerator := NewULIDGenerator()
+	identityGenerator.clock = fixedClock(time.UnixMilli(0x0123456789ab))
+	identityGenerator.random = bytes.NewReader(bytes.Repeat([]byte{0xff}, 10))
+
+	got, err := identityGenerator.NextIdentity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "014D2PF2DBZZZZZZZZZZZZZZZZ"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	identityGenerator = NewUUIDv7Generator()
+	identityGenerator.clock = fixedClock(time.UnixMilli(0x0123456789ab))
+	identityGenerator.random = bytes.NewReader(bytes.Repeat([]byte{0xff}, 10))
+
+	got, err = identityGenerator.NextIdentity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "01234567-89ab-7fff-bfff-ffffffffffff"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestIdentityGeneratorMonotonicity(t *testing.T) {
+	t.Run("overflow moves on to the next millisecond", func(t *testing.T) {
+		now := time.UnixMilli(1700000000123)
+		identityGenerator := NewUUIDv7Generator()
+		identityGenerator.clock = fixedClock(now)
+		identityGenerator.random = bytes.NewReader(bytes.Repeat([]byte{0xff}, 20))
+
+		first, err := identityGenerator.NextIdentity()
+		if err != nil {
+			t.Fatal(err)
+		}
+		second, err := identityGenerator.NextIdentity()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if second <= first {
+			t.Errorf("got %s after %s, want it to sort after", second, first)
+		}
+		if sec, nsec := uuid.Must(uuid.Parse(second)).Time().UnixTime(); time.Unix(sec, nsec).UnixMilli() != now.UnixMilli()+1 {
+			t.Errorf("got %v of %s, want the millisecond after %v", time.Unix(sec, nsec), second, now)
+		}
+	})
+	t.Run("clock going backwards", func(t *testing.T) {
+		now := time.UnixMilli(1700000000123)
+		identityGenerator := NewULIDGenerator()
+		identityGenerator.clock = fixedClock(now)
+
+		first, err := identityGenerator.NextIdentity()
+		if err != nil {
+			t.Fatal(err)
+		}
+		identityGenerator.clock = fixedClock(now.Add(-time.Second))
+		second, err := identityGenerator.NextIdentity()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if second <= first {
+			t.Errorf("got %s after %s, want it to sort after", second, first)
+		}
+	})
+	t.Run("fail random", func(t *testing.T) {
+		identityGenerator := NewUUIDv7Generator()
+		identityGenerator.random = bytes.NewReader(nil)
+
+		if _, err := identityGenerator.NextIdentity(); err == nil {
+			t.Error("got nil, want an error")
+		}
+	})
+}
@@ -0,0 +1,188 @@
+package ierrors
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// AssertionKind names the assertion that failed, in the form the problem types and rpc reasons are derived from.
+type AssertionKind string
+
+const (
+	ASSERTION_KIND_ARGUMENT_EQUALS     AssertionKind = "argument-equals"
+	ASSERTION_KIND_ARGUMENT_NOT_EQUALS AssertionKind = "argument-not-equals"
+	ASSERTION_KIND_ARGUMENT_NOT_NULL   AssertionKind = "argument-not-null"
+	ASSERTION_KIND_ARGUMENT_NOT_EMPTY  AssertionKind = "argument-not-empty"
+	ASSERTION_KIND_ARGUMENT_LENGTH     AssertionKind = "argument-length"
+	ASSERTION_KIND_ARGUMENT_RANGE      AssertionKind = "argument-range"
+	ASSERTION_KIND_ARGUMENT_MATCHES    AssertionKind = "argument-matches"
+	ASSERTION_KIND_ARGUMENT_TRUE       AssertionKind = "argument-true"
+	ASSERTION_KIND_ARGUMENT_FALSE      AssertionKind = "argument-false"
+	ASSERTION_KIND_STATE_TRUE          AssertionKind = "state-true"
+	ASSERTION_KIND_STATE_FALSE         AssertionKind = "state-false"
+)
+
+// IsState tells the assertions on the state of an object apart from those on the arguments it was given.
+func (assertionKind AssertionKind) IsState() bool {
+	return strings.HasPrefix(string(assertionKind), "state-")
+}
+
+// AssertionError is the single error type of every AssertionConcern assertion. The constraint is empty for assertions without one, such as ArgumentNotNull.
+type AssertionError struct {
+	kind       AssertionKind
+	field      string
+	constraint string
+	message    string
+	code       Code
+	key        MessageKey
+	cause      error
+}
+
+func (assertionError *AssertionError) Kind() AssertionKind {
+	return assertionError.kind
+}
+
+func (assertionError *AssertionError) Field() string {
+	return assertionError.field
+}
+
+func (assertionError *AssertionError) Constraint() string {
+	return assertionError.constraint
+}
+
+func (assertionError *AssertionError) Error() string {
+	return assertionError.message
+}
+
+func (assertionError *AssertionError) Unwrap() error {
+	return assertionError.cause
+}
+
+// AssertionConcern follows the AssertionConcern of IDDD. Every error it returns names its field, which is empty for assertions on the object as a whole.
+type AssertionConcern struct {
+	field string
+	code  Code
+	key   MessageKey
+	cause error
+}
+
+func NewAssertionConcern(aField string) AssertionConcern {
+	return AssertionConcern{field: aField}
+}
+
+// WithCause makes the errors of the assertions made through the returned AssertionConcern wrap aCause, such as a sentinel error
+// callers match with errors.Is, and take its code unless WithCode gives another.
+func (assertionConcern AssertionConcern) WithCause(aCause error) AssertionConcern {
+	assertionConcern.cause = aCause
+	return assertionConcern
+}
+
+func (assertionConcern AssertionConcern) ArgumentEquals(anObject interface{}, otherObject interface{}, aMessage string) error {
+	if !reflect.DeepEqual(anObject, otherObject) {
+		return assertionConcern.fail(ASSERTION_KIND_ARGUMENT_EQUALS, fmt.Sprintf("%v", otherObject), aMessage)
+	}
+	return nil
+}
+
+func (assertionConcern AssertionConcern) ArgumentNotEquals(anObject interface{}, otherObject interface{}, aMessage string) error {
+	if reflect.DeepEqual(anObject, otherObject) {
+		return assertionConcern.fail(ASSERTION_KIND_ARGUMENT_NOT_EQUALS, fmt.Sprintf("%v", otherObject), aMessage)
+	}
+	return nil
+}
+
+// ArgumentNotNull also fails for a nil pointer, map, slice, channel or function held in a non-nil interface.
+func (assertionConcern AssertionConcern) ArgumentNotNull(anObject interface{}, aMessage string) error {
+	if isNull(anObject) {
+		return assertionConcern.fail(ASSERTION_KIND_ARGUMENT_NOT_NULL, "", aMessage)
+	}
+	return nil
+}
+
+func (assertionConcern AssertionConcern) ArgumentNotEmpty(aString string, aMessage string) error {
+	if strings.TrimSpace(aString) == "" {
+		return assertionConcern.fail(ASSERTION_KIND_ARGUMENT_NOT_EMPTY, "", aMessage)
+	}
+	return nil
+}
+
+// ArgumentLength counts runes, as ArgumentLengthError does by default.
+func (assertionConcern AssertionConcern) ArgumentLength(aString string, aMinimum int, aMaximum int, aMessage string) error {
+	return assertionConcern.ArgumentLengthWithPolicy(aString, aMinimum, aMaximum, LengthPolicy{}, aMessage)
+}
+
+func (assertionConcern AssertionConcern) ArgumentLengthWithPolicy(aString string, aMinimum int, aMaximum int, aPolicy LengthPolicy, aMessage string) error {
+	if length := aPolicy.Length(aString); length < aMinimum || length > aMaximum {
+		return assertionConcern.fail(ASSERTION_KIND_ARGUMENT_LENGTH, fmt.Sprintf("%d..%d", aMinimum, aMaximum), aMessage)
+	}
+	return nil
+}
+
+func (assertionConcern AssertionConcern) ArgumentRange(aValue int, aMinimum int, aMaximum int, aMessage string) error {
+	if aValue < aMinimum || aValue > aMaximum {
+		return assertionConcern.fail(ASSERTION_KIND_ARGUMENT_RANGE, fmt.Sprintf("%d..%d", aMinimum, aMaximum), aMessage)
+	}
+	return nil
+}
+
+// ArgumentFloatRange fails for NaN, which lies in no range.
+func (assertionConcern AssertionConcern) ArgumentFloatRange(aValue float64, aMinimum float64, aMaximum float64, aMessage string) error {
+	if !(aValue >= aMinimum && aValue <= aMaximum) {
+		return assertionConcern.fail(ASSERTION_KIND_ARGUMENT_RANGE, fmt.Sprintf("%g..%g", aMinimum, aMaximum), aMessage)
+	}
+	return nil
+}
+
+// ArgumentMatches takes a compiled pattern so that callers compile it once rather than on every assertion.
+func (assertionConcern AssertionConcern) ArgumentMatches(aString string, aPattern *regexp.Regexp, aMessage string) error {
+	if !aPattern.MatchString(aString) {
+		return assertionConcern.fail(ASSERTION_KIND_ARGUMENT_MATCHES, aPattern.String(), aMessage)
+	}
+	return nil
+}
+
+func (assertionConcern AssertionConcern) ArgumentTrue(aBool bool, aMessage string) error {
+	if !aBool {
+		return assertionConcern.fail(ASSERTION_KIND_ARGUMENT_TRUE, "", aMessage)
+	}
+	return nil
+}
+
+func (assertionConcern AssertionConcern) ArgumentFalse(aBool bool, aMessage string) error {
+	if aBool {
+		return assertionConcern.fail(ASSERTION_KIND_ARGUMENT_FALSE, "", aMessage)
+	}
+	return nil
+}
+
+func (assertionConcern AssertionConcern) StateTrue(aBool bool, aMessage string) error {
+	if !aBool {
+		return assertionConcern.fail(ASSERTION_KIND_STATE_TRUE, "", aMessage)
+	}
+	return nil
+}
+
+func (assertionConcern AssertionConcern) StateFalse(aBool bool, aMessage string) error {
+	if aBool {
+		return assertionConcern.fail(ASSERTION_KIND_STATE_FALSE, "", aMessage)
+	}
+	return nil
+}
+
+func (assertionConcern AssertionConcern) fail(aKind AssertionKind, aConstraint string, aMessage string) *AssertionError {
+	return &AssertionError{kind: aKind, field: assertionConcern.field, constraint: aConstraint, message: aMessage, code: assertionConcern.code, key: assertionConcern.key, cause: assertionConcern.cause}
+}
+
+func isNull(anObject interface{}) bool {
+	if anObject == nil {
+		return true
+	}
+	value := reflect.ValueOf(anObject)
+	switch value.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+		return value.IsNil()
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package ierrors
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"testing"
+)
+
+func TestAssertionConcern(t *testing.T) {
+	assertionConcern := NewAssertionConcern("name")
+	var nilPointer *AssertionError
+	pattern := regexp.MustCompile(`^[a-z]+$`)
+
+	tests := []struct {
+		name           string
+		err            error
+		wantKind       AssertionKind
+		wantConstraint string
+	}{
+		{name: "success equals", err: assertionConcern.ArgumentEquals([]string{"a"}, []string{"a"}, "The values must be equal.")},
+		{name: "fail equals", err: assertionConcern.ArgumentEquals("a", "b", "The values must be equal."), wantKind: ASSERTION_KIND_ARGUMENT_EQUALS, wantConstraint: "b"},
+		{name: "success not equals", err: assertionConcern.ArgumentNotEquals("a", "b", "The values must differ.")},
+		{name: "fail not equals", err: assertionConcern.ArgumentNotEquals(1, 1, "The values must differ."), wantKind: ASSERTION_KIND_ARGUMENT_NOT_EQUALS, wantConstraint: "1"},
+		{name: "success not null", err: assertionConcern.ArgumentNotNull(0, "The value is required.")},
+		{name: "fail not null", err: assertionConcern.ArgumentNotNull(nil, "The value is required."), wantKind: ASSERTION_KIND_ARGUMENT_NOT_NULL},
+		{name: "fail not null nil pointer", err: assertionConcern.ArgumentNotNull(nilPointer, "The value is required."), wantKind: ASSERTION_KIND_ARGUMENT_NOT_NULL},
+		{name: "success not empty", err: assertionConcern.ArgumentNotEmpty("a", "The value is required.")},
+		{name: "fail not empty", err: assertionConcern.ArgumentNotEmpty(" ", "The value is required."), wantKind: ASSERTION_KIND_ARGUMENT_NOT_EMPTY},
+		{name: "success length", err: assertionConcern.ArgumentLength("abc", 3, 3, "The value must be 3 characters.")},
+		{name: "fail length", err: assertionConcern.ArgumentLength("ab", 3, 5, "The value must be 3 to 5 characters."), wantKind: ASSERTION_KIND_ARGUMENT_LENGTH, wantConstraint: "3..5"},
+		{name: "success length with policy", err: assertionConcern.ArgumentLengthWithPolicy("山田太", 3, 3, NewLengthPolicy(NORMALIZATION_FORM_NONE, LENGTH_MODE_RUNES), "The value must be 3 characters.")},
+		{name: "fail length with policy", err: assertionConcern.ArgumentLengthWithPolicy("山田太", 3, 5, NewLengthPolicy(NORMALIZATION_FORM_NONE, LENGTH_MODE_DISPLAY_WIDTH), "The value must be 3 to 5 columns."), wantKind: ASSERTION_KIND_ARGUMENT_LENGTH, wantConstraint: "3..5"},
+		{name: "success range", err: assertionConcern.ArgumentRange(10, 1, 10, "The value must be 1 to 10.")},
+		{name: "fail range", err: assertionConcern.ArgumentRange(0, 1, 10, "The value must be 1 to 10."), wantKind: ASSERTION_KIND_ARGUMENT_RANGE, wantConstraint: "1..10"},
+		{name: "success float range", err: assertionConcern.ArgumentFloatRange(0.5, 0, 1, "The value must be 0 to 1.")},
+		{name: "fail float range", err: assertionConcern.ArgumentFloatRange(1.5, 0, 1, "The value must be 0 to 1."), wantKind: ASSERTION_KIND_ARGUMENT_RANGE, wantConstraint: "0..1"},
+		{name: "fail float range NaN", err: assertionConcern.ArgumentFloatRange(math.NaN(), 0, 1, "The value must be 0 to 1."), wantKind: ASSERTION_KIND_ARGUMENT_RANGE, wantConstraint: "0..1"},
+		{name: "success matches", err: assertionConcern.ArgumentMatches("abc", pattern, "The value must be lower case letters.")},
+		{name: "fail matches", err: assertionConcern.ArgumentMatches("ABC", pattern, "The value must be lower case letters."), wantKind: ASSERTION_KIND_ARGUMENT_MATCHES, wantConstraint: "^[a-z]+$"},
+		{name: "success argument true", err: assertionConcern.ArgumentTrue(true, "The value must be true.")},
+		{name: "fail argument true", err: assertionConcern.ArgumentTrue(false, "The value must be true."), wantKind: ASSERTION_KIND_ARGUMENT_TRUE},
+		{name: "success argument false", err: assertionConcern.ArgumentFalse(false, "The value must be false.")},
+		{name: "fail argument false", err: assertionConcern.ArgumentFalse(true, "The value must be false."), wantKind: ASSERTION_KIND_ARGUMENT_FALSE},
+		{name: "success state true", err: assertionConcern.StateTrue(true, "The object must be active.")},
+		{name: "fail state true", err: assertionConcern.StateTrue(false, "The object must be active."), wantKind: ASSERTION_KIND_STATE_TRUE},
+		{name: "success state false", err: assertionConcern.StateFalse(false, "The object must not be active.")},
+		{name: "fail state false", err: assertionConcern.StateFalse(true, "The object must not be active."), wantKind: ASSERTION_KIND_STATE_FALSE},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantKind == "" {
+				if tt.err != nil {
+					t.Errorf("got %v, want nil", tt.err)
+				}
+				return
+			}
+			err := tt.err
+			Wrap(&err, "whatever()")
+			var assertionError *AssertionError
+			if !errors.As(err, &assertionError) {
+				t.Fatalf("err type: %T, expect type: %T", err, assertionError)
+			}
+			if assertionError.Kind() != tt.wantKind || assertionError.Field() != "name" || assertionError.Constraint() != tt.wantConstraint || assertionError.Error() != tt.err.Error() {
+				t.Errorf("got %s, %s, %s, want %s, name, %s", assertionError.Kind(), assertionError.Field(), assertionError.Constraint(), tt.wantKind, tt.wantConstraint)
+			}
+		})
+	}
+}
+
+func TestAssertionKindIsState(t *testing.T) {
+	if !ASSERTION_KIND_STATE_TRUE.IsState() || !ASSERTION_KIND_STATE_FALSE.IsState() || ASSERTION_KIND_ARGUMENT_TRUE.IsState() {
+		t.Errorf("only the state assertions must be state assertions")
+	}
+}
+
+func TestAssertionConcernWithCause(t *testing.T) {
+	errObjectInactive := New("OBJECT_INACTIVE", "", "The object must be active.")
+
+	t.Run("code of the cause", func(t *testing.T) {
+		err := NewAssertionConcern("").WithCause(errObjectInactive).StateTrue(false, errObjectInactive.Error())
+		Wrap(&err, "whatever()")
+		if !errors.Is(err, errObjectInactive) {
+			t.Errorf("got %v, want it to match %v", err, errObjectInactive)
+		}
+		if got := CodeOf(err); got != "OBJECT_INACTIVE" {
+			t.Errorf("got %s, want %s", got, "OBJECT_INACTIVE")
+		}
+	})
+	t.Run("code given with WithCode", func(t *testing.T) {
+		err := NewAssertionConcern("").WithCause(errObjectInactive).WithCode("OBJECT_SUSPENDED").StateTrue(false, errObjectInactive.Error())
+		if got := CodeOf(err); got != "OBJECT_SUSPENDED" {
+			t.Errorf("got %s, want %s", got, "OBJECT_SUSPENDED")
+		}
+	})
+}
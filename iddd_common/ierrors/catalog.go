@@ -0,0 +1,168 @@
+package ierrors
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/language"
+)
+
+// SOURCE_LANGUAGE is the language the messages of the errors are written in.
+const SOURCE_LANGUAGE = "en"
+
+// Catalog translates error messages. A message is translated by the template of its MessageKey when the catalog has one,
+// and otherwise by the template of its code, so that a new message reads generically rather than in English.
+type Catalog struct {
+	mutex         sync.RWMutex
+	languages     []language.Tag
+	codeTemplates map[language.Tag]map[Code]string
+	keyTemplates  map[language.Tag]map[MessageKey]string
+}
+
+func NewCatalog() *Catalog {
+	catalog := &Catalog{codeTemplates: map[language.Tag]map[Code]string{}, keyTemplates: map[language.Tag]map[MessageKey]string{}}
+	catalog.addLanguage(language.Make(SOURCE_LANGUAGE))
+	return catalog
+}
+
+// DEFAULT_CATALOG holds the templates of every code in English and Japanese. Packages add the translations of their own messages to it.
+var DEFAULT_CATALOG = newDefaultCatalog()
+
+func newDefaultCatalog() *Catalog {
+	catalog := NewCatalog()
+	for code, template := range map[Code]string{
+		CODE_ARGUMENT_NOT_EMPTY:   "The value is required.",
+		CODE_ARGUMENT_LENGTH:      "The value must be {minimum} to {maximum} characters.",
+		CODE_ARGUMENT_TRUE:        "The value is invalid.",
+		CODE_ARGUMENT_FALSE:       "The value is invalid.",
+		CODE_EXCLUSIVE_CONSTRAINT: "{held} and {requested} cannot be held together.",
+		CODE_VALIDATION_FAILED:    "The values are invalid.",
+		CODE_ARGUMENT_EQUALS:      "The value must be {constraint}.",
+		CODE_ARGUMENT_NOT_EQUALS:  "The value must not be {constraint}.",
+		CODE_ARGUMENT_NOT_NULL:    "The value is required.",
+		CODE_ARGUMENT_RANGE:       "The value must be {minimum} to {maximum}.",
+		CODE_ARGUMENT_MATCHES:     "The value is malformed.",
+		CODE_STATE_TRUE:           "The operation is not allowed in the current state.",
+		CODE_STATE_FALSE:          "The operation is not allowed in the current state.",
+	} {
+		catalog.SetCode("en", code, template)
+	}
+	for code, template := range map[Code]string{
+		CODE_ARGUMENT_NOT_EMPTY:   "値を入力してください。",
+		CODE_ARGUMENT_LENGTH:      "{minimum}文字以上{maximum}文字以下で入力してください。",
+		CODE_ARGUMENT_TRUE:        "値が正しくありません。",
+		CODE_ARGUMENT_FALSE:       "値が正しくありません。",
+		CODE_EXCLUSIVE_CONSTRAINT: "{held}と{requested}を同時に持つことはできません。",
+		CODE_VALIDATION_FAILED:    "入力内容に誤りがあります。",
+		CODE_ARGUMENT_EQUALS:      "値は{constraint}でなければなりません。",
+		CODE_ARGUMENT_NOT_EQUALS:  "値に{constraint}は使用できません。",
+		CODE_ARGUMENT_NOT_NULL:    "値を指定してください。",
+		CODE_ARGUMENT_RANGE:       "{minimum}から{maximum}の範囲で指定してください。",
+		CODE_ARGUMENT_MATCHES:     "形式が正しくありません。",
+		CODE_STATE_TRUE:           "現在の状態ではこの操作を実行できません。",
+		CODE_STATE_FALSE:          "現在の状態ではこの操作を実行できません。",
+	} {
+		catalog.SetCode("ja", code, template)
+	}
+	return catalog
+}
+
+// SetCode sets the template of every message of aCode in aLanguage, a BCP 47 tag such as "ja".
+func (catalog *Catalog) SetCode(aLanguage string, aCode Code, aTemplate string) {
+	catalog.mutex.Lock()
+	defer catalog.mutex.Unlock()
+
+	tag := catalog.addLanguage(language.Make(aLanguage))
+	if catalog.codeTemplates[tag] == nil {
+		catalog.codeTemplates[tag] = map[Code]string{}
+	}
+	catalog.codeTemplates[tag][aCode] = aTemplate
+}
+
+// SetMessage sets the template of the messages of aKey in aLanguage.
+func (catalog *Catalog) SetMessage(aLanguage string, aKey MessageKey, aTemplate string) {
+	catalog.mutex.Lock()
+	defer catalog.mutex.Unlock()
+
+	tag := catalog.addLanguage(language.Make(aLanguage))
+	if catalog.keyTemplates[tag] == nil {
+		catalog.keyTemplates[tag] = map[MessageKey]string{}
+	}
+	catalog.keyTemplates[tag][aKey] = aTemplate
+}
+
+func (catalog *Catalog) addLanguage(aTag language.Tag) language.Tag {
+	for _, tag := range catalog.languages {
+		if tag == aTag {
+			return tag
+		}
+	}
+	catalog.languages = append(catalog.languages, aTag)
+	return aTag
+}
+
+// Localize translates the message of anError into aLanguage, which is a BCP 47 tag or an Accept-Language header.
+// It walks the chain for the first error of this package, so that the context added by Wrap is never shown,
+// and translates each failure of a ValidationError on its own. A language the catalog lacks falls back to the source language.
+func (catalog *Catalog) Localize(anError error, aLanguage string) string {
+	if anError == nil {
+		return ""
+	}
+	var validationError *ValidationError
+	if errors.As(anError, &validationError) {
+		messages := make([]string, len(validationError.fieldErrors))
+		for i, fieldError := range validationError.fieldErrors {
+			messages[i] = catalog.Localize(fieldError.Unwrap(), aLanguage)
+		}
+		return strings.Join(messages, " ")
+	}
+
+	message, code, parameters := rootMessage(anError), Code(""), map[string]string{}
+	var codedError CodedError
+	if errors.As(anError, &codedError) {
+		message, code, parameters = codedError.Error(), codedError.Code(), codedError.Parameters()
+	}
+
+	catalog.mutex.RLock()
+	defer catalog.mutex.RUnlock()
+
+	tag := catalog.match(aLanguage)
+	if template, ok := catalog.keyTemplates[tag][MessageKeyOf(anError)]; ok {
+		return interpolate(template, parameters)
+	}
+	if tag != language.Make(SOURCE_LANGUAGE) || message == "" {
+		if template, ok := catalog.codeTemplates[tag][code]; ok {
+			return interpolate(template, parameters)
+		}
+	}
+	return message
+}
+
+func (catalog *Catalog) match(aLanguage string) language.Tag {
+	if aLanguage == "" {
+		return catalog.languages[0]
+	}
+	_, index := language.MatchStrings(language.NewMatcher(catalog.languages), aLanguage)
+	return catalog.languages[index]
+}
+
+func interpolate(aTemplate string, aParameters map[string]string) string {
+	oldnew := make([]string, 0, len(aParameters)*2)
+	for name, value := range aParameters {
+		oldnew = append(oldnew, "{"+name+"}", value)
+	}
+	return strings.NewReplacer(oldnew...).Replace(aTemplate)
+}
+
+func rootMessage(anError error) string {
+	for errors.Unwrap(anError) != nil {
+		anError = errors.Unwrap(anError)
+	}
+	return anError.Error()
+}
+
+// Localize translates anError with DEFAULT_CATALOG.
+func Localize(anError error, aLanguage string) string {
+	return DEFAULT_CATALOG.Localize(anError, aLanguage)
+}
@@ -0,0 +1,101 @@
+package ierrors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCodeOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Code
+	}{
+		{name: "argument length error", err: NewArgumentLengthError("", 1, 2, "The value must be 1 to 2 characters.").GetError(), want: CODE_ARGUMENT_LENGTH},
+		{name: "argument not empty error", err: NewArgumentNotEmptyError("", "The value is required.").GetError(), want: CODE_ARGUMENT_NOT_EMPTY},
+		{name: "argument true error", err: NewArgumentTrueErrorArguments(false, "The value must be true.").GetError(), want: CODE_ARGUMENT_TRUE},
+		{name: "argument false error", err: NewArgumentFalseError(true, "The value must be false.").GetError(), want: CODE_ARGUMENT_FALSE},
+		{name: "exclusive constraint error", err: NewExclusiveConstraintError(true, "Requester", "Approver", "The roles are mutually exclusive.").GetError(), want: CODE_EXCLUSIVE_CONSTRAINT},
+		{name: "assertion error", err: NewAssertionConcern("").StateFalse(true, "The object must be inactive."), want: CODE_STATE_FALSE},
+		{name: "assertion error with a code", err: NewAssertionConcern("").WithCode("OBJECT_ACTIVE").StateFalse(true, "The object must be inactive."), want: "OBJECT_ACTIVE"},
+		{name: "validation error", err: newValidationNotification().Err(), want: CODE_VALIDATION_FAILED},
+		{name: "keyed error", err: fmt.Errorf("identityapplicationservice.User(secret): %w", New("USER_NOT_FOUND", "USER_NOT_FOUND", "The user does not exist.")), want: "USER_NOT_FOUND"},
+		{name: "wrapped field error", err: fmt.Errorf("user.NewUser(): %w", NewFieldError("username", NewArgumentNotEmptyError("", "The username is required.").GetError())), want: CODE_ARGUMENT_NOT_EMPTY},
+		{name: "other error", err: errors.New("The user does not exist."), want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CodeOf(tt.err); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMessageKeyOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want MessageKey
+	}{
+		{name: "keyed error", err: New("USER_NOT_FOUND", "USER_NOT_FOUND", "The user does not exist."), want: "USER_NOT_FOUND"},
+		{name: "argument length error", err: NewArgumentLengthError("", 1, 2, "The value must be 1 to 2 characters.").WithKey("VALUE_LENGTH").GetError(), want: "VALUE_LENGTH"},
+		{name: "assertion error", err: NewAssertionConcern("").WithKey("OBJECT_ACTIVE").StateFalse(true, "The object must be inactive."), want: "OBJECT_ACTIVE"},
+		{name: "wrapped field error", err: fmt.Errorf("user.NewUser(): %w", NewFieldError("username", NewArgumentNotEmptyError("", "The username is required.").WithKey("USERNAME_REQUIRED").GetError())), want: "USERNAME_REQUIRED"},
+		{name: "error without a key", err: NewArgumentTrueErrorArguments(false, "The value must be true.").GetError(), want: ""},
+		{name: "other error", err: errors.New("The user does not exist."), want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MessageKeyOf(tt.err); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func newValidationNotification() *ValidationNotification {
+	validationNotification := NewValidationNotification()
+	validationNotification.Add("username", NewArgumentLengthError("ab", 3, 250, "The username must be 3 to 250 characters.").WithKey("USERNAME_LENGTH").GetError())
+	validationNotification.Add("firstName", NewArgumentNotEmptyError("", "First name is required.").GetError())
+	return validationNotification
+}
+
+func TestCatalogLocalize(t *testing.T) {
+	catalog := newDefaultCatalog()
+	catalog.SetMessage("ja", "USERNAME_LENGTH", "ユーザー名は{minimum}文字以上{maximum}文字以下で入力してください。")
+	catalog.SetMessage("ja", "USER_NOT_FOUND", "ユーザーが存在しません。")
+	catalog.SetCode("ja", "OBJECT_ACTIVE", "対象が有効です。")
+
+	lengthErr := error(NewArgumentLengthError("ab", 3, 250, "The username must be 3 to 250 characters.").WithKey("USERNAME_LENGTH").GetError())
+	Wrap(&lengthErr, "user.NewUser(%s)", "secret")
+
+	tests := []struct {
+		name     string
+		err      error
+		language string
+		want     string
+	}{
+		{name: "source language", err: lengthErr, language: "en", want: "The username must be 3 to 250 characters."},
+		{name: "no language", err: lengthErr, language: "", want: "The username must be 3 to 250 characters."},
+		{name: "message template", err: lengthErr, language: "ja", want: "ユーザー名は3文字以上250文字以下で入力してください。"},
+		{name: "accept language header", err: lengthErr, language: "fr-CH, ja;q=0.9, en;q=0.8", want: "ユーザー名は3文字以上250文字以下で入力してください。"},
+		{name: "regional language", err: lengthErr, language: "ja-JP", want: "ユーザー名は3文字以上250文字以下で入力してください。"},
+		{name: "unsupported language", err: lengthErr, language: "fr", want: "The username must be 3 to 250 characters."},
+		{name: "reworded message", err: NewArgumentLengthError("ab", 3, 250, "Usernames are 3 to 250 characters long.").WithKey("USERNAME_LENGTH").GetError(), language: "ja", want: "ユーザー名は3文字以上250文字以下で入力してください。"},
+		{name: "code template", err: NewAssertionConcern("attempts").ArgumentRange(0, 1, 10, "The attempts must be 1 to 10."), language: "ja", want: "1から10の範囲で指定してください。"},
+		{name: "dedicated code template", err: NewAssertionConcern("").WithCode("OBJECT_ACTIVE").StateFalse(true, "The object must be inactive."), language: "ja", want: "対象が有効です。"},
+		{name: "validation error", err: newValidationNotification().Err(), language: "ja", want: "ユーザー名は3文字以上250文字以下で入力してください。 値を入力してください。"},
+		{name: "keyed error", err: fmt.Errorf("identityapplicationservice.User(secret): %w", New("USER_NOT_FOUND", "USER_NOT_FOUND", "The user does not exist.")), language: "ja", want: "ユーザーが存在しません。"},
+		{name: "error of another package", err: fmt.Errorf("identityapplicationservice.User(secret): %w", errors.New("The user does not exist.")), language: "ja", want: "The user does not exist."},
+		{name: "nil", err: nil, language: "ja", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := catalog.Localize(tt.err, tt.language); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
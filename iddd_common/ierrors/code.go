@@ -0,0 +1,131 @@
+package ierrors
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Code identifies an error of this package independently of its message, which differs from call to call and from language to language.
+type Code string
+
+const (
+	CODE_ARGUMENT_NOT_EMPTY   Code = "ARGUMENT_NOT_EMPTY"
+	CODE_ARGUMENT_LENGTH      Code = "ARGUMENT_LENGTH"
+	CODE_ARGUMENT_TRUE        Code = "ARGUMENT_TRUE"
+	CODE_ARGUMENT_FALSE       Code = "ARGUMENT_FALSE"
+	CODE_EXCLUSIVE_CONSTRAINT Code = "EXCLUSIVE_CONSTRAINT"
+	CODE_VALIDATION_FAILED    Code = "VALIDATION_FAILED"
+
+	CODE_ARGUMENT_EQUALS     Code = "ARGUMENT_EQUALS"
+	CODE_ARGUMENT_NOT_EQUALS Code = "ARGUMENT_NOT_EQUALS"
+	CODE_ARGUMENT_NOT_NULL   Code = "ARGUMENT_NOT_NULL"
+	CODE_ARGUMENT_RANGE      Code = "ARGUMENT_RANGE"
+	CODE_ARGUMENT_MATCHES    Code = "ARGUMENT_MATCHES"
+	CODE_STATE_TRUE          Code = "STATE_TRUE"
+	CODE_STATE_FALSE         Code = "STATE_FALSE"
+)
+
+// CodedError is implemented by every error of this package. The parameters are the values a localized message interpolates, such as "minimum" and "maximum".
+type CodedError interface {
+	error
+	Code() Code
+	Parameters() map[string]string
+}
+
+// CodeOf returns the code of the outermost error of this package in the chain, or an empty code if there is none.
+func CodeOf(anError error) Code {
+	var codedError CodedError
+	if errors.As(anError, &codedError) {
+		return codedError.Code()
+	}
+	return ""
+}
+
+func (ArgumentLengthError *ArgumentLengthError) Code() Code {
+	return CODE_ARGUMENT_LENGTH
+}
+
+func (ArgumentLengthError *ArgumentLengthError) Parameters() map[string]string {
+	return map[string]string{"minimum": strconv.Itoa(ArgumentLengthError.Arguments.Minimum), "maximum": strconv.Itoa(ArgumentLengthError.Arguments.Maximum)}
+}
+
+func (ArgumentNotEmptyError *ArgumentNotEmptyError) Code() Code {
+	return CODE_ARGUMENT_NOT_EMPTY
+}
+
+func (ArgumentNotEmptyError *ArgumentNotEmptyError) Parameters() map[string]string {
+	return map[string]string{}
+}
+
+func (ArgumentTrueError *ArgumentTrueError) Code() Code {
+	return CODE_ARGUMENT_TRUE
+}
+
+func (ArgumentTrueError *ArgumentTrueError) Parameters() map[string]string {
+	return map[string]string{}
+}
+
+func (argumentFalseError *ArgumentFalseError) Code() Code {
+	return CODE_ARGUMENT_FALSE
+}
+
+func (argumentFalseError *ArgumentFalseError) Parameters() map[string]string {
+	return map[string]string{}
+}
+
+func (exclusiveConstraintError *ExclusiveConstraintError) Code() Code {
+	return CODE_EXCLUSIVE_CONSTRAINT
+}
+
+func (exclusiveConstraintError *ExclusiveConstraintError) Parameters() map[string]string {
+	return map[string]string{"held": exclusiveConstraintError.Arguments.Held, "requested": exclusiveConstraintError.Arguments.Requested}
+}
+
+// WithCode gives aCode to the errors of the assertions made through the returned AssertionConcern, in place of the code of their kind.
+func (assertionConcern AssertionConcern) WithCode(aCode Code) AssertionConcern {
+	assertionConcern.code = aCode
+	return assertionConcern
+}
+
+// Code is the code given with WithCode, or else that of the cause given with WithCause, or else the kind in upper snake case,
+// such as ARGUMENT_LENGTH for argument-length.
+func (assertionError *AssertionError) Code() Code {
+	if assertionError.code != "" {
+		return assertionError.code
+	}
+	if code := CodeOf(assertionError.cause); code != "" {
+		return code
+	}
+	return Code(strings.ToUpper(strings.ReplaceAll(string(assertionError.kind), "-", "_")))
+}
+
+// Parameters splits a "minimum..maximum" constraint, and passes any other constraint as it is.
+func (assertionError *AssertionError) Parameters() map[string]string {
+	parameters := map[string]string{}
+	if assertionError.field != "" {
+		parameters["field"] = assertionError.field
+	}
+	if minimum, maximum, ok := strings.Cut(assertionError.constraint, ".."); ok {
+		parameters["minimum"], parameters["maximum"] = minimum, maximum
+	} else if assertionError.constraint != "" {
+		parameters["constraint"] = assertionError.constraint
+	}
+	return parameters
+}
+
+func (keyedError *keyedError) Code() Code {
+	return keyedError.code
+}
+
+func (keyedError *keyedError) Parameters() map[string]string {
+	return map[string]string{}
+}
+
+func (validationError *ValidationError) Code() Code {
+	return CODE_VALIDATION_FAILED
+}
+
+func (validationError *ValidationError) Parameters() map[string]string {
+	return map[string]string{}
+}
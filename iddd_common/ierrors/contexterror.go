@@ -0,0 +1,215 @@
+package ierrors
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strings"
+)
+
+// Capture decides what a wrap records of the place it happened.
+type Capture int
+
+const (
+	CAPTURE_NONE Capture = iota
+	CAPTURE_CALLER
+	CAPTURE_STACK
+)
+
+// CAPTURE applies to every Wrap, WrapFields and WrapAttrs.
+var CAPTURE = CAPTURE_NONE
+
+const MAXIMUM_STACK_DEPTH = 32
+
+// Field is a key/value pair of the context of an operation.
+type Field struct {
+	key       string
+	value     interface{}
+	sensitive bool
+}
+
+func NewField(aKey string, aValue interface{}) Field {
+	return Field{key: aKey, value: aValue}
+}
+
+func NewSensitiveField(aKey string, aValue interface{}) Field {
+	return Field{key: aKey, value: aValue, sensitive: true}
+}
+
+func (field Field) Key() string {
+	return field.key
+}
+
+// Value returns REDACTED for a sensitive field.
+func (field Field) Value() interface{} {
+	if field.sensitive {
+		return REDACTED
+	}
+	return field.value
+}
+
+func (field Field) IsSensitive() bool {
+	return field.sensitive
+}
+
+func (field Field) String() string {
+	return fmt.Sprintf("%s=%v", field.key, field.Value())
+}
+
+// ContextError records the operation that failed, its fields and, depending on CAPTURE, where it was wrapped.
+type ContextError struct {
+	operation string
+	fields    []Field
+	frames    []runtime.Frame
+	err       error
+}
+
+// WrapFields wraps a non-nil *errp in a ContextError, as Wrap does with a format.
+func WrapFields(errp *error, anOperation string, aFields ...Field) {
+	if *errp != nil {
+		*errp = newContextError(anOperation, aFields, *errp)
+	}
+}
+
+// WrapAttrs takes its fields as log/slog does. A Secret value is marked sensitive.
+func WrapAttrs(errp *error, anOperation string, args ...interface{}) {
+	if *errp != nil {
+		*errp = newContextError(anOperation, fieldsOf(args), *errp)
+	}
+}
+
+func newContextError(anOperation string, aFields []Field, anError error) *ContextError {
+	contextError := &ContextError{operation: anOperation, fields: append([]Field(nil), aFields...), err: anError}
+	switch CAPTURE {
+	case CAPTURE_CALLER:
+		contextError.frames = callers(1)
+	case CAPTURE_STACK:
+		contextError.frames = callers(MAXIMUM_STACK_DEPTH)
+	}
+	return contextError
+}
+
+// callers skips itself, newContextError and the wrap function.
+func callers(aDepth int) []runtime.Frame {
+	programCounters := make([]uintptr, aDepth)
+	count := runtime.Callers(4, programCounters)
+	frames := runtime.CallersFrames(programCounters[:count])
+	result := []runtime.Frame{}
+	for {
+		frame, more := frames.Next()
+		result = append(result, frame)
+		if !more {
+			return result
+		}
+	}
+}
+
+func fieldsOf(args []interface{}) []Field {
+	fields := []Field{}
+	for len(args) > 0 {
+		switch arg := args[0].(type) {
+		case slog.Attr:
+			fields = append(fields, fieldOf(arg.Key, arg.Value.Any()))
+			args = args[1:]
+		case string:
+			if len(args) == 1 {
+				fields = append(fields, NewField("!BADKEY", arg))
+				return fields
+			}
+			fields = append(fields, fieldOf(arg, args[1]))
+			args = args[2:]
+		default:
+			fields = append(fields, fieldOf("!BADKEY", arg))
+			args = args[1:]
+		}
+	}
+	return fields
+}
+
+func fieldOf(aKey string, aValue interface{}) Field {
+	if _, ok := aValue.(Secret); ok {
+		return NewSensitiveField(aKey, aValue)
+	}
+	return NewField(aKey, aValue)
+}
+
+func (contextError *ContextError) Operation() string {
+	return contextError.operation
+}
+
+func (contextError *ContextError) Fields() []Field {
+	return append([]Field(nil), contextError.fields...)
+}
+
+// Caller is the file:line of the wrapped operation, or empty when CAPTURE was CAPTURE_NONE.
+func (contextError *ContextError) Caller() string {
+	if len(contextError.frames) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", contextError.frames[0].File, contextError.frames[0].Line)
+}
+
+// Stack lists "function file:line" from the wrapped operation outwards, or nothing unless CAPTURE was CAPTURE_STACK.
+func (contextError *ContextError) Stack() []string {
+	if len(contextError.frames) < 2 {
+		return nil
+	}
+	stack := make([]string, len(contextError.frames))
+	for i, frame := range contextError.frames {
+		stack[i] = fmt.Sprintf("%s %s:%d", frame.Function, frame.File, frame.Line)
+	}
+	return stack
+}
+
+func (contextError *ContextError) Error() string {
+	if len(contextError.fields) == 0 {
+		return fmt.Sprintf("%s: %v", contextError.operation, contextError.err)
+	}
+	fields := make([]string, len(contextError.fields))
+	for i, field := range contextError.fields {
+		fields[i] = field.String()
+	}
+	return fmt.Sprintf("%s(%s): %v", contextError.operation, strings.Join(fields, ", "), contextError.err)
+}
+
+func (contextError *ContextError) Unwrap() error {
+	return contextError.err
+}
+
+// LogValue logs the whole chain as a group of its message, operations, fields and innermost caller.
+func (contextError *ContextError) LogValue() slog.Value {
+	attrs := []slog.Attr{slog.String("error", rootMessage(contextError)), slog.Any("operations", Operations(contextError))}
+	caller, stack := "", []string(nil)
+	var err error = contextError
+	for err != nil {
+		if current, ok := err.(*ContextError); ok {
+			for _, field := range current.fields {
+				attrs = append(attrs, slog.Any(field.key, field.Value()))
+			}
+			if current.Caller() != "" {
+				caller, stack = current.Caller(), current.Stack()
+			}
+		}
+		err = errors.Unwrap(err)
+	}
+	if caller != "" {
+		attrs = append(attrs, slog.String("caller", caller))
+	}
+	if stack != nil {
+		attrs = append(attrs, slog.Any("stack", stack))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// Operations lists the operations of the chain from the outermost inwards.
+func Operations(anError error) []string {
+	operations := []string{}
+	for anError != nil {
+		if contextError, ok := anError.(*ContextError); ok {
+			operations = append(operations, contextError.operation)
+		}
+		anError = errors.Unwrap(anError)
+	}
+	return operations
+}
@@ -0,0 +1,170 @@
+package ierrors
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWrapFields(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		var err error
+		WrapFields(&err, "user.ChangePassword", NewField("username", "zoe"))
+		if err != nil {
+			t.Errorf("got %v, want nil", err)
+		}
+	})
+	t.Run("redacts sensitive fields", func(t *testing.T) {
+		orig := errors.New("The password is unchanged")
+		err := orig
+		WrapFields(&err, "user.assertPasswordNotSame", NewField("username", "zoe"), NewSensitiveField("changedPassword", "qwerty!ASDFG#"))
+
+		want := "user.assertPasswordNotSame(username=zoe, changedPassword=[REDACTED]): The password is unchanged"
+		if got := err.Error(); got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+		if !errors.Is(err, orig) {
+			t.Errorf("got %v, want it to wrap %v", err, orig)
+		}
+
+		var contextError *ContextError
+		if !errors.As(err, &contextError) {
+			t.Fatalf("err type: %T, expect type: %T", err, contextError)
+		}
+		if got := contextError.Operation(); got != "user.assertPasswordNotSame" {
+			t.Errorf("got %s, want user.assertPasswordNotSame", got)
+		}
+		got := map[string]interface{}{}
+		for _, field := range contextError.Fields() {
+			got[field.Key()] = field.Value()
+		}
+		if diff := cmp.Diff(map[string]interface{}{"username": "zoe", "changedPassword": REDACTED}, got); diff != "" {
+			t.Errorf("mismatch (-want, +got):\n%s", diff)
+		}
+	})
+	t.Run("redacts secrets in plain fields", func(t *testing.T) {
+		err := errors.New("The password must be stronger.")
+		WrapFields(&err, "user.assertPasswordNotWeak", NewField("changedPassword", Secret("123456")))
+
+		want := "user.assertPasswordNotWeak(changedPassword=[REDACTED]): The password must be stronger."
+		if got := err.Error(); got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	})
+}
+
+func TestWrapAttrs(t *testing.T) {
+	err := errors.New("The password is unchanged")
+	WrapAttrs(&err, "user.ChangePassword", "username", "zoe", slog.Int("attempt", 2), "password", Secret("qwerty!ASDFG#"), "dangling")
+
+	want := "user.ChangePassword(username=zoe, attempt=2, password=[REDACTED], !BADKEY=dangling): The password is unchanged"
+	if got := err.Error(); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestWrapCapture(t *testing.T) {
+	defer func(aCapture Capture) { CAPTURE = aCapture }(CAPTURE)
+
+	wrapped := func() (err error) {
+		defer Wrap(&err, "Frob(%d)", 3)
+		return errors.New("bad stuff")
+	}
+
+	tests := []struct {
+		name       string
+		capture    Capture
+		wantCaller bool
+		wantStack  bool
+	}{
+		{name: "none", capture: CAPTURE_NONE},
+		{name: "caller", capture: CAPTURE_CALLER, wantCaller: true},
+		{name: "stack", capture: CAPTURE_STACK, wantCaller: true, wantStack: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			CAPTURE = tt.capture
+			err := wrapped()
+
+			var contextError *ContextError
+			if !errors.As(err, &contextError) {
+				t.Fatalf("err type: %T, expect type: %T", err, contextError)
+			}
+			if got := strings.Contains(contextError.Caller(), "contexterror_test.go:"); got != tt.wantCaller {
+				t.Errorf("got caller %q, want it in contexterror_test.go: %v", contextError.Caller(), tt.wantCaller)
+			}
+			stack := contextError.Stack()
+			if got := len(stack) > 1 && strings.Contains(stack[1], "TestWrapCapture"); got != tt.wantStack {
+				t.Errorf("got stack %q, want it through TestWrapCapture: %v", stack, tt.wantStack)
+			}
+			if got := err.Error(); got != "Frob(3): bad stuff" {
+				t.Errorf("got %s, want Frob(3): bad stuff", got)
+			}
+		})
+	}
+}
+
+func TestOperations(t *testing.T) {
+	orig := NewArgumentTrueErrorArguments(false, "The username is reserved.")
+	err := error(orig)
+	WrapFields(&err, "user.validateUsername", NewField("username", "admin"))
+	err = NewFieldError("username", err)
+	Wrap(&err, "user.NewUser(%s)", "admin")
+	err = fmt.Errorf("unnamed: %w", err)
+	WrapAttrs(&err, "identityapplicationservice.RegisterUser", "tenantId", "a")
+
+	want := []string{"identityapplicationservice.RegisterUser", "user.NewUser(admin)", "user.validateUsername"}
+	if diff := cmp.Diff(want, Operations(err)); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+	var argumentTrueError *ArgumentTrueError
+	if !errors.As(err, &argumentTrueError) {
+		t.Errorf("err type: %T, expect type: %T", err, argumentTrueError)
+	}
+	if got := Operations(orig); len(got) != 0 {
+		t.Errorf("got %v, want no operations", got)
+	}
+}
+
+func TestContextErrorLogValue(t *testing.T) {
+	defer func(aCapture Capture) { CAPTURE = aCapture }(CAPTURE)
+	CAPTURE = CAPTURE_CALLER
+
+	err := errors.New("The password is unchanged")
+	WrapFields(&err, "user.assertPasswordNotSame", NewSensitiveField("changedPassword", "qwerty!ASDFG#"))
+	CAPTURE = CAPTURE_NONE
+	WrapAttrs(&err, "user.ChangePassword", "username", "zoe")
+
+	var buffer bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buffer, nil)).Error("failed", "err", err)
+	if strings.Contains(buffer.String(), "qwerty!ASDFG#") {
+		t.Errorf("got %s, want the password redacted", buffer.String())
+	}
+
+	var got struct {
+		Err map[string]interface{} `json:"err"`
+	}
+	if err := json.Unmarshal(buffer.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	caller, _ := got.Err["caller"].(string)
+	if !strings.Contains(caller, "contexterror_test.go:") {
+		t.Errorf("got caller %q, want it in contexterror_test.go", caller)
+	}
+	delete(got.Err, "caller")
+	want := map[string]interface{}{
+		"error":           "The password is unchanged",
+		"operations":      []interface{}{"user.ChangePassword", "user.assertPasswordNotSame"},
+		"username":        "zoe",
+		"changedPassword": REDACTED,
+	}
+	if diff := cmp.Diff(want, got.Err); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+}
@@ -7,7 +7,7 @@ import (
 
 func Wrap(errp *error, format string, args ...interface{}) {
 	if *errp != nil {
-		*errp = fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), *errp)
+		*errp = newContextError(fmt.Sprintf(format, args...), nil, *errp)
 	}
 }
 
@@ -25,6 +25,14 @@ type ArgumentLengthErrorArguments struct {
 
 type ArgumentLengthError struct {
 	Arguments ArgumentLengthErrorArguments
+	policy    LengthPolicy
+	key       MessageKey
+}
+
+// WithPolicy measures the string under aPolicy instead of counting its runes.
+func (ArgumentLengthError *ArgumentLengthError) WithPolicy(aPolicy LengthPolicy) *ArgumentLengthError {
+	ArgumentLengthError.policy = aPolicy
+	return ArgumentLengthError
 }
 
 func (ArgumentLengthError *ArgumentLengthError) GetArguments() ArgumentLengthErrorArguments {
@@ -33,7 +41,7 @@ func (ArgumentLengthError *ArgumentLengthError) GetArguments() ArgumentLengthErr
 
 func (ArgumentLengthError *ArgumentLengthError) GetError() error {
 	args := ArgumentLengthError.Arguments
-	length := len(args.String)
+	length := ArgumentLengthError.policy.Length(args.String)
 	if length < args.Minimum || length > args.Maximum {
 		return ArgumentLengthError
 	}
@@ -56,6 +64,7 @@ type ArgumentNotEmptyErrorArguments struct {
 
 type ArgumentNotEmptyError struct {
 	Arguments ArgumentNotEmptyErrorArguments
+	key       MessageKey
 }
 
 func (ArgumentNotEmptyError *ArgumentNotEmptyError) GetArguments() ArgumentNotEmptyErrorArguments {
@@ -86,6 +95,7 @@ type ArgumentTrueErrorArguments struct {
 
 type ArgumentTrueError struct {
 	Arguments ArgumentTrueErrorArguments
+	key       MessageKey
 }
 
 func (ArgumentTrueError *ArgumentTrueError) GetArguments() ArgumentTrueErrorArguments {
@@ -116,6 +126,7 @@ type ArgumentFalseErrorArguments struct {
 
 type ArgumentFalseError struct {
 	arguments ArgumentFalseErrorArguments
+	key       MessageKey
 }
 
 func (argumentFalseError *ArgumentFalseError) GetArguments() ArgumentFalseErrorArguments {
@@ -133,3 +144,58 @@ func (argumentFalseError *ArgumentFalseError) GetError() error {
 func (argumentFalseError *ArgumentFalseError) Error() string {
 	return argumentFalseError.arguments.message
 }
+
+func NewExclusiveConstraintError(aViolated bool, aHeld string, aRequested string, aMessage string) *ExclusiveConstraintError {
+	arguments := ExclusiveConstraintErrorArguments{Violated: aViolated, Held: aHeld, Requested: aRequested, Message: aMessage}
+	return &ExclusiveConstraintError{Arguments: arguments}
+}
+
+type ExclusiveConstraintErrorArguments struct {
+	Violated  bool
+	Held      string
+	Requested string
+	Message   string
+}
+
+type ExclusiveConstraintError struct {
+	Arguments ExclusiveConstraintErrorArguments
+	key       MessageKey
+}
+
+func (exclusiveConstraintError *ExclusiveConstraintError) GetArguments() ExclusiveConstraintErrorArguments {
+	return exclusiveConstraintError.Arguments
+}
+
+func (exclusiveConstraintError *ExclusiveConstraintError) GetError() error {
+	args := exclusiveConstraintError.Arguments
+	if args.Violated {
+		return exclusiveConstraintError
+	}
+	return nil
+}
+
+func (exclusiveConstraintError *ExclusiveConstraintError) Error() string {
+	return exclusiveConstraintError.Arguments.Message
+}
+
+// FieldError names the request field whose value caused the wrapped validation error.
+func NewFieldError(aField string, anError error) *FieldError {
+	return &FieldError{field: aField, err: anError}
+}
+
+type FieldError struct {
+	field string
+	err   error
+}
+
+func (fieldError *FieldError) Field() string {
+	return fieldError.field
+}
+
+func (fieldError *FieldError) Error() string {
+	return fieldError.err.Error()
+}
+
+func (fieldError *FieldError) Unwrap() error {
+	return fieldError.err
+}
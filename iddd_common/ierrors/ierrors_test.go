@@ -23,3 +23,64 @@ func TestWrap(t *testing.T) {
 		t.Errorf("Unwrap: got %#v, want %#v", got, orig)
 	}
 }
+
+func TestArgumentLengthError(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     *ArgumentLengthError
+		wantErr bool
+	}{
+		{name: "success runes by default", err: NewArgumentLengthError("山田太", 1, 3, "The value must be 3 characters or less.")},
+		{name: "fail runes by default", err: NewArgumentLengthError("山田太郎", 1, 3, "The value must be 3 characters or less."), wantErr: true},
+		{name: "fail bytes", err: NewArgumentLengthError("山田太", 1, 3, "The value must be 3 bytes or less.").WithPolicy(NewLengthPolicy(NORMALIZATION_FORM_NONE, LENGTH_MODE_BYTES)), wantErr: true},
+		{name: "success graphemes", err: NewArgumentLengthError("e\u0301a\u0308", 1, 2, "The value must be 2 characters or less.").WithPolicy(NewLengthPolicy(NORMALIZATION_FORM_NONE, LENGTH_MODE_GRAPHEMES))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.err.GetError(); (err != nil) != tt.wantErr {
+				t.Errorf("got %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExclusiveConstraintError(t *testing.T) {
+	t.Run("violated", func(t *testing.T) {
+		err := NewExclusiveConstraintError(true, "Requester", "Approver", "The roles are mutually exclusive.").GetError()
+		var exclusiveConstraintError *ExclusiveConstraintError
+		if !errors.As(err, &exclusiveConstraintError) {
+			t.Fatalf("err type: %T, expect type: %T", err, exclusiveConstraintError)
+		}
+		want := ExclusiveConstraintErrorArguments{Violated: true, Held: "Requester", Requested: "Approver", Message: "The roles are mutually exclusive."}
+		if got := exclusiveConstraintError.GetArguments(); got != want {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+	t.Run("not violated", func(t *testing.T) {
+		if err := NewExclusiveConstraintError(false, "Requester", "Approver", "The roles are mutually exclusive.").GetError(); err != nil {
+			t.Errorf("got %v, want nil", err)
+		}
+	})
+}
+
+func TestFieldError(t *testing.T) {
+	err := NewArgumentNotEmptyError("", "The tenant name is required.").GetError()
+	Wrap(&err, "tenant.NewTenant()")
+	err = NewFieldError("name", err)
+	Wrap(&err, "identityapplicationservice.ProvisionTenant()")
+
+	var fieldError *FieldError
+	if !errors.As(err, &fieldError) {
+		t.Fatalf("err type: %T, expect type: %T", err, fieldError)
+	}
+	if got := fieldError.Field(); got != "name" {
+		t.Errorf("got %s, want %s", got, "name")
+	}
+	if got := fieldError.Error(); got != "tenant.NewTenant(): The tenant name is required." {
+		t.Errorf("got %s, want %s", got, "tenant.NewTenant(): The tenant name is required.")
+	}
+	var argumentNotEmptyError *ArgumentNotEmptyError
+	if !errors.As(err, &argumentNotEmptyError) {
+		t.Errorf("err type: %T, expect type: %T", err, argumentNotEmptyError)
+	}
+}
@@ -0,0 +1,175 @@
+package ierrors
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/width"
+)
+
+// LengthMode names how the length of a string is measured.
+type LengthMode string
+
+const (
+	LENGTH_MODE_BYTES         LengthMode = "bytes"
+	LENGTH_MODE_RUNES         LengthMode = "runes"
+	LENGTH_MODE_GRAPHEMES     LengthMode = "graphemes"
+	LENGTH_MODE_DISPLAY_WIDTH LengthMode = "display-width"
+)
+
+// NormalizationForm names the Unicode normalization applied to a string before it is measured.
+type NormalizationForm string
+
+const (
+	NORMALIZATION_FORM_NONE NormalizationForm = ""
+	NORMALIZATION_FORM_NFC  NormalizationForm = "NFC"
+	NORMALIZATION_FORM_NFKC NormalizationForm = "NFKC"
+)
+
+// LengthPolicy measures strings. The zero value counts the runes of the string as given, as ArgumentLengthError does by default;
+// bytes are counted only under LENGTH_MODE_BYTES.
+type LengthPolicy struct {
+	Normalization NormalizationForm
+	Mode          LengthMode
+}
+
+func NewLengthPolicy(aNormalization NormalizationForm, aMode LengthMode) LengthPolicy {
+	return LengthPolicy{Normalization: aNormalization, Mode: aMode}
+}
+
+func (lengthPolicy LengthPolicy) Normalize(aString string) string {
+	switch lengthPolicy.Normalization {
+	case NORMALIZATION_FORM_NFC:
+		return norm.NFC.String(aString)
+	case NORMALIZATION_FORM_NFKC:
+		return norm.NFKC.String(aString)
+	}
+	return aString
+}
+
+func (lengthPolicy LengthPolicy) Length(aString string) int {
+	normalized := lengthPolicy.Normalize(aString)
+	switch lengthPolicy.Mode {
+	case LENGTH_MODE_BYTES:
+		return len(normalized)
+	case LENGTH_MODE_GRAPHEMES:
+		return len(graphemeClusters(normalized))
+	case LENGTH_MODE_DISPLAY_WIDTH:
+		displayWidth := 0
+		for _, graphemeCluster := range graphemeClusters(normalized) {
+			displayWidth += graphemeClusterWidth(graphemeCluster)
+		}
+		return displayWidth
+	}
+	return utf8.RuneCountInString(normalized)
+}
+
+// graphemeClusters approximates the extended grapheme clusters of UAX #29: it keeps CR LF, combining marks, joiners,
+// variation selectors, emoji modifiers, regional indicator pairs and Hangul syllable sequences together.
+func graphemeClusters(aString string) []string {
+	var graphemeClusters []string
+	start := 0
+	previous := rune(-1)
+	regionalIndicators := 0
+	for index, current := range aString {
+		if previous >= 0 && isGraphemeBoundary(previous, current, regionalIndicators) {
+			graphemeClusters = append(graphemeClusters, aString[start:index])
+			start = index
+		}
+		if isRegionalIndicator(current) {
+			regionalIndicators++
+		} else {
+			regionalIndicators = 0
+		}
+		previous = current
+	}
+	if start < len(aString) {
+		graphemeClusters = append(graphemeClusters, aString[start:])
+	}
+	return graphemeClusters
+}
+
+func isGraphemeBoundary(aPrevious rune, aCurrent rune, aRegionalIndicators int) bool {
+	switch {
+	case aPrevious == '\r' && aCurrent == '\n':
+		return false
+	case unicode.IsControl(aPrevious) || unicode.IsControl(aCurrent):
+		return true
+	case isGraphemeExtend(aCurrent) || aPrevious == '\u200d':
+		return false
+	case isRegionalIndicator(aPrevious) && isRegionalIndicator(aCurrent):
+		return aRegionalIndicators%2 == 0
+	}
+	return !isHangulSequence(aPrevious, aCurrent)
+}
+
+func isGraphemeExtend(aRune rune) bool {
+	return unicode.In(aRune, unicode.Mn, unicode.Me, unicode.Mc) ||
+		aRune == '\u200d' ||
+		unicode.Is(unicode.Variation_Selector, aRune) ||
+		(aRune >= 0x1f3fb && aRune <= 0x1f3ff)
+}
+
+func isRegionalIndicator(aRune rune) bool {
+	return aRune >= 0x1f1e6 && aRune <= 0x1f1ff
+}
+
+type hangulType int
+
+const (
+	hangulNone hangulType = iota
+	hangulLeading
+	hangulVowel
+	hangulTrailing
+	hangulSyllableLV
+	hangulSyllableLVT
+)
+
+func hangulTypeOf(aRune rune) hangulType {
+	switch {
+	case aRune >= 0x1100 && aRune <= 0x115f, aRune >= 0xa960 && aRune <= 0xa97c:
+		return hangulLeading
+	case aRune >= 0x1160 && aRune <= 0x11a7, aRune >= 0xd7b0 && aRune <= 0xd7c6:
+		return hangulVowel
+	case aRune >= 0x11a8 && aRune <= 0x11ff, aRune >= 0xd7cb && aRune <= 0xd7fb:
+		return hangulTrailing
+	case aRune >= 0xac00 && aRune <= 0xd7a3:
+		if (aRune-0xac00)%28 == 0 {
+			return hangulSyllableLV
+		}
+		return hangulSyllableLVT
+	}
+	return hangulNone
+}
+
+func isHangulSequence(aPrevious rune, aCurrent rune) bool {
+	previous, current := hangulTypeOf(aPrevious), hangulTypeOf(aCurrent)
+	switch previous {
+	case hangulLeading:
+		return current != hangulNone && current != hangulTrailing
+	case hangulVowel, hangulSyllableLV:
+		return current == hangulVowel || current == hangulTrailing
+	case hangulTrailing, hangulSyllableLVT:
+		return current == hangulTrailing
+	}
+	return false
+}
+
+// graphemeClusterWidth takes the width of the base rune, widening a cluster that asks for emoji presentation.
+func graphemeClusterWidth(aGraphemeCluster string) int {
+	base, _ := utf8.DecodeRuneInString(aGraphemeCluster)
+	if unicode.IsControl(base) || unicode.In(base, unicode.Mn, unicode.Me, unicode.Cf) {
+		return 0
+	}
+	for _, current := range aGraphemeCluster {
+		if current == '\ufe0f' || isRegionalIndicator(current) {
+			return 2
+		}
+	}
+	switch width.LookupRune(base).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+	return 1
+}
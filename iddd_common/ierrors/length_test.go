@@ -0,0 +1,38 @@
+package ierrors
+
+import "testing"
+
+func TestLengthPolicyLength(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy LengthPolicy
+		string string
+		want   int
+	}{
+		{name: "runes by default", policy: LengthPolicy{}, string: "山田太", want: 3},
+		{name: "bytes", policy: NewLengthPolicy(NORMALIZATION_FORM_NONE, LENGTH_MODE_BYTES), string: "山田太", want: 9},
+		{name: "runes", policy: NewLengthPolicy(NORMALIZATION_FORM_NONE, LENGTH_MODE_RUNES), string: "山田太", want: 3},
+		{name: "runes of a decomposed string", policy: NewLengthPolicy(NORMALIZATION_FORM_NONE, LENGTH_MODE_RUNES), string: "\u30cf\u309a", want: 2},
+		{name: "runes after NFC", policy: NewLengthPolicy(NORMALIZATION_FORM_NFC, LENGTH_MODE_RUNES), string: "\u30cf\u309a", want: 1},
+		{name: "runes after NFKC", policy: NewLengthPolicy(NORMALIZATION_FORM_NFKC, LENGTH_MODE_RUNES), string: "ﾊﾟ", want: 1},
+		{name: "graphemes of combining marks", policy: NewLengthPolicy(NORMALIZATION_FORM_NONE, LENGTH_MODE_GRAPHEMES), string: "e\u0301a\u0308", want: 2},
+		{name: "graphemes of an emoji sequence", policy: NewLengthPolicy(NORMALIZATION_FORM_NONE, LENGTH_MODE_GRAPHEMES), string: "\U0001f468\u200d\U0001f469\u200d\U0001f467", want: 1},
+		{name: "graphemes of an emoji modifier", policy: NewLengthPolicy(NORMALIZATION_FORM_NONE, LENGTH_MODE_GRAPHEMES), string: "\U0001f44d\U0001f3fd", want: 1},
+		{name: "graphemes of flags", policy: NewLengthPolicy(NORMALIZATION_FORM_NONE, LENGTH_MODE_GRAPHEMES), string: "\U0001f1ef\U0001f1f5\U0001f1fa\U0001f1f8\U0001f1ef", want: 3},
+		{name: "graphemes of hangul jamo", policy: NewLengthPolicy(NORMALIZATION_FORM_NONE, LENGTH_MODE_GRAPHEMES), string: "\u1100\u1161\u11a8\u1100", want: 2},
+		{name: "graphemes of CR LF", policy: NewLengthPolicy(NORMALIZATION_FORM_NONE, LENGTH_MODE_GRAPHEMES), string: "a\r\nb", want: 3},
+		{name: "display width of wide characters", policy: NewLengthPolicy(NORMALIZATION_FORM_NONE, LENGTH_MODE_DISPLAY_WIDTH), string: "山田a", want: 5},
+		{name: "display width of halfwidth katakana", policy: NewLengthPolicy(NORMALIZATION_FORM_NONE, LENGTH_MODE_DISPLAY_WIDTH), string: "ﾊﾟ", want: 2},
+		{name: "display width after NFKC", policy: NewLengthPolicy(NORMALIZATION_FORM_NFKC, LENGTH_MODE_DISPLAY_WIDTH), string: "ﾊﾟ", want: 2},
+		{name: "display width of combining marks", policy: NewLengthPolicy(NORMALIZATION_FORM_NONE, LENGTH_MODE_DISPLAY_WIDTH), string: "e\u0301", want: 1},
+		{name: "display width of an emoji sequence", policy: NewLengthPolicy(NORMALIZATION_FORM_NONE, LENGTH_MODE_DISPLAY_WIDTH), string: "\u2764\ufe0f\U0001f468\u200d\U0001f469\u200d\U0001f467", want: 4},
+		{name: "empty", policy: NewLengthPolicy(NORMALIZATION_FORM_NFC, LENGTH_MODE_GRAPHEMES), string: "", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Length(tt.string); got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
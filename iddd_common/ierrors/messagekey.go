@@ -0,0 +1,99 @@
+package ierrors
+
+import "errors"
+
+// MessageKey identifies a message independently of its wording. Catalogs translate messages by it.
+type MessageKey string
+
+// KeyedError is implemented by every error of this package. The key is empty for an error that was not given one.
+type KeyedError interface {
+	error
+	MessageKey() MessageKey
+}
+
+// New returns an error with aMessage, identified by aCode and translated by aKey.
+func New(aCode Code, aKey MessageKey, aMessage string) error {
+	return &keyedError{code: aCode, key: aKey, message: aMessage}
+}
+
+type keyedError struct {
+	code    Code
+	key     MessageKey
+	message string
+}
+
+func (keyedError *keyedError) Error() string {
+	return keyedError.message
+}
+
+func (keyedError *keyedError) MessageKey() MessageKey {
+	return keyedError.key
+}
+
+// MessageKeyOf returns the key of the outermost error of this package in the chain, or an empty key if there is none.
+func MessageKeyOf(anError error) MessageKey {
+	var keyedError KeyedError
+	if errors.As(anError, &keyedError) {
+		return keyedError.MessageKey()
+	}
+	return ""
+}
+
+func (ArgumentLengthError *ArgumentLengthError) WithKey(aKey MessageKey) *ArgumentLengthError {
+	ArgumentLengthError.key = aKey
+	return ArgumentLengthError
+}
+
+func (ArgumentLengthError *ArgumentLengthError) MessageKey() MessageKey {
+	return ArgumentLengthError.key
+}
+
+func (ArgumentNotEmptyError *ArgumentNotEmptyError) WithKey(aKey MessageKey) *ArgumentNotEmptyError {
+	ArgumentNotEmptyError.key = aKey
+	return ArgumentNotEmptyError
+}
+
+func (ArgumentNotEmptyError *ArgumentNotEmptyError) MessageKey() MessageKey {
+	return ArgumentNotEmptyError.key
+}
+
+func (ArgumentTrueError *ArgumentTrueError) WithKey(aKey MessageKey) *ArgumentTrueError {
+	ArgumentTrueError.key = aKey
+	return ArgumentTrueError
+}
+
+func (ArgumentTrueError *ArgumentTrueError) MessageKey() MessageKey {
+	return ArgumentTrueError.key
+}
+
+func (argumentFalseError *ArgumentFalseError) WithKey(aKey MessageKey) *ArgumentFalseError {
+	argumentFalseError.key = aKey
+	return argumentFalseError
+}
+
+func (argumentFalseError *ArgumentFalseError) MessageKey() MessageKey {
+	return argumentFalseError.key
+}
+
+func (exclusiveConstraintError *ExclusiveConstraintError) WithKey(aKey MessageKey) *ExclusiveConstraintError {
+	exclusiveConstraintError.key = aKey
+	return exclusiveConstraintError
+}
+
+func (exclusiveConstraintError *ExclusiveConstraintError) MessageKey() MessageKey {
+	return exclusiveConstraintError.key
+}
+
+// WithKey gives aKey to the errors of the assertions made through the returned AssertionConcern.
+func (assertionConcern AssertionConcern) WithKey(aKey MessageKey) AssertionConcern {
+	assertionConcern.key = aKey
+	return assertionConcern
+}
+
+func (assertionError *AssertionError) MessageKey() MessageKey {
+	return assertionError.key
+}
+
+func (validationError *ValidationError) MessageKey() MessageKey {
+	return ""
+}
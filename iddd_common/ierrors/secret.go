@@ -0,0 +1,40 @@
+package ierrors
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+const REDACTED = "[REDACTED]"
+
+// Secret holds a value, such as a password, that every fmt verb prints as REDACTED.
+type Secret string
+
+func (secret Secret) Reveal() string {
+	return string(secret)
+}
+
+func (secret Secret) IsEmpty() bool {
+	return secret == ""
+}
+
+func (secret Secret) String() string {
+	return REDACTED
+}
+
+func (secret Secret) GoString() string {
+	return REDACTED
+}
+
+// Format also covers %q, %x and the other verbs that would otherwise print the underlying string.
+func (secret Secret) Format(aState fmt.State, aVerb rune) {
+	fmt.Fprint(aState, REDACTED)
+}
+
+func (secret Secret) LogValue() slog.Value {
+	return slog.StringValue(REDACTED)
+}
+
+func (secret Secret) MarshalText() ([]byte, error) {
+	return []byte(REDACTED), nil
+}
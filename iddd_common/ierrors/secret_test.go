@@ -0,0 +1,48 @@
+package ierrors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSecretIsRedacted(t *testing.T) {
+	secret := Secret("qwerty!ASDFG#")
+
+	for _, format := range []string{"%s", "%v", "%+v", "%#v", "%q", "%x", "%10s"} {
+		t.Run(format, func(t *testing.T) {
+			if got := fmt.Sprintf(format, secret); strings.Contains(got, "qwerty") || !strings.Contains(got, REDACTED) {
+				t.Errorf("got %s, want %s", got, REDACTED)
+			}
+		})
+	}
+	t.Run("struct", func(t *testing.T) {
+		if got := fmt.Sprintf("%+v", struct{ Password Secret }{secret}); strings.Contains(got, "qwerty") {
+			t.Errorf("got %s, want the password redacted", got)
+		}
+	})
+	t.Run("json", func(t *testing.T) {
+		got, err := json.Marshal(map[string]Secret{"password": secret})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := `{"password":"[REDACTED]"}`; string(got) != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	})
+	t.Run("slog", func(t *testing.T) {
+		var buffer bytes.Buffer
+		slog.New(slog.NewTextHandler(&buffer, nil)).Info("changing password", "password", secret)
+		if got := buffer.String(); strings.Contains(got, "qwerty") {
+			t.Errorf("got %s, want the password redacted", got)
+		}
+	})
+	t.Run("reveal", func(t *testing.T) {
+		if got := secret.Reveal(); got != "qwerty!ASDFG#" {
+			t.Errorf("got %s, want qwerty!ASDFG#", got)
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package ierrors
+
+import "strings"
+
+// ValidationNotification collects every failed validation rather than stopping at the first, following the notification pattern of IDDD's validators.
+type ValidationNotification struct {
+	fieldErrors []*FieldError
+}
+
+func NewValidationNotification() *ValidationNotification {
+	return &ValidationNotification{fieldErrors: []*FieldError{}}
+}
+
+// Add records anError under aFieldPath, such as "person.emailAddress". A nil error is ignored so that assertions can be passed in directly.
+func (validationNotification *ValidationNotification) Add(aFieldPath string, anError error) {
+	if anError == nil {
+		return
+	}
+	validationNotification.fieldErrors = append(validationNotification.fieldErrors, NewFieldError(aFieldPath, anError))
+}
+
+func (validationNotification *ValidationNotification) HasErrors() bool {
+	return len(validationNotification.fieldErrors) > 0
+}
+
+func (validationNotification *ValidationNotification) Errors() map[string][]error {
+	errors := map[string][]error{}
+	for _, fieldError := range validationNotification.fieldErrors {
+		errors[fieldError.Field()] = append(errors[fieldError.Field()], fieldError.Unwrap())
+	}
+	return errors
+}
+
+// Err returns nil when nothing failed, so that it can be returned as the result of a validation.
+func (validationNotification *ValidationNotification) Err() error {
+	if !validationNotification.HasErrors() {
+		return nil
+	}
+	fieldErrors := make([]*FieldError, len(validationNotification.fieldErrors))
+	copy(fieldErrors, validationNotification.fieldErrors)
+	return &ValidationError{fieldErrors: fieldErrors}
+}
+
+// ValidationError joins the failures of a ValidationNotification in the order they were added. errors.Is and errors.As find each of them.
+type ValidationError struct {
+	fieldErrors []*FieldError
+}
+
+func (validationError *ValidationError) FieldErrors() []*FieldError {
+	fieldErrors := make([]*FieldError, len(validationError.fieldErrors))
+	copy(fieldErrors, validationError.fieldErrors)
+	return fieldErrors
+}
+
+func (validationError *ValidationError) Error() string {
+	messages := make([]string, len(validationError.fieldErrors))
+	for i, fieldError := range validationError.fieldErrors {
+		messages[i] = fieldError.Error()
+	}
+	return strings.Join(messages, " ")
+}
+
+func (validationError *ValidationError) Unwrap() []error {
+	errors := make([]error, len(validationError.fieldErrors))
+	for i, fieldError := range validationError.fieldErrors {
+		errors[i] = fieldError
+	}
+	return errors
+}
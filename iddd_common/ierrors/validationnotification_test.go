@@ -0,0 +1,71 @@
+package ierrors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var errTenantInactive = errors.New("The tenant is not active.")
+
+func TestValidationNotification(t *testing.T) {
+	t.Run("no errors", func(t *testing.T) {
+		validationNotification := NewValidationNotification()
+		validationNotification.Add("name", nil)
+
+		if validationNotification.HasErrors() {
+			t.Errorf("notification must not have errors")
+		}
+		if err := validationNotification.Err(); err != nil {
+			t.Errorf("got %v, want nil", err)
+		}
+	})
+	t.Run("errors", func(t *testing.T) {
+		validationNotification := NewValidationNotification()
+		validationNotification.Add("firstName", NewAssertionConcern("firstName").ArgumentNotEmpty("", "First name is required."))
+		validationNotification.Add("person.emailAddress", NewAssertionConcern("").ArgumentLength("a@b.c", 6, 100, "Email address must be 6 to 100 characters."))
+		validationNotification.Add("firstName", errTenantInactive)
+
+		if !validationNotification.HasErrors() {
+			t.Fatalf("notification must have errors")
+		}
+		gotMessages := map[string][]string{}
+		for fieldPath, errs := range validationNotification.Errors() {
+			for _, err := range errs {
+				gotMessages[fieldPath] = append(gotMessages[fieldPath], err.Error())
+			}
+		}
+		wantMessages := map[string][]string{
+			"firstName":           {"First name is required.", "The tenant is not active."},
+			"person.emailAddress": {"Email address must be 6 to 100 characters."},
+		}
+		if diff := cmp.Diff(wantMessages, gotMessages); diff != "" {
+			t.Errorf("mismatch (-want, +got):\n%s", diff)
+		}
+
+		err := validationNotification.Err()
+		Wrap(&err, "tenant.NewTenant()")
+		if want := "tenant.NewTenant(): First name is required. Email address must be 6 to 100 characters. The tenant is not active."; err.Error() != want {
+			t.Errorf("got %s, want %s", err.Error(), want)
+		}
+		if !errors.Is(err, errTenantInactive) {
+			t.Errorf("errors.Is must find every error")
+		}
+		var assertionError *AssertionError
+		if !errors.As(err, &assertionError) || assertionError.Kind() != ASSERTION_KIND_ARGUMENT_NOT_EMPTY {
+			t.Errorf("errors.As must find the first assertion error")
+		}
+		var validationError *ValidationError
+		if !errors.As(err, &validationError) {
+			t.Fatalf("err type: %T, expect type: %T", err, validationError)
+		}
+		gotFields := []string{}
+		for _, fieldError := range validationError.FieldErrors() {
+			gotFields = append(gotFields, fieldError.Field())
+		}
+		if diff := cmp.Diff([]string{"firstName", "person.emailAddress", "firstName"}, gotFields); diff != "" {
+			t.Errorf("mismatch (-want, +got):\n%s", diff)
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+)
+
+const (
+	ALGORITHM_HS256 = "HS256"
+	ALGORITHM_EDDSA = "EdDSA"
+)
+
+const MINIMUM_HMAC_SECRET_LENGTH = 32
+
+type Key struct {
+	keyId      string
+	algorithm  string
+	secret     []byte
+	privateKey ed25519.PrivateKey
+	publicKey  ed25519.PublicKey
+}
+
+func NewHmacKey(aKeyId string, aSecret []byte) (_ *Key, err error) {
+	defer ierrors.Wrap(&err, "key.NewHmacKey(%s)", aKeyId)
+
+	if err := ierrors.NewArgumentNotEmptyError(aKeyId, "The key id is required.").GetError(); err != nil {
+		return nil, err
+	}
+	if err := ierrors.NewArgumentTrueErrorArguments(len(aSecret) >= MINIMUM_HMAC_SECRET_LENGTH, fmt.Sprintf("The HMAC secret must be at least %d bytes.", MINIMUM_HMAC_SECRET_LENGTH)).GetError(); err != nil {
+		return nil, err
+	}
+
+	secret := make([]byte, len(aSecret))
+	copy(secret, aSecret)
+	return &Key{keyId: aKeyId, algorithm: ALGORITHM_HS256, secret: secret}, nil
+}
+
+func NewEd25519Key(aKeyId string, aPrivateKey ed25519.PrivateKey) (_ *Key, err error) {
+	defer ierrors.Wrap(&err, "key.NewEd25519Key(%s)", aKeyId)
+
+	if err := ierrors.NewArgumentNotEmptyError(aKeyId, "The key id is required.").GetError(); err != nil {
+		return nil, err
+	}
+	if err := ierrors.NewArgumentTrueErrorArguments(len(aPrivateKey) == ed25519.PrivateKeySize, "The Ed25519 private key is invalid.").GetError(); err != nil {
+		return nil, err
+	}
+
+	publicKey, _ := aPrivateKey.Public().(ed25519.PublicKey)
+	return &Key{keyId: aKeyId, algorithm: ALGORITHM_EDDSA, privateKey: aPrivateKey, publicKey: publicKey}, nil
+}
+
+func NewEd25519VerificationKey(aKeyId string, aPublicKey ed25519.PublicKey) (_ *Key, err error) {
+	defer ierrors.Wrap(&err, "key.NewEd25519VerificationKey(%s)", aKeyId)
+
+	if err := ierrors.NewArgumentNotEmptyError(aKeyId, "The key id is required.").GetError(); err != nil {
+		return nil, err
+	}
+	if err := ierrors.NewArgumentTrueErrorArguments(len(aPublicKey) == ed25519.PublicKeySize, "The Ed25519 public key is invalid.").GetError(); err != nil {
+		return nil, err
+	}
+
+	return &Key{keyId: aKeyId, algorithm: ALGORITHM_EDDSA, publicKey: aPublicKey}, nil
+}
+
+func (key *Key) KeyId() string {
+	return key.keyId
+}
+
+func (key *Key) Algorithm() string {
+	return key.algorithm
+}
+
+func (key *Key) CanSign() bool {
+	return len(key.secret) > 0 || len(key.privateKey) > 0
+}
+
+func (key *Key) sign(aSigningInput []byte) ([]byte, error) {
+	switch {
+	case key.algorithm == ALGORITHM_HS256:
+		mac := hmac.New(sha256.New, key.secret)
+		mac.Write(aSigningInput)
+		return mac.Sum(nil), nil
+	case key.algorithm == ALGORITHM_EDDSA && len(key.privateKey) > 0:
+		return ed25519.Sign(key.privateKey, aSigningInput), nil
+	}
+	return nil, fmt.Errorf("The key %s cannot sign.", key.keyId)
+}
+
+func (key *Key) verify(aSigningInput []byte, aSignature []byte) bool {
+	switch key.algorithm {
+	case ALGORITHM_HS256:
+		mac := hmac.New(sha256.New, key.secret)
+		mac.Write(aSigningInput)
+		return hmac.Equal(mac.Sum(nil), aSignature)
+	case ALGORITHM_EDDSA:
+		return ed25519.Verify(key.publicKey, aSigningInput, aSignature)
+	}
+	return false
+}
+
+func (key *Key) String() string {
+	return fmt.Sprintf("Key [keyId=%s, algorithm=%s, canSign=%v]", key.keyId, key.algorithm, key.CanSign())
+}
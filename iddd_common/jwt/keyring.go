@@ -0,0 +1,112 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+)
+
+type Keyring struct {
+	activeKeyId string
+	keys        map[string]*Key
+}
+
+func NewKeyring(anActiveKeyId string, aKeys ...*Key) (_ *Keyring, err error) {
+	defer ierrors.Wrap(&err, "keyring.NewKeyring(%s)", anActiveKeyId)
+
+	keys := map[string]*Key{}
+	for _, key := range aKeys {
+		if _, ok := keys[key.keyId]; ok {
+			return nil, fmt.Errorf("The key id %s is duplicated.", key.keyId)
+		}
+		keys[key.keyId] = key
+	}
+	activeKey, ok := keys[anActiveKeyId]
+	if !ok {
+		return nil, fmt.Errorf("The active key %s is not in the keyring.", anActiveKeyId)
+	}
+	if !activeKey.CanSign() {
+		return nil, fmt.Errorf("The active key %s cannot sign.", anActiveKeyId)
+	}
+
+	return &Keyring{activeKeyId: anActiveKeyId, keys: keys}, nil
+}
+
+type keyringFile struct {
+	ActiveKeyId string    `json:"activeKeyId"`
+	Keys        []keyFile `json:"keys"`
+}
+
+type keyFile struct {
+	KeyId      string `json:"keyId"`
+	Algorithm  string `json:"algorithm"`
+	Secret     string `json:"secret,omitempty"`
+	PrivateKey string `json:"privateKey,omitempty"`
+	PublicKey  string `json:"publicKey,omitempty"`
+}
+
+// LoadKeyring reads a JSON keyring whose secrets and keys are standard base64. Ed25519 private keys may be either the 32 byte seed or the 64 byte key.
+func LoadKeyring(aPath string) (_ *Keyring, err error) {
+	defer ierrors.Wrap(&err, "keyring.LoadKeyring(%s)", aPath)
+
+	data, err := os.ReadFile(aPath)
+	if err != nil {
+		return nil, err
+	}
+	var file keyringFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	keys := make([]*Key, 0, len(file.Keys))
+	for _, entry := range file.Keys {
+		key, err := entry.toKey()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return NewKeyring(file.ActiveKeyId, keys...)
+}
+
+func (keyFile keyFile) toKey() (*Key, error) {
+	switch keyFile.Algorithm {
+	case ALGORITHM_HS256:
+		secret, err := base64.StdEncoding.DecodeString(keyFile.Secret)
+		if err != nil {
+			return nil, err
+		}
+		return NewHmacKey(keyFile.KeyId, secret)
+	case ALGORITHM_EDDSA:
+		if keyFile.PrivateKey == "" {
+			publicKey, err := base64.StdEncoding.DecodeString(keyFile.PublicKey)
+			if err != nil {
+				return nil, err
+			}
+			return NewEd25519VerificationKey(keyFile.KeyId, publicKey)
+		}
+		privateKey, err := base64.StdEncoding.DecodeString(keyFile.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		if len(privateKey) == ed25519.SeedSize {
+			privateKey = ed25519.NewKeyFromSeed(privateKey)
+		}
+		return NewEd25519Key(keyFile.KeyId, privateKey)
+	}
+	return nil, fmt.Errorf("The algorithm %s of key %s is not supported.", keyFile.Algorithm, keyFile.KeyId)
+}
+
+func (keyring *Keyring) ActiveKey() *Key {
+	return keyring.keys[keyring.activeKeyId]
+}
+
+func (keyring *Keyring) KeyOfId(aKeyId string) (*Key, bool) {
+	key, ok := keyring.keys[aKeyId]
+	return key, ok
+}
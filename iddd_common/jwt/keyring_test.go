@@ -0,0 +1,92 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewKeyring(t *testing.T) {
+	t.Run("fail unknown active key", func(t *testing.T) {
+		key, err := NewHmacKey("hs-1", []byte(strings.Repeat("s", MINIMUM_HMAC_SECRET_LENGTH)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := NewKeyring("hs-2", key); err == nil {
+			t.Errorf("keyring without its active key must be rejected")
+		}
+	})
+	t.Run("fail active key cannot sign", func(t *testing.T) {
+		publicKey, _, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, err := NewEd25519VerificationKey("ed-1", publicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := NewKeyring("ed-1", key); err == nil {
+			t.Errorf("keyring with a verification-only active key must be rejected")
+		}
+	})
+	t.Run("fail short HMAC secret", func(t *testing.T) {
+		if _, err := NewHmacKey("hs-1", []byte("short")); err == nil {
+			t.Errorf("short HMAC secret must be rejected")
+		}
+	})
+}
+
+func TestLoadKeyringRotation(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("s", MINIMUM_HMAC_SECRET_LENGTH)))
+	seed := base64.StdEncoding.EncodeToString(privateKey.Seed())
+	path := filepath.Join(t.TempDir(), "keyring.json")
+	writeKeyring := func(anActiveKeyId string) *Keyring {
+		t.Helper()
+
+		content := fmt.Sprintf(`{"activeKeyId": %q, "keys": [
+			{"keyId": "2022-01", "algorithm": "HS256", "secret": %q},
+			{"keyId": "2022-02", "algorithm": "EdDSA", "privateKey": %q}
+		]}`, anActiveKeyId, secret, seed)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		keyring, err := LoadKeyring(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return keyring
+	}
+	expectation := Expectation{Issuer: "iddd", Audience: "collaboration"}
+
+	oldKeyring := writeKeyring("2022-01")
+	oldToken, err := Sign(oldKeyring, newTestClaims(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rotatedKeyring := writeKeyring("2022-02")
+	if got := rotatedKeyring.ActiveKey().Algorithm(); got != ALGORITHM_EDDSA {
+		t.Errorf("got %s, want %s", got, ALGORITHM_EDDSA)
+	}
+	newToken, err := Sign(rotatedKeyring, newTestClaims(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, token := range map[string]string{"old token": oldToken, "new token": newToken} {
+		t.Run(name, func(t *testing.T) {
+			if err := Verify(rotatedKeyring, token, expectation, &testClaims{}); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package jwt
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+)
+
+var (
+	ErrTokenMalformed        = errors.New("The token is malformed.")
+	ErrTokenUnknownKey       = errors.New("The token is signed by an unknown key.")
+	ErrTokenSignatureInvalid = errors.New("The token signature is invalid.")
+	ErrTokenExpired          = errors.New("The token is expired.")
+	ErrTokenNotYetValid      = errors.New("The token is not yet valid.")
+	ErrTokenIssuerInvalid    = errors.New("The token issuer is invalid.")
+	ErrTokenAudienceInvalid  = errors.New("The token audience is invalid.")
+)
+
+type RegisteredClaims struct {
+	Issuer    string `json:"iss"`
+	Subject   string `json:"sub,omitempty"`
+	Audience  string `json:"aud"`
+	ExpiresAt int64  `json:"exp"`
+	NotBefore int64  `json:"nbf,omitempty"`
+	IssuedAt  int64  `json:"iat,omitempty"`
+	Id        string `json:"jti,omitempty"`
+}
+
+func (registeredClaims *RegisteredClaims) Registered() *RegisteredClaims {
+	return registeredClaims
+}
+
+type Claims interface {
+	Registered() *RegisteredClaims
+}
+
+type Expectation struct {
+	Issuer   string
+	Audience string
+	Leeway   time.Duration
+}
+
+type header struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+	KeyId     string `json:"kid"`
+}
+
+func Sign(aKeyring *Keyring, aClaims Claims) (_ string, err error) {
+	defer ierrors.Wrap(&err, "token.Sign()")
+
+	key := aKeyring.ActiveKey()
+	encodedHeader, err := encodeSegment(header{Algorithm: key.algorithm, Type: "JWT", KeyId: key.keyId})
+	if err != nil {
+		return "", err
+	}
+	encodedClaims, err := encodeSegment(aClaims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := encodedHeader + "." + encodedClaims
+	signature, err := key.sign([]byte(signingInput))
+	if err != nil {
+		return "", err
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// Verify checks the signature with the key named by the token's kid, then the exp, nbf, iss and aud claims, and finally decodes the claims into aClaims.
+func Verify(aKeyring *Keyring, aToken string, anExpectation Expectation, aClaims Claims) (err error) {
+	defer ierrors.Wrap(&err, "token.Verify()")
+
+	segments := strings.Split(aToken, ".")
+	if len(segments) != 3 {
+		return ErrTokenMalformed
+	}
+
+	var tokenHeader header
+	if err := decodeSegment(segments[0], &tokenHeader); err != nil {
+		return ErrTokenMalformed
+	}
+	key, ok := aKeyring.KeyOfId(tokenHeader.KeyId)
+	if !ok {
+		return ErrTokenUnknownKey
+	}
+	if tokenHeader.Algorithm != key.algorithm {
+		return ErrTokenSignatureInvalid
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(segments[2])
+	if err != nil {
+		return ErrTokenMalformed
+	}
+	if !key.verify([]byte(segments[0]+"."+segments[1]), signature) {
+		return ErrTokenSignatureInvalid
+	}
+
+	if err := decodeSegment(segments[1], aClaims); err != nil {
+		return ErrTokenMalformed
+	}
+	registeredClaims := aClaims.Registered()
+	now := time.Now()
+	if registeredClaims.ExpiresAt == 0 || !now.Before(time.Unix(registeredClaims.ExpiresAt, 0).Add(anExpectation.Leeway)) {
+		return ErrTokenExpired
+	}
+	if registeredClaims.NotBefore != 0 && now.Add(anExpectation.Leeway).Before(time.Unix(registeredClaims.NotBefore, 0)) {
+		return ErrTokenNotYetValid
+	}
+	if registeredClaims.Issuer != anExpectation.Issuer {
+		return ErrTokenIssuerInvalid
+	}
+	if registeredClaims.Audience != anExpectation.Audience {
+		return ErrTokenAudienceInvalid
+	}
+
+	return nil
+}
+
+func encodeSegment(aValue interface{}) (string, error) {
+	data, err := json.Marshal(aValue)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeSegment(aSegment string, aValue interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(aSegment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, aValue)
+}
@@ -0,0 +1,128 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testClaims struct {
+	RegisteredClaims
+	Name string `json:"name"`
+}
+
+func newTestClaims(aLifetime time.Duration) *testClaims {
+	now := time.Now()
+	return &testClaims{
+		RegisteredClaims: RegisteredClaims{Issuer: "iddd", Audience: "collaboration", ExpiresAt: now.Add(aLifetime).Unix(), IssuedAt: now.Unix()},
+		Name:             "zoe",
+	}
+}
+
+func newHmacKeyring(t *testing.T, aKeyId string) *Keyring {
+	t.Helper()
+
+	key, err := NewHmacKey(aKeyId, []byte(strings.Repeat("s", MINIMUM_HMAC_SECRET_LENGTH)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyring, err := NewKeyring(aKeyId, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keyring
+}
+
+func TestSignAndVerify(t *testing.T) {
+	expectation := Expectation{Issuer: "iddd", Audience: "collaboration"}
+
+	_, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ed25519Key, err := NewEd25519Key("ed-1", privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ed25519Keyring, err := NewKeyring("ed-1", ed25519Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, keyring := range map[string]*Keyring{"HS256": newHmacKeyring(t, "hs-1"), "EdDSA": ed25519Keyring} {
+		t.Run(name, func(t *testing.T) {
+			token, err := Sign(keyring, newTestClaims(time.Minute))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := &testClaims{}
+			if err := Verify(keyring, token, expectation, got); err != nil {
+				t.Fatal(err)
+			}
+			if got.Name != "zoe" {
+				t.Errorf("got %s, want %s", got.Name, "zoe")
+			}
+		})
+	}
+}
+
+func TestVerifyFailure(t *testing.T) {
+	keyring := newHmacKeyring(t, "hs-1")
+	validToken, err := Sign(keyring, newTestClaims(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expiredToken, err := Sign(keyring, newTestClaims(-time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKeyToken, err := Sign(newHmacKeyring(t, "hs-2"), newTestClaims(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	segments := strings.Split(validToken, ".")
+	tamperedToken := segments[0] + "." + segments[1] + "x." + segments[2]
+
+	tests := []struct {
+		name        string
+		token       string
+		expectation Expectation
+		want        error
+	}{
+		{name: "malformed", token: "not a token", expectation: Expectation{Issuer: "iddd", Audience: "collaboration"}, want: ErrTokenMalformed},
+		{name: "tampered", token: tamperedToken, expectation: Expectation{Issuer: "iddd", Audience: "collaboration"}, want: ErrTokenSignatureInvalid},
+		{name: "unknown key", token: otherKeyToken, expectation: Expectation{Issuer: "iddd", Audience: "collaboration"}, want: ErrTokenUnknownKey},
+		{name: "expired", token: expiredToken, expectation: Expectation{Issuer: "iddd", Audience: "collaboration"}, want: ErrTokenExpired},
+		{name: "other issuer", token: validToken, expectation: Expectation{Issuer: "other", Audience: "collaboration"}, want: ErrTokenIssuerInvalid},
+		{name: "other audience", token: validToken, expectation: Expectation{Issuer: "iddd", Audience: "other"}, want: ErrTokenAudienceInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(keyring, tt.token, tt.expectation, &testClaims{})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyAlgorithmMismatch(t *testing.T) {
+	keyring := newHmacKeyring(t, "hs-1")
+	token, err := Sign(keyring, newTestClaims(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	forgedHeader, err := encodeSegment(header{Algorithm: ALGORITHM_EDDSA, Type: "JWT", KeyId: "hs-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	segments := strings.Split(token, ".")
+
+	err = Verify(keyring, forgedHeader+"."+segments[1]+"."+segments[2], Expectation{Issuer: "iddd", Audience: "collaboration"}, &testClaims{})
+	if !errors.Is(err, ErrTokenSignatureInvalid) {
+		t.Errorf("got %v, want %v", err, ErrTokenSignatureInvalid)
+	}
+}
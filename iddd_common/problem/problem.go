@@ -0,0 +1,173 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+)
+
+const CONTENT_TYPE = "application/problem+json"
+
+const (
+	TYPE_ABOUT_BLANK          = "about:blank"
+	TYPE_ARGUMENT_NOT_EMPTY   = "urn:iddd:problem:argument-not-empty"
+	TYPE_ARGUMENT_LENGTH      = "urn:iddd:problem:argument-length"
+	TYPE_ARGUMENT_TRUE        = "urn:iddd:problem:argument-true"
+	TYPE_ARGUMENT_FALSE       = "urn:iddd:problem:argument-false"
+	TYPE_EXCLUSIVE_CONSTRAINT = "urn:iddd:problem:exclusive-constraint"
+	TYPE_VALIDATION           = "urn:iddd:problem:validation"
+
+	// TYPE_ASSERTION_PREFIX is followed by the ierrors.AssertionKind, so that the argument kinds share the types above.
+	TYPE_ASSERTION_PREFIX = "urn:iddd:problem:"
+)
+
+// Problem follows RFC 9457. Code is an extension member holding the ierrors.Code of the error,
+// and is left to the invalid params for a single invalid argument.
+type Problem struct {
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status"`
+	Code          string         `json:"code,omitempty"`
+	Detail        string         `json:"detail,omitempty"`
+	Instance      string         `json:"instance,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+}
+
+type InvalidParam struct {
+	Name       string `json:"name,omitempty"`
+	Code       string `json:"code,omitempty"`
+	Reason     string `json:"reason"`
+	Minimum    *int   `json:"minimum,omitempty"`
+	Maximum    *int   `json:"maximum,omitempty"`
+	Constraint string `json:"constraint,omitempty"`
+}
+
+func (problem *Problem) Write(aResponseWriter http.ResponseWriter) {
+	aResponseWriter.Header().Set("Content-Type", CONTENT_TYPE)
+	aResponseWriter.WriteHeader(problem.Status)
+	if err := json.NewEncoder(aResponseWriter).Encode(problem); err != nil {
+		log.Printf("problem.Write(): %v", err)
+	}
+}
+
+type mapping struct {
+	target      error
+	status      int
+	problemType string
+	title       string
+}
+
+// Translator turns error chains into problem documents. Registered errors are matched with errors.Is before the ierrors types.
+type Translator struct {
+	mappings []mapping
+}
+
+func NewTranslator() *Translator {
+	return &Translator{}
+}
+
+func (translator *Translator) Register(anError error, aStatus int, aType string, aTitle string) {
+	translator.mappings = append(translator.mappings, mapping{target: anError, status: aStatus, problemType: aType, title: aTitle})
+}
+
+// Translate reports only the innermost message as the detail, since the operations added by ierrors.Wrap may carry arguments such as passwords.
+// Errors it does not know become 500 responses without any detail.
+func (translator *Translator) Translate(anError error) *Problem {
+	return translator.TranslateIn(anError, "")
+}
+
+// TranslateIn localizes the detail and the reasons with ierrors.Localize. aLanguage may be an Accept-Language header; the titles stay in English.
+func (translator *Translator) TranslateIn(anError error, aLanguage string) *Problem {
+	for _, mapping := range translator.mappings {
+		if errors.Is(anError, mapping.target) {
+			return &Problem{Type: mapping.problemType, Title: mapping.title, Status: mapping.status, Code: string(ierrors.CodeOf(mapping.target)), Detail: ierrors.Localize(anError, aLanguage)}
+		}
+	}
+
+	var exclusiveConstraintError *ierrors.ExclusiveConstraintError
+	if errors.As(anError, &exclusiveConstraintError) {
+		return &Problem{Type: TYPE_EXCLUSIVE_CONSTRAINT, Title: "The exclusive constraint is violated.", Status: http.StatusConflict, Code: string(exclusiveConstraintError.Code()), Detail: ierrors.Localize(exclusiveConstraintError, aLanguage)}
+	}
+
+	// A single failure reads the same whether or not it was collected by a ValidationNotification.
+	var validationError *ierrors.ValidationError
+	if errors.As(anError, &validationError) && len(validationError.FieldErrors()) > 1 {
+		return validationProblem(validationError, aLanguage)
+	}
+
+	var assertionError *ierrors.AssertionError
+	if errors.As(anError, &assertionError) && assertionError.Kind().IsState() {
+		return &Problem{Type: TYPE_ASSERTION_PREFIX + string(assertionError.Kind()), Title: "The state is invalid.", Status: http.StatusConflict, Code: string(assertionError.Code()), Detail: ierrors.Localize(assertionError, aLanguage)}
+	}
+
+	if problem := invalidArgumentProblem(anError, aLanguage); problem != nil {
+		var fieldError *ierrors.FieldError
+		if problem.InvalidParams[0].Name == "" && errors.As(anError, &fieldError) {
+			problem.InvalidParams[0].Name = fieldError.Field()
+		}
+		return problem
+	}
+
+	return &Problem{Type: TYPE_ABOUT_BLANK, Title: http.StatusText(http.StatusInternalServerError), Status: http.StatusInternalServerError}
+}
+
+func invalidArgumentProblem(anError error, aLanguage string) *Problem {
+	var argumentNotEmptyError *ierrors.ArgumentNotEmptyError
+	var argumentLengthError *ierrors.ArgumentLengthError
+	var argumentTrueError *ierrors.ArgumentTrueError
+	var argumentFalseError *ierrors.ArgumentFalseError
+	var assertionError *ierrors.AssertionError
+
+	switch {
+	case errors.As(anError, &assertionError):
+		return newInvalidArgumentProblem(TYPE_ASSERTION_PREFIX+string(assertionError.Kind()), assertionTitle(assertionError.Kind()), InvalidParam{Name: assertionError.Field(), Code: string(assertionError.Code()), Reason: ierrors.Localize(assertionError, aLanguage), Constraint: assertionError.Constraint()})
+	case errors.As(anError, &argumentNotEmptyError):
+		return newInvalidArgumentProblem(TYPE_ARGUMENT_NOT_EMPTY, "The argument is empty.", InvalidParam{Code: string(argumentNotEmptyError.Code()), Reason: ierrors.Localize(argumentNotEmptyError, aLanguage)})
+	case errors.As(anError, &argumentLengthError):
+		arguments := argumentLengthError.GetArguments()
+		return newInvalidArgumentProblem(TYPE_ARGUMENT_LENGTH, "The argument length is out of range.", InvalidParam{Code: string(argumentLengthError.Code()), Reason: ierrors.Localize(argumentLengthError, aLanguage), Minimum: &arguments.Minimum, Maximum: &arguments.Maximum})
+	case errors.As(anError, &argumentTrueError):
+		return newInvalidArgumentProblem(TYPE_ARGUMENT_TRUE, "The argument is invalid.", InvalidParam{Code: string(argumentTrueError.Code()), Reason: ierrors.Localize(argumentTrueError, aLanguage)})
+	case errors.As(anError, &argumentFalseError):
+		return newInvalidArgumentProblem(TYPE_ARGUMENT_FALSE, "The argument is invalid.", InvalidParam{Code: string(argumentFalseError.Code()), Reason: ierrors.Localize(argumentFalseError, aLanguage)})
+	}
+
+	var fieldError *ierrors.FieldError
+	if errors.As(anError, &fieldError) {
+		return newInvalidArgumentProblem(TYPE_ABOUT_BLANK, http.StatusText(http.StatusBadRequest), InvalidParam{Reason: ierrors.Localize(fieldError, aLanguage)})
+	}
+	return nil
+}
+
+func validationProblem(aValidationError *ierrors.ValidationError, aLanguage string) *Problem {
+	problem := &Problem{Type: TYPE_VALIDATION, Title: "The arguments are invalid.", Status: http.StatusBadRequest, Code: string(aValidationError.Code())}
+	reasons := []string{}
+	for _, fieldError := range aValidationError.FieldErrors() {
+		invalidParam := invalidArgumentProblem(fieldError, aLanguage).InvalidParams[0]
+		invalidParam.Name = fieldError.Field()
+		problem.InvalidParams = append(problem.InvalidParams, invalidParam)
+		reasons = append(reasons, invalidParam.Reason)
+	}
+	problem.Detail = strings.Join(reasons, " ")
+	return problem
+}
+
+func assertionTitle(anAssertionKind ierrors.AssertionKind) string {
+	switch anAssertionKind {
+	case ierrors.ASSERTION_KIND_ARGUMENT_NOT_NULL, ierrors.ASSERTION_KIND_ARGUMENT_NOT_EMPTY:
+		return "The argument is empty."
+	case ierrors.ASSERTION_KIND_ARGUMENT_LENGTH:
+		return "The argument length is out of range."
+	case ierrors.ASSERTION_KIND_ARGUMENT_RANGE:
+		return "The argument is out of range."
+	}
+	return "The argument is invalid."
+}
+
+func newInvalidArgumentProblem(aType string, aTitle string, anInvalidParam InvalidParam) *Problem {
+	return &Problem{Type: aType, Title: aTitle, Status: http.StatusBadRequest, Detail: anInvalidParam.Reason, InvalidParams: []InvalidParam{anInvalidParam}}
+}
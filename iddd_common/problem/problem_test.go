@@ -0,0 +1,195 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/google/go-cmp/cmp"
+)
+
+var (
+	errTenantNotFound = errors.New("The tenant does not exist.")
+	errUserNotFound   = ierrors.New("USER_NOT_FOUND", "USER_NOT_FOUND", "The user does not exist.")
+)
+
+func wrapped(anError error, aField string) error {
+	err := anError
+	ierrors.Wrap(&err, "tenant.NewTenant(%s)", "secret")
+	if aField != "" {
+		err = ierrors.NewFieldError(aField, err)
+	}
+	ierrors.Wrap(&err, "identityapplicationservice.ProvisionTenant(%s)", "secret")
+	return err
+}
+
+func newValidationError() error {
+	validationNotification := ierrors.NewValidationNotification()
+	validationNotification.Add("firstName", ierrors.NewArgumentNotEmptyError("", "First name is required.").GetError())
+	passwordErr := fmt.Errorf("The password must be stronger.")
+	ierrors.Wrap(&passwordErr, "user.assertPasswordNotWeak(%s)", "secret")
+	validationNotification.Add("password", passwordErr)
+	return validationNotification.Err()
+}
+
+func newSingleValidationError() error {
+	validationNotification := ierrors.NewValidationNotification()
+	validationNotification.Add("firstName", ierrors.NewArgumentNotEmptyError("", "First name is required.").GetError())
+	return validationNotification.Err()
+}
+
+func intPointer(anInt int) *int {
+	return &anInt
+}
+
+func TestTranslatorTranslate(t *testing.T) {
+	translator := NewTranslator()
+	translator.Register(errTenantNotFound, http.StatusNotFound, "urn:iddd:problem:tenant-not-found", "The tenant is not found.")
+	translator.Register(errUserNotFound, http.StatusNotFound, "urn:iddd:problem:user-not-found", "The user is not found.")
+
+	tests := []struct {
+		name string
+		err  error
+		want *Problem
+	}{
+		{
+			name: "registered error",
+			err:  fmt.Errorf("identityapplicationservice.Tenant(secret): %w", errTenantNotFound),
+			want: &Problem{Type: "urn:iddd:problem:tenant-not-found", Title: "The tenant is not found.", Status: http.StatusNotFound, Detail: "The tenant does not exist."},
+		},
+		{
+			name: "registered error with a code",
+			err:  fmt.Errorf("identityapplicationservice.User(secret): %w", errUserNotFound),
+			want: &Problem{Type: "urn:iddd:problem:user-not-found", Title: "The user is not found.", Status: http.StatusNotFound, Code: "USER_NOT_FOUND", Detail: "The user does not exist."},
+		},
+		{
+			name: "argument not empty error with field",
+			err:  wrapped(ierrors.NewArgumentNotEmptyError("", "The tenant name is required.").GetError(), "name"),
+			want: &Problem{Type: TYPE_ARGUMENT_NOT_EMPTY, Title: "The argument is empty.", Status: http.StatusBadRequest, Detail: "The tenant name is required.", InvalidParams: []InvalidParam{{Name: "name", Code: "ARGUMENT_NOT_EMPTY", Reason: "The tenant name is required."}}},
+		},
+		{
+			name: "argument length error",
+			err:  wrapped(ierrors.NewArgumentLengthError("ab", 3, 250, "The username must be 3 to 250 characters.").GetError(), "username"),
+			want: &Problem{Type: TYPE_ARGUMENT_LENGTH, Title: "The argument length is out of range.", Status: http.StatusBadRequest, Detail: "The username must be 3 to 250 characters.", InvalidParams: []InvalidParam{{Name: "username", Code: "ARGUMENT_LENGTH", Reason: "The username must be 3 to 250 characters.", Minimum: intPointer(3), Maximum: intPointer(250)}}},
+		},
+		{
+			name: "argument true error without field",
+			err:  wrapped(ierrors.NewArgumentTrueErrorArguments(false, "Tenant is not active.").GetError(), ""),
+			want: &Problem{Type: TYPE_ARGUMENT_TRUE, Title: "The argument is invalid.", Status: http.StatusBadRequest, Detail: "Tenant is not active.", InvalidParams: []InvalidParam{{Code: "ARGUMENT_TRUE", Reason: "Tenant is not active."}}},
+		},
+		{
+			name: "argument false error",
+			err:  wrapped(ierrors.NewArgumentFalseError(true, "Group recursion.").GetError(), ""),
+			want: &Problem{Type: TYPE_ARGUMENT_FALSE, Title: "The argument is invalid.", Status: http.StatusBadRequest, Detail: "Group recursion.", InvalidParams: []InvalidParam{{Code: "ARGUMENT_FALSE", Reason: "Group recursion."}}},
+		},
+		{
+			name: "assertion error",
+			err:  wrapped(ierrors.NewAssertionConcern("username").ArgumentLength("ab", 3, 250, "The username must be 3 to 250 characters."), ""),
+			want: &Problem{Type: TYPE_ARGUMENT_LENGTH, Title: "The argument length is out of range.", Status: http.StatusBadRequest, Detail: "The username must be 3 to 250 characters.", InvalidParams: []InvalidParam{{Name: "username", Code: "ARGUMENT_LENGTH", Reason: "The username must be 3 to 250 characters.", Constraint: "3..250"}}},
+		},
+		{
+			name: "assertion error with field error",
+			err:  wrapped(ierrors.NewAssertionConcern("").ArgumentRange(0, 1, 10, "The attempts must be 1 to 10."), "attempts"),
+			want: &Problem{Type: TYPE_ASSERTION_PREFIX + "argument-range", Title: "The argument is out of range.", Status: http.StatusBadRequest, Detail: "The attempts must be 1 to 10.", InvalidParams: []InvalidParam{{Name: "attempts", Code: "ARGUMENT_RANGE", Reason: "The attempts must be 1 to 10.", Constraint: "1..10"}}},
+		},
+		{
+			name: "state assertion error",
+			err:  wrapped(ierrors.NewAssertionConcern("").StateTrue(false, "Tenant is not active."), ""),
+			want: &Problem{Type: TYPE_ASSERTION_PREFIX + "state-true", Title: "The state is invalid.", Status: http.StatusConflict, Code: "STATE_TRUE", Detail: "Tenant is not active."},
+		},
+		{
+			name: "validation error",
+			err:  wrapped(newValidationError(), ""),
+			want: &Problem{Type: TYPE_VALIDATION, Title: "The arguments are invalid.", Status: http.StatusBadRequest, Code: "VALIDATION_FAILED", Detail: "First name is required. The password must be stronger.", InvalidParams: []InvalidParam{{Name: "firstName", Code: "ARGUMENT_NOT_EMPTY", Reason: "First name is required."}, {Name: "password", Reason: "The password must be stronger."}}},
+		},
+		{
+			name: "validation error with a single error",
+			err:  wrapped(newSingleValidationError(), ""),
+			want: &Problem{Type: TYPE_ARGUMENT_NOT_EMPTY, Title: "The argument is empty.", Status: http.StatusBadRequest, Detail: "First name is required.", InvalidParams: []InvalidParam{{Name: "firstName", Code: "ARGUMENT_NOT_EMPTY", Reason: "First name is required."}}},
+		},
+		{
+			name: "plain error with field",
+			err:  wrapped(fmt.Errorf("The password must be stronger."), "password"),
+			want: &Problem{Type: TYPE_ABOUT_BLANK, Title: "Bad Request", Status: http.StatusBadRequest, Detail: "The password must be stronger.", InvalidParams: []InvalidParam{{Name: "password", Reason: "The password must be stronger."}}},
+		},
+		{
+			name: "exclusive constraint error",
+			err:  wrapped(ierrors.NewExclusiveConstraintError(true, "Requester", "Approver", "The roles are mutually exclusive.").GetError(), ""),
+			want: &Problem{Type: TYPE_EXCLUSIVE_CONSTRAINT, Title: "The exclusive constraint is violated.", Status: http.StatusConflict, Code: "EXCLUSIVE_CONSTRAINT", Detail: "The roles are mutually exclusive."},
+		},
+		{
+			name: "unknown error",
+			err:  wrapped(errors.New("dial tcp 10.0.0.1:5432: connection refused"), ""),
+			want: &Problem{Type: TYPE_ABOUT_BLANK, Title: "Internal Server Error", Status: http.StatusInternalServerError},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, translator.Translate(tt.err)); diff != "" {
+				t.Errorf("mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProblemWrite(t *testing.T) {
+	problem := &Problem{Type: TYPE_ARGUMENT_NOT_EMPTY, Title: "The argument is empty.", Status: http.StatusBadRequest, Instance: "/tenants", InvalidParams: []InvalidParam{{Name: "name", Code: "ARGUMENT_NOT_EMPTY", Reason: "The tenant name is required."}}}
+	responseRecorder := httptest.NewRecorder()
+
+	problem.Write(responseRecorder)
+
+	if responseRecorder.Code != http.StatusBadRequest {
+		t.Errorf("got status %d, want %d", responseRecorder.Code, http.StatusBadRequest)
+	}
+	if got := responseRecorder.Header().Get("Content-Type"); got != CONTENT_TYPE {
+		t.Errorf("got content type %s, want %s", got, CONTENT_TYPE)
+	}
+	var document map[string]interface{}
+	if err := json.NewDecoder(responseRecorder.Body).Decode(&document); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := document["invalid-params"]; !ok {
+		t.Errorf("document %v must have invalid-params", document)
+	}
+}
+
+func TestTranslatorTranslateIn(t *testing.T) {
+	translator := NewTranslator()
+
+	tests := []struct {
+		name     string
+		err      error
+		language string
+		want     *Problem
+	}{
+		{
+			name:     "argument length error",
+			err:      wrapped(ierrors.NewArgumentLengthError("ab", 3, 250, "The username must be 3 to 250 characters.").GetError(), "username"),
+			language: "ja-JP,en;q=0.8",
+			want:     &Problem{Type: TYPE_ARGUMENT_LENGTH, Title: "The argument length is out of range.", Status: http.StatusBadRequest, Detail: "3文字以上250文字以下で入力してください。", InvalidParams: []InvalidParam{{Name: "username", Code: "ARGUMENT_LENGTH", Reason: "3文字以上250文字以下で入力してください。", Minimum: intPointer(3), Maximum: intPointer(250)}}},
+		},
+		{
+			name:     "validation error",
+			err:      wrapped(newValidationError(), ""),
+			language: "ja",
+			want:     &Problem{Type: TYPE_VALIDATION, Title: "The arguments are invalid.", Status: http.StatusBadRequest, Code: "VALIDATION_FAILED", Detail: "値を入力してください。 The password must be stronger.", InvalidParams: []InvalidParam{{Name: "firstName", Code: "ARGUMENT_NOT_EMPTY", Reason: "値を入力してください。"}, {Name: "password", Reason: "The password must be stronger."}}},
+		},
+		{
+			name:     "unsupported language",
+			err:      wrapped(ierrors.NewArgumentNotEmptyError("", "The tenant name is required.").GetError(), "name"),
+			language: "fr",
+			want:     &Problem{Type: TYPE_ARGUMENT_NOT_EMPTY, Title: "The argument is empty.", Status: http.StatusBadRequest, Detail: "The tenant name is required.", InvalidParams: []InvalidParam{{Name: "name", Code: "ARGUMENT_NOT_EMPTY", Reason: "The tenant name is required."}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, translator.TranslateIn(tt.err, tt.language)); diff != "" {
+				t.Errorf("mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
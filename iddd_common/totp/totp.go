@@ -0,0 +1,165 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+)
+
+type Algorithm int
+
+const (
+	ALGORITHM_SHA1 Algorithm = iota + 1
+	ALGORITHM_SHA256
+)
+
+func (algorithm Algorithm) String() string {
+	switch algorithm {
+	case ALGORITHM_SHA1:
+		return "SHA1"
+	case ALGORITHM_SHA256:
+		return "SHA256"
+	}
+	return "Unknown"
+}
+
+func ParseAlgorithm(aName string) (Algorithm, error) {
+	switch strings.ToUpper(aName) {
+	case "SHA1":
+		return ALGORITHM_SHA1, nil
+	case "SHA256":
+		return ALGORITHM_SHA256, nil
+	}
+	return 0, fmt.Errorf("totp.ParseAlgorithm(%s): The TOTP algorithm must be SHA1 or SHA256.", aName)
+}
+
+func (algorithm Algorithm) hash() func() hash.Hash {
+	if algorithm == ALGORITHM_SHA256 {
+		return sha256.New
+	}
+	return sha1.New
+}
+
+// SecretSize is the key length RFC 6238 uses for each algorithm's reference values.
+func (algorithm Algorithm) SecretSize() int {
+	if algorithm == ALGORITHM_SHA256 {
+		return 32
+	}
+	return 20
+}
+
+const (
+	DEFAULT_DIGITS = 6
+	DEFAULT_PERIOD = 30 * time.Second
+)
+
+type Totp struct {
+	secret    []byte
+	algorithm Algorithm
+	digits    int
+	period    time.Duration
+}
+
+func NewTotp(aSecret []byte, anAlgorithm Algorithm, aDigits int, aPeriod time.Duration) (_ *Totp, err error) {
+	defer ierrors.Wrap(&err, "totp.NewTotp(%v, %d, %v)", anAlgorithm, aDigits, aPeriod)
+
+	if err := ierrors.NewArgumentTrueErrorArguments(len(aSecret) > 0, "The TOTP secret is required.").GetError(); err != nil {
+		return nil, err
+	}
+	isKnownAlgorithm := anAlgorithm == ALGORITHM_SHA1 || anAlgorithm == ALGORITHM_SHA256
+	if err := ierrors.NewArgumentTrueErrorArguments(isKnownAlgorithm, "The TOTP algorithm must be SHA1 or SHA256.").GetError(); err != nil {
+		return nil, err
+	}
+	if err := ierrors.NewArgumentTrueErrorArguments(aDigits >= 6 && aDigits <= 8, "The TOTP digits must be 6 to 8.").GetError(); err != nil {
+		return nil, err
+	}
+	if err := ierrors.NewArgumentTrueErrorArguments(aPeriod >= time.Second, "The TOTP period must be at least a second.").GetError(); err != nil {
+		return nil, err
+	}
+
+	secret := make([]byte, len(aSecret))
+	copy(secret, aSecret)
+	return &Totp{secret: secret, algorithm: anAlgorithm, digits: aDigits, period: aPeriod}, nil
+}
+
+func GenerateSecret(anAlgorithm Algorithm) ([]byte, error) {
+	secret := make([]byte, anAlgorithm.SecretSize())
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+func (totp *Totp) Step(aTime time.Time) int64 {
+	return aTime.Unix() / int64(totp.period/time.Second)
+}
+
+func (totp *Totp) CodeAt(aTime time.Time) string {
+	return totp.codeOfStep(totp.Step(aTime))
+}
+
+func (totp *Totp) codeOfStep(aStep int64) string {
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(aStep))
+
+	mac := hmac.New(totp.algorithm.hash(), totp.secret)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	// dynamic truncation of RFC 4226 section 5.3
+	offset := sum[len(sum)-1] & 0x0f
+	binaryCode := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < totp.digits; i++ {
+		modulo *= 10
+	}
+	return fmt.Sprintf("%0*d", totp.digits, binaryCode%modulo)
+}
+
+// Validate accepts a code from up to aSkew steps either side of aTime and returns the matched step, so callers can refuse replays.
+func (totp *Totp) Validate(aCode string, aTime time.Time, aSkew int) (int64, bool) {
+	if len(aCode) != totp.digits {
+		return 0, false
+	}
+	if _, err := strconv.ParseUint(aCode, 10, 64); err != nil {
+		return 0, false
+	}
+
+	current := totp.Step(aTime)
+	for delta := -int64(aSkew); delta <= int64(aSkew); delta++ {
+		step := current + delta
+		if subtle.ConstantTimeCompare([]byte(totp.codeOfStep(step)), []byte(aCode)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+func (totp *Totp) EncodedSecret() string {
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(totp.secret)
+}
+
+func (totp *Totp) ProvisioningUri(anIssuer string, anAccountName string) string {
+	query := url.Values{}
+	query.Set("secret", totp.EncodedSecret())
+	query.Set("issuer", anIssuer)
+	query.Set("algorithm", totp.algorithm.String())
+	query.Set("digits", strconv.Itoa(totp.digits))
+	query.Set("period", strconv.Itoa(int(totp.period/time.Second)))
+
+	label := url.PathEscape(anIssuer + ":" + anAccountName)
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
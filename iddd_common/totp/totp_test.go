@@ -0,0 +1,127 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// test vectors of RFC 6238 appendix B
+func TestCodeAt(t *testing.T) {
+	sha1Secret := []byte("12345678901234567890")
+	sha256Secret := []byte("12345678901234567890123456789012")
+
+	tests := []struct {
+		unix      int64
+		algorithm Algorithm
+		want      string
+	}{
+		{unix: 59, algorithm: ALGORITHM_SHA1, want: "94287082"},
+		{unix: 59, algorithm: ALGORITHM_SHA256, want: "46119246"},
+		{unix: 1111111109, algorithm: ALGORITHM_SHA1, want: "07081804"},
+		{unix: 1111111109, algorithm: ALGORITHM_SHA256, want: "68084774"},
+		{unix: 1234567890, algorithm: ALGORITHM_SHA1, want: "89005924"},
+		{unix: 1234567890, algorithm: ALGORITHM_SHA256, want: "91819424"},
+		{unix: 20000000000, algorithm: ALGORITHM_SHA1, want: "65353130"},
+		{unix: 20000000000, algorithm: ALGORITHM_SHA256, want: "77737706"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.algorithm.String()+"/"+tt.want, func(t *testing.T) {
+			secret := sha1Secret
+			if tt.algorithm == ALGORITHM_SHA256 {
+				secret = sha256Secret
+			}
+			totp, err := NewTotp(secret, tt.algorithm, 8, DEFAULT_PERIOD)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := totp.CodeAt(time.Unix(tt.unix, 0)); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := GenerateSecret(ALGORITHM_SHA1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	totp, err := NewTotp(secret, ALGORITHM_SHA1, DEFAULT_DIGITS, DEFAULT_PERIOD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1650000000, 0)
+
+	tests := []struct {
+		name string
+		code string
+		want bool
+	}{
+		{name: "current step", code: totp.CodeAt(now), want: true},
+		{name: "previous step within skew", code: totp.CodeAt(now.Add(-DEFAULT_PERIOD)), want: true},
+		{name: "next step within skew", code: totp.CodeAt(now.Add(DEFAULT_PERIOD)), want: true},
+		{name: "outside skew", code: totp.CodeAt(now.Add(-3 * DEFAULT_PERIOD)), want: false},
+		{name: "wrong length", code: "12345", want: false},
+		{name: "not digits", code: "abcdef", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, got := totp.Validate(tt.code, now, 1); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProvisioningUri(t *testing.T) {
+	totp, err := NewTotp([]byte("12345678901234567890"), ALGORITHM_SHA256, DEFAULT_DIGITS, DEFAULT_PERIOD)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := totp.ProvisioningUri("SaaSOvation", "zoe@saasovation.com")
+	want := "otpauth://totp/SaaSOvation:zoe@saasovation.com?algorithm=SHA256&digits=6&issuer=SaaSOvation&period=30&secret=GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+	if !strings.HasPrefix(got, "otpauth://totp/") {
+		t.Errorf("got %s, want otpauth uri", got)
+	}
+}
+
+func TestNewTotpFailure(t *testing.T) {
+	if _, err := NewTotp(nil, ALGORITHM_SHA1, DEFAULT_DIGITS, DEFAULT_PERIOD); err == nil {
+		t.Errorf("empty secret must be rejected")
+	}
+	if _, err := NewTotp([]byte("secret"), Algorithm(0), DEFAULT_DIGITS, DEFAULT_PERIOD); err == nil {
+		t.Errorf("unknown algorithm must be rejected")
+	}
+	if _, err := NewTotp([]byte("secret"), ALGORITHM_SHA1, 4, DEFAULT_PERIOD); err == nil {
+		t.Errorf("4 digits must be rejected")
+	}
+}
+
+func TestParseAlgorithm(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Algorithm
+		wantErr bool
+	}{
+		{name: "SHA1", want: ALGORITHM_SHA1},
+		{name: "sha256", want: ALGORITHM_SHA256},
+		{name: "MD5", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAlgorithm(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got err %v, want err %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package webauthn
+
+import (
+	"encoding/binary"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/cbor"
+)
+
+const (
+	FLAG_USER_PRESENT             = 0x01
+	FLAG_USER_VERIFIED            = 0x04
+	FLAG_ATTESTED_CREDENTIAL_DATA = 0x40
+	FLAG_EXTENSION_DATA           = 0x80
+)
+
+const (
+	rpIdHashLength = 32
+	aaguidLength   = 16
+)
+
+type AuthenticatorData struct {
+	rpIdHash            []byte
+	flags               byte
+	signCount           uint32
+	aaguid              []byte
+	credentialId        []byte
+	credentialPublicKey []byte
+}
+
+func ParseAuthenticatorData(aData []byte) (*AuthenticatorData, error) {
+	if len(aData) < rpIdHashLength+1+4 {
+		return nil, ErrAuthenticatorDataMalformed
+	}
+
+	authenticatorData := &AuthenticatorData{
+		rpIdHash:  aData[:rpIdHashLength],
+		flags:     aData[rpIdHashLength],
+		signCount: binary.BigEndian.Uint32(aData[rpIdHashLength+1:]),
+	}
+	rest := aData[rpIdHashLength+5:]
+
+	if authenticatorData.HasFlag(FLAG_ATTESTED_CREDENTIAL_DATA) {
+		if len(rest) < aaguidLength+2 {
+			return nil, ErrAuthenticatorDataMalformed
+		}
+		authenticatorData.aaguid = rest[:aaguidLength]
+		credentialIdLength := int(binary.BigEndian.Uint16(rest[aaguidLength:]))
+		rest = rest[aaguidLength+2:]
+		if credentialIdLength == 0 || len(rest) < credentialIdLength {
+			return nil, ErrAuthenticatorDataMalformed
+		}
+		authenticatorData.credentialId = rest[:credentialIdLength]
+		rest = rest[credentialIdLength:]
+
+		_, afterPublicKey, err := cbor.Decode(rest)
+		if err != nil {
+			return nil, ErrAuthenticatorDataMalformed
+		}
+		authenticatorData.credentialPublicKey = rest[:len(rest)-len(afterPublicKey)]
+		rest = afterPublicKey
+	}
+
+	if authenticatorData.HasFlag(FLAG_EXTENSION_DATA) {
+		_, afterExtensions, err := cbor.Decode(rest)
+		if err != nil {
+			return nil, ErrAuthenticatorDataMalformed
+		}
+		rest = afterExtensions
+	}
+
+	if len(rest) != 0 {
+		return nil, ErrAuthenticatorDataMalformed
+	}
+	return authenticatorData, nil
+}
+
+func (authenticatorData *AuthenticatorData) RpIdHash() []byte {
+	return authenticatorData.rpIdHash
+}
+
+func (authenticatorData *AuthenticatorData) HasFlag(aFlag byte) bool {
+	return authenticatorData.flags&aFlag == aFlag
+}
+
+func (authenticatorData *AuthenticatorData) SignCount() uint32 {
+	return authenticatorData.signCount
+}
+
+func (authenticatorData *AuthenticatorData) Aaguid() []byte {
+	return authenticatorData.aaguid
+}
+
+func (authenticatorData *AuthenticatorData) CredentialId() []byte {
+	return authenticatorData.credentialId
+}
+
+func (authenticatorData *AuthenticatorData) CredentialPublicKey() []byte {
+	return authenticatorData.credentialPublicKey
+}
@@ -0,0 +1,62 @@
+package webauthn_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/cbor"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/webauthn"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/webauthn/webauthntest"
+)
+
+func TestParseAuthenticatorData(t *testing.T) {
+	authenticator, err := webauthntest.NewAuthenticator(webauthn.COSE_ALGORITHM_ES256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authenticator.SignCount = 42
+	_, attestationObject, err := authenticator.Register(rpId, origin, []byte("challenge"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, _, err := cbor.Decode(attestationObject)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := decoded.(map[interface{}]interface{})["authData"].([]byte)
+
+	t.Run("success", func(t *testing.T) {
+		authenticatorData, err := webauthn.ParseAuthenticatorData(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if authenticatorData.SignCount() != 42 {
+			t.Errorf("got sign count %d, want 42", authenticatorData.SignCount())
+		}
+		if !authenticatorData.HasFlag(webauthn.FLAG_USER_PRESENT | webauthn.FLAG_ATTESTED_CREDENTIAL_DATA) {
+			t.Errorf("user present and attested credential data flags must be set")
+		}
+		if string(authenticatorData.CredentialId()) != string(authenticator.CredentialId()) {
+			t.Errorf("got credential id %x, want %x", authenticatorData.CredentialId(), authenticator.CredentialId())
+		}
+		if string(authenticatorData.CredentialPublicKey()) != string(authenticator.PublicKey()) {
+			t.Errorf("got public key %x, want %x", authenticatorData.CredentialPublicKey(), authenticator.PublicKey())
+		}
+	})
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "too short", data: data[:36]},
+		{name: "truncated credential", data: data[:60]},
+		{name: "trailing bytes", data: append(append([]byte{}, data...), 0x00)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := webauthn.ParseAuthenticatorData(tt.data); !errors.Is(err, webauthn.ErrAuthenticatorDataMalformed) {
+				t.Errorf("got %v, want %v", err, webauthn.ErrAuthenticatorDataMalformed)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package webauthn
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"math/big"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/cbor"
+)
+
+// COSE algorithm, key type and curve identifiers of RFC 9053
+const (
+	COSE_ALGORITHM_ES256 = -7
+	COSE_ALGORITHM_EDDSA = -8
+
+	COSE_KEY_TYPE_OKP = 1
+	COSE_KEY_TYPE_EC2 = 2
+
+	COSE_CURVE_P256    = 1
+	COSE_CURVE_ED25519 = 6
+)
+
+const (
+	coseKeyType      = 1
+	coseKeyAlgorithm = 3
+	coseKeyCurve     = -1
+	coseKeyX         = -2
+	coseKeyY         = -3
+)
+
+type PublicKey struct {
+	algorithm  int64
+	ecdsaKey   *ecdsa.PublicKey
+	ed25519Key ed25519.PublicKey
+}
+
+func ParsePublicKey(aCoseKey []byte) (*PublicKey, error) {
+	decoded, rest, err := cbor.Decode(aCoseKey)
+	if err != nil || len(rest) != 0 {
+		return nil, ErrPublicKeyMalformed
+	}
+	coseKey, ok := decoded.(map[interface{}]interface{})
+	if !ok {
+		return nil, ErrPublicKeyMalformed
+	}
+
+	keyType, _ := coseKey[int64(coseKeyType)].(int64)
+	algorithm, _ := coseKey[int64(coseKeyAlgorithm)].(int64)
+	curve, _ := coseKey[int64(coseKeyCurve)].(int64)
+	x, _ := coseKey[int64(coseKeyX)].([]byte)
+
+	switch {
+	case algorithm == COSE_ALGORITHM_ES256 && keyType == COSE_KEY_TYPE_EC2 && curve == COSE_CURVE_P256:
+		y, _ := coseKey[int64(coseKeyY)].([]byte)
+		if len(x) != 32 || len(y) != 32 {
+			return nil, ErrPublicKeyMalformed
+		}
+		ecdsaKey := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !ecdsaKey.Curve.IsOnCurve(ecdsaKey.X, ecdsaKey.Y) {
+			return nil, ErrPublicKeyMalformed
+		}
+		return &PublicKey{algorithm: algorithm, ecdsaKey: ecdsaKey}, nil
+	case algorithm == COSE_ALGORITHM_EDDSA && keyType == COSE_KEY_TYPE_OKP && curve == COSE_CURVE_ED25519:
+		if len(x) != ed25519.PublicKeySize {
+			return nil, ErrPublicKeyMalformed
+		}
+		return &PublicKey{algorithm: algorithm, ed25519Key: ed25519.PublicKey(x)}, nil
+	}
+	return nil, ErrAlgorithmUnsupported
+}
+
+func (publicKey *PublicKey) Algorithm() int64 {
+	return publicKey.algorithm
+}
+
+func (publicKey *PublicKey) Verify(aMessage []byte, aSignature []byte) bool {
+	if publicKey.algorithm == COSE_ALGORITHM_EDDSA {
+		return ed25519.Verify(publicKey.ed25519Key, aMessage, aSignature)
+	}
+	digest := sha256.Sum256(aMessage)
+	return ecdsa.VerifyASN1(publicKey.ecdsaKey, digest[:], aSignature)
+}
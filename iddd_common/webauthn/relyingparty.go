@@ -0,0 +1,207 @@
+package webauthn
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/cbor"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+)
+
+var (
+	ErrClientDataMalformed          = errors.New("The client data is malformed.")
+	ErrAttestationMalformed         = errors.New("The attestation object is malformed.")
+	ErrAuthenticatorDataMalformed   = errors.New("The authenticator data is malformed.")
+	ErrPublicKeyMalformed           = errors.New("The credential public key is malformed.")
+	ErrAttestationFormatUnsupported = errors.New("The attestation format is not supported.")
+	ErrAlgorithmUnsupported         = errors.New("The credential algorithm is not supported.")
+	ErrCeremonyTypeInvalid          = errors.New("The ceremony type is invalid.")
+	ErrChallengeMismatch            = errors.New("The challenge does not match.")
+	ErrOriginInvalid                = errors.New("The origin is not allowed.")
+	ErrRpIdMismatch                 = errors.New("The relying party id does not match.")
+	ErrUserNotPresent               = errors.New("The user was not present.")
+	ErrUserNotVerified              = errors.New("The user was not verified.")
+	ErrSignatureInvalid             = errors.New("The assertion signature is invalid.")
+	ErrSignCountInvalid             = errors.New("The sign count did not increase, the authenticator may be cloned.")
+)
+
+const (
+	CEREMONY_TYPE_CREATE = "webauthn.create"
+	CEREMONY_TYPE_GET    = "webauthn.get"
+)
+
+const CHALLENGE_LENGTH = 32
+
+type clientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+type RelyingParty struct {
+	id                       string
+	origins                  []string
+	userVerificationRequired bool
+}
+
+func NewRelyingParty(anId string, anOrigins []string, aUserVerificationRequired bool) (_ *RelyingParty, err error) {
+	defer ierrors.Wrap(&err, "relyingparty.NewRelyingParty(%s, %v, %v)", anId, anOrigins, aUserVerificationRequired)
+
+	if err := ierrors.NewArgumentNotEmptyError(anId, "The relying party id is required.").GetError(); err != nil {
+		return nil, err
+	}
+	if err := ierrors.NewArgumentTrueErrorArguments(len(anOrigins) > 0, "At least one origin is required.").GetError(); err != nil {
+		return nil, err
+	}
+
+	origins := make([]string, len(anOrigins))
+	copy(origins, anOrigins)
+	return &RelyingParty{id: anId, origins: origins, userVerificationRequired: aUserVerificationRequired}, nil
+}
+
+func NewChallenge() ([]byte, error) {
+	challenge := make([]byte, CHALLENGE_LENGTH)
+	if _, err := rand.Read(challenge); err != nil {
+		return nil, err
+	}
+	return challenge, nil
+}
+
+func (relyingParty *RelyingParty) Id() string {
+	return relyingParty.id
+}
+
+type Registration struct {
+	credentialId []byte
+	publicKey    []byte
+	signCount    uint32
+}
+
+func (registration *Registration) CredentialId() []byte {
+	return registration.credentialId
+}
+
+func (registration *Registration) PublicKey() []byte {
+	return registration.publicKey
+}
+
+func (registration *Registration) SignCount() uint32 {
+	return registration.signCount
+}
+
+// VerifyRegistration accepts only the "none" attestation format, which is what passkey providers send unless attestation is requested.
+func (relyingParty *RelyingParty) VerifyRegistration(aChallenge []byte, aClientDataJson []byte, anAttestationObject []byte) (_ *Registration, err error) {
+	defer ierrors.Wrap(&err, "relyingparty.VerifyRegistration()")
+
+	if err := relyingParty.verifyClientData(CEREMONY_TYPE_CREATE, aChallenge, aClientDataJson); err != nil {
+		return nil, err
+	}
+
+	decoded, rest, err := cbor.Decode(anAttestationObject)
+	if err != nil || len(rest) != 0 {
+		return nil, ErrAttestationMalformed
+	}
+	attestationObject, ok := decoded.(map[interface{}]interface{})
+	if !ok {
+		return nil, ErrAttestationMalformed
+	}
+	format, _ := attestationObject["fmt"].(string)
+	rawAuthenticatorData, ok := attestationObject["authData"].([]byte)
+	if !ok {
+		return nil, ErrAttestationMalformed
+	}
+	if format != "none" {
+		return nil, ErrAttestationFormatUnsupported
+	}
+
+	authenticatorData, err := relyingParty.verifyAuthenticatorData(rawAuthenticatorData)
+	if err != nil {
+		return nil, err
+	}
+	if !authenticatorData.HasFlag(FLAG_ATTESTED_CREDENTIAL_DATA) {
+		return nil, ErrAuthenticatorDataMalformed
+	}
+	if _, err := ParsePublicKey(authenticatorData.credentialPublicKey); err != nil {
+		return nil, err
+	}
+
+	return &Registration{
+		credentialId: append([]byte{}, authenticatorData.credentialId...),
+		publicKey:    append([]byte{}, authenticatorData.credentialPublicKey...),
+		signCount:    authenticatorData.signCount,
+	}, nil
+}
+
+// VerifyAssertion returns the sign count the credential must be stored with from now on.
+func (relyingParty *RelyingParty) VerifyAssertion(aChallenge []byte, aClientDataJson []byte, anAuthenticatorData []byte, aSignature []byte, aPublicKey []byte, aStoredSignCount uint32) (_ uint32, err error) {
+	defer ierrors.Wrap(&err, "relyingparty.VerifyAssertion()")
+
+	if err := relyingParty.verifyClientData(CEREMONY_TYPE_GET, aChallenge, aClientDataJson); err != nil {
+		return 0, err
+	}
+	authenticatorData, err := relyingParty.verifyAuthenticatorData(anAuthenticatorData)
+	if err != nil {
+		return 0, err
+	}
+
+	publicKey, err := ParsePublicKey(aPublicKey)
+	if err != nil {
+		return 0, err
+	}
+	clientDataHash := sha256.Sum256(aClientDataJson)
+	signed := append(append([]byte{}, anAuthenticatorData...), clientDataHash[:]...)
+	if !publicKey.Verify(signed, aSignature) {
+		return 0, ErrSignatureInvalid
+	}
+
+	// authenticators that do not count always report zero
+	if (authenticatorData.signCount != 0 || aStoredSignCount != 0) && authenticatorData.signCount <= aStoredSignCount {
+		return 0, ErrSignCountInvalid
+	}
+	return authenticatorData.signCount, nil
+}
+
+func (relyingParty *RelyingParty) verifyClientData(aCeremonyType string, aChallenge []byte, aClientDataJson []byte) error {
+	clientData := clientData{}
+	if err := json.Unmarshal(aClientDataJson, &clientData); err != nil {
+		return ErrClientDataMalformed
+	}
+	if clientData.Type != aCeremonyType {
+		return ErrCeremonyTypeInvalid
+	}
+
+	challenge, err := base64.RawURLEncoding.DecodeString(clientData.Challenge)
+	if err != nil || len(aChallenge) == 0 || subtle.ConstantTimeCompare(challenge, aChallenge) != 1 {
+		return ErrChallengeMismatch
+	}
+
+	for _, origin := range relyingParty.origins {
+		if clientData.Origin == origin {
+			return nil
+		}
+	}
+	return ErrOriginInvalid
+}
+
+func (relyingParty *RelyingParty) verifyAuthenticatorData(aData []byte) (*AuthenticatorData, error) {
+	authenticatorData, err := ParseAuthenticatorData(aData)
+	if err != nil {
+		return nil, err
+	}
+
+	rpIdHash := sha256.Sum256([]byte(relyingParty.id))
+	if subtle.ConstantTimeCompare(authenticatorData.rpIdHash, rpIdHash[:]) != 1 {
+		return nil, ErrRpIdMismatch
+	}
+	if !authenticatorData.HasFlag(FLAG_USER_PRESENT) {
+		return nil, ErrUserNotPresent
+	}
+	if relyingParty.userVerificationRequired && !authenticatorData.HasFlag(FLAG_USER_VERIFIED) {
+		return nil, ErrUserNotVerified
+	}
+	return authenticatorData, nil
+}
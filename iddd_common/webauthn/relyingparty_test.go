@@ -0,0 +1,206 @@
+package webauthn_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/webauthn"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/webauthn/webauthntest"
+)
+
+const (
+	rpId   = "saasovation.com"
+	origin = "https://saasovation.com"
+)
+
+func newRelyingParty(t *testing.T) *webauthn.RelyingParty {
+	t.Helper()
+
+	relyingParty, err := webauthn.NewRelyingParty(rpId, []string{origin}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return relyingParty
+}
+
+func newChallenge(t *testing.T) []byte {
+	t.Helper()
+
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return challenge
+}
+
+func TestVerifyRegistrationAndAssertion(t *testing.T) {
+	for _, algorithm := range []int64{webauthn.COSE_ALGORITHM_ES256, webauthn.COSE_ALGORITHM_EDDSA} {
+		t.Run(webauthnAlgorithmName(algorithm), func(t *testing.T) {
+			relyingParty := newRelyingParty(t)
+			authenticator, err := webauthntest.NewAuthenticator(algorithm)
+			if err != nil {
+				t.Fatal(err)
+			}
+			authenticator.SignCount = 1
+
+			challenge := newChallenge(t)
+			clientDataJson, attestationObject, err := authenticator.Register(rpId, origin, challenge)
+			if err != nil {
+				t.Fatal(err)
+			}
+			registration, err := relyingParty.VerifyRegistration(challenge, clientDataJson, attestationObject)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(registration.CredentialId()) != string(authenticator.CredentialId()) {
+				t.Errorf("got credential id %x, want %x", registration.CredentialId(), authenticator.CredentialId())
+			}
+			publicKey, err := webauthn.ParsePublicKey(registration.PublicKey())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if publicKey.Algorithm() != algorithm {
+				t.Errorf("got algorithm %d, want %d", publicKey.Algorithm(), algorithm)
+			}
+
+			challenge = newChallenge(t)
+			clientDataJson, authenticatorData, signature, err := authenticator.Assert(rpId, origin, challenge)
+			if err != nil {
+				t.Fatal(err)
+			}
+			signCount, err := relyingParty.VerifyAssertion(challenge, clientDataJson, authenticatorData, signature, registration.PublicKey(), registration.SignCount())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if signCount != 2 {
+				t.Errorf("got sign count %d, want 2", signCount)
+			}
+		})
+	}
+}
+
+func webauthnAlgorithmName(anAlgorithm int64) string {
+	if anAlgorithm == webauthn.COSE_ALGORITHM_EDDSA {
+		return "EdDSA"
+	}
+	return "ES256"
+}
+
+func TestVerifyRegistrationFailure(t *testing.T) {
+	tests := []struct {
+		name           string
+		rpId           string
+		origin         string
+		flags          byte
+		wrongChallenge bool
+		want           error
+	}{
+		{name: "challenge mismatch", rpId: rpId, origin: origin, flags: webauthn.FLAG_USER_PRESENT | webauthn.FLAG_USER_VERIFIED, wrongChallenge: true, want: webauthn.ErrChallengeMismatch},
+		{name: "origin not allowed", rpId: rpId, origin: "https://evil.example", flags: webauthn.FLAG_USER_PRESENT | webauthn.FLAG_USER_VERIFIED, want: webauthn.ErrOriginInvalid},
+		{name: "rp id mismatch", rpId: "evil.example", origin: origin, flags: webauthn.FLAG_USER_PRESENT | webauthn.FLAG_USER_VERIFIED, want: webauthn.ErrRpIdMismatch},
+		{name: "user not present", rpId: rpId, origin: origin, flags: webauthn.FLAG_USER_VERIFIED, want: webauthn.ErrUserNotPresent},
+		{name: "user not verified", rpId: rpId, origin: origin, flags: webauthn.FLAG_USER_PRESENT, want: webauthn.ErrUserNotVerified},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authenticator, err := webauthntest.NewAuthenticator(webauthn.COSE_ALGORITHM_ES256)
+			if err != nil {
+				t.Fatal(err)
+			}
+			authenticator.Flags = tt.flags
+
+			challenge := newChallenge(t)
+			clientDataJson, attestationObject, err := authenticator.Register(tt.rpId, tt.origin, challenge)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wrongChallenge {
+				challenge = newChallenge(t)
+			}
+
+			_, err = newRelyingParty(t).VerifyRegistration(challenge, clientDataJson, attestationObject)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+	t.Run("assertion is not a registration", func(t *testing.T) {
+		authenticator, err := webauthntest.NewAuthenticator(webauthn.COSE_ALGORITHM_ES256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		challenge := newChallenge(t)
+		clientDataJson, err := webauthntest.ClientDataJson(webauthn.CEREMONY_TYPE_GET, origin, challenge)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, attestationObject, err := authenticator.Register(rpId, origin, challenge)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = newRelyingParty(t).VerifyRegistration(challenge, clientDataJson, attestationObject)
+		if !errors.Is(err, webauthn.ErrCeremonyTypeInvalid) {
+			t.Errorf("got %v, want %v", err, webauthn.ErrCeremonyTypeInvalid)
+		}
+	})
+}
+
+func TestVerifyAssertionFailure(t *testing.T) {
+	relyingParty := newRelyingParty(t)
+	authenticator, err := webauthntest.NewAuthenticator(webauthn.COSE_ALGORITHM_EDDSA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authenticator.SignCount = 5
+
+	t.Run("signature by other key", func(t *testing.T) {
+		other, err := webauthntest.NewAuthenticator(webauthn.COSE_ALGORITHM_EDDSA)
+		if err != nil {
+			t.Fatal(err)
+		}
+		challenge := newChallenge(t)
+		clientDataJson, authenticatorData, signature, err := other.Assert(rpId, origin, challenge)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = relyingParty.VerifyAssertion(challenge, clientDataJson, authenticatorData, signature, authenticator.PublicKey(), 0)
+		if !errors.Is(err, webauthn.ErrSignatureInvalid) {
+			t.Errorf("got %v, want %v", err, webauthn.ErrSignatureInvalid)
+		}
+	})
+	t.Run("sign count not increased", func(t *testing.T) {
+		challenge := newChallenge(t)
+		clientDataJson, authenticatorData, signature, err := authenticator.Assert(rpId, origin, challenge)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = relyingParty.VerifyAssertion(challenge, clientDataJson, authenticatorData, signature, authenticator.PublicKey(), authenticator.SignCount)
+		if !errors.Is(err, webauthn.ErrSignCountInvalid) {
+			t.Errorf("got %v, want %v", err, webauthn.ErrSignCountInvalid)
+		}
+	})
+	t.Run("sign count zero without counter", func(t *testing.T) {
+		authenticator.SignCount = 0
+		challenge := newChallenge(t)
+		clientDataJson, authenticatorData, signature, err := authenticator.Assert(rpId, origin, challenge)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := relyingParty.VerifyAssertion(challenge, clientDataJson, authenticatorData, signature, authenticator.PublicKey(), 0); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestParsePublicKeyFailure(t *testing.T) {
+	if _, err := webauthn.ParsePublicKey([]byte{0xa0}); !errors.Is(err, webauthn.ErrAlgorithmUnsupported) {
+		t.Errorf("got %v, want %v", err, webauthn.ErrAlgorithmUnsupported)
+	}
+	if _, err := webauthn.ParsePublicKey([]byte{0x01}); !errors.Is(err, webauthn.ErrPublicKeyMalformed) {
+		t.Errorf("got %v, want %v", err, webauthn.ErrPublicKeyMalformed)
+	}
+}
@@ -0,0 +1,135 @@
+package webauthntest
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/cbor"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/webauthn"
+)
+
+// Authenticator is a software authenticator, so registration and assertion can be tested without a browser.
+type Authenticator struct {
+	// Flags are set on every authenticator data, user presence and verification by default.
+	Flags     byte
+	SignCount uint32
+
+	algorithm    int64
+	credentialId []byte
+	ecdsaKey     *ecdsa.PrivateKey
+	ed25519Key   ed25519.PrivateKey
+}
+
+func NewAuthenticator(anAlgorithm int64) (*Authenticator, error) {
+	authenticator := &Authenticator{Flags: webauthn.FLAG_USER_PRESENT | webauthn.FLAG_USER_VERIFIED, algorithm: anAlgorithm, credentialId: make([]byte, 16)}
+	if _, err := rand.Read(authenticator.credentialId); err != nil {
+		return nil, err
+	}
+
+	var err error
+	switch anAlgorithm {
+	case webauthn.COSE_ALGORITHM_ES256:
+		authenticator.ecdsaKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case webauthn.COSE_ALGORITHM_EDDSA:
+		_, authenticator.ed25519Key, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		err = fmt.Errorf("webauthntest.NewAuthenticator(%d): The algorithm is not supported.", anAlgorithm)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return authenticator, nil
+}
+
+func (authenticator *Authenticator) CredentialId() []byte {
+	return authenticator.credentialId
+}
+
+func (authenticator *Authenticator) PublicKey() []byte {
+	coseKey := map[interface{}]interface{}{1: int64(webauthn.COSE_KEY_TYPE_OKP), 3: authenticator.algorithm}
+	if authenticator.algorithm == webauthn.COSE_ALGORITHM_ES256 {
+		x := make([]byte, 32)
+		y := make([]byte, 32)
+		authenticator.ecdsaKey.X.FillBytes(x)
+		authenticator.ecdsaKey.Y.FillBytes(y)
+		coseKey[1] = int64(webauthn.COSE_KEY_TYPE_EC2)
+		coseKey[-1] = int64(webauthn.COSE_CURVE_P256)
+		coseKey[-2] = x
+		coseKey[-3] = y
+	} else {
+		coseKey[-1] = int64(webauthn.COSE_CURVE_ED25519)
+		coseKey[-2] = []byte(authenticator.ed25519Key.Public().(ed25519.PublicKey))
+	}
+	encoded, _ := cbor.Encode(coseKey)
+	return encoded
+}
+
+func (authenticator *Authenticator) Register(aRpId string, anOrigin string, aChallenge []byte) (clientDataJson []byte, attestationObject []byte, err error) {
+	clientDataJson, err = ClientDataJson(webauthn.CEREMONY_TYPE_CREATE, anOrigin, aChallenge)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attestedCredentialData := make([]byte, 16+2)
+	binary.BigEndian.PutUint16(attestedCredentialData[16:], uint16(len(authenticator.credentialId)))
+	attestedCredentialData = append(attestedCredentialData, authenticator.credentialId...)
+	attestedCredentialData = append(attestedCredentialData, authenticator.PublicKey()...)
+	authenticatorData := authenticator.authenticatorData(aRpId, webauthn.FLAG_ATTESTED_CREDENTIAL_DATA, attestedCredentialData)
+
+	attestationObject, err = cbor.Encode(map[interface{}]interface{}{"fmt": "none", "attStmt": map[interface{}]interface{}{}, "authData": authenticatorData})
+	if err != nil {
+		return nil, nil, err
+	}
+	return clientDataJson, attestationObject, nil
+}
+
+// Assert increments SignCount unless it is zero, as authenticators without a counter do.
+func (authenticator *Authenticator) Assert(aRpId string, anOrigin string, aChallenge []byte) (clientDataJson []byte, authenticatorData []byte, signature []byte, err error) {
+	clientDataJson, err = ClientDataJson(webauthn.CEREMONY_TYPE_GET, anOrigin, aChallenge)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if authenticator.SignCount != 0 {
+		authenticator.SignCount++
+	}
+	authenticatorData = authenticator.authenticatorData(aRpId, 0, nil)
+
+	clientDataHash := sha256.Sum256(clientDataJson)
+	signed := append(append([]byte{}, authenticatorData...), clientDataHash[:]...)
+	if authenticator.algorithm == webauthn.COSE_ALGORITHM_EDDSA {
+		signature = ed25519.Sign(authenticator.ed25519Key, signed)
+	} else {
+		digest := sha256.Sum256(signed)
+		signature, err = ecdsa.SignASN1(rand.Reader, authenticator.ecdsaKey, digest[:])
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	return clientDataJson, authenticatorData, signature, nil
+}
+
+func (authenticator *Authenticator) authenticatorData(aRpId string, anAdditionalFlags byte, anAttestedCredentialData []byte) []byte {
+	rpIdHash := sha256.Sum256([]byte(aRpId))
+	authenticatorData := append([]byte{}, rpIdHash[:]...)
+	authenticatorData = append(authenticatorData, authenticator.Flags|anAdditionalFlags)
+	signCount := make([]byte, 4)
+	binary.BigEndian.PutUint32(signCount, authenticator.SignCount)
+	authenticatorData = append(authenticatorData, signCount...)
+	return append(authenticatorData, anAttestedCredentialData...)
+}
+
+func ClientDataJson(aCeremonyType string, anOrigin string, aChallenge []byte) ([]byte, error) {
+	return json.Marshal(map[string]string{
+		"type":      aCeremonyType,
+		"challenge": base64.RawURLEncoding.EncodeToString(aChallenge),
+		"origin":    anOrigin,
+	})
+}
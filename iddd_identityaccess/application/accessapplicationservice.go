@@ -0,0 +1,215 @@
+package application
+
+import (
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/totp"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/access"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+var (
+	ErrRoleNotFound      = ierrors.New(CODE_ROLE_NOT_FOUND, MESSAGE_KEY_ROLE_NOT_FOUND, "The role does not exist.")
+	ErrRoleAlreadyExists = ierrors.New(CODE_ROLE_ALREADY_EXISTS, MESSAGE_KEY_ROLE_ALREADY_EXISTS, "The role already exists.")
+)
+
+type AccessApplicationService struct {
+	authenticationService *identity.AuthenticationService
+	authorizationService  *access.AuthorizationService
+	roleAssignmentService *access.RoleAssignmentService
+	refreshTokenService   *identity.RefreshTokenService
+	tenantRepository      identity.TenantRepository
+	userRepository        identity.UserRepository
+	groupRepository       identity.GroupRepository
+	roleRepository        access.RoleRepository
+}
+
+func NewAccessApplicationService(anAuthenticationService *identity.AuthenticationService, anAuthorizationService *access.AuthorizationService, aRoleAssignmentService *access.RoleAssignmentService, aRefreshTokenService *identity.RefreshTokenService, aTenantRepository identity.TenantRepository, aUserRepository identity.UserRepository, aGroupRepository identity.GroupRepository, aRoleRepository access.RoleRepository) *AccessApplicationService {
+	return &AccessApplicationService{
+		authenticationService: anAuthenticationService,
+		authorizationService:  anAuthorizationService,
+		roleAssignmentService: aRoleAssignmentService,
+		refreshTokenService:   aRefreshTokenService,
+		tenantRepository:      aTenantRepository,
+		userRepository:        aUserRepository,
+		groupRepository:       aGroupRepository,
+		roleRepository:        aRoleRepository,
+	}
+}
+
+func (accessApplicationService *AccessApplicationService) Authenticate(aTenantId string, aUsername string, aPassword string) (_ *identity.UserDescriptor, err error) {
+	defer ierrors.Wrap(&err, "accessapplicationservice.Authenticate(%s, %s)", aTenantId, aUsername)
+
+	tenantId, err := identity.NewTenantId(aTenantId)
+	if err != nil {
+		return nil, identity.ErrAuthenticationFailed
+	}
+
+	return accessApplicationService.authenticationService.Authenticate(*tenantId, aUsername, aPassword)
+}
+
+func (accessApplicationService *AccessApplicationService) AuthenticateWithSecondFactor(aTenantId string, aUsername string, aPassword string, aCode string) (_ *identity.UserDescriptor, err error) {
+	defer ierrors.Wrap(&err, "accessapplicationservice.AuthenticateWithSecondFactor(%s, %s)", aTenantId, aUsername)
+
+	tenantId, err := identity.NewTenantId(aTenantId)
+	if err != nil {
+		return nil, identity.ErrAuthenticationFailed
+	}
+
+	return accessApplicationService.authenticationService.AuthenticateWithSecondFactor(*tenantId, aUsername, aPassword, aCode)
+}
+
+func (accessApplicationService *AccessApplicationService) EnrollSecondFactor(aTenantId string, aUsername string, aPassword string, anAlgorithm string) (_ *identity.TotpEnrollment, err error) {
+	defer ierrors.Wrap(&err, "accessapplicationservice.EnrollSecondFactor(%s, %s, %s)", aTenantId, aUsername, anAlgorithm)
+
+	tenantId, err := identity.NewTenantId(aTenantId)
+	if err != nil {
+		return nil, identity.ErrAuthenticationFailed
+	}
+	algorithm, err := totp.ParseAlgorithm(anAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	return accessApplicationService.authenticationService.EnrollSecondFactor(*tenantId, aUsername, aPassword, algorithm)
+}
+
+func (accessApplicationService *AccessApplicationService) ConfirmSecondFactor(aTenantId string, aUsername string, aPassword string, aCode string) (_ *identity.UserDescriptor, err error) {
+	defer ierrors.Wrap(&err, "accessapplicationservice.ConfirmSecondFactor(%s, %s)", aTenantId, aUsername)
+
+	tenantId, err := identity.NewTenantId(aTenantId)
+	if err != nil {
+		return nil, identity.ErrAuthenticationFailed
+	}
+
+	return accessApplicationService.authenticationService.ConfirmSecondFactor(*tenantId, aUsername, aPassword, aCode)
+}
+
+func (accessApplicationService *AccessApplicationService) IssueRefreshToken(aTenantId string, aUsername string, aPassword string) (_ string, err error) {
+	defer ierrors.Wrap(&err, "accessapplicationservice.IssueRefreshToken(%s, %s)", aTenantId, aUsername)
+
+	userDescriptor, err := accessApplicationService.Authenticate(aTenantId, aUsername, aPassword)
+	if err != nil {
+		return "", err
+	}
+	return accessApplicationService.issueRefreshToken(userDescriptor)
+}
+
+func (accessApplicationService *AccessApplicationService) IssueRefreshTokenWithSecondFactor(aTenantId string, aUsername string, aPassword string, aCode string) (_ string, err error) {
+	defer ierrors.Wrap(&err, "accessapplicationservice.IssueRefreshTokenWithSecondFactor(%s, %s)", aTenantId, aUsername)
+
+	userDescriptor, err := accessApplicationService.AuthenticateWithSecondFactor(aTenantId, aUsername, aPassword, aCode)
+	if err != nil {
+		return "", err
+	}
+	return accessApplicationService.issueRefreshToken(userDescriptor)
+}
+
+func (accessApplicationService *AccessApplicationService) issueRefreshToken(aUserDescriptor *identity.UserDescriptor) (string, error) {
+	user, err := accessApplicationService.userRepository.UserWithUsername(aUserDescriptor.TenantId(), aUserDescriptor.Username())
+	if err != nil {
+		return "", err
+	}
+	if user == nil {
+		return "", identity.ErrAuthenticationFailed
+	}
+
+	return accessApplicationService.refreshTokenService.Issue(user)
+}
+
+func (accessApplicationService *AccessApplicationService) RotateRefreshToken(aPlainToken string) (_ string, err error) {
+	defer ierrors.Wrap(&err, "accessapplicationservice.RotateRefreshToken()")
+
+	plainToken, _, err := accessApplicationService.refreshTokenService.Rotate(aPlainToken)
+	return plainToken, err
+}
+
+func (accessApplicationService *AccessApplicationService) IsUserInRole(aTenantId string, aUsername string, aRoleName string) (_ bool, err error) {
+	defer ierrors.Wrap(&err, "accessapplicationservice.IsUserInRole(%s, %s, %s)", aTenantId, aUsername, aRoleName)
+
+	tenantId, err := identity.NewTenantId(aTenantId)
+	if err != nil {
+		return false, err
+	}
+
+	return accessApplicationService.authorizationService.IsUserInRole(*tenantId, aUsername, aRoleName)
+}
+
+func (accessApplicationService *AccessApplicationService) ProvisionRole(aTenantId string, aName string, aDescription string, aSupportsNesting bool) (_ *access.Role, err error) {
+	defer ierrors.Wrap(&err, "accessapplicationservice.ProvisionRole(%s, %s, %s, %v)", aTenantId, aName, aDescription, aSupportsNesting)
+
+	tenant, err := existingTenant(accessApplicationService.tenantRepository, aTenantId)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := accessApplicationService.roleRepository.RoleNamed(tenant.TenantId(), aName)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrRoleAlreadyExists
+	}
+
+	role, err := access.NewRole(tenant.TenantId(), aName, aDescription, aSupportsNesting)
+	if err != nil {
+		return nil, err
+	}
+	if err := accessApplicationService.roleRepository.Add(role); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (accessApplicationService *AccessApplicationService) Role(aTenantId string, aRoleName string) (_ *access.Role, err error) {
+	defer ierrors.Wrap(&err, "accessapplicationservice.Role(%s, %s)", aTenantId, aRoleName)
+
+	return accessApplicationService.existingRole(aTenantId, aRoleName)
+}
+
+func (accessApplicationService *AccessApplicationService) AssignUserToRole(aTenantId string, aRoleName string, aUsername string) (err error) {
+	defer ierrors.Wrap(&err, "accessapplicationservice.AssignUserToRole(%s, %s, %s)", aTenantId, aRoleName, aUsername)
+
+	role, err := accessApplicationService.existingRole(aTenantId, aRoleName)
+	if err != nil {
+		return err
+	}
+	user, err := existingUser(accessApplicationService.tenantRepository, accessApplicationService.userRepository, aTenantId, aUsername)
+	if err != nil {
+		return err
+	}
+	if err := accessApplicationService.roleAssignmentService.AssignUser(role, user); err != nil {
+		return err
+	}
+	return accessApplicationService.roleRepository.Add(role)
+}
+
+func (accessApplicationService *AccessApplicationService) AssignGroupToRole(aTenantId string, aRoleName string, aGroupName string) (err error) {
+	defer ierrors.Wrap(&err, "accessapplicationservice.AssignGroupToRole(%s, %s, %s)", aTenantId, aRoleName, aGroupName)
+
+	role, err := accessApplicationService.existingRole(aTenantId, aRoleName)
+	if err != nil {
+		return err
+	}
+	group, err := existingGroup(accessApplicationService.tenantRepository, accessApplicationService.groupRepository, aTenantId, aGroupName)
+	if err != nil {
+		return err
+	}
+	if err := accessApplicationService.roleAssignmentService.AssignGroup(role, group); err != nil {
+		return err
+	}
+	return accessApplicationService.roleRepository.Add(role)
+}
+
+func (accessApplicationService *AccessApplicationService) existingRole(aTenantId string, aRoleName string) (*access.Role, error) {
+	tenant, err := existingTenant(accessApplicationService.tenantRepository, aTenantId)
+	if err != nil {
+		return nil, err
+	}
+	role, err := accessApplicationService.roleRepository.RoleNamed(tenant.TenantId(), aRoleName)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, ErrRoleNotFound
+	}
+	return role, nil
+}
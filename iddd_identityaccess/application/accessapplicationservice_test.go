@@ -0,0 +1,301 @@
+package application
+
+import (
+	"encoding/base32"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/domain/model"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/totp"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/access"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/infrastructure/persistence"
+	"github.com/google/uuid"
+)
+
+const password = "qwerty!ASDFG#"
+
+type fixture struct {
+	tenant           *identity.Tenant
+	user             *identity.User
+	tenantRepository *persistence.InMemoryTenantRepository
+	userRepository   *persistence.InMemoryUserRepository
+	groupRepository  *persistence.InMemoryGroupRepository
+	roleRepository   *persistence.InMemoryRoleRepository
+}
+
+func newPerson(t *testing.T, aTenantId identity.TenantId) *identity.Person {
+	t.Helper()
+
+	fullName, err := identity.NewFullName("Zoe", "Doe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	emailAddress, err := identity.NewEmailAddress("zoe@saasovation.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return identity.NewPerson(aTenantId, *fullName, *emailAddress)
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	tenantId, err := identity.NewTenantId(uuid.New().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenant, err := identity.NewTenant(*tenantId, "TenantName", true, *identity.DefaultNameLengthPolicy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	enablement, err := identity.NewEnablement(true, time.Now().AddDate(-1, 0, 0), time.Now().AddDate(1, 0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, err := identity.NewUser(*tenantId, "zoeusername", password, *enablement, *newPerson(t, *tenantId), *identity.DefaultNameLengthPolicy())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fixture := &fixture{
+		tenant:           tenant,
+		user:             user,
+		tenantRepository: persistence.NewInMemoryTenantRepository(),
+		userRepository:   persistence.NewInMemoryUserRepository(),
+		groupRepository:  persistence.NewInMemoryGroupRepository(),
+		roleRepository:   persistence.NewInMemoryRoleRepository(),
+	}
+	if err := fixture.tenantRepository.Add(tenant); err != nil {
+		t.Fatal(err)
+	}
+	if err := fixture.userRepository.Add(user); err != nil {
+		t.Fatal(err)
+	}
+	return fixture
+}
+
+func (fixture *fixture) roleAssignmentService() *access.RoleAssignmentService {
+	return access.NewRoleAssignmentService(fixture.roleRepository, identity.NewGroupMemberService(fixture.userRepository, fixture.groupRepository))
+}
+
+func (fixture *fixture) accessApplicationService(t *testing.T) *AccessApplicationService {
+	t.Helper()
+
+	lockoutPolicy, err := identity.NewLockoutPolicy(3, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authenticationService := identity.NewAuthenticationService(fixture.tenantRepository, fixture.userRepository, *lockoutPolicy)
+	authorizationService := access.NewAuthorizationService(fixture.userRepository, fixture.groupRepository, fixture.roleRepository)
+	refreshTokenService := identity.NewRefreshTokenService(fixture.tenantRepository, fixture.userRepository, persistence.NewInMemoryRefreshTokenRepository(), time.Hour)
+	model.DomainEventPublisherInstance().Subscribe(refreshTokenService)
+	t.Cleanup(model.DomainEventPublisherInstance().Reset)
+	return NewAccessApplicationService(authenticationService, authorizationService, fixture.roleAssignmentService(), refreshTokenService, fixture.tenantRepository, fixture.userRepository, fixture.groupRepository, fixture.roleRepository)
+}
+
+func TestAccessApplicationServiceAuthenticate(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		fixture := newFixture(t)
+		tenantId := fixture.tenant.TenantId()
+
+		userDescriptor, err := fixture.accessApplicationService(t).Authenticate(tenantId.Id(), "zoeusername", password)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if userDescriptor.Username() != "zoeusername" {
+			t.Errorf("got %s, want %s", userDescriptor.Username(), "zoeusername")
+		}
+	})
+	t.Run("fail invalid tenant id", func(t *testing.T) {
+		fixture := newFixture(t)
+
+		_, err := fixture.accessApplicationService(t).Authenticate("invalid", "zoeusername", password)
+		if !errors.Is(err, identity.ErrAuthenticationFailed) {
+			t.Errorf("got %v, want %v", err, identity.ErrAuthenticationFailed)
+		}
+	})
+}
+
+func TestAccessApplicationServiceSecondFactor(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		fixture := newFixture(t)
+		tenantId := fixture.tenant.TenantId()
+		accessApplicationService := fixture.accessApplicationService(t)
+
+		totpEnrollment, err := accessApplicationService.EnrollSecondFactor(tenantId.Id(), "zoeusername", password, "SHA1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		secret, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(totpEnrollment.Secret())
+		if err != nil {
+			t.Fatal(err)
+		}
+		generator, err := totp.NewTotp(secret, totp.ALGORITHM_SHA1, totp.DEFAULT_DIGITS, totp.DEFAULT_PERIOD)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := accessApplicationService.ConfirmSecondFactor(tenantId.Id(), "zoeusername", password, generator.CodeAt(time.Now())); err != nil {
+			t.Fatal(err)
+		}
+
+		userDescriptor, err := accessApplicationService.AuthenticateWithSecondFactor(tenantId.Id(), "zoeusername", password, generator.CodeAt(time.Now().Add(totp.DEFAULT_PERIOD)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if userDescriptor.Username() != "zoeusername" {
+			t.Errorf("got %s, want %s", userDescriptor.Username(), "zoeusername")
+		}
+	})
+	t.Run("fail unknown algorithm", func(t *testing.T) {
+		fixture := newFixture(t)
+		tenantId := fixture.tenant.TenantId()
+
+		if _, err := fixture.accessApplicationService(t).EnrollSecondFactor(tenantId.Id(), "zoeusername", password, "MD5"); err == nil {
+			t.Errorf("unknown algorithm must be rejected")
+		}
+	})
+}
+
+func TestAccessApplicationServiceRefreshToken(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		fixture := newFixture(t)
+		tenantId := fixture.tenant.TenantId()
+		accessApplicationService := fixture.accessApplicationService(t)
+
+		plainToken, err := accessApplicationService.IssueRefreshToken(tenantId.Id(), "zoeusername", password)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := accessApplicationService.RotateRefreshToken(plainToken); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("success second factor", func(t *testing.T) {
+		fixture := newFixture(t)
+		tenantId := fixture.tenant.TenantId()
+		accessApplicationService := fixture.accessApplicationService(t)
+		totpEnrollment, err := accessApplicationService.EnrollSecondFactor(tenantId.Id(), "zoeusername", password, "SHA1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		secret, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(totpEnrollment.Secret())
+		if err != nil {
+			t.Fatal(err)
+		}
+		generator, err := totp.NewTotp(secret, totp.ALGORITHM_SHA1, totp.DEFAULT_DIGITS, totp.DEFAULT_PERIOD)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := accessApplicationService.ConfirmSecondFactor(tenantId.Id(), "zoeusername", password, generator.CodeAt(time.Now())); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := accessApplicationService.IssueRefreshToken(tenantId.Id(), "zoeusername", password); !errors.Is(err, identity.ErrSecondFactorRequired) {
+			t.Fatalf("got %v, want %v", err, identity.ErrSecondFactorRequired)
+		}
+
+		plainToken, err := accessApplicationService.IssueRefreshTokenWithSecondFactor(tenantId.Id(), "zoeusername", password, generator.CodeAt(time.Now().Add(totp.DEFAULT_PERIOD)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := accessApplicationService.RotateRefreshToken(plainToken); err != nil {
+			t.Error(err)
+		}
+	})
+	t.Run("fail wrong password", func(t *testing.T) {
+		fixture := newFixture(t)
+		tenantId := fixture.tenant.TenantId()
+
+		if _, err := fixture.accessApplicationService(t).IssueRefreshToken(tenantId.Id(), "zoeusername", "wrong"); !errors.Is(err, identity.ErrAuthenticationFailed) {
+			t.Errorf("got %v, want %v", err, identity.ErrAuthenticationFailed)
+		}
+	})
+	t.Run("fail tenant deactivated", func(t *testing.T) {
+		fixture := newFixture(t)
+		tenantId := fixture.tenant.TenantId()
+		accessApplicationService := fixture.accessApplicationService(t)
+		identityApplicationService := fixture.identityApplicationService(t, &recordingNotifier{})
+		plainToken, err := accessApplicationService.IssueRefreshToken(tenantId.Id(), "zoeusername", password)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := identityApplicationService.DeactivateTenant(tenantId.Id()); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := accessApplicationService.RotateRefreshToken(plainToken); !errors.Is(err, identity.ErrRefreshTokenInvalid) {
+			t.Errorf("got %v, want %v", err, identity.ErrRefreshTokenInvalid)
+		}
+	})
+	t.Run("fail tenant reactivated", func(t *testing.T) {
+		fixture := newFixture(t)
+		tenantId := fixture.tenant.TenantId()
+		accessApplicationService := fixture.accessApplicationService(t)
+		identityApplicationService := fixture.identityApplicationService(t, &recordingNotifier{})
+		plainToken, err := accessApplicationService.IssueRefreshToken(tenantId.Id(), "zoeusername", password)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := identityApplicationService.DeactivateTenant(tenantId.Id()); err != nil {
+			t.Fatal(err)
+		}
+		if err := identityApplicationService.ActivateTenant(tenantId.Id()); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := accessApplicationService.RotateRefreshToken(plainToken); !errors.Is(err, identity.ErrRefreshTokenInvalid) {
+			t.Errorf("got %v, want %v", err, identity.ErrRefreshTokenInvalid)
+		}
+	})
+}
+
+func TestAccessApplicationServiceIsUserInRole(t *testing.T) {
+	fixture := newFixture(t)
+	role, err := access.NewRole(fixture.tenant.TenantId(), "Manager", "A manager role.", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fixture.roleAssignmentService().AssignUser(role, fixture.user); err != nil {
+		t.Fatal(err)
+	}
+	if err := fixture.roleRepository.Add(role); err != nil {
+		t.Fatal(err)
+	}
+	tenantId := fixture.tenant.TenantId()
+
+	isInRole, err := fixture.accessApplicationService(t).IsUserInRole(tenantId.Id(), "zoeusername", "Manager")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isInRole {
+		t.Errorf("user must be in role %v", role)
+	}
+}
+
+func TestAccessApplicationServiceAssignUserToRole(t *testing.T) {
+	fixture := newFixture(t)
+	tenantId := fixture.tenant.TenantId()
+	accessApplicationService := fixture.accessApplicationService(t)
+	if _, err := accessApplicationService.ProvisionRole(tenantId.Id(), "Manager", "A manager role.", true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := accessApplicationService.ProvisionRole(tenantId.Id(), "Manager", "A manager role.", true); !errors.Is(err, ErrRoleAlreadyExists) {
+		t.Errorf("got %v, want %v", err, ErrRoleAlreadyExists)
+	}
+
+	if err := accessApplicationService.AssignUserToRole(tenantId.Id(), "Manager", "zoeusername"); err != nil {
+		t.Fatal(err)
+	}
+	isInRole, err := accessApplicationService.IsUserInRole(tenantId.Id(), "zoeusername", "Manager")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isInRole {
+		t.Errorf("user must be in role Manager")
+	}
+
+	if err := accessApplicationService.AssignUserToRole(tenantId.Id(), "Unknown", "zoeusername"); !errors.Is(err, ErrRoleNotFound) {
+		t.Errorf("got %v, want %v", err, ErrRoleNotFound)
+	}
+}
@@ -0,0 +1,621 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+var (
+	ErrTenantNotFound     = ierrors.New(CODE_TENANT_NOT_FOUND, MESSAGE_KEY_TENANT_NOT_FOUND, "The tenant does not exist.")
+	ErrUserNotFound       = ierrors.New(CODE_USER_NOT_FOUND, MESSAGE_KEY_USER_NOT_FOUND, "The user does not exist.")
+	ErrGroupNotFound      = ierrors.New(CODE_GROUP_NOT_FOUND, MESSAGE_KEY_GROUP_NOT_FOUND, "The group does not exist.")
+	ErrUserAlreadyExists  = identity.ErrUserAlreadyExists
+	ErrUsernameConfusable = identity.ErrUsernameConfusable
+	ErrGroupAlreadyExists = ierrors.New(CODE_GROUP_ALREADY_EXISTS, MESSAGE_KEY_GROUP_ALREADY_EXISTS, "The group already exists.")
+
+	ErrProvisioningTokenInvalid = ierrors.New(CODE_PROVISIONING_TOKEN_INVALID, MESSAGE_KEY_PROVISIONING_TOKEN_INVALID, "The provisioning token is invalid.")
+)
+
+type IdentityApplicationService struct {
+	tenantRepository         identity.TenantRepository
+	userRepository           identity.UserRepository
+	groupRepository          identity.GroupRepository
+	groupMembershipPolicy    identity.GroupMembershipPolicy
+	nameLengthPolicy         identity.NameLengthPolicy
+	passwordResetService     *identity.PasswordResetService
+	emailVerificationService *identity.EmailVerificationService
+	notifier                 Notifier
+}
+
+func NewIdentityApplicationService(aTenantRepository identity.TenantRepository, aUserRepository identity.UserRepository, aGroupRepository identity.GroupRepository, aGroupMembershipPolicy identity.GroupMembershipPolicy, aNameLengthPolicy identity.NameLengthPolicy, aPasswordResetService *identity.PasswordResetService, anEmailVerificationService *identity.EmailVerificationService, aNotifier Notifier) *IdentityApplicationService {
+	return &IdentityApplicationService{tenantRepository: aTenantRepository, userRepository: aUserRepository, groupRepository: aGroupRepository, groupMembershipPolicy: aGroupMembershipPolicy, nameLengthPolicy: aNameLengthPolicy, passwordResetService: aPasswordResetService, emailVerificationService: anEmailVerificationService, notifier: aNotifier}
+}
+
+func (identityApplicationService *IdentityApplicationService) ProvisionTenant(aName string) (_ *identity.Tenant, err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.ProvisionTenant(%s)", aName)
+
+	validationNotification := ierrors.NewValidationNotification()
+	identity.NewTenantValidator(aName, identityApplicationService.nameLengthPolicy).Validate(validationNotification)
+	if err := validationNotification.Err(); err != nil {
+		return nil, err
+	}
+
+	tenantId, err := identity.NextTenantId()
+	if err != nil {
+		return nil, err
+	}
+	tenant, err := identity.NewTenant(*tenantId, aName, true, identityApplicationService.nameLengthPolicy)
+	if err != nil {
+		return nil, err
+	}
+	if err := identityApplicationService.tenantRepository.Add(tenant); err != nil {
+		return nil, err
+	}
+	return tenant, nil
+}
+
+func (identityApplicationService *IdentityApplicationService) Tenant(aTenantId string) (_ *identity.Tenant, err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.Tenant(%s)", aTenantId)
+
+	return existingTenant(identityApplicationService.tenantRepository, aTenantId)
+}
+
+func (identityApplicationService *IdentityApplicationService) AllTenants() (_ []*identity.Tenant, err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.AllTenants()")
+
+	return identityApplicationService.tenantRepository.AllTenants()
+}
+
+func (identityApplicationService *IdentityApplicationService) ChangeTenantName(aTenantId string, aName string) (err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.ChangeTenantName(%s, %s)", aTenantId, aName)
+
+	tenant, err := existingTenant(identityApplicationService.tenantRepository, aTenantId)
+	if err != nil {
+		return err
+	}
+	if err := tenant.ChangeName(aName, identityApplicationService.nameLengthPolicy); err != nil {
+		return err
+	}
+	return identityApplicationService.tenantRepository.Add(tenant)
+}
+
+func (identityApplicationService *IdentityApplicationService) ActivateTenant(aTenantId string) (err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.ActivateTenant(%s)", aTenantId)
+
+	tenant, err := existingTenant(identityApplicationService.tenantRepository, aTenantId)
+	if err != nil {
+		return err
+	}
+	if err := tenant.Activate(); err != nil {
+		return err
+	}
+	return identityApplicationService.tenantRepository.Add(tenant)
+}
+
+func (identityApplicationService *IdentityApplicationService) DeactivateTenant(aTenantId string) (err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.DeactivateTenant(%s)", aTenantId)
+
+	tenant, err := existingTenant(identityApplicationService.tenantRepository, aTenantId)
+	if err != nil {
+		return err
+	}
+	if err := tenant.Deactivate(); err != nil {
+		return err
+	}
+	return identityApplicationService.tenantRepository.Add(tenant)
+}
+
+func (identityApplicationService *IdentityApplicationService) RemoveTenant(aTenantId string) (err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.RemoveTenant(%s)", aTenantId)
+
+	tenant, err := existingTenant(identityApplicationService.tenantRepository, aTenantId)
+	if err != nil {
+		return err
+	}
+	return identityApplicationService.tenantRepository.Remove(tenant)
+}
+
+// IssueProvisioningToken returns the plaintext of a token that replaces any issued before.
+func (identityApplicationService *IdentityApplicationService) IssueProvisioningToken(aTenantId string) (_ string, err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.IssueProvisioningToken(%s)", aTenantId)
+
+	tenant, err := existingTenant(identityApplicationService.tenantRepository, aTenantId)
+	if err != nil {
+		return "", err
+	}
+	plainToken, err := tenant.IssueProvisioningToken()
+	if err != nil {
+		return "", err
+	}
+	if err := identityApplicationService.tenantRepository.Add(tenant); err != nil {
+		return "", err
+	}
+	return plainToken, nil
+}
+
+func (identityApplicationService *IdentityApplicationService) RevokeProvisioningToken(aTenantId string) (err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.RevokeProvisioningToken(%s)", aTenantId)
+
+	tenant, err := existingTenant(identityApplicationService.tenantRepository, aTenantId)
+	if err != nil {
+		return err
+	}
+	tenant.RevokeProvisioningToken()
+	return identityApplicationService.tenantRepository.Add(tenant)
+}
+
+// AuthenticateProvisioning does not tell an unknown tenant from a wrong token, so that callers cannot probe for tenants.
+func (identityApplicationService *IdentityApplicationService) AuthenticateProvisioning(aTenantId string, aPlainToken string) (err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.AuthenticateProvisioning(%s)", aTenantId)
+
+	tenant, err := existingTenant(identityApplicationService.tenantRepository, aTenantId)
+	if errors.Is(err, ErrTenantNotFound) {
+		return ErrProvisioningTokenInvalid
+	}
+	if err != nil {
+		return err
+	}
+	if !tenant.IsProvisioningTokenValid(aPlainToken) {
+		return ErrProvisioningTokenInvalid
+	}
+	return nil
+}
+
+// OfferRegistrationInvitation offers an open-ended invitation when both aStartingOn and anUntil are zero.
+func (identityApplicationService *IdentityApplicationService) OfferRegistrationInvitation(aTenantId string, aDescription string, aStartingOn time.Time, anUntil time.Time) (_ *identity.RegistrationInvitation, err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.OfferRegistrationInvitation(%s, %s, %v, %v)", aTenantId, aDescription, aStartingOn, anUntil)
+
+	tenant, err := existingTenant(identityApplicationService.tenantRepository, aTenantId)
+	if err != nil {
+		return nil, err
+	}
+	registrationInvitation, err := tenant.OfferRegistrationInvitation(aDescription)
+	if err != nil {
+		return nil, err
+	}
+	if !aStartingOn.IsZero() || !anUntil.IsZero() {
+		if err := registrationInvitation.LimitTo(aStartingOn, anUntil); err != nil {
+			tenant.WithdrawInvitation(registrationInvitation.InvitationId())
+			return nil, err
+		}
+	}
+	if err := identityApplicationService.tenantRepository.Add(tenant); err != nil {
+		return nil, err
+	}
+	return registrationInvitation, nil
+}
+
+func (identityApplicationService *IdentityApplicationService) AvailableRegistrationInvitations(aTenantId string) (_ []identity.RegistrationInvitation, err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.AvailableRegistrationInvitations(%s)", aTenantId)
+
+	tenant, err := existingTenant(identityApplicationService.tenantRepository, aTenantId)
+	if err != nil {
+		return nil, err
+	}
+	return tenant.AllAvailableRegistrationInvitations(), nil
+}
+
+func (identityApplicationService *IdentityApplicationService) WithdrawRegistrationInvitation(aTenantId string, anInvitationIdentifier string) (err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.WithdrawRegistrationInvitation(%s, %s)", aTenantId, anInvitationIdentifier)
+
+	tenant, err := existingTenant(identityApplicationService.tenantRepository, aTenantId)
+	if err != nil {
+		return err
+	}
+	tenant.WithdrawInvitation(anInvitationIdentifier)
+	return identityApplicationService.tenantRepository.Add(tenant)
+}
+
+func (identityApplicationService *IdentityApplicationService) RegisterUser(aCommand RegisterUserCommand) (_ *identity.User, err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.RegisterUser(%s, %s, %s)", aCommand.TenantId, aCommand.InvitationIdentifier, aCommand.Username)
+
+	tenant, err := existingTenant(identityApplicationService.tenantRepository, aCommand.TenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	validationNotification := ierrors.NewValidationNotification()
+	identity.NewUserValidator(aCommand.Username, aCommand.Password, aCommand.FirstName, aCommand.LastName, aCommand.EmailAddress, aCommand.StartDate, aCommand.EndDate, identityApplicationService.nameLengthPolicy).Validate(validationNotification)
+	if err := validationNotification.Err(); err != nil {
+		return nil, err
+	}
+
+	fullName, err := identity.NewFullName(aCommand.FirstName, aCommand.LastName)
+	if err != nil {
+		return nil, err
+	}
+	emailAddress, err := identity.NewEmailAddress(aCommand.EmailAddress)
+	if err != nil {
+		return nil, err
+	}
+	enablement, err := identity.NewEnablement(aCommand.Enabled, aCommand.StartDate, aCommand.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	user, err := tenant.RegisterUser(aCommand.InvitationIdentifier, aCommand.Username, aCommand.Password, *enablement, *identity.NewPerson(tenant.TenantId(), *fullName, *emailAddress), identityApplicationService.nameLengthPolicy)
+	if err != nil {
+		return nil, err
+	}
+	if err := identityApplicationService.userRepository.Add(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// ProvisionUser registers a user without an invitation, on behalf of the tenant's identity provider.
+func (identityApplicationService *IdentityApplicationService) ProvisionUser(aCommand ProvisionUserCommand) (_ *identity.User, err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.ProvisionUser(%s, %s)", aCommand.TenantId, aCommand.Username)
+
+	tenant, err := existingTenant(identityApplicationService.tenantRepository, aCommand.TenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	enablement := identity.NewIndefiniteEnablement(aCommand.Enabled)
+	validationNotification := ierrors.NewValidationNotification()
+	identity.NewUserValidator(aCommand.Username, aCommand.Password, aCommand.FirstName, aCommand.LastName, aCommand.EmailAddress, enablement.StartDate(), enablement.EndDate(), identityApplicationService.nameLengthPolicy).Validate(validationNotification)
+	if err := validationNotification.Err(); err != nil {
+		return nil, err
+	}
+
+	fullName, err := identity.NewFullName(aCommand.FirstName, aCommand.LastName)
+	if err != nil {
+		return nil, err
+	}
+	emailAddress, err := identity.NewEmailAddress(aCommand.EmailAddress)
+	if err != nil {
+		return nil, err
+	}
+	user, err := tenant.ProvisionUser(aCommand.Username, aCommand.Password, *enablement, *identity.NewPerson(tenant.TenantId(), *fullName, *emailAddress), identityApplicationService.nameLengthPolicy)
+	if err != nil {
+		return nil, err
+	}
+	if err := identityApplicationService.userRepository.Add(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (identityApplicationService *IdentityApplicationService) AllUsers(aTenantId string) (_ []*identity.User, err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.AllUsers(%s)", aTenantId)
+
+	tenant, err := existingTenant(identityApplicationService.tenantRepository, aTenantId)
+	if err != nil {
+		return nil, err
+	}
+	return identityApplicationService.userRepository.AllUsers(tenant.TenantId())
+}
+
+func (identityApplicationService *IdentityApplicationService) User(aTenantId string, aUsername string) (_ *identity.User, err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.User(%s, %s)", aTenantId, aUsername)
+
+	return existingUser(identityApplicationService.tenantRepository, identityApplicationService.userRepository, aTenantId, aUsername)
+}
+
+func (identityApplicationService *IdentityApplicationService) ChangeUserPassword(aTenantId string, aUsername string, aCurrentPassword string, aChangedPassword string) (err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.ChangeUserPassword(%s, %s)", aTenantId, aUsername)
+
+	user, err := existingUser(identityApplicationService.tenantRepository, identityApplicationService.userRepository, aTenantId, aUsername)
+	if err != nil {
+		return err
+	}
+	if err := user.ChangePassword(aCurrentPassword, aChangedPassword); err != nil {
+		return err
+	}
+	return identityApplicationService.userRepository.Add(user)
+}
+
+func (identityApplicationService *IdentityApplicationService) ChangeUserPersonalName(aTenantId string, aUsername string, aFirstName string, aLastName string) (err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.ChangeUserPersonalName(%s, %s, %s, %s)", aTenantId, aUsername, aFirstName, aLastName)
+
+	user, err := existingUser(identityApplicationService.tenantRepository, identityApplicationService.userRepository, aTenantId, aUsername)
+	if err != nil {
+		return err
+	}
+	fullName, err := identity.NewFullName(aFirstName, aLastName)
+	if err != nil {
+		return err
+	}
+	user.Person().ChangeName(*fullName)
+	return identityApplicationService.userRepository.Add(user)
+}
+
+func (identityApplicationService *IdentityApplicationService) ChangeUserEmailAddress(aTenantId string, aUsername string, anEmailAddress string) (err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.ChangeUserEmailAddress(%s, %s, %s)", aTenantId, aUsername, anEmailAddress)
+
+	user, err := existingUser(identityApplicationService.tenantRepository, identityApplicationService.userRepository, aTenantId, aUsername)
+	if err != nil {
+		return err
+	}
+	emailAddress, err := identity.NewEmailAddress(anEmailAddress)
+	if err != nil {
+		return err
+	}
+	user.Person().ChangeEmailAddress(*emailAddress)
+	return identityApplicationService.userRepository.Add(user)
+}
+
+// ResetUserPassword sets a new password without knowing the current one, for administrators rather than the user themself.
+func (identityApplicationService *IdentityApplicationService) ResetUserPassword(aTenantId string, aUsername string, aNewPassword string) (err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.ResetUserPassword(%s, %s)", aTenantId, aUsername)
+
+	user, err := existingUser(identityApplicationService.tenantRepository, identityApplicationService.userRepository, aTenantId, aUsername)
+	if err != nil {
+		return err
+	}
+	if err := user.ResetPassword(aNewPassword); err != nil {
+		return err
+	}
+	return identityApplicationService.userRepository.Add(user)
+}
+
+func (identityApplicationService *IdentityApplicationService) DefineUserEnablement(aTenantId string, aUsername string, anEnabled bool, aStartDate time.Time, anEndDate time.Time) (err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.DefineUserEnablement(%s, %s, %v, %v, %v)", aTenantId, aUsername, anEnabled, aStartDate, anEndDate)
+
+	user, err := existingUser(identityApplicationService.tenantRepository, identityApplicationService.userRepository, aTenantId, aUsername)
+	if err != nil {
+		return err
+	}
+	enablement, err := identity.NewEnablement(anEnabled, aStartDate, anEndDate)
+	if err != nil {
+		return err
+	}
+	if err := user.DefineEnablement(*enablement); err != nil {
+		return err
+	}
+	return identityApplicationService.userRepository.Add(user)
+}
+
+func (identityApplicationService *IdentityApplicationService) ProvisionGroup(aTenantId string, aName string, aDescription string) (_ *identity.Group, err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.ProvisionGroup(%s, %s, %s)", aTenantId, aName, aDescription)
+
+	tenant, err := existingTenant(identityApplicationService.tenantRepository, aTenantId)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := identityApplicationService.groupRepository.GroupNamed(tenant.TenantId(), aName)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrGroupAlreadyExists
+	}
+
+	group, err := identity.NewGroup(tenant.TenantId(), aName, aDescription)
+	if err != nil {
+		return nil, err
+	}
+	if err := identityApplicationService.groupRepository.Add(group); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+func (identityApplicationService *IdentityApplicationService) AllGroups(aTenantId string) (_ []*identity.Group, err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.AllGroups(%s)", aTenantId)
+
+	tenant, err := existingTenant(identityApplicationService.tenantRepository, aTenantId)
+	if err != nil {
+		return nil, err
+	}
+	return identityApplicationService.groupRepository.AllGroups(tenant.TenantId())
+}
+
+func (identityApplicationService *IdentityApplicationService) Group(aTenantId string, aGroupName string) (_ *identity.Group, err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.Group(%s, %s)", aTenantId, aGroupName)
+
+	return existingGroup(identityApplicationService.tenantRepository, identityApplicationService.groupRepository, aTenantId, aGroupName)
+}
+
+func (identityApplicationService *IdentityApplicationService) AddUserToGroup(aTenantId string, aGroupName string, aUsername string) (err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.AddUserToGroup(%s, %s, %s)", aTenantId, aGroupName, aUsername)
+
+	group, user, err := identityApplicationService.groupAndUser(aTenantId, aGroupName, aUsername)
+	if err != nil {
+		return err
+	}
+	if err := identityApplicationService.groupMembershipPolicy.AssertUserMayJoin(group, user); err != nil {
+		return err
+	}
+	if err := group.AddUser(user); err != nil {
+		return err
+	}
+	return identityApplicationService.groupRepository.Add(group)
+}
+
+func (identityApplicationService *IdentityApplicationService) RemoveUserFromGroup(aTenantId string, aGroupName string, aUsername string) (err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.RemoveUserFromGroup(%s, %s, %s)", aTenantId, aGroupName, aUsername)
+
+	group, user, err := identityApplicationService.groupAndUser(aTenantId, aGroupName, aUsername)
+	if err != nil {
+		return err
+	}
+	if err := group.RemoveUser(user); err != nil {
+		return err
+	}
+	return identityApplicationService.groupRepository.Add(group)
+}
+
+func (identityApplicationService *IdentityApplicationService) AddGroupToGroup(aTenantId string, aGroupName string, aMemberGroupName string) (err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.AddGroupToGroup(%s, %s, %s)", aTenantId, aGroupName, aMemberGroupName)
+
+	group, memberGroup, err := identityApplicationService.groupAndMemberGroup(aTenantId, aGroupName, aMemberGroupName)
+	if err != nil {
+		return err
+	}
+	if err := identityApplicationService.groupMembershipPolicy.AssertGroupMayJoin(group, memberGroup); err != nil {
+		return err
+	}
+	if err := group.AddGroup(memberGroup, identityApplicationService.groupMemberService()); err != nil {
+		return err
+	}
+	return identityApplicationService.groupRepository.Add(group)
+}
+
+func (identityApplicationService *IdentityApplicationService) RemoveGroupFromGroup(aTenantId string, aGroupName string, aMemberGroupName string) (err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.RemoveGroupFromGroup(%s, %s, %s)", aTenantId, aGroupName, aMemberGroupName)
+
+	group, memberGroup, err := identityApplicationService.groupAndMemberGroup(aTenantId, aGroupName, aMemberGroupName)
+	if err != nil {
+		return err
+	}
+	if err := group.RemoveGroup(memberGroup); err != nil {
+		return err
+	}
+	return identityApplicationService.groupRepository.Add(group)
+}
+
+func (identityApplicationService *IdentityApplicationService) IsGroupMember(aTenantId string, aGroupName string, aUsername string) (_ bool, err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.IsGroupMember(%s, %s, %s)", aTenantId, aGroupName, aUsername)
+
+	group, user, err := identityApplicationService.groupAndUser(aTenantId, aGroupName, aUsername)
+	if err != nil {
+		return false, err
+	}
+	return group.IsMember(user, identityApplicationService.groupMemberService())
+}
+
+// RequestPasswordReset succeeds silently when no enabled user has the address, so the response does not reveal who is registered.
+func (identityApplicationService *IdentityApplicationService) RequestPasswordReset(aTenantId string, anEmailAddress string) (err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.RequestPasswordReset(%s, %s)", aTenantId, anEmailAddress)
+
+	tenantId, err := identity.NewTenantId(aTenantId)
+	if err != nil {
+		return err
+	}
+	tenant, err := identityApplicationService.tenantRepository.TenantOfId(*tenantId)
+	if err != nil {
+		return err
+	}
+	if tenant == nil || !tenant.IsActive() {
+		return nil
+	}
+	user, err := identityApplicationService.userRepository.UserWithEmailAddress(*tenantId, anEmailAddress)
+	if err != nil {
+		return err
+	}
+	if user == nil || !user.IsEnabled() {
+		return nil
+	}
+
+	plainToken, err := identityApplicationService.passwordResetService.Issue(user)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Hello %s,\n\nUse the following token to reset your %s password. It can be used only once.\n\n%s\n", user.Person().Name().AsFormattedName(), tenant.Name(), plainToken)
+	return identityApplicationService.notifier.Notify(NewNotification(user.Person().EmailAddress().Address(), "Password reset", body))
+}
+
+func (identityApplicationService *IdentityApplicationService) ResetPassword(aToken string, aNewPassword string) (err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.ResetPassword()")
+
+	_, err = identityApplicationService.passwordResetService.ResetPassword(aToken, aNewPassword)
+	return err
+}
+
+func (identityApplicationService *IdentityApplicationService) SendEmailVerification(aTenantId string, aUsername string) (err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.SendEmailVerification(%s, %s)", aTenantId, aUsername)
+
+	tenant, err := existingTenant(identityApplicationService.tenantRepository, aTenantId)
+	if err != nil {
+		return err
+	}
+	user, err := existingUser(identityApplicationService.tenantRepository, identityApplicationService.userRepository, aTenantId, aUsername)
+	if err != nil {
+		return err
+	}
+
+	plainToken, err := identityApplicationService.emailVerificationService.Issue(user)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Hello %s,\n\nUse the following token to verify your email address for %s.\n\n%s\n", user.Person().Name().AsFormattedName(), tenant.Name(), plainToken)
+	return identityApplicationService.notifier.Notify(NewNotification(user.Person().EmailAddress().Address(), "Email address verification", body))
+}
+
+func (identityApplicationService *IdentityApplicationService) VerifyEmailAddress(aToken string) (err error) {
+	defer ierrors.Wrap(&err, "identityapplicationservice.VerifyEmailAddress()")
+
+	_, err = identityApplicationService.emailVerificationService.Verify(aToken)
+	return err
+}
+
+func (identityApplicationService *IdentityApplicationService) groupAndUser(aTenantId string, aGroupName string, aUsername string) (*identity.Group, *identity.User, error) {
+	group, err := existingGroup(identityApplicationService.tenantRepository, identityApplicationService.groupRepository, aTenantId, aGroupName)
+	if err != nil {
+		return nil, nil, err
+	}
+	user, err := existingUser(identityApplicationService.tenantRepository, identityApplicationService.userRepository, aTenantId, aUsername)
+	if err != nil {
+		return nil, nil, err
+	}
+	return group, user, nil
+}
+
+func (identityApplicationService *IdentityApplicationService) groupAndMemberGroup(aTenantId string, aGroupName string, aMemberGroupName string) (*identity.Group, *identity.Group, error) {
+	group, err := existingGroup(identityApplicationService.tenantRepository, identityApplicationService.groupRepository, aTenantId, aGroupName)
+	if err != nil {
+		return nil, nil, err
+	}
+	memberGroup, err := existingGroup(identityApplicationService.tenantRepository, identityApplicationService.groupRepository, aTenantId, aMemberGroupName)
+	if err != nil {
+		return nil, nil, err
+	}
+	return group, memberGroup, nil
+}
+
+func (identityApplicationService *IdentityApplicationService) groupMemberService() *identity.GroupMemberService {
+	return identity.NewGroupMemberService(identityApplicationService.userRepository, identityApplicationService.groupRepository)
+}
+
+// existingTenant reports a malformed tenant id as not found, since it cannot name any tenant.
+func existingTenant(aTenantRepository identity.TenantRepository, aTenantId string) (*identity.Tenant, error) {
+	tenantId, err := identity.NewTenantId(aTenantId)
+	if err != nil {
+		return nil, ErrTenantNotFound
+	}
+	tenant, err := aTenantRepository.TenantOfId(*tenantId)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, ErrTenantNotFound
+	}
+	return tenant, nil
+}
+
+func existingUser(aTenantRepository identity.TenantRepository, aUserRepository identity.UserRepository, aTenantId string, aUsername string) (*identity.User, error) {
+	tenant, err := existingTenant(aTenantRepository, aTenantId)
+	if err != nil {
+		return nil, err
+	}
+	user, err := aUserRepository.UserWithUsername(tenant.TenantId(), aUsername)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+func existingGroup(aTenantRepository identity.TenantRepository, aGroupRepository identity.GroupRepository, aTenantId string, aGroupName string) (*identity.Group, error) {
+	tenant, err := existingTenant(aTenantRepository, aTenantId)
+	if err != nil {
+		return nil, err
+	}
+	group, err := aGroupRepository.GroupNamed(tenant.TenantId(), aGroupName)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, ErrGroupNotFound
+	}
+	return group, nil
+}
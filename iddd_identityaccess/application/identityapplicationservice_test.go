@@ -0,0 +1,465 @@
+package application
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/infrastructure/persistence"
+)
+
+type recordingNotifier struct {
+	notifications []*Notification
+}
+
+func (recordingNotifier *recordingNotifier) Notify(aNotification *Notification) error {
+	recordingNotifier.notifications = append(recordingNotifier.notifications, aNotification)
+	return nil
+}
+
+func lastLine(aBody string) string {
+	lines := strings.Split(strings.TrimSpace(aBody), "\n")
+	return lines[len(lines)-1]
+}
+
+func (fixture *fixture) identityApplicationService(t *testing.T, aNotifier Notifier) *IdentityApplicationService {
+	t.Helper()
+
+	passwordResetService := identity.NewPasswordResetService(persistence.NewInMemoryPasswordResetTokenRepository(), fixture.userRepository, time.Hour)
+	emailVerificationService := identity.NewEmailVerificationService(persistence.NewInMemoryEmailVerificationTokenRepository(), fixture.userRepository, time.Hour, time.Minute)
+	return NewIdentityApplicationService(fixture.tenantRepository, fixture.userRepository, fixture.groupRepository, fixture.roleAssignmentService(), *identity.DefaultNameLengthPolicy(), passwordResetService, emailVerificationService, aNotifier)
+}
+
+func TestIdentityApplicationServicePasswordReset(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		fixture := newFixture(t)
+		tenantId := fixture.tenant.TenantId()
+		notifier := &recordingNotifier{}
+		identityApplicationService := fixture.identityApplicationService(t, notifier)
+
+		if err := identityApplicationService.RequestPasswordReset(tenantId.Id(), "zoe@saasovation.com"); err != nil {
+			t.Fatal(err)
+		}
+		if len(notifier.notifications) != 1 {
+			t.Fatalf("got %d notifications, want 1", len(notifier.notifications))
+		}
+		notification := notifier.notifications[0]
+		if notification.Recipient() != "zoe@saasovation.com" {
+			t.Errorf("got recipient %s, want %s", notification.Recipient(), "zoe@saasovation.com")
+		}
+		token := lastLine(notification.Body())
+
+		if err := identityApplicationService.ResetPassword(token, "ASDFG#qwerty!12"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fixture.accessApplicationService(t).Authenticate(tenantId.Id(), "zoeusername", "ASDFG#qwerty!12"); err != nil {
+			t.Errorf("new password must authenticate: %v", err)
+		}
+		if err := identityApplicationService.ResetPassword(token, "ZXCVB#qwerty!34"); !errors.Is(err, identity.ErrPasswordResetTokenInvalid) {
+			t.Errorf("got %v, want %v", err, identity.ErrPasswordResetTokenInvalid)
+		}
+	})
+	t.Run("unknown email address is not revealed", func(t *testing.T) {
+		fixture := newFixture(t)
+		tenantId := fixture.tenant.TenantId()
+		notifier := &recordingNotifier{}
+
+		if err := fixture.identityApplicationService(t, notifier).RequestPasswordReset(tenantId.Id(), "unknown@saasovation.com"); err != nil {
+			t.Fatal(err)
+		}
+		if len(notifier.notifications) != 0 {
+			t.Errorf("got %d notifications, want 0", len(notifier.notifications))
+		}
+	})
+	t.Run("inactive tenant is not revealed", func(t *testing.T) {
+		fixture := newFixture(t)
+		if err := fixture.tenant.Deactivate(); err != nil {
+			t.Fatal(err)
+		}
+		tenantId := fixture.tenant.TenantId()
+		notifier := &recordingNotifier{}
+
+		if err := fixture.identityApplicationService(t, notifier).RequestPasswordReset(tenantId.Id(), "zoe@saasovation.com"); err != nil {
+			t.Fatal(err)
+		}
+		if len(notifier.notifications) != 0 {
+			t.Errorf("got %d notifications, want 0", len(notifier.notifications))
+		}
+	})
+}
+
+func TestIdentityApplicationServiceEmailVerification(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		fixture := newFixture(t)
+		tenantId := fixture.tenant.TenantId()
+		notifier := &recordingNotifier{}
+		identityApplicationService := fixture.identityApplicationService(t, notifier)
+
+		if err := identityApplicationService.SendEmailVerification(tenantId.Id(), "zoeusername"); err != nil {
+			t.Fatal(err)
+		}
+		if len(notifier.notifications) != 1 {
+			t.Fatalf("got %d notifications, want 1", len(notifier.notifications))
+		}
+
+		if err := identityApplicationService.VerifyEmailAddress(lastLine(notifier.notifications[0].Body())); err != nil {
+			t.Fatal(err)
+		}
+		if !fixture.user.IsEmailAddressVerified() {
+			t.Errorf("email address must be verified")
+		}
+	})
+	t.Run("fail resend throttled", func(t *testing.T) {
+		fixture := newFixture(t)
+		tenantId := fixture.tenant.TenantId()
+		identityApplicationService := fixture.identityApplicationService(t, &recordingNotifier{})
+
+		if err := identityApplicationService.SendEmailVerification(tenantId.Id(), "zoeusername"); err != nil {
+			t.Fatal(err)
+		}
+		if err := identityApplicationService.SendEmailVerification(tenantId.Id(), "zoeusername"); !errors.Is(err, identity.ErrEmailVerificationThrottled) {
+			t.Errorf("got %v, want %v", err, identity.ErrEmailVerificationThrottled)
+		}
+	})
+}
+
+func registerUserCommand(aTenantId string, anInvitationIdentifier string, aUsername string) RegisterUserCommand {
+	return RegisterUserCommand{
+		TenantId:             aTenantId,
+		InvitationIdentifier: anInvitationIdentifier,
+		Username:             aUsername,
+		Password:             password,
+		FirstName:            "Jane",
+		LastName:             "Doe",
+		EmailAddress:         "jane@saasovation.com",
+		Enabled:              true,
+		StartDate:            time.Now().AddDate(-1, 0, 0),
+		EndDate:              time.Now().AddDate(1, 0, 0),
+	}
+}
+
+func TestIdentityApplicationServiceRegisterUser(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		fixture := newFixture(t)
+		tenantId := fixture.tenant.TenantId()
+		identityApplicationService := fixture.identityApplicationService(t, &recordingNotifier{})
+		registrationInvitation, err := identityApplicationService.OfferRegistrationInvitation(tenantId.Id(), "Today-and-Tomorrow", time.Time{}, time.Time{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		user, err := identityApplicationService.RegisterUser(registerUserCommand(tenantId.Id(), registrationInvitation.InvitationId(), "janeusername"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := identityApplicationService.User(tenantId.Id(), "janeusername")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != user {
+			t.Errorf("got %v, want %v", got, user)
+		}
+	})
+	t.Run("fail user already exists", func(t *testing.T) {
+		fixture := newFixture(t)
+		tenantId := fixture.tenant.TenantId()
+		identityApplicationService := fixture.identityApplicationService(t, &recordingNotifier{})
+		if _, err := identityApplicationService.OfferRegistrationInvitation(tenantId.Id(), "Today-and-Tomorrow", time.Time{}, time.Time{}); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := identityApplicationService.RegisterUser(registerUserCommand(tenantId.Id(), "Today-and-Tomorrow", "zoeusername"))
+		if !errors.Is(err, ErrUserAlreadyExists) {
+			t.Errorf("got %v, want %v", err, ErrUserAlreadyExists)
+		}
+	})
+	t.Run("fail invalid invitation window", func(t *testing.T) {
+		fixture := newFixture(t)
+		tenantId := fixture.tenant.TenantId()
+		identityApplicationService := fixture.identityApplicationService(t, &recordingNotifier{})
+
+		if _, err := identityApplicationService.OfferRegistrationInvitation(tenantId.Id(), "Today-and-Tomorrow", time.Now(), time.Now().Add(-time.Hour)); err == nil {
+			t.Fatalf("invalid invitation window must be rejected")
+		}
+		registrationInvitations, err := identityApplicationService.AvailableRegistrationInvitations(tenantId.Id())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(registrationInvitations) != 0 {
+			t.Errorf("got %d invitations, want 0", len(registrationInvitations))
+		}
+	})
+	t.Run("fail every invalid field at once", func(t *testing.T) {
+		fixture := newFixture(t)
+		tenantId := fixture.tenant.TenantId()
+		command := registerUserCommand(tenantId.Id(), "Today-and-Tomorrow", "janeusername")
+		command.FirstName, command.LastName, command.EmailAddress = "", "", "jane"
+
+		_, err := fixture.identityApplicationService(t, &recordingNotifier{}).RegisterUser(command)
+		var validationError *ierrors.ValidationError
+		if !errors.As(err, &validationError) {
+			t.Fatalf("err type: %T, expect type: %T", err, validationError)
+		}
+		gotFields := []string{}
+		for _, fieldError := range validationError.FieldErrors() {
+			gotFields = append(gotFields, fieldError.Field())
+		}
+		if want := "firstName lastName emailAddress"; strings.Join(gotFields, " ") != want {
+			t.Errorf("got %v, want %s", gotFields, want)
+		}
+	})
+	t.Run("fail unknown tenant", func(t *testing.T) {
+		fixture := newFixture(t)
+
+		_, err := fixture.identityApplicationService(t, &recordingNotifier{}).RegisterUser(registerUserCommand("unknown", "Today-and-Tomorrow", "janeusername"))
+		if !errors.Is(err, ErrTenantNotFound) {
+			t.Errorf("got %v, want %v", err, ErrTenantNotFound)
+		}
+	})
+}
+
+func TestIdentityApplicationServiceGroupMembership(t *testing.T) {
+	fixture := newFixture(t)
+	tenantId := fixture.tenant.TenantId()
+	identityApplicationService := fixture.identityApplicationService(t, &recordingNotifier{})
+	if _, err := identityApplicationService.ProvisionGroup(tenantId.Id(), "Staff", "All staff."); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := identityApplicationService.ProvisionGroup(tenantId.Id(), "Engineers", "All engineers."); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := identityApplicationService.ProvisionGroup(tenantId.Id(), "Staff", "All staff."); !errors.Is(err, ErrGroupAlreadyExists) {
+		t.Errorf("got %v, want %v", err, ErrGroupAlreadyExists)
+	}
+
+	if err := identityApplicationService.AddGroupToGroup(tenantId.Id(), "Staff", "Engineers"); err != nil {
+		t.Fatal(err)
+	}
+	if err := identityApplicationService.AddUserToGroup(tenantId.Id(), "Engineers", "zoeusername"); err != nil {
+		t.Fatal(err)
+	}
+	isMember, err := identityApplicationService.IsGroupMember(tenantId.Id(), "Staff", "zoeusername")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isMember {
+		t.Errorf("user must be a member of the nested group")
+	}
+
+	if err := identityApplicationService.RemoveGroupFromGroup(tenantId.Id(), "Staff", "Engineers"); err != nil {
+		t.Fatal(err)
+	}
+	isMember, err = identityApplicationService.IsGroupMember(tenantId.Id(), "Staff", "zoeusername")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if isMember {
+		t.Errorf("user must not be a member after the nested group is removed")
+	}
+
+	if _, err := identityApplicationService.IsGroupMember(tenantId.Id(), "Unknown", "zoeusername"); !errors.Is(err, ErrGroupNotFound) {
+		t.Errorf("got %v, want %v", err, ErrGroupNotFound)
+	}
+}
+
+func TestIdentityApplicationServiceGroupMembershipExclusiveRoles(t *testing.T) {
+	fixture := newFixture(t)
+	tenantId := fixture.tenant.TenantId()
+	identityApplicationService := fixture.identityApplicationService(t, &recordingNotifier{})
+	accessApplicationService := fixture.accessApplicationService(t)
+	approver, err := accessApplicationService.ProvisionRole(tenantId.Id(), "Approver", "Approves requests.", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	requester, err := accessApplicationService.ProvisionRole(tenantId.Id(), "Requester", "Makes requests.", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := approver.ExcludeRole(requester); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"Approvers", "Requesters"} {
+		if _, err := identityApplicationService.ProvisionGroup(tenantId.Id(), name, "All "+name+"."); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := accessApplicationService.AssignGroupToRole(tenantId.Id(), "Approver", "Approvers"); err != nil {
+		t.Fatal(err)
+	}
+	if err := accessApplicationService.AssignGroupToRole(tenantId.Id(), "Requester", "Requesters"); err != nil {
+		t.Fatal(err)
+	}
+	if err := identityApplicationService.AddUserToGroup(tenantId.Id(), "Requesters", "zoeusername"); err != nil {
+		t.Fatal(err)
+	}
+
+	var exclusiveConstraintError *ierrors.ExclusiveConstraintError
+	if err := identityApplicationService.AddUserToGroup(tenantId.Id(), "Approvers", "zoeusername"); !errors.As(err, &exclusiveConstraintError) {
+		t.Errorf("got %v, want %T", err, exclusiveConstraintError)
+	}
+	if err := identityApplicationService.AddGroupToGroup(tenantId.Id(), "Approvers", "Requesters"); !errors.As(err, &exclusiveConstraintError) {
+		t.Errorf("got %v, want %T", err, exclusiveConstraintError)
+	}
+	isMember, err := identityApplicationService.IsGroupMember(tenantId.Id(), "Approvers", "zoeusername")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if isMember {
+		t.Errorf("user must not join a group whose role is exclusive with one the user holds")
+	}
+}
+
+func TestIdentityApplicationServiceUserAdministration(t *testing.T) {
+	t.Run("define enablement", func(t *testing.T) {
+		fixture := newFixture(t)
+		tenantId := fixture.tenant.TenantId()
+		identityApplicationService := fixture.identityApplicationService(t, &recordingNotifier{})
+
+		if err := identityApplicationService.DefineUserEnablement(tenantId.Id(), "zoeusername", false, time.Now().AddDate(-1, 0, 0), time.Now().AddDate(1, 0, 0)); err != nil {
+			t.Fatal(err)
+		}
+		user, err := identityApplicationService.User(tenantId.Id(), "zoeusername")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if user.IsEnabled() {
+			t.Errorf("user must be disabled")
+		}
+
+		if err := identityApplicationService.DefineUserEnablement(tenantId.Id(), "zoeusername", true, time.Now(), time.Now().AddDate(-1, 0, 0)); err == nil {
+			t.Errorf("invalid enablement window must be rejected")
+		}
+	})
+	t.Run("reset password", func(t *testing.T) {
+		fixture := newFixture(t)
+		tenantId := fixture.tenant.TenantId()
+		identityApplicationService := fixture.identityApplicationService(t, &recordingNotifier{})
+
+		if err := identityApplicationService.ResetUserPassword(tenantId.Id(), "zoeusername", "ytrewq!GFDSA#"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fixture.accessApplicationService(t).Authenticate(tenantId.Id(), "zoeusername", "ytrewq!GFDSA#"); err != nil {
+			t.Errorf("the new password must authenticate: %v", err)
+		}
+	})
+	t.Run("fail unknown user", func(t *testing.T) {
+		fixture := newFixture(t)
+		tenantId := fixture.tenant.TenantId()
+		identityApplicationService := fixture.identityApplicationService(t, &recordingNotifier{})
+
+		if err := identityApplicationService.ResetUserPassword(tenantId.Id(), "unknown", "ytrewq!GFDSA#"); !errors.Is(err, ErrUserNotFound) {
+			t.Errorf("got %v, want %v", err, ErrUserNotFound)
+		}
+	})
+}
+
+func TestIdentityApplicationServiceProvisioning(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		fixture := newFixture(t)
+		tenantId := fixture.tenant.TenantId()
+		identityApplicationService := fixture.identityApplicationService(t, &recordingNotifier{})
+
+		plainToken, err := identityApplicationService.IssueProvisioningToken(tenantId.Id())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := identityApplicationService.AuthenticateProvisioning(tenantId.Id(), plainToken); err != nil {
+			t.Fatal(err)
+		}
+
+		user, err := identityApplicationService.ProvisionUser(ProvisionUserCommand{TenantId: tenantId.Id(), Username: "janeusername", Password: password, FirstName: "Jane", LastName: "Doe", EmailAddress: "jane@saasovation.com", Enabled: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !user.IsEnabled() {
+			t.Errorf("a provisioned user must be enabled")
+		}
+		if err := identityApplicationService.ChangeUserPersonalName(tenantId.Id(), "janeusername", "Janet", "Roe"); err != nil {
+			t.Fatal(err)
+		}
+		if err := identityApplicationService.ChangeUserEmailAddress(tenantId.Id(), "janeusername", "janet@saasovation.com"); err != nil {
+			t.Fatal(err)
+		}
+
+		users, err := identityApplicationService.AllUsers(tenantId.Id())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(users) != 2 || users[0].Username() != "janeusername" {
+			t.Fatalf("got %v, want janeusername and zoeusername", users)
+		}
+		person := users[0].Person()
+		if person.Name().FirstName() != "Janet" || person.EmailAddress().Address() != "janet@saasovation.com" {
+			t.Errorf("got %v, want Janet at janet@saasovation.com", person)
+		}
+	})
+	t.Run("fail invalid token", func(t *testing.T) {
+		fixture := newFixture(t)
+		tenantId := fixture.tenant.TenantId()
+		identityApplicationService := fixture.identityApplicationService(t, &recordingNotifier{})
+		plainToken, err := identityApplicationService.IssueProvisioningToken(tenantId.Id())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tests := []struct {
+			name       string
+			tenantId   string
+			plainToken string
+		}{
+			{name: "wrong token", tenantId: tenantId.Id(), plainToken: "wrong"},
+			{name: "unknown tenant", tenantId: "unknown", plainToken: plainToken},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if err := identityApplicationService.AuthenticateProvisioning(tt.tenantId, tt.plainToken); !errors.Is(err, ErrProvisioningTokenInvalid) {
+					t.Errorf("got %v, want %v", err, ErrProvisioningTokenInvalid)
+				}
+			})
+		}
+
+		if err := identityApplicationService.RevokeProvisioningToken(tenantId.Id()); err != nil {
+			t.Fatal(err)
+		}
+		if err := identityApplicationService.AuthenticateProvisioning(tenantId.Id(), plainToken); !errors.Is(err, ErrProvisioningTokenInvalid) {
+			t.Errorf("got %v, want %v", err, ErrProvisioningTokenInvalid)
+		}
+	})
+	t.Run("fail user already exists", func(t *testing.T) {
+		fixture := newFixture(t)
+		tenantId := fixture.tenant.TenantId()
+
+		_, err := fixture.identityApplicationService(t, &recordingNotifier{}).ProvisionUser(ProvisionUserCommand{TenantId: tenantId.Id(), Username: "zoeusername", Password: password, FirstName: "Zoe", LastName: "Doe", EmailAddress: "zoe@saasovation.com", Enabled: true})
+		if !errors.Is(err, ErrUserAlreadyExists) {
+			t.Errorf("got %v, want %v", err, ErrUserAlreadyExists)
+		}
+	})
+	t.Run("fail username of an existing user", func(t *testing.T) {
+		fixture := newFixture(t)
+		tenantId := fixture.tenant.TenantId()
+		identityApplicationService := fixture.identityApplicationService(t, &recordingNotifier{})
+
+		tests := []struct {
+			name     string
+			username string
+			want     error
+		}{
+			{name: "differs in case", username: "ZoeUsername", want: ErrUserAlreadyExists},
+			{name: "differs in width", username: "ｚｏｅusername", want: ErrUserAlreadyExists},
+			{name: "Cyrillic o", username: "z\u043eeusername", want: ErrUsernameConfusable},
+			{name: "digit zero", username: "z0eusername", want: ErrUsernameConfusable},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := identityApplicationService.ProvisionUser(ProvisionUserCommand{TenantId: tenantId.Id(), Username: tt.username, Password: password, FirstName: "Zoe", LastName: "Doe", EmailAddress: "zoe@saasovation.com", Enabled: true})
+				if !errors.Is(err, tt.want) {
+					t.Errorf("got %v, want %v", err, tt.want)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,39 @@
+package application
+
+import "github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+
+const (
+	MESSAGE_KEY_TENANT_NOT_FOUND           ierrors.MessageKey = "TENANT_NOT_FOUND"
+	MESSAGE_KEY_USER_NOT_FOUND             ierrors.MessageKey = "USER_NOT_FOUND"
+	MESSAGE_KEY_GROUP_NOT_FOUND            ierrors.MessageKey = "GROUP_NOT_FOUND"
+	MESSAGE_KEY_ROLE_NOT_FOUND             ierrors.MessageKey = "ROLE_NOT_FOUND"
+	MESSAGE_KEY_GROUP_ALREADY_EXISTS       ierrors.MessageKey = "GROUP_ALREADY_EXISTS"
+	MESSAGE_KEY_ROLE_ALREADY_EXISTS        ierrors.MessageKey = "ROLE_ALREADY_EXISTS"
+	MESSAGE_KEY_PROVISIONING_TOKEN_INVALID ierrors.MessageKey = "PROVISIONING_TOKEN_INVALID"
+)
+
+const (
+	CODE_TENANT_NOT_FOUND           ierrors.Code = "TENANT_NOT_FOUND"
+	CODE_USER_NOT_FOUND             ierrors.Code = "USER_NOT_FOUND"
+	CODE_GROUP_NOT_FOUND            ierrors.Code = "GROUP_NOT_FOUND"
+	CODE_ROLE_NOT_FOUND             ierrors.Code = "ROLE_NOT_FOUND"
+	CODE_GROUP_ALREADY_EXISTS       ierrors.Code = "GROUP_ALREADY_EXISTS"
+	CODE_ROLE_ALREADY_EXISTS        ierrors.Code = "ROLE_ALREADY_EXISTS"
+	CODE_PROVISIONING_TOKEN_INVALID ierrors.Code = "PROVISIONING_TOKEN_INVALID"
+)
+
+var japaneseMessages = map[ierrors.MessageKey]string{
+	MESSAGE_KEY_TENANT_NOT_FOUND:           "テナントが存在しません。",
+	MESSAGE_KEY_USER_NOT_FOUND:             "ユーザーが存在しません。",
+	MESSAGE_KEY_GROUP_NOT_FOUND:            "グループが存在しません。",
+	MESSAGE_KEY_ROLE_NOT_FOUND:             "ロールが存在しません。",
+	MESSAGE_KEY_GROUP_ALREADY_EXISTS:       "グループは既に存在します。",
+	MESSAGE_KEY_ROLE_ALREADY_EXISTS:        "ロールは既に存在します。",
+	MESSAGE_KEY_PROVISIONING_TOKEN_INVALID: "プロビジョニングトークンが正しくありません。",
+}
+
+func init() {
+	for key, template := range japaneseMessages {
+		ierrors.DEFAULT_CATALOG.SetMessage("ja", key, template)
+	}
+}
@@ -0,0 +1,27 @@
+package application
+
+type Notification struct {
+	recipient string
+	subject   string
+	body      string
+}
+
+func NewNotification(aRecipient string, aSubject string, aBody string) *Notification {
+	return &Notification{recipient: aRecipient, subject: aSubject, body: aBody}
+}
+
+func (notification *Notification) Recipient() string {
+	return notification.recipient
+}
+
+func (notification *Notification) Subject() string {
+	return notification.subject
+}
+
+func (notification *Notification) Body() string {
+	return notification.body
+}
+
+type Notifier interface {
+	Notify(aNotification *Notification) error
+}
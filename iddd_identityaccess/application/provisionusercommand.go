@@ -0,0 +1,11 @@
+package application
+
+type ProvisionUserCommand struct {
+	TenantId     string
+	Username     string
+	Password     string
+	FirstName    string
+	LastName     string
+	EmailAddress string
+	Enabled      bool
+}
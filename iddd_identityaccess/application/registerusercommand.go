@@ -0,0 +1,16 @@
+package application
+
+import "time"
+
+type RegisterUserCommand struct {
+	TenantId             string
+	InvitationIdentifier string
+	Username             string
+	Password             string
+	FirstName            string
+	LastName             string
+	EmailAddress         string
+	Enabled              bool
+	StartDate            time.Time
+	EndDate              time.Time
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/access"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/infrastructure/persistence"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const (
+	BACKEND_FILE = "file"
+	BACKEND_SQL  = "sql"
+)
+
+type backend struct {
+	tenantRepository                 identity.TenantRepository
+	userRepository                   identity.UserRepository
+	groupRepository                  identity.GroupRepository
+	roleRepository                   access.RoleRepository
+	refreshTokenRepository           identity.RefreshTokenRepository
+	passwordResetTokenRepository     identity.PasswordResetTokenRepository
+	emailVerificationTokenRepository identity.EmailVerificationTokenRepository
+	save                             func() error
+	close                            func() error
+}
+
+func openBackend(aName string, aSnapshotPath string, aDriverName string, aDataSourceName string) (*backend, error) {
+	switch aName {
+	case BACKEND_FILE:
+		fileSnapshot, err := persistence.LoadFileSnapshot(aSnapshotPath)
+		if err != nil {
+			return nil, err
+		}
+		return &backend{
+			tenantRepository:                 fileSnapshot.TenantRepository(),
+			userRepository:                   fileSnapshot.UserRepository(),
+			groupRepository:                  fileSnapshot.GroupRepository(),
+			roleRepository:                   fileSnapshot.RoleRepository(),
+			refreshTokenRepository:           fileSnapshot.RefreshTokenRepository(),
+			passwordResetTokenRepository:     fileSnapshot.PasswordResetTokenRepository(),
+			emailVerificationTokenRepository: fileSnapshot.EmailVerificationTokenRepository(),
+			save:                             fileSnapshot.Save,
+			close:                            func() error { return nil },
+		}, nil
+	case BACKEND_SQL:
+		if aDataSourceName == "" {
+			return nil, fmt.Errorf("%w The sql backend requires -dsn.", errUsage)
+		}
+		db, err := sql.Open(aDriverName, aDataSourceName)
+		if err != nil {
+			return nil, err
+		}
+		if err := persistence.CreateSqlSchema(db); err != nil {
+			db.Close()
+			return nil, err
+		}
+		return &backend{
+			tenantRepository:                 persistence.NewSqlTenantRepository(db),
+			userRepository:                   persistence.NewSqlUserRepository(db),
+			groupRepository:                  persistence.NewSqlGroupRepository(db),
+			roleRepository:                   persistence.NewSqlRoleRepository(db),
+			refreshTokenRepository:           persistence.NewSqlRefreshTokenRepository(db),
+			passwordResetTokenRepository:     persistence.NewSqlPasswordResetTokenRepository(db),
+			emailVerificationTokenRepository: persistence.NewSqlEmailVerificationTokenRepository(db),
+			save:                             func() error { return nil },
+			close:                            db.Close,
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w Unknown backend %s.", errUsage, aName)
+	}
+}
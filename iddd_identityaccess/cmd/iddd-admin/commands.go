@@ -0,0 +1,363 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/application"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+type command func(anAdmin *admin, anArgs []string) (interface{}, error)
+
+var commands = map[string]command{
+	"tenant create":       createTenant,
+	"tenant activate":     activateTenant,
+	"tenant deactivate":   deactivateTenant,
+	"tenant list":         listTenants,
+	"tenant issue-token":  issueProvisioningToken,
+	"tenant revoke-token": revokeProvisioningToken,
+	"user register":       registerUser,
+	"user disable":        disableUser,
+	"user set-enablement": setUserEnablement,
+	"user reset-password": resetUserPassword,
+	"group create":        createGroup,
+	"group add-member":    addGroupMember,
+	"role create":         createRole,
+	"role assign":         assignRole,
+}
+
+func createTenant(anAdmin *admin, anArgs []string) (interface{}, error) {
+	flagSet := flag.NewFlagSet("tenant create", flag.ContinueOnError)
+	name := flagSet.String("name", "", "Name of the tenant.")
+	if err := parseFlags(flagSet, anArgs, "name"); err != nil {
+		return nil, err
+	}
+
+	tenant, err := anAdmin.identityApplicationService.ProvisionTenant(*name)
+	if err != nil {
+		return nil, err
+	}
+	return newTenantRepresentation(tenant), nil
+}
+
+func activateTenant(anAdmin *admin, anArgs []string) (interface{}, error) {
+	flagSet := flag.NewFlagSet("tenant activate", flag.ContinueOnError)
+	tenantId := flagSet.String("tenant", "", "Id of the tenant.")
+	if err := parseFlags(flagSet, anArgs, "tenant"); err != nil {
+		return nil, err
+	}
+
+	if err := anAdmin.identityApplicationService.ActivateTenant(*tenantId); err != nil {
+		return nil, err
+	}
+	return anAdmin.tenant(*tenantId)
+}
+
+func deactivateTenant(anAdmin *admin, anArgs []string) (interface{}, error) {
+	flagSet := flag.NewFlagSet("tenant deactivate", flag.ContinueOnError)
+	tenantId := flagSet.String("tenant", "", "Id of the tenant.")
+	if err := parseFlags(flagSet, anArgs, "tenant"); err != nil {
+		return nil, err
+	}
+
+	if err := anAdmin.identityApplicationService.DeactivateTenant(*tenantId); err != nil {
+		return nil, err
+	}
+	return anAdmin.tenant(*tenantId)
+}
+
+func listTenants(anAdmin *admin, anArgs []string) (interface{}, error) {
+	flagSet := flag.NewFlagSet("tenant list", flag.ContinueOnError)
+	if err := parseFlags(flagSet, anArgs); err != nil {
+		return nil, err
+	}
+
+	tenants, err := anAdmin.identityApplicationService.AllTenants()
+	if err != nil {
+		return nil, err
+	}
+	tenantRepresentations := make([]tenantRepresentation, len(tenants))
+	for i, tenant := range tenants {
+		tenantRepresentations[i] = newTenantRepresentation(tenant)
+	}
+	return tenantRepresentations, nil
+}
+
+// issueProvisioningToken replaces the tenant's SCIM bearer token, which is shown only this once.
+func issueProvisioningToken(anAdmin *admin, anArgs []string) (interface{}, error) {
+	flagSet := flag.NewFlagSet("tenant issue-token", flag.ContinueOnError)
+	tenantId := flagSet.String("tenant", "", "Id of the tenant.")
+	if err := parseFlags(flagSet, anArgs, "tenant"); err != nil {
+		return nil, err
+	}
+
+	token, err := anAdmin.identityApplicationService.IssueProvisioningToken(*tenantId)
+	if err != nil {
+		return nil, err
+	}
+	return provisioningTokenRepresentation{TenantId: *tenantId, Token: token}, nil
+}
+
+func revokeProvisioningToken(anAdmin *admin, anArgs []string) (interface{}, error) {
+	flagSet := flag.NewFlagSet("tenant revoke-token", flag.ContinueOnError)
+	tenantId := flagSet.String("tenant", "", "Id of the tenant.")
+	if err := parseFlags(flagSet, anArgs, "tenant"); err != nil {
+		return nil, err
+	}
+
+	if err := anAdmin.identityApplicationService.RevokeProvisioningToken(*tenantId); err != nil {
+		return nil, err
+	}
+	return anAdmin.tenant(*tenantId)
+}
+
+// registerUser offers a single-use invitation on behalf of the administrator and withdraws it once the user is registered.
+func registerUser(anAdmin *admin, anArgs []string) (_ interface{}, err error) {
+	flagSet := flag.NewFlagSet("user register", flag.ContinueOnError)
+	tenantId := flagSet.String("tenant", "", "Id of the tenant.")
+	username := flagSet.String("username", "", "Username of the user.")
+	firstName := flagSet.String("first-name", "", "First name of the user.")
+	lastName := flagSet.String("last-name", "", "Last name of the user.")
+	emailAddress := flagSet.String("email", "", "Email address of the user.")
+	startDate, endDate := time.Now(), identity.INDEFINITE_END_DATE
+	timeVar(flagSet, &startDate, "start", "Start of the enablement.")
+	timeVar(flagSet, &endDate, "end", "End of the enablement.")
+	if err := parseFlags(flagSet, anArgs, "tenant", "username", "first-name", "last-name", "email"); err != nil {
+		return nil, err
+	}
+	password, err := readPassword(anAdmin.stdin)
+	if err != nil {
+		return nil, err
+	}
+
+	registrationInvitation, err := anAdmin.identityApplicationService.OfferRegistrationInvitation(*tenantId, fmt.Sprintf("Registration of %s by iddd-admin.", *username), time.Time{}, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if withdrawErr := anAdmin.identityApplicationService.WithdrawRegistrationInvitation(*tenantId, registrationInvitation.InvitationId()); err == nil {
+			err = withdrawErr
+		}
+	}()
+	user, err := anAdmin.identityApplicationService.RegisterUser(application.RegisterUserCommand{
+		TenantId:             *tenantId,
+		InvitationIdentifier: registrationInvitation.InvitationId(),
+		Username:             *username,
+		Password:             password,
+		FirstName:            *firstName,
+		LastName:             *lastName,
+		EmailAddress:         *emailAddress,
+		Enabled:              true,
+		StartDate:            startDate,
+		EndDate:              endDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newUserRepresentation(user), nil
+}
+
+// disableUser keeps the enablement window so that a later set-enablement only has to flip the flag back.
+func disableUser(anAdmin *admin, anArgs []string) (interface{}, error) {
+	flagSet := flag.NewFlagSet("user disable", flag.ContinueOnError)
+	tenantId := flagSet.String("tenant", "", "Id of the tenant.")
+	username := flagSet.String("username", "", "Username of the user.")
+	if err := parseFlags(flagSet, anArgs, "tenant", "username"); err != nil {
+		return nil, err
+	}
+
+	user, err := anAdmin.identityApplicationService.User(*tenantId, *username)
+	if err != nil {
+		return nil, err
+	}
+	enablement := user.Enablement()
+	if err := anAdmin.identityApplicationService.DefineUserEnablement(*tenantId, *username, false, enablement.StartDate(), enablement.EndDate()); err != nil {
+		return nil, err
+	}
+	return anAdmin.user(*tenantId, *username)
+}
+
+func setUserEnablement(anAdmin *admin, anArgs []string) (interface{}, error) {
+	flagSet := flag.NewFlagSet("user set-enablement", flag.ContinueOnError)
+	tenantId := flagSet.String("tenant", "", "Id of the tenant.")
+	username := flagSet.String("username", "", "Username of the user.")
+	enabled := flagSet.Bool("enabled", true, "Whether the user is enabled.")
+	startDate, endDate := time.Now(), identity.INDEFINITE_END_DATE
+	timeVar(flagSet, &startDate, "start", "Start of the enablement.")
+	timeVar(flagSet, &endDate, "end", "End of the enablement.")
+	if err := parseFlags(flagSet, anArgs, "tenant", "username"); err != nil {
+		return nil, err
+	}
+
+	if err := anAdmin.identityApplicationService.DefineUserEnablement(*tenantId, *username, *enabled, startDate, endDate); err != nil {
+		return nil, err
+	}
+	return anAdmin.user(*tenantId, *username)
+}
+
+func resetUserPassword(anAdmin *admin, anArgs []string) (interface{}, error) {
+	flagSet := flag.NewFlagSet("user reset-password", flag.ContinueOnError)
+	tenantId := flagSet.String("tenant", "", "Id of the tenant.")
+	username := flagSet.String("username", "", "Username of the user.")
+	if err := parseFlags(flagSet, anArgs, "tenant", "username"); err != nil {
+		return nil, err
+	}
+	password, err := readPassword(anAdmin.stdin)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := anAdmin.identityApplicationService.ResetUserPassword(*tenantId, *username, password); err != nil {
+		return nil, err
+	}
+	return anAdmin.user(*tenantId, *username)
+}
+
+func createGroup(anAdmin *admin, anArgs []string) (interface{}, error) {
+	flagSet := flag.NewFlagSet("group create", flag.ContinueOnError)
+	tenantId := flagSet.String("tenant", "", "Id of the tenant.")
+	name := flagSet.String("name", "", "Name of the group.")
+	description := flagSet.String("description", "", "Description of the group.")
+	if err := parseFlags(flagSet, anArgs, "tenant", "name", "description"); err != nil {
+		return nil, err
+	}
+
+	group, err := anAdmin.identityApplicationService.ProvisionGroup(*tenantId, *name, *description)
+	if err != nil {
+		return nil, err
+	}
+	return newGroupRepresentation(group), nil
+}
+
+func addGroupMember(anAdmin *admin, anArgs []string) (interface{}, error) {
+	flagSet := flag.NewFlagSet("group add-member", flag.ContinueOnError)
+	tenantId := flagSet.String("tenant", "", "Id of the tenant.")
+	groupName := flagSet.String("group", "", "Name of the group.")
+	username := flagSet.String("username", "", "Username of the user to add.")
+	memberGroupName := flagSet.String("member-group", "", "Name of the group to add.")
+	if err := parseFlags(flagSet, anArgs, "tenant", "group"); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case *username != "" && *memberGroupName == "":
+		if err := anAdmin.identityApplicationService.AddUserToGroup(*tenantId, *groupName, *username); err != nil {
+			return nil, err
+		}
+	case *username == "" && *memberGroupName != "":
+		if err := anAdmin.identityApplicationService.AddGroupToGroup(*tenantId, *groupName, *memberGroupName); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w Exactly one of -username and -member-group is required.", errUsage)
+	}
+	group, err := anAdmin.identityApplicationService.Group(*tenantId, *groupName)
+	if err != nil {
+		return nil, err
+	}
+	return newGroupRepresentation(group), nil
+}
+
+func createRole(anAdmin *admin, anArgs []string) (interface{}, error) {
+	flagSet := flag.NewFlagSet("role create", flag.ContinueOnError)
+	tenantId := flagSet.String("tenant", "", "Id of the tenant.")
+	name := flagSet.String("name", "", "Name of the role.")
+	description := flagSet.String("description", "", "Description of the role.")
+	supportsNesting := flagSet.Bool("supports-nesting", false, "Whether groups may be assigned to the role.")
+	if err := parseFlags(flagSet, anArgs, "tenant", "name", "description"); err != nil {
+		return nil, err
+	}
+
+	role, err := anAdmin.accessApplicationService.ProvisionRole(*tenantId, *name, *description, *supportsNesting)
+	if err != nil {
+		return nil, err
+	}
+	return newRoleRepresentation(role), nil
+}
+
+func assignRole(anAdmin *admin, anArgs []string) (interface{}, error) {
+	flagSet := flag.NewFlagSet("role assign", flag.ContinueOnError)
+	tenantId := flagSet.String("tenant", "", "Id of the tenant.")
+	roleName := flagSet.String("role", "", "Name of the role.")
+	username := flagSet.String("username", "", "Username of the user to assign.")
+	groupName := flagSet.String("group", "", "Name of the group to assign.")
+	if err := parseFlags(flagSet, anArgs, "tenant", "role"); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case *username != "" && *groupName == "":
+		if err := anAdmin.accessApplicationService.AssignUserToRole(*tenantId, *roleName, *username); err != nil {
+			return nil, err
+		}
+	case *username == "" && *groupName != "":
+		if err := anAdmin.accessApplicationService.AssignGroupToRole(*tenantId, *roleName, *groupName); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w Exactly one of -username and -group is required.", errUsage)
+	}
+	role, err := anAdmin.accessApplicationService.Role(*tenantId, *roleName)
+	if err != nil {
+		return nil, err
+	}
+	return newRoleRepresentation(role), nil
+}
+
+func (admin *admin) tenant(aTenantId string) (interface{}, error) {
+	tenant, err := admin.identityApplicationService.Tenant(aTenantId)
+	if err != nil {
+		return nil, err
+	}
+	return newTenantRepresentation(tenant), nil
+}
+
+func (admin *admin) user(aTenantId string, aUsername string) (interface{}, error) {
+	user, err := admin.identityApplicationService.User(aTenantId, aUsername)
+	if err != nil {
+		return nil, err
+	}
+	return newUserRepresentation(user), nil
+}
+
+func parseFlags(aFlagSet *flag.FlagSet, anArgs []string, aRequiredNames ...string) error {
+	aFlagSet.SetOutput(io.Discard)
+	if err := aFlagSet.Parse(anArgs); err != nil {
+		return fmt.Errorf("%w %s: %v", errUsage, aFlagSet.Name(), err)
+	}
+	if aFlagSet.NArg() > 0 {
+		return fmt.Errorf("%w %s: unexpected argument %s.", errUsage, aFlagSet.Name(), aFlagSet.Arg(0))
+	}
+	for _, name := range aRequiredNames {
+		if aFlagSet.Lookup(name).Value.String() == "" {
+			return fmt.Errorf("%w %s: the flag -%s is required.", errUsage, aFlagSet.Name(), name)
+		}
+	}
+	return nil
+}
+
+func timeVar(aFlagSet *flag.FlagSet, aTime *time.Time, aName string, aUsage string) {
+	aFlagSet.Func(aName, aUsage, func(aValue string) (err error) {
+		*aTime, err = time.Parse(time.RFC3339, aValue)
+		return err
+	})
+}
+
+// readPassword takes the first line of standard input so that passwords stay out of the shell history and the process list.
+func readPassword(aReader io.Reader) (string, error) {
+	line, err := bufio.NewReader(aReader).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		return "", fmt.Errorf("%w A password must be given on standard input.", errUsage)
+	}
+	return password, nil
+}
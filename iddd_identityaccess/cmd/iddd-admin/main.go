@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/domain/model"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/application"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/access"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/infrastructure/notification"
+)
+
+const USAGE = `Usage: iddd-admin [-backend file|sql] [-snapshot path] [-driver name] [-dsn dsn] [-json] <command> [flags]
+
+Commands:
+  tenant create -name NAME
+  tenant activate -tenant ID
+  tenant deactivate -tenant ID
+  tenant list
+  tenant issue-token -tenant ID
+  tenant revoke-token -tenant ID
+  user register -tenant ID -username NAME -first-name NAME -last-name NAME -email ADDRESS [-start TIME] [-end TIME]
+  user disable -tenant ID -username NAME
+  user set-enablement -tenant ID -username NAME -enabled=BOOL [-start TIME] [-end TIME]
+  user reset-password -tenant ID -username NAME
+  group create -tenant ID -name NAME -description TEXT
+  group add-member -tenant ID -group NAME (-username NAME | -member-group NAME)
+  role create -tenant ID -name NAME -description TEXT [-supports-nesting]
+  role assign -tenant ID -role NAME (-username NAME | -group NAME)
+
+Passwords are read from the first line of standard input. Times are RFC 3339.
+`
+
+var errUsage = errors.New("Invalid usage.")
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		fmt.Fprintf(os.Stderr, "iddd-admin: %v\n", err)
+		if errors.Is(err, errUsage) {
+			os.Exit(2)
+		}
+		os.Exit(1)
+	}
+}
+
+func run(anArgs []string, aStdin io.Reader, aStdout io.Writer, aStderr io.Writer) (err error) {
+	flagSet := flag.NewFlagSet("iddd-admin", flag.ContinueOnError)
+	flagSet.SetOutput(aStderr)
+	flagSet.Usage = func() { fmt.Fprint(aStderr, USAGE) }
+	backendName := flagSet.String("backend", "file", "Repository backend, file or sql.")
+	snapshotPath := flagSet.String("snapshot", "iddd-admin.json", "Snapshot file of the file backend.")
+	driverName := flagSet.String("driver", "sqlite3", "database/sql driver of the sql backend.")
+	dataSourceName := flagSet.String("dsn", "", "Data source name of the sql backend.")
+	jsonOutput := flagSet.Bool("json", false, "Write results as JSON.")
+	if err := flagSet.Parse(anArgs); err != nil {
+		return err
+	}
+	if flagSet.NArg() < 2 {
+		flagSet.Usage()
+		return errUsage
+	}
+	command, ok := commands[flagSet.Arg(0)+" "+flagSet.Arg(1)]
+	if !ok {
+		flagSet.Usage()
+		return fmt.Errorf("%w Unknown command %s %s.", errUsage, flagSet.Arg(0), flagSet.Arg(1))
+	}
+
+	backend, err := openBackend(*backendName, *snapshotPath, *driverName, *dataSourceName)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := backend.close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	admin, err := newAdmin(backend, aStdin, aStderr)
+	if err != nil {
+		return err
+	}
+	result, err := command(admin, flagSet.Args()[2:])
+	if err != nil {
+		return err
+	}
+	if err := backend.save(); err != nil {
+		return err
+	}
+	return writeResult(aStdout, result, *jsonOutput)
+}
+
+type admin struct {
+	identityApplicationService *application.IdentityApplicationService
+	accessApplicationService   *application.AccessApplicationService
+	stdin                      io.Reader
+}
+
+func newAdmin(aBackend *backend, aStdin io.Reader, aStderr io.Writer) (*admin, error) {
+	groupMemberService := identity.NewGroupMemberService(aBackend.userRepository, aBackend.groupRepository)
+	passwordResetService := identity.NewPasswordResetService(aBackend.passwordResetTokenRepository, aBackend.userRepository, time.Hour)
+	emailVerificationService := identity.NewEmailVerificationService(aBackend.emailVerificationTokenRepository, aBackend.userRepository, time.Hour, time.Minute)
+	roleAssignmentService := access.NewRoleAssignmentService(aBackend.roleRepository, groupMemberService)
+	refreshTokenService := identity.NewRefreshTokenService(aBackend.tenantRepository, aBackend.userRepository, aBackend.refreshTokenRepository, 30*24*time.Hour)
+	// the publisher is reset so that the services of an earlier invocation within the same process stop receiving events
+	model.DomainEventPublisherInstance().Reset()
+	model.DomainEventPublisherInstance().Subscribe(refreshTokenService)
+	lockoutPolicy, err := identity.NewLockoutPolicy(5, 15*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	return &admin{
+		identityApplicationService: application.NewIdentityApplicationService(aBackend.tenantRepository, aBackend.userRepository, aBackend.groupRepository, roleAssignmentService, *identity.DefaultNameLengthPolicy(), passwordResetService, emailVerificationService, notification.NewConsoleNotifier(aStderr)),
+		accessApplicationService: application.NewAccessApplicationService(
+			identity.NewAuthenticationService(aBackend.tenantRepository, aBackend.userRepository, *lockoutPolicy),
+			access.NewAuthorizationService(aBackend.userRepository, aBackend.groupRepository, aBackend.roleRepository),
+			roleAssignmentService,
+			refreshTokenService,
+			aBackend.tenantRepository, aBackend.userRepository, aBackend.groupRepository, aBackend.roleRepository,
+		),
+		stdin: aStdin,
+	}, nil
+}
+
+func writeResult(aWriter io.Writer, aResult interface{}, aJsonOutput bool) error {
+	if aJsonOutput {
+		encoder := json.NewEncoder(aWriter)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(aResult)
+	}
+	if tenantRepresentations, ok := aResult.([]tenantRepresentation); ok {
+		for _, tenantRepresentation := range tenantRepresentations {
+			if _, err := fmt.Fprintln(aWriter, tenantRepresentation); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	_, err := fmt.Fprintln(aWriter, aResult)
+	return err
+}
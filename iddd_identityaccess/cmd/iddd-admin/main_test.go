@@ -0,0 +1,291 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+const password = "qwerty!ASDFG#"
+
+type fixture struct {
+	globalArgs  []string
+	openBackend func() (*backend, error)
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	snapshotPath := filepath.Join(t.TempDir(), "snapshot.json")
+	return &fixture{
+		globalArgs:  []string{"-snapshot", snapshotPath, "-json"},
+		openBackend: func() (*backend, error) { return openBackend(BACKEND_FILE, snapshotPath, "", "") },
+	}
+}
+
+func newSqlFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	dataSourceName := filepath.Join(t.TempDir(), "identityaccess.db")
+	return &fixture{
+		globalArgs:  []string{"-backend", "sql", "-dsn", dataSourceName, "-json"},
+		openBackend: func() (*backend, error) { return openBackend(BACKEND_SQL, "", "sqlite3", dataSourceName) },
+	}
+}
+
+func (fixture *fixture) run(t *testing.T, aStdin string, anArgs ...string) ([]byte, error) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	err := run(append(append([]string{}, fixture.globalArgs...), anArgs...), strings.NewReader(aStdin), &stdout, &stderr)
+	return stdout.Bytes(), err
+}
+
+func (fixture *fixture) mustRun(t *testing.T, aStdin string, aResult interface{}, anArgs ...string) {
+	t.Helper()
+
+	stdout, err := fixture.run(t, aStdin, anArgs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(stdout, aResult); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (fixture *fixture) createTenant(t *testing.T) string {
+	t.Helper()
+
+	var tenant tenantRepresentation
+	fixture.mustRun(t, "", &tenant, "tenant", "create", "-name", "TenantName")
+	return tenant.TenantId
+}
+
+func (fixture *fixture) registerUser(t *testing.T, aTenantId string) {
+	t.Helper()
+
+	var user userRepresentation
+	fixture.mustRun(t, password+"\n", &user, "user", "register", "-tenant", aTenantId, "-username", "zoeusername", "-first-name", "Zoe", "-last-name", "Doe", "-email", "zoe@saasovation.com")
+	if user.Username != "zoeusername" || !user.Enabled {
+		t.Fatalf("got %v, want an enabled zoeusername", user)
+	}
+}
+
+// withBackend runs aFunc against the backend the commands of fixture use, and saves it afterwards.
+func (fixture *fixture) withBackend(t *testing.T, aFunc func(aBackend *backend)) {
+	t.Helper()
+
+	backend, err := fixture.openBackend()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer backend.close()
+	aFunc(backend)
+	if err := backend.save(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (fixture *fixture) issueRefreshToken(t *testing.T, aTenantId string) {
+	t.Helper()
+
+	fixture.withBackend(t, func(aBackend *backend) {
+		tenantId, err := identity.NewTenantId(aTenantId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		user, err := aBackend.userRepository.UserWithUsername(*tenantId, "zoeusername")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := identity.NewRefreshTokenService(aBackend.tenantRepository, aBackend.userRepository, aBackend.refreshTokenRepository, time.Hour).Issue(user); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func (fixture *fixture) refreshTokens(t *testing.T, aTenantId string) []*identity.RefreshToken {
+	t.Helper()
+
+	var refreshTokens []*identity.RefreshToken
+	fixture.withBackend(t, func(aBackend *backend) {
+		tenantId, err := identity.NewTenantId(aTenantId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if refreshTokens, err = aBackend.refreshTokenRepository.AllRefreshTokensOfTenant(*tenantId); err != nil {
+			t.Fatal(err)
+		}
+	})
+	return refreshTokens
+}
+
+func TestTenantCommands(t *testing.T) {
+	for name, newFixture := range map[string]func(t *testing.T) *fixture{"file": newFixture, "sql": newSqlFixture} {
+		t.Run(name, func(t *testing.T) {
+			fixture := newFixture(t)
+			tenantId := fixture.createTenant(t)
+
+			var tenant tenantRepresentation
+			fixture.mustRun(t, "", &tenant, "tenant", "deactivate", "-tenant", tenantId)
+			if tenant.Active {
+				t.Errorf("tenant must be inactive")
+			}
+			var tenants []tenantRepresentation
+			fixture.mustRun(t, "", &tenants, "tenant", "list")
+			if len(tenants) != 1 || tenants[0].TenantId != tenantId || tenants[0].Active {
+				t.Errorf("got %v, want the inactive tenant %s", tenants, tenantId)
+			}
+			fixture.mustRun(t, "", &tenant, "tenant", "activate", "-tenant", tenantId)
+			if !tenant.Active {
+				t.Errorf("tenant must be active")
+			}
+
+			var provisioningToken provisioningTokenRepresentation
+			fixture.mustRun(t, "", &provisioningToken, "tenant", "issue-token", "-tenant", tenantId)
+			if provisioningToken.TenantId != tenantId || provisioningToken.Token == "" {
+				t.Errorf("got %v, want a token of tenant %s", provisioningToken, tenantId)
+			}
+			fixture.mustRun(t, "", &tenant, "tenant", "revoke-token", "-tenant", tenantId)
+		})
+	}
+}
+
+func TestUserCommands(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		fixture := newFixture(t)
+		tenantId := fixture.createTenant(t)
+		fixture.registerUser(t, tenantId)
+
+		var user userRepresentation
+		fixture.mustRun(t, "", &user, "user", "disable", "-tenant", tenantId, "-username", "zoeusername")
+		if user.Enabled {
+			t.Errorf("user must be disabled")
+		}
+		fixture.mustRun(t, "", &user, "user", "set-enablement", "-tenant", tenantId, "-username", "zoeusername", "-enabled=true", "-start", "2000-01-01T00:00:00Z", "-end", "2999-01-01T00:00:00Z")
+		if !user.Enabled || user.StartDate.Year() != 2000 {
+			t.Errorf("got %v, want enabled from 2000", user)
+		}
+		fixture.mustRun(t, "ytrewq!GFDSA#\n", &user, "user", "reset-password", "-tenant", tenantId, "-username", "zoeusername")
+
+		var tenant tenantRepresentation
+		fixture.mustRun(t, "", &tenant, "tenant", "activate", "-tenant", tenantId)
+		if _, err := fixture.run(t, password+"\n", "user", "register", "-tenant", tenantId, "-username", "zoeusername", "-first-name", "Zoe", "-last-name", "Doe", "-email", "zoe@saasovation.com"); err == nil {
+			t.Errorf("registering the same username twice must fail")
+		}
+	})
+	t.Run("fail password missing", func(t *testing.T) {
+		fixture := newFixture(t)
+		tenantId := fixture.createTenant(t)
+
+		_, err := fixture.run(t, "", "user", "register", "-tenant", tenantId, "-username", "zoeusername", "-first-name", "Zoe", "-last-name", "Doe", "-email", "zoe@saasovation.com")
+		if !errors.Is(err, errUsage) {
+			t.Errorf("got %v, want %v", err, errUsage)
+		}
+	})
+}
+
+func TestCommandsRevokeRefreshTokens(t *testing.T) {
+	for name, newFixture := range map[string]func(t *testing.T) *fixture{"file": newFixture, "sql": newSqlFixture} {
+		t.Run(name, func(t *testing.T) {
+			fixture := newFixture(t)
+			tenantId := fixture.createTenant(t)
+			fixture.registerUser(t, tenantId)
+			fixture.issueRefreshToken(t, tenantId)
+
+			var user userRepresentation
+			fixture.mustRun(t, "ytrewq!GFDSA#\n", &user, "user", "reset-password", "-tenant", tenantId, "-username", "zoeusername")
+			refreshTokens := fixture.refreshTokens(t, tenantId)
+			if len(refreshTokens) != 1 || !refreshTokens[0].IsRevoked() {
+				t.Errorf("got %v, want the refresh token revoked", refreshTokens)
+			}
+		})
+	}
+}
+
+func TestPasswordResetTokenOutlivesInvocation(t *testing.T) {
+	for name, newFixture := range map[string]func(t *testing.T) *fixture{"file": newFixture, "sql": newSqlFixture} {
+		t.Run(name, func(t *testing.T) {
+			fixture := newFixture(t)
+			tenantId := fixture.createTenant(t)
+			fixture.registerUser(t, tenantId)
+
+			var token string
+			fixture.withBackend(t, func(aBackend *backend) {
+				id, err := identity.NewTenantId(tenantId)
+				if err != nil {
+					t.Fatal(err)
+				}
+				user, err := aBackend.userRepository.UserWithUsername(*id, "zoeusername")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if token, err = identity.NewPasswordResetService(aBackend.passwordResetTokenRepository, aBackend.userRepository, time.Hour).Issue(user); err != nil {
+					t.Fatal(err)
+				}
+			})
+			fixture.withBackend(t, func(aBackend *backend) {
+				admin, err := newAdmin(aBackend, strings.NewReader(""), &bytes.Buffer{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := admin.identityApplicationService.ResetPassword(token, "ytrewq!GFDSA#"); err != nil {
+					t.Errorf("got %v, want the token issued by an earlier invocation redeemed", err)
+				}
+			})
+		})
+	}
+}
+
+func TestGroupAndRoleCommands(t *testing.T) {
+	fixture := newFixture(t)
+	tenantId := fixture.createTenant(t)
+	fixture.registerUser(t, tenantId)
+
+	var group groupRepresentation
+	fixture.mustRun(t, "", &group, "group", "create", "-tenant", tenantId, "-name", "Staff", "-description", "All staff.")
+	fixture.mustRun(t, "", &group, "group", "add-member", "-tenant", tenantId, "-group", "Staff", "-username", "zoeusername")
+	if len(group.GroupMembers) != 1 || group.GroupMembers[0].Name != "zoeusername" {
+		t.Errorf("got %v, want zoeusername as the only member", group.GroupMembers)
+	}
+
+	var role roleRepresentation
+	fixture.mustRun(t, "", &role, "role", "create", "-tenant", tenantId, "-name", "Manager", "-description", "A manager role.", "-supports-nesting")
+	fixture.mustRun(t, "", &role, "role", "assign", "-tenant", tenantId, "-role", "Manager", "-group", "Staff")
+	if role.Name != "Manager" || !role.SupportsNesting {
+		t.Errorf("got %v, want the nesting Manager role", role)
+	}
+
+	if _, err := fixture.run(t, "", "role", "assign", "-tenant", tenantId, "-role", "Manager", "-group", "Staff", "-username", "zoeusername"); !errors.Is(err, errUsage) {
+		t.Errorf("got %v, want %v", err, errUsage)
+	}
+}
+
+func TestRunUsage(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "no command", args: []string{}},
+		{name: "unknown command", args: []string{"tenant", "remove"}},
+		{name: "unknown backend", args: []string{"-backend", "ldap", "tenant", "list"}},
+		{name: "sql without dsn", args: []string{"-backend", "sql", "tenant", "list"}},
+		{name: "missing flag", args: []string{"tenant", "activate"}},
+		{name: "invalid time", args: []string{"user", "set-enablement", "-tenant", "id", "-username", "zoeusername", "-start", "yesterday"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := append([]string{"-snapshot", filepath.Join(t.TempDir(), "snapshot.json")}, tt.args...)
+			var stdout, stderr bytes.Buffer
+			if err := run(args, strings.NewReader(""), &stdout, &stderr); !errors.Is(err, errUsage) {
+				t.Errorf("got %v, want %v", err, errUsage)
+			}
+		})
+	}
+}
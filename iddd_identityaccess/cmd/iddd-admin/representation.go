@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/access"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+// The representations leave out password hashes and second factors, which the aggregates' own JSON carries for persistence.
+
+type tenantRepresentation struct {
+	TenantId string `json:"tenantId"`
+	Name     string `json:"name"`
+	Active   bool   `json:"active"`
+}
+
+func newTenantRepresentation(aTenant *identity.Tenant) tenantRepresentation {
+	tenantId := aTenant.TenantId()
+	return tenantRepresentation{TenantId: tenantId.Id(), Name: aTenant.Name(), Active: aTenant.IsActive()}
+}
+
+func (tenantRepresentation tenantRepresentation) String() string {
+	return fmt.Sprintf("%s\t%s\tactive=%v", tenantRepresentation.TenantId, tenantRepresentation.Name, tenantRepresentation.Active)
+}
+
+type provisioningTokenRepresentation struct {
+	TenantId string `json:"tenantId"`
+	Token    string `json:"token"`
+}
+
+func (provisioningTokenRepresentation provisioningTokenRepresentation) String() string {
+	return provisioningTokenRepresentation.Token
+}
+
+type userRepresentation struct {
+	TenantId     string    `json:"tenantId"`
+	Username     string    `json:"username"`
+	FirstName    string    `json:"firstName"`
+	LastName     string    `json:"lastName"`
+	EmailAddress string    `json:"emailAddress"`
+	Enabled      bool      `json:"enabled"`
+	StartDate    time.Time `json:"startDate"`
+	EndDate      time.Time `json:"endDate"`
+}
+
+func newUserRepresentation(aUser *identity.User) userRepresentation {
+	tenantId := aUser.TenantId()
+	person := aUser.Person()
+	enablement := aUser.Enablement()
+	return userRepresentation{
+		TenantId:     tenantId.Id(),
+		Username:     aUser.Username(),
+		FirstName:    person.Name().FirstName(),
+		LastName:     person.Name().LastName(),
+		EmailAddress: person.EmailAddress().Address(),
+		Enabled:      aUser.IsEnabled(),
+		StartDate:    enablement.StartDate(),
+		EndDate:      enablement.EndDate(),
+	}
+}
+
+func (userRepresentation userRepresentation) String() string {
+	return fmt.Sprintf("%s\t%s\t%s\tenabled=%v", userRepresentation.TenantId, userRepresentation.Username, userRepresentation.EmailAddress, userRepresentation.Enabled)
+}
+
+type groupRepresentation struct {
+	TenantId     string                      `json:"tenantId"`
+	Name         string                      `json:"name"`
+	Description  string                      `json:"description"`
+	GroupMembers []groupMemberRepresentation `json:"groupMembers"`
+}
+
+type groupMemberRepresentation struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+func newGroupRepresentation(aGroup *identity.Group) groupRepresentation {
+	tenantId := aGroup.TenantId()
+	groupMembers := aGroup.GroupMembers()
+	representation := groupRepresentation{TenantId: tenantId.Id(), Name: aGroup.Name(), Description: aGroup.Description(), GroupMembers: make([]groupMemberRepresentation, len(groupMembers))}
+	for i, groupMember := range groupMembers {
+		representation.GroupMembers[i] = groupMemberRepresentation{Name: groupMember.Name(), Type: groupMember.Type().String()}
+	}
+	return representation
+}
+
+func (groupRepresentation groupRepresentation) String() string {
+	groupMembers := make([]string, len(groupRepresentation.GroupMembers))
+	for i, groupMember := range groupRepresentation.GroupMembers {
+		groupMembers[i] = groupMember.Type + ":" + groupMember.Name
+	}
+	return fmt.Sprintf("%s\t%s\tmembers=%s", groupRepresentation.TenantId, groupRepresentation.Name, strings.Join(groupMembers, ","))
+}
+
+type roleRepresentation struct {
+	TenantId        string `json:"tenantId"`
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	SupportsNesting bool   `json:"supportsNesting"`
+}
+
+func newRoleRepresentation(aRole *access.Role) roleRepresentation {
+	tenantId := aRole.TenantId()
+	return roleRepresentation{TenantId: tenantId.Id(), Name: aRole.Name(), Description: aRole.Description(), SupportsNesting: aRole.SupportsNesting()}
+}
+
+func (roleRepresentation roleRepresentation) String() string {
+	return fmt.Sprintf("%s\t%s\tsupportsNesting=%v", roleRepresentation.TenantId, roleRepresentation.Name, roleRepresentation.SupportsNesting)
+}
@@ -0,0 +1,45 @@
+package access
+
+import (
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+type AuthorizationService struct {
+	userRepository  identity.UserRepository
+	groupRepository identity.GroupRepository
+	roleRepository  RoleRepository
+}
+
+func NewAuthorizationService(aUserRepository identity.UserRepository, aGroupRepository identity.GroupRepository, aRoleRepository RoleRepository) *AuthorizationService {
+	return &AuthorizationService{userRepository: aUserRepository, groupRepository: aGroupRepository, roleRepository: aRoleRepository}
+}
+
+func (authorizationService *AuthorizationService) IsUserInRole(aTenantId identity.TenantId, aUsername string, aRoleName string) (_ bool, err error) {
+	defer ierrors.Wrap(&err, "authorizationservice.IsUserInRole(%v, %s, %s)", aTenantId, aUsername, aRoleName)
+
+	user, err := authorizationService.userRepository.UserWithUsername(aTenantId, aUsername)
+	if err != nil {
+		return false, err
+	}
+	if user == nil {
+		return false, nil
+	}
+
+	return authorizationService.IsUserInRoleOf(user, aRoleName)
+}
+
+func (authorizationService *AuthorizationService) IsUserInRoleOf(aUser *identity.User, aRoleName string) (_ bool, err error) {
+	defer ierrors.Wrap(&err, "authorizationservice.IsUserInRoleOf(%s, %s)", aUser.Username(), aRoleName)
+
+	role, err := authorizationService.roleRepository.RoleNamed(aUser.TenantId(), aRoleName)
+	if err != nil {
+		return false, err
+	}
+	if role == nil {
+		return false, nil
+	}
+
+	groupMemberService := identity.NewGroupMemberService(authorizationService.userRepository, authorizationService.groupRepository)
+	return role.IsInRole(aUser, groupMemberService)
+}
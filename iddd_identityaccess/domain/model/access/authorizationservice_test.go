@@ -0,0 +1,40 @@
+package access_test
+
+import (
+	"testing"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/access"
+)
+
+func TestIsUserInRole(t *testing.T) {
+	fixture := newFixture(t)
+	user := fixture.newUser(t, "zoeusername", true)
+	role := fixture.newRole(t, "Manager", true)
+	if err := fixture.roleAssignmentService().AssignUser(role, user); err != nil {
+		t.Fatal(err)
+	}
+	authorizationService := access.NewAuthorizationService(fixture.userRepository, fixture.groupRepository, fixture.roleRepository)
+
+	tests := []struct {
+		name     string
+		username string
+		roleName string
+		want     bool
+	}{
+		{name: "in role", username: "zoeusername", roleName: "Manager", want: true},
+		{name: "in role by fullwidth username", username: "\uff5a\uff4f\uff45username", roleName: "Manager", want: true},
+		{name: "unknown role", username: "zoeusername", roleName: "Director", want: false},
+		{name: "unknown user", username: "unknownusername", roleName: "Manager", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := authorizationService.IsUserInRole(fixture.tenantId, tt.username, tt.roleName)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package access
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+type ConditionName string
+
+const (
+	CONDITION_TENANT_IS                                 ConditionName = "TenantIs"
+	CONDITION_USER_ATTRIBUTE_EQUALS                     ConditionName = "UserAttributeEquals"
+	CONDITION_RESOURCE_ATTRIBUTE_EQUALS                 ConditionName = "ResourceAttributeEquals"
+	CONDITION_USER_ATTRIBUTE_MATCHES_RESOURCE_ATTRIBUTE ConditionName = "UserAttributeMatchesResourceAttribute"
+	CONDITION_RESOURCE_OWNED_BY_USER                    ConditionName = "ResourceOwnedByUser"
+	CONDITION_ALL_OF                                    ConditionName = "AllOf"
+	CONDITION_ANY_OF                                    ConditionName = "AnyOf"
+	CONDITION_NOT                                       ConditionName = "Not"
+)
+
+// conditionArity is the number of arguments and of nested conditions of each name; -1 is any number.
+var conditionArity = map[ConditionName][2]int{
+	CONDITION_TENANT_IS:                                 {1, 0},
+	CONDITION_USER_ATTRIBUTE_EQUALS:                     {2, 0},
+	CONDITION_RESOURCE_ATTRIBUTE_EQUALS:                 {2, 0},
+	CONDITION_USER_ATTRIBUTE_MATCHES_RESOURCE_ATTRIBUTE: {2, 0},
+	CONDITION_RESOURCE_OWNED_BY_USER:                    {1, 0},
+	CONDITION_ALL_OF:                                    {0, -1},
+	CONDITION_ANY_OF:                                    {0, -1},
+	CONDITION_NOT:                                       {0, 1},
+}
+
+// Condition is a named predicate over a user and a resource, compared and persisted by its name, arguments and nested conditions.
+type Condition struct {
+	name       ConditionName
+	arguments  []string
+	conditions []Condition
+}
+
+func NewCondition(aName ConditionName, anArguments []string, aConditions []*Condition) (_ *Condition, err error) {
+	defer ierrors.Wrap(&err, "condition.NewCondition(%s)", aName)
+
+	arity, isKnownName := conditionArity[aName]
+	if err := ierrors.NewArgumentTrueErrorArguments(isKnownName, "The condition name is unknown.").GetError(); err != nil {
+		return nil, err
+	}
+	if err := ierrors.NewArgumentTrueErrorArguments(len(anArguments) == arity[0], fmt.Sprintf("The condition %s takes %d arguments.", aName, arity[0])).GetError(); err != nil {
+		return nil, err
+	}
+	isConditionCount := arity[1] == -1 || len(aConditions) == arity[1]
+	if err := ierrors.NewArgumentTrueErrorArguments(isConditionCount, fmt.Sprintf("The condition %s takes %d conditions.", aName, arity[1])).GetError(); err != nil {
+		return nil, err
+	}
+
+	return newCondition(aName, anArguments, aConditions...), nil
+}
+
+func newCondition(aName ConditionName, anArguments []string, aConditions ...*Condition) *Condition {
+	conditions := make([]Condition, len(aConditions))
+	for i, condition := range aConditions {
+		conditions[i] = *condition
+	}
+	return &Condition{name: aName, arguments: append([]string{}, anArguments...), conditions: conditions}
+}
+
+func TenantIs(aTenantId identity.TenantId) *Condition {
+	return newCondition(CONDITION_TENANT_IS, []string{aTenantId.Id()})
+}
+
+func UserAttributeEquals(aKey string, aValue string) *Condition {
+	return newCondition(CONDITION_USER_ATTRIBUTE_EQUALS, []string{aKey, aValue})
+}
+
+func ResourceAttributeEquals(aKey string, aValue string) *Condition {
+	return newCondition(CONDITION_RESOURCE_ATTRIBUTE_EQUALS, []string{aKey, aValue})
+}
+
+func UserAttributeMatchesResourceAttribute(aUserKey string, aResourceKey string) *Condition {
+	return newCondition(CONDITION_USER_ATTRIBUTE_MATCHES_RESOURCE_ATTRIBUTE, []string{aUserKey, aResourceKey})
+}
+
+func ResourceOwnedByUser(aResourceKey string) *Condition {
+	return newCondition(CONDITION_RESOURCE_OWNED_BY_USER, []string{aResourceKey})
+}
+
+func AllOf(aConditions ...*Condition) *Condition {
+	return newCondition(CONDITION_ALL_OF, nil, aConditions...)
+}
+
+func AnyOf(aConditions ...*Condition) *Condition {
+	return newCondition(CONDITION_ANY_OF, nil, aConditions...)
+}
+
+func Not(aCondition *Condition) *Condition {
+	return newCondition(CONDITION_NOT, nil, aCondition)
+}
+
+func (condition Condition) Name() ConditionName {
+	return condition.name
+}
+
+func (condition Condition) Arguments() []string {
+	return append([]string{}, condition.arguments...)
+}
+
+func (condition Condition) Conditions() []*Condition {
+	conditions := make([]*Condition, len(condition.conditions))
+	for i := range condition.conditions {
+		nested := condition.conditions[i]
+		conditions[i] = &nested
+	}
+	return conditions
+}
+
+func (condition Condition) IsSatisfiedBy(aUser *identity.User, aResource Resource) bool {
+	switch condition.name {
+	case CONDITION_TENANT_IS:
+		tenantId := aUser.TenantId()
+		return tenantId.Id() == condition.arguments[0]
+	case CONDITION_USER_ATTRIBUTE_EQUALS:
+		value, ok := aUser.Attribute(condition.arguments[0])
+		return ok && value == condition.arguments[1]
+	case CONDITION_RESOURCE_ATTRIBUTE_EQUALS:
+		value, ok := aResource.Attribute(condition.arguments[0])
+		return ok && value == condition.arguments[1]
+	case CONDITION_USER_ATTRIBUTE_MATCHES_RESOURCE_ATTRIBUTE:
+		userValue, ok := aUser.Attribute(condition.arguments[0])
+		if !ok {
+			return false
+		}
+		resourceValue, ok := aResource.Attribute(condition.arguments[1])
+		return ok && userValue == resourceValue
+	case CONDITION_RESOURCE_OWNED_BY_USER:
+		owner, ok := aResource.Attribute(condition.arguments[0])
+		return ok && owner == aUser.Username()
+	case CONDITION_ALL_OF:
+		for _, nested := range condition.conditions {
+			if !nested.IsSatisfiedBy(aUser, aResource) {
+				return false
+			}
+		}
+		return true
+	case CONDITION_ANY_OF:
+		for _, nested := range condition.conditions {
+			if nested.IsSatisfiedBy(aUser, aResource) {
+				return true
+			}
+		}
+		return false
+	case CONDITION_NOT:
+		return !condition.conditions[0].IsSatisfiedBy(aUser, aResource)
+	}
+	return false
+}
+
+func (condition Condition) Equals(otherCondition *Condition) bool {
+	if otherCondition == nil || condition.name != otherCondition.name || len(condition.arguments) != len(otherCondition.arguments) || len(condition.conditions) != len(otherCondition.conditions) {
+		return false
+	}
+	for i, argument := range condition.arguments {
+		if argument != otherCondition.arguments[i] {
+			return false
+		}
+	}
+	for i, nested := range condition.conditions {
+		if !nested.Equals(&otherCondition.conditions[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (condition Condition) String() string {
+	operands := append([]string{}, condition.arguments...)
+	for _, nested := range condition.conditions {
+		operands = append(operands, nested.String())
+	}
+	return fmt.Sprintf("%s(%s)", condition.name, strings.Join(operands, ", "))
+}
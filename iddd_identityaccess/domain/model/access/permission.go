@@ -0,0 +1,111 @@
+package access
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+type PermissionEffect int
+
+const (
+	PERMISSION_EFFECT_ALLOW PermissionEffect = iota + 1
+	PERMISSION_EFFECT_DENY
+)
+
+func (permissionEffect PermissionEffect) String() string {
+	switch permissionEffect {
+	case PERMISSION_EFFECT_ALLOW:
+		return "Allow"
+	case PERMISSION_EFFECT_DENY:
+		return "Deny"
+	}
+	return "Unknown"
+}
+
+const PERMISSION_WILDCARD = "*"
+
+type Permission struct {
+	resource  string
+	action    string
+	effect    PermissionEffect
+	condition *Condition
+}
+
+func NewPermission(aResource string, anAction string, anEffect PermissionEffect, aCondition *Condition) (_ *Permission, err error) {
+	defer ierrors.Wrap(&err, "permission.NewPermission(%s, %s, %v)", aResource, anAction, anEffect)
+
+	if err := ierrors.NewArgumentNotEmptyError(aResource, "The permission resource is required.").GetError(); err != nil {
+		return nil, err
+	}
+	if err := ierrors.NewArgumentLengthError(aResource, 1, 250, "The permission resource must be 250 characters or less.").GetError(); err != nil {
+		return nil, err
+	}
+	if err := ierrors.NewArgumentNotEmptyError(anAction, "The permission action is required.").GetError(); err != nil {
+		return nil, err
+	}
+	if err := ierrors.NewArgumentLengthError(anAction, 1, 100, "The permission action must be 100 characters or less.").GetError(); err != nil {
+		return nil, err
+	}
+	isKnownEffect := anEffect == PERMISSION_EFFECT_ALLOW || anEffect == PERMISSION_EFFECT_DENY
+	if err := ierrors.NewArgumentTrueErrorArguments(isKnownEffect, "The permission effect must be allow or deny.").GetError(); err != nil {
+		return nil, err
+	}
+
+	return &Permission{resource: aResource, action: anAction, effect: anEffect, condition: aCondition}, nil
+}
+
+func (permission Permission) Resource() string {
+	return permission.resource
+}
+
+func (permission Permission) Action() string {
+	return permission.action
+}
+
+func (permission Permission) Effect() PermissionEffect {
+	return permission.effect
+}
+
+// Condition is nil for an unconditional permission.
+func (permission Permission) Condition() *Condition {
+	return permission.condition
+}
+
+func (permission Permission) IsDeny() bool {
+	return permission.effect == PERMISSION_EFFECT_DENY
+}
+
+func (permission Permission) AppliesTo(aUser *identity.User, anAction string, aResource Resource) bool {
+	if !matchesPattern(permission.action, anAction) || !matchesPattern(permission.resource, aResource.name) {
+		return false
+	}
+	return permission.condition == nil || permission.condition.IsSatisfiedBy(aUser, aResource)
+}
+
+// matchesPattern accepts an exact value, "*" for anything, or a trailing "/*" for everything below a prefix.
+func matchesPattern(aPattern string, aValue string) bool {
+	if aPattern == PERMISSION_WILDCARD || aPattern == aValue {
+		return true
+	}
+	if strings.HasSuffix(aPattern, "/"+PERMISSION_WILDCARD) {
+		return strings.HasPrefix(aValue, strings.TrimSuffix(aPattern, PERMISSION_WILDCARD))
+	}
+	return false
+}
+
+func (permission Permission) Equals(otherPermission Permission) bool {
+	if permission.resource != otherPermission.resource || permission.action != otherPermission.action || permission.effect != otherPermission.effect {
+		return false
+	}
+	if permission.condition == nil || otherPermission.condition == nil {
+		return permission.condition == otherPermission.condition
+	}
+	return permission.condition.Equals(otherPermission.condition)
+}
+
+func (permission Permission) String() string {
+	return fmt.Sprintf("Permission [resource=%s, action=%s, effect=%v, condition=%v]", permission.resource, permission.action, permission.effect, permission.condition)
+}
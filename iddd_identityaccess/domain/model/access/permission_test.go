@@ -0,0 +1,131 @@
+package access_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/access"
+)
+
+func TestNewPermission(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		permission, err := access.NewPermission("documents/*", "read", access.PERMISSION_EFFECT_ALLOW, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if permission.Resource() != "documents/*" || permission.Action() != "read" || permission.IsDeny() {
+			t.Errorf("unexpected permission %v", permission)
+		}
+	})
+	t.Run("fail empty action", func(t *testing.T) {
+		_, err := access.NewPermission("documents/*", "", access.PERMISSION_EFFECT_ALLOW, nil)
+		if !errors.As(err, &argumentNotEmptyError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentNotEmptyError))
+		}
+	})
+	t.Run("fail unknown effect", func(t *testing.T) {
+		_, err := access.NewPermission("documents/*", "read", access.PermissionEffect(0), nil)
+		if !errors.As(err, &argumentTrueError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentTrueError))
+		}
+	})
+}
+
+func TestNewCondition(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		condition, err := access.NewCondition(access.CONDITION_NOT, nil, []*access.Condition{access.ResourceOwnedByUser("owner")})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !condition.Equals(access.Not(access.ResourceOwnedByUser("owner"))) {
+			t.Errorf("got %v, want %v", condition, access.Not(access.ResourceOwnedByUser("owner")))
+		}
+	})
+	t.Run("fail unknown name", func(t *testing.T) {
+		_, err := access.NewCondition(access.ConditionName("Always"), nil, nil)
+		if !errors.As(err, &argumentTrueError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentTrueError))
+		}
+	})
+	t.Run("fail wrong number of arguments", func(t *testing.T) {
+		_, err := access.NewCondition(access.CONDITION_USER_ATTRIBUTE_EQUALS, []string{"department"}, nil)
+		if !errors.As(err, &argumentTrueError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentTrueError))
+		}
+	})
+}
+
+func TestPermissionEquals(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition *access.Condition
+		other     *access.Condition
+		want      bool
+	}{
+		{name: "unconditional", want: true},
+		{name: "same condition", condition: access.AllOf(access.ResourceOwnedByUser("owner")), other: access.AllOf(access.ResourceOwnedByUser("owner")), want: true},
+		{name: "conditional and unconditional", condition: access.ResourceOwnedByUser("owner"), want: false},
+		{name: "other argument", condition: access.ResourceOwnedByUser("owner"), other: access.ResourceOwnedByUser("author"), want: false},
+		{name: "other nested condition", condition: access.Not(access.ResourceOwnedByUser("owner")), other: access.Not(access.ResourceOwnedByUser("author")), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			permission, err := access.NewPermission("documents/*", "read", access.PERMISSION_EFFECT_ALLOW, tt.condition)
+			if err != nil {
+				t.Fatal(err)
+			}
+			other, err := access.NewPermission("documents/*", "read", access.PERMISSION_EFFECT_ALLOW, tt.other)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := permission.Equals(*other); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPermissionAppliesTo(t *testing.T) {
+	fixture := newFixture(t)
+	user := fixture.newUser(t, "zoeusername", true)
+	if err := user.ChangeAttribute("department", "sales"); err != nil {
+		t.Fatal(err)
+	}
+	resource, err := access.NewResource(fixture.tenantId, "documents/42", map[string]string{"owner": "zoeusername", "department": "sales"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		resource  string
+		action    string
+		condition *access.Condition
+		want      bool
+	}{
+		{name: "exact", resource: "documents/42", action: "read", want: true},
+		{name: "wildcard resource", resource: "*", action: "read", want: true},
+		{name: "prefix resource", resource: "documents/*", action: "read", want: true},
+		{name: "wildcard action", resource: "documents/42", action: "*", want: true},
+		{name: "other action", resource: "documents/42", action: "write", want: false},
+		{name: "other prefix", resource: "invoices/*", action: "read", want: false},
+		{name: "owned by user", resource: "documents/*", action: "read", condition: access.ResourceOwnedByUser("owner"), want: true},
+		{name: "same tenant", resource: "documents/*", action: "read", condition: access.TenantIs(fixture.tenantId), want: true},
+		{name: "same department", resource: "documents/*", action: "read", condition: access.UserAttributeMatchesResourceAttribute("department", "department"), want: true},
+		{name: "other department", resource: "documents/*", action: "read", condition: access.UserAttributeEquals("department", "engineering"), want: false},
+		{name: "all of", resource: "documents/*", action: "read", condition: access.AllOf(access.ResourceOwnedByUser("owner"), access.Not(access.UserAttributeEquals("department", "engineering"))), want: true},
+		{name: "any of", resource: "documents/*", action: "read", condition: access.AnyOf(access.UserAttributeEquals("department", "engineering"), access.ResourceAttributeEquals("department", "hr")), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			permission, err := access.NewPermission(tt.resource, tt.action, access.PERMISSION_EFFECT_ALLOW, tt.condition)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := permission.AppliesTo(user, "read", *resource); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
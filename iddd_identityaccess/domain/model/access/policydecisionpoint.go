@@ -0,0 +1,54 @@
+package access
+
+import (
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+type PolicyDecisionPoint struct {
+	roleRepository     RoleRepository
+	groupMemberService *identity.GroupMemberService
+}
+
+func NewPolicyDecisionPoint(aRoleRepository RoleRepository, aGroupMemberService *identity.GroupMemberService) *PolicyDecisionPoint {
+	return &PolicyDecisionPoint{roleRepository: aRoleRepository, groupMemberService: aGroupMemberService}
+}
+
+// Can answers with deny-overrides: any applicable deny wins over every allow, and no applicable permission means deny.
+func (policyDecisionPoint *PolicyDecisionPoint) Can(aUser *identity.User, anAction string, aResource Resource) (_ bool, err error) {
+	defer ierrors.Wrap(&err, "policydecisionpoint.Can(%s, %s, %v)", aUser.Username(), anAction, aResource)
+
+	if aUser.TenantId() != aResource.tenantId || !aUser.IsEnabled() {
+		return false, nil
+	}
+
+	roles, err := policyDecisionPoint.roleRepository.AllRoles(aUser.TenantId())
+	if err != nil {
+		return false, err
+	}
+
+	allowed := false
+	for _, role := range roles {
+		if role.tenantId != aUser.TenantId() {
+			continue
+		}
+		isInRole, err := role.IsInRole(aUser, policyDecisionPoint.groupMemberService)
+		if err != nil {
+			return false, err
+		}
+		if !isInRole {
+			continue
+		}
+		for _, permission := range role.permissions {
+			if !permission.AppliesTo(aUser, anAction, aResource) {
+				continue
+			}
+			if permission.IsDeny() {
+				return false, nil
+			}
+			allowed = true
+		}
+	}
+
+	return allowed, nil
+}
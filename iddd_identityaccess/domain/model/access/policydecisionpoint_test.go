@@ -0,0 +1,77 @@
+package access_test
+
+import (
+	"testing"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/access"
+)
+
+func (fixture *fixture) grant(t *testing.T, aRole *access.Role, aResource string, anAction string, anEffect access.PermissionEffect, aCondition *access.Condition) {
+	t.Helper()
+
+	permission, err := access.NewPermission(aResource, anAction, anEffect, aCondition)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aRole.GrantPermission(*permission)
+}
+
+func TestPolicyDecisionPointCan(t *testing.T) {
+	fixture := newFixture(t)
+	user := fixture.newUser(t, "zoeusername", true)
+	if err := user.ChangeAttribute("department", "sales"); err != nil {
+		t.Fatal(err)
+	}
+	editor := fixture.newRole(t, "Editor", true)
+	fixture.grant(t, editor, "documents/*", "*", access.PERMISSION_EFFECT_ALLOW, nil)
+	fixture.grant(t, editor, "documents/*", "delete", access.PERMISSION_EFFECT_DENY, access.ResourceAttributeEquals("locked", "true"))
+	auditor := fixture.newRole(t, "Auditor", true)
+	fixture.grant(t, auditor, "reports/*", "read", access.PERMISSION_EFFECT_ALLOW, access.UserAttributeMatchesResourceAttribute("department", "department"))
+	if err := fixture.roleAssignmentService().AssignUser(editor, user); err != nil {
+		t.Fatal(err)
+	}
+	if err := fixture.roleAssignmentService().AssignUser(auditor, user); err != nil {
+		t.Fatal(err)
+	}
+	unassigned := fixture.newRole(t, "Administrator", true)
+	fixture.grant(t, unassigned, "*", "*", access.PERMISSION_EFFECT_ALLOW, nil)
+	policyDecisionPoint := access.NewPolicyDecisionPoint(fixture.roleRepository, fixture.groupMemberService())
+
+	otherTenantId := newFixture(t).tenantId
+	tests := []struct {
+		name       string
+		action     string
+		resource   string
+		attributes map[string]string
+		other      bool
+		want       bool
+	}{
+		{name: "allowed", action: "write", resource: "documents/1", want: true},
+		{name: "deny overrides allow", action: "delete", resource: "documents/1", attributes: map[string]string{"locked": "true"}, want: false},
+		{name: "deny condition not satisfied", action: "delete", resource: "documents/1", attributes: map[string]string{"locked": "false"}, want: true},
+		{name: "condition on user attribute", action: "read", resource: "reports/1", attributes: map[string]string{"department": "sales"}, want: true},
+		{name: "condition on user attribute not satisfied", action: "read", resource: "reports/1", attributes: map[string]string{"department": "hr"}, want: false},
+		{name: "no applicable permission", action: "read", resource: "invoices/1", want: false},
+		{name: "other tenant", action: "write", resource: "documents/1", other: true, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenantId := fixture.tenantId
+			if tt.other {
+				tenantId = otherTenantId
+			}
+			resource, err := access.NewResource(tenantId, tt.resource, tt.attributes)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := policyDecisionPoint.Can(user, tt.action, *resource)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
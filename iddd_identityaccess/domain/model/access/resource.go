@@ -0,0 +1,47 @@
+package access
+
+import (
+	"fmt"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+type Resource struct {
+	tenantId   identity.TenantId
+	name       string
+	attributes map[string]string
+}
+
+func NewResource(aTenantId identity.TenantId, aName string, anAttributes map[string]string) (_ *Resource, err error) {
+	defer ierrors.Wrap(&err, "resource.NewResource(%v, %s)", aTenantId, aName)
+
+	if err := ierrors.NewArgumentNotEmptyError(aName, "The resource name is required.").GetError(); err != nil {
+		return nil, err
+	}
+
+	attributes := make(map[string]string, len(anAttributes))
+	for key, value := range anAttributes {
+		attributes[key] = value
+	}
+
+	return &Resource{tenantId: aTenantId, name: aName, attributes: attributes}, nil
+}
+
+func (resource Resource) TenantId() identity.TenantId {
+	return resource.tenantId
+}
+
+func (resource Resource) Name() string {
+	return resource.name
+}
+
+func (resource Resource) Attribute(aKey string) (string, bool) {
+	value, ok := resource.attributes[aKey]
+	return value, ok
+}
+
+func (resource Resource) String() string {
+	tenantId := resource.tenantId
+	return fmt.Sprintf("Resource [tenantId=%s, name=%s, attributes=%v]", tenantId.Id(), resource.name, resource.attributes)
+}
@@ -0,0 +1,222 @@
+package access
+
+import (
+	"fmt"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+	"github.com/google/uuid"
+)
+
+type Role struct {
+	tenantId           identity.TenantId
+	name               string
+	description        string
+	supportsNesting    bool
+	exclusiveRoleNames []string
+	permissions        []Permission
+	group              identity.Group
+}
+
+func NewRole(aTenantId identity.TenantId, aName string, aDescription string, aSupportsNesting bool) (_ *Role, err error) {
+	defer ierrors.Wrap(&err, "role.NewRole(%v, %s, %s, %v)", aTenantId, aName, aDescription, aSupportsNesting)
+	// validate name
+	if err := ierrors.NewArgumentNotEmptyError(aName, "Role name must be provided.").GetError(); err != nil {
+		return nil, ierrors.NewFieldError("name", err)
+	}
+	if err := ierrors.NewArgumentLengthError(aName, 1, 100, "Role name must be 100 characters or less.").GetError(); err != nil {
+		return nil, ierrors.NewFieldError("name", err)
+	}
+	// validate description
+	if err := ierrors.NewArgumentNotEmptyError(aDescription, "Role description is required.").GetError(); err != nil {
+		return nil, ierrors.NewFieldError("description", err)
+	}
+	if err := ierrors.NewArgumentLengthError(aDescription, 1, 250, "Role description must be 250 characters or less.").GetError(); err != nil {
+		return nil, ierrors.NewFieldError("description", err)
+	}
+
+	group, err := createInternalGroup(aTenantId, aName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Role{tenantId: aTenantId, name: aName, description: aDescription, supportsNesting: aSupportsNesting, exclusiveRoleNames: []string{}, permissions: []Permission{}, group: *group}, nil
+}
+
+func createInternalGroup(aTenantId identity.TenantId, aName string) (*identity.Group, error) {
+	uuId, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	groupName := identity.ROLE_GROUP_PREFIX + uuId.String()
+
+	return identity.NewGroup(aTenantId, groupName, "Role backing group for: "+aName)
+}
+
+func (role *Role) TenantId() identity.TenantId {
+	return role.tenantId
+}
+
+func (role *Role) Name() string {
+	return role.name
+}
+
+func (role *Role) Description() string {
+	return role.description
+}
+
+func (role *Role) SupportsNesting() bool {
+	return role.supportsNesting
+}
+
+func (role *Role) ExclusiveRoleNames() []string {
+	exclusiveRoleNames := make([]string, len(role.exclusiveRoleNames))
+	copy(exclusiveRoleNames, role.exclusiveRoleNames)
+	return exclusiveRoleNames
+}
+
+func (role *Role) IsExclusiveOf(aRoleName string) bool {
+	for _, exclusiveRoleName := range role.exclusiveRoleNames {
+		if exclusiveRoleName == aRoleName {
+			return true
+		}
+	}
+	return false
+}
+
+func (role *Role) Permissions() []Permission {
+	permissions := make([]Permission, len(role.permissions))
+	copy(permissions, role.permissions)
+	return permissions
+}
+
+func (role *Role) GrantPermission(aPermission Permission) {
+	for _, permission := range role.permissions {
+		if permission.Equals(aPermission) {
+			return
+		}
+	}
+	role.permissions = append(role.permissions, aPermission)
+}
+
+func (role *Role) RevokePermission(aPermission Permission) {
+	for i, permission := range role.permissions {
+		if permission.Equals(aPermission) {
+			role.permissions = append(role.permissions[:i], role.permissions[i+1:]...)
+			return
+		}
+	}
+}
+
+func (role *Role) Group() identity.Group {
+	return role.group
+}
+
+func (role *Role) assignGroup(aGroup *identity.Group, aGroupMemberService *identity.GroupMemberService) (err error) {
+	defer ierrors.Wrap(&err, "role.assignGroup(%s)", aGroup.Name())
+
+	if err := ierrors.NewArgumentTrueErrorArguments(role.supportsNesting, "This role does not support group nesting.").GetError(); err != nil {
+		return err
+	}
+	if err := ierrors.NewArgumentTrueErrorArguments(role.tenantId == aGroup.TenantId(), "Wrong tenant for this group.").GetError(); err != nil {
+		return err
+	}
+
+	return role.group.AddGroup(aGroup, aGroupMemberService)
+}
+
+func (role *Role) assignUser(aUser *identity.User) (err error) {
+	defer ierrors.Wrap(&err, "role.assignUser(%s)", aUser.Username())
+
+	if err := ierrors.NewArgumentTrueErrorArguments(role.tenantId == aUser.TenantId(), "Wrong tenant for this user.").GetError(); err != nil {
+		return err
+	}
+
+	return role.group.AddUser(aUser)
+}
+
+func (role *Role) UnassignGroup(aGroup *identity.Group) (err error) {
+	defer ierrors.Wrap(&err, "role.UnassignGroup(%s)", aGroup.Name())
+
+	if err := ierrors.NewArgumentTrueErrorArguments(role.tenantId == aGroup.TenantId(), "Wrong tenant for this group.").GetError(); err != nil {
+		return err
+	}
+
+	return role.group.RemoveGroup(aGroup)
+}
+
+func (role *Role) UnassignUser(aUser *identity.User) (err error) {
+	defer ierrors.Wrap(&err, "role.UnassignUser(%s)", aUser.Username())
+
+	if err := ierrors.NewArgumentTrueErrorArguments(role.tenantId == aUser.TenantId(), "Wrong tenant for this user.").GetError(); err != nil {
+		return err
+	}
+
+	return role.group.RemoveUser(aUser)
+}
+
+func (role *Role) ExcludeRole(aRole *Role) (err error) {
+	defer ierrors.Wrap(&err, "role.ExcludeRole(%s)", aRole.name)
+
+	if err := ierrors.NewArgumentTrueErrorArguments(role.tenantId == aRole.tenantId, "Wrong tenant for this role.").GetError(); err != nil {
+		return err
+	}
+	if err := ierrors.NewArgumentFalseError(role.name == aRole.name, "A role cannot exclude itself.").GetError(); err != nil {
+		return err
+	}
+
+	role.addExclusiveRoleName(aRole.name)
+	aRole.addExclusiveRoleName(role.name)
+	return nil
+}
+
+func (role *Role) IncludeRole(aRole *Role) (err error) {
+	defer ierrors.Wrap(&err, "role.IncludeRole(%s)", aRole.name)
+
+	if err := ierrors.NewArgumentTrueErrorArguments(role.tenantId == aRole.tenantId, "Wrong tenant for this role.").GetError(); err != nil {
+		return err
+	}
+
+	role.removeExclusiveRoleName(aRole.name)
+	aRole.removeExclusiveRoleName(role.name)
+	return nil
+}
+
+func (role *Role) addExclusiveRoleName(aRoleName string) {
+	if !role.IsExclusiveOf(aRoleName) {
+		role.exclusiveRoleNames = append(role.exclusiveRoleNames, aRoleName)
+	}
+}
+
+func (role *Role) removeExclusiveRoleName(aRoleName string) {
+	for i, exclusiveRoleName := range role.exclusiveRoleNames {
+		if exclusiveRoleName == aRoleName {
+			role.exclusiveRoleNames = append(role.exclusiveRoleNames[:i], role.exclusiveRoleNames[i+1:]...)
+			return
+		}
+	}
+}
+
+func (role *Role) IsInRole(aUser *identity.User, aGroupMemberService *identity.GroupMemberService) (bool, error) {
+	if !role.supportsNesting {
+		return role.group.IsDirectMember(aUser, aGroupMemberService)
+	}
+	return role.group.IsMember(aUser, aGroupMemberService)
+}
+
+// isAssigned is IsInRole for users who are disabled as well.
+func (role *Role) isAssigned(aUser *identity.User, aGroupMemberService *identity.GroupMemberService) (bool, error) {
+	if !role.supportsNesting {
+		return role.group.HasDirectMember(aUser), nil
+	}
+	return role.group.HasMember(aUser, aGroupMemberService)
+}
+
+func (role *Role) Equals(otherRole *Role) bool {
+	return role.tenantId == otherRole.tenantId && role.name == otherRole.name
+}
+
+func (role *Role) String() string {
+	tenantId := role.tenantId
+	return fmt.Sprintf("Role [tenantId=%s, name=%s, description=%s, supportsNesting=%v]", tenantId.Id(), role.name, role.description, role.supportsNesting)
+}
@@ -0,0 +1,296 @@
+package access_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/utils"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/access"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/infrastructure/persistence"
+	"github.com/google/uuid"
+)
+
+const password = "qwerty!ASDFG#"
+
+var (
+	argumentLengthError   *ierrors.ArgumentLengthError
+	argumentNotEmptyError *ierrors.ArgumentNotEmptyError
+	argumentTrueError     *ierrors.ArgumentTrueError
+)
+
+type fixture struct {
+	tenantId        identity.TenantId
+	userRepository  *persistence.InMemoryUserRepository
+	groupRepository *persistence.InMemoryGroupRepository
+	roleRepository  *persistence.InMemoryRoleRepository
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	tenantId, err := identity.NewTenantId(uuid.New().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &fixture{
+		tenantId:        *tenantId,
+		userRepository:  persistence.NewInMemoryUserRepository(),
+		groupRepository: persistence.NewInMemoryGroupRepository(),
+		roleRepository:  persistence.NewInMemoryRoleRepository(),
+	}
+}
+
+func newPerson(t *testing.T, aTenantId identity.TenantId) *identity.Person {
+	t.Helper()
+
+	fullName, err := identity.NewFullName("Zoe", "Doe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	emailAddress, err := identity.NewEmailAddress("zoe@saasovation.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return identity.NewPerson(aTenantId, *fullName, *emailAddress)
+}
+
+func (fixture *fixture) groupMemberService() *identity.GroupMemberService {
+	return identity.NewGroupMemberService(fixture.userRepository, fixture.groupRepository)
+}
+
+func (fixture *fixture) roleAssignmentService() *access.RoleAssignmentService {
+	return access.NewRoleAssignmentService(fixture.roleRepository, fixture.groupMemberService())
+}
+
+func (fixture *fixture) newUser(t *testing.T, aUsername string, anEnabled bool) *identity.User {
+	t.Helper()
+
+	enablement, err := identity.NewEnablement(anEnabled, time.Now().AddDate(-1, 0, 0), time.Now().AddDate(1, 0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, err := identity.NewUser(fixture.tenantId, aUsername, password, *enablement, *newPerson(t, fixture.tenantId), *identity.DefaultNameLengthPolicy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fixture.userRepository.Add(user); err != nil {
+		t.Fatal(err)
+	}
+	return user
+}
+
+func (fixture *fixture) disable(t *testing.T, aUser *identity.User) {
+	t.Helper()
+
+	if err := aUser.DefineEnablement(*identity.NewIndefiniteEnablement(false)); err != nil {
+		t.Fatal(err)
+	}
+	if err := fixture.userRepository.Add(aUser); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (fixture *fixture) newGroup(t *testing.T, aName string) *identity.Group {
+	t.Helper()
+
+	group, err := identity.NewGroup(fixture.tenantId, aName, "A group named "+aName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fixture.groupRepository.Add(group); err != nil {
+		t.Fatal(err)
+	}
+	return group
+}
+
+func (fixture *fixture) newRole(t *testing.T, aName string, aSupportsNesting bool) *access.Role {
+	t.Helper()
+
+	role, err := access.NewRole(fixture.tenantId, aName, "A role named "+aName, aSupportsNesting)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fixture.roleRepository.Add(role); err != nil {
+		t.Fatal(err)
+	}
+	return role
+}
+
+func TestNewRole(t *testing.T) {
+	fixture := newFixture(t)
+	t.Run("success", func(t *testing.T) {
+		role, err := access.NewRole(fixture.tenantId, "Manager", "A manager role.", true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		group := role.Group()
+		if !group.IsInternalGroup() {
+			t.Errorf("role group %v must be internal group", &group)
+		}
+	})
+	t.Run("fail empty name", func(t *testing.T) {
+		_, err := access.NewRole(fixture.tenantId, "", "A manager role.", true)
+		if !errors.As(err, &argumentNotEmptyError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentNotEmptyError))
+		}
+	})
+	t.Run("success 100 Japanese characters name", func(t *testing.T) {
+		if _, err := access.NewRole(fixture.tenantId, strings.Repeat("山", 100), "A manager role.", true); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("fail over 100 characters name", func(t *testing.T) {
+		_, err := access.NewRole(fixture.tenantId, utils.RandString(101), "A manager role.", true)
+		if !errors.As(err, &argumentLengthError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentLengthError))
+		}
+	})
+}
+
+func TestRoleIsInRole(t *testing.T) {
+	t.Run("assigned user", func(t *testing.T) {
+		fixture := newFixture(t)
+		user := fixture.newUser(t, "zoeusername", true)
+		role := fixture.newRole(t, "Manager", true)
+		if err := fixture.roleAssignmentService().AssignUser(role, user); err != nil {
+			t.Fatal(err)
+		}
+
+		isInRole, err := role.IsInRole(user, fixture.groupMemberService())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !isInRole {
+			t.Errorf("user %s must be in role %v", user.Username(), role)
+		}
+	})
+	t.Run("assigned group", func(t *testing.T) {
+		fixture := newFixture(t)
+		user := fixture.newUser(t, "zoeusername", true)
+		groupA := fixture.newGroup(t, "GroupA")
+		groupB := fixture.newGroup(t, "GroupB")
+		if err := groupB.AddUser(user); err != nil {
+			t.Fatal(err)
+		}
+		if err := groupA.AddGroup(groupB, fixture.groupMemberService()); err != nil {
+			t.Fatal(err)
+		}
+		role := fixture.newRole(t, "Manager", true)
+		if err := fixture.roleAssignmentService().AssignGroup(role, groupA); err != nil {
+			t.Fatal(err)
+		}
+
+		isInRole, err := role.IsInRole(user, fixture.groupMemberService())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !isInRole {
+			t.Errorf("user %s must be in role %v through nested groups", user.Username(), role)
+		}
+	})
+	t.Run("unassigned user", func(t *testing.T) {
+		fixture := newFixture(t)
+		user := fixture.newUser(t, "zoeusername", true)
+		role := fixture.newRole(t, "Manager", true)
+		if err := fixture.roleAssignmentService().AssignUser(role, user); err != nil {
+			t.Fatal(err)
+		}
+		if err := role.UnassignUser(user); err != nil {
+			t.Fatal(err)
+		}
+
+		isInRole, err := role.IsInRole(user, fixture.groupMemberService())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if isInRole {
+			t.Errorf("user %s must not be in role %v", user.Username(), role)
+		}
+	})
+	t.Run("fail assign disabled user", func(t *testing.T) {
+		fixture := newFixture(t)
+		user := fixture.newUser(t, "zoeusername", false)
+		role := fixture.newRole(t, "Manager", true)
+
+		err := fixture.roleAssignmentService().AssignUser(role, user)
+		if !errors.As(err, &argumentTrueError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentTrueError))
+		}
+	})
+	t.Run("fail assign user of other tenant", func(t *testing.T) {
+		fixture := newFixture(t)
+		user := newFixture(t).newUser(t, "zoeusername", true)
+		role := fixture.newRole(t, "Manager", true)
+
+		err := fixture.roleAssignmentService().AssignUser(role, user)
+		if !errors.As(err, &argumentTrueError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentTrueError))
+		}
+	})
+}
+
+func TestRoleSupportsNesting(t *testing.T) {
+	t.Run("fail assign group", func(t *testing.T) {
+		fixture := newFixture(t)
+		group := fixture.newGroup(t, "GroupA")
+		role := fixture.newRole(t, "Manager", false)
+
+		err := fixture.roleAssignmentService().AssignGroup(role, group)
+		if !errors.As(err, &argumentTrueError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentTrueError))
+		}
+	})
+	t.Run("direct user is in role", func(t *testing.T) {
+		fixture := newFixture(t)
+		user := fixture.newUser(t, "zoeusername", true)
+		role := fixture.newRole(t, "Manager", false)
+		if err := fixture.roleAssignmentService().AssignUser(role, user); err != nil {
+			t.Fatal(err)
+		}
+
+		isInRole, err := role.IsInRole(user, fixture.groupMemberService())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !isInRole {
+			t.Errorf("user %s must be in role %v", user.Username(), role)
+		}
+	})
+}
+
+func TestRoleExcludeRole(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		fixture := newFixture(t)
+		approver := fixture.newRole(t, "Approver", true)
+		requester := fixture.newRole(t, "Requester", true)
+
+		if err := approver.ExcludeRole(requester); err != nil {
+			t.Fatal(err)
+		}
+
+		if !approver.IsExclusiveOf("Requester") || !requester.IsExclusiveOf("Approver") {
+			t.Errorf("roles %v and %v must exclude each other", approver, requester)
+		}
+
+		if err := approver.IncludeRole(requester); err != nil {
+			t.Fatal(err)
+		}
+		if approver.IsExclusiveOf("Requester") || requester.IsExclusiveOf("Approver") {
+			t.Errorf("roles %v and %v must not exclude each other", approver, requester)
+		}
+	})
+	t.Run("fail exclude itself", func(t *testing.T) {
+		fixture := newFixture(t)
+		approver := fixture.newRole(t, "Approver", true)
+
+		if err := approver.ExcludeRole(approver); err == nil {
+			t.Errorf("role %v must not exclude itself", approver)
+		}
+	})
+}
@@ -0,0 +1,151 @@
+package access
+
+import (
+	"fmt"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+type RoleAssignmentService struct {
+	roleRepository     RoleRepository
+	groupMemberService *identity.GroupMemberService
+}
+
+func NewRoleAssignmentService(aRoleRepository RoleRepository, aGroupMemberService *identity.GroupMemberService) *RoleAssignmentService {
+	return &RoleAssignmentService{roleRepository: aRoleRepository, groupMemberService: aGroupMemberService}
+}
+
+func (roleAssignmentService *RoleAssignmentService) AssignUser(aRole *Role, aUser *identity.User) (err error) {
+	defer ierrors.Wrap(&err, "roleassignmentservice.AssignUser(%s, %s)", aRole.name, aUser.Username())
+
+	if err := roleAssignmentService.assertUserNotInExclusiveRole(aRole, aUser); err != nil {
+		return err
+	}
+
+	return aRole.assignUser(aUser)
+}
+
+func (roleAssignmentService *RoleAssignmentService) AssignGroup(aRole *Role, aGroup *identity.Group) (err error) {
+	defer ierrors.Wrap(&err, "roleassignmentservice.AssignGroup(%s, %s)", aRole.name, aGroup.Name())
+
+	if err := roleAssignmentService.assertGroupNotInExclusiveRole(aRole, aGroup); err != nil {
+		return err
+	}
+
+	return aRole.assignGroup(aGroup, roleAssignmentService.groupMemberService)
+}
+
+func (roleAssignmentService *RoleAssignmentService) AssertUserMayJoin(aGroup *identity.Group, aUser *identity.User) (err error) {
+	defer ierrors.Wrap(&err, "roleassignmentservice.AssertUserMayJoin(%s, %s)", aGroup.Name(), aUser.Username())
+
+	roles, err := roleAssignmentService.rolesThrough(aGroup)
+	if err != nil {
+		return err
+	}
+	for _, role := range roles {
+		if err := roleAssignmentService.assertUserNotInExclusiveRole(role, aUser); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (roleAssignmentService *RoleAssignmentService) AssertGroupMayJoin(aGroup *identity.Group, aMemberGroup *identity.Group) (err error) {
+	defer ierrors.Wrap(&err, "roleassignmentservice.AssertGroupMayJoin(%s, %s)", aGroup.Name(), aMemberGroup.Name())
+
+	roles, err := roleAssignmentService.rolesThrough(aGroup)
+	if err != nil {
+		return err
+	}
+	for _, role := range roles {
+		if err := roleAssignmentService.assertGroupNotInExclusiveRole(role, aMemberGroup); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rolesThrough lists the roles the members of aGroup hold through it.
+func (roleAssignmentService *RoleAssignmentService) rolesThrough(aGroup *identity.Group) ([]*Role, error) {
+	roles, err := roleAssignmentService.roleRepository.AllRoles(aGroup.TenantId())
+	if err != nil {
+		return nil, err
+	}
+	rolesThrough := []*Role{}
+	for _, role := range roles {
+		if !role.supportsNesting {
+			continue
+		}
+		isMemberGroup, err := role.group.IsMemberGroup(aGroup, roleAssignmentService.groupMemberService)
+		if err != nil {
+			return nil, err
+		}
+		if isMemberGroup {
+			rolesThrough = append(rolesThrough, role)
+		}
+	}
+	return rolesThrough, nil
+}
+
+// The assertions below count disabled users as well, as they hold their roles again once they are enabled.
+func (roleAssignmentService *RoleAssignmentService) assertGroupNotInExclusiveRole(aRole *Role, aGroup *identity.Group) error {
+	exclusiveRoles, err := roleAssignmentService.exclusiveRoles(aRole)
+	if err != nil {
+		return err
+	}
+	for _, exclusiveRole := range exclusiveRoles {
+		exclusiveGroup := exclusiveRole.Group()
+		isMemberGroup, err := exclusiveGroup.IsMemberGroup(aGroup, roleAssignmentService.groupMemberService)
+		if err != nil {
+			return err
+		}
+		message := fmt.Sprintf("Group %s cannot be assigned to role %s since it is assigned to the exclusive role %s.", aGroup.Name(), aRole.name, exclusiveRole.name)
+		if err := ierrors.NewExclusiveConstraintError(isMemberGroup, exclusiveRole.name, aRole.name, message).GetError(); err != nil {
+			return err
+		}
+	}
+
+	users, err := roleAssignmentService.groupMemberService.AllMemberUsers(aGroup)
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		if err := roleAssignmentService.assertUserNotInExclusiveRole(aRole, user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (roleAssignmentService *RoleAssignmentService) assertUserNotInExclusiveRole(aRole *Role, aUser *identity.User) error {
+	exclusiveRoles, err := roleAssignmentService.exclusiveRoles(aRole)
+	if err != nil {
+		return err
+	}
+	for _, exclusiveRole := range exclusiveRoles {
+		isInRole, err := exclusiveRole.isAssigned(aUser, roleAssignmentService.groupMemberService)
+		if err != nil {
+			return err
+		}
+		message := fmt.Sprintf("User %s cannot be assigned to role %s since the user holds the exclusive role %s.", aUser.Username(), aRole.name, exclusiveRole.name)
+		if err := ierrors.NewExclusiveConstraintError(isInRole, exclusiveRole.name, aRole.name, message).GetError(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (roleAssignmentService *RoleAssignmentService) exclusiveRoles(aRole *Role) ([]*Role, error) {
+	exclusiveRoles := []*Role{}
+	for _, exclusiveRoleName := range aRole.exclusiveRoleNames {
+		exclusiveRole, err := roleAssignmentService.roleRepository.RoleNamed(aRole.tenantId, exclusiveRoleName)
+		if err != nil {
+			return nil, err
+		}
+		if exclusiveRole != nil {
+			exclusiveRoles = append(exclusiveRoles, exclusiveRole)
+		}
+	}
+	return exclusiveRoles, nil
+}
@@ -0,0 +1,221 @@
+package access_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/access"
+)
+
+var exclusiveConstraintError *ierrors.ExclusiveConstraintError
+
+func TestRoleAssignmentServiceAssignUser(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		fixture := newFixture(t)
+		user := fixture.newUser(t, "zoeusername", true)
+		approver := fixture.newRole(t, "Approver", true)
+		requester := fixture.newRole(t, "Requester", true)
+		if err := approver.ExcludeRole(requester); err != nil {
+			t.Fatal(err)
+		}
+		roleAssignmentService := access.NewRoleAssignmentService(fixture.roleRepository, fixture.groupMemberService())
+
+		if err := roleAssignmentService.AssignUser(approver, user); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("fail user holds exclusive role", func(t *testing.T) {
+		fixture := newFixture(t)
+		user := fixture.newUser(t, "zoeusername", true)
+		approver := fixture.newRole(t, "Approver", true)
+		requester := fixture.newRole(t, "Requester", true)
+		if err := approver.ExcludeRole(requester); err != nil {
+			t.Fatal(err)
+		}
+		roleAssignmentService := access.NewRoleAssignmentService(fixture.roleRepository, fixture.groupMemberService())
+		if err := roleAssignmentService.AssignUser(requester, user); err != nil {
+			t.Fatal(err)
+		}
+
+		err := roleAssignmentService.AssignUser(approver, user)
+		if !errors.As(err, &exclusiveConstraintError) {
+			t.Fatalf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&exclusiveConstraintError))
+		}
+		want := ierrors.ExclusiveConstraintErrorArguments{Violated: true, Held: "Requester", Requested: "Approver", Message: exclusiveConstraintError.Error()}
+		if got := exclusiveConstraintError.GetArguments(); got != want {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+	t.Run("fail disabled user holds exclusive role", func(t *testing.T) {
+		fixture := newFixture(t)
+		user := fixture.newUser(t, "zoeusername", true)
+		approver := fixture.newRole(t, "Approver", true)
+		requester := fixture.newRole(t, "Requester", true)
+		if err := approver.ExcludeRole(requester); err != nil {
+			t.Fatal(err)
+		}
+		roleAssignmentService := fixture.roleAssignmentService()
+		if err := roleAssignmentService.AssignUser(approver, user); err != nil {
+			t.Fatal(err)
+		}
+		fixture.disable(t, user)
+
+		err := roleAssignmentService.AssignUser(requester, user)
+		if !errors.As(err, &exclusiveConstraintError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&exclusiveConstraintError))
+		}
+	})
+	t.Run("fail user holds exclusive role through group", func(t *testing.T) {
+		fixture := newFixture(t)
+		user := fixture.newUser(t, "zoeusername", true)
+		group := fixture.newGroup(t, "Requesters")
+		if err := group.AddUser(user); err != nil {
+			t.Fatal(err)
+		}
+		approver := fixture.newRole(t, "Approver", true)
+		requester := fixture.newRole(t, "Requester", true)
+		if err := approver.ExcludeRole(requester); err != nil {
+			t.Fatal(err)
+		}
+		roleAssignmentService := access.NewRoleAssignmentService(fixture.roleRepository, fixture.groupMemberService())
+		if err := roleAssignmentService.AssignGroup(requester, group); err != nil {
+			t.Fatal(err)
+		}
+
+		err := roleAssignmentService.AssignUser(approver, user)
+		if !errors.As(err, &exclusiveConstraintError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&exclusiveConstraintError))
+		}
+	})
+}
+
+func TestRoleAssignmentServiceAssignGroup(t *testing.T) {
+	t.Run("fail group member holds exclusive role", func(t *testing.T) {
+		fixture := newFixture(t)
+		user := fixture.newUser(t, "zoeusername", true)
+		group := fixture.newGroup(t, "Approvers")
+		if err := group.AddUser(user); err != nil {
+			t.Fatal(err)
+		}
+		approver := fixture.newRole(t, "Approver", true)
+		requester := fixture.newRole(t, "Requester", true)
+		if err := approver.ExcludeRole(requester); err != nil {
+			t.Fatal(err)
+		}
+		roleAssignmentService := access.NewRoleAssignmentService(fixture.roleRepository, fixture.groupMemberService())
+		if err := roleAssignmentService.AssignUser(requester, user); err != nil {
+			t.Fatal(err)
+		}
+
+		err := roleAssignmentService.AssignGroup(approver, group)
+		if !errors.As(err, &exclusiveConstraintError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&exclusiveConstraintError))
+		}
+	})
+	t.Run("fail disabled group member holds exclusive role", func(t *testing.T) {
+		fixture := newFixture(t)
+		user := fixture.newUser(t, "zoeusername", true)
+		group := fixture.newGroup(t, "Requesters")
+		if err := group.AddUser(user); err != nil {
+			t.Fatal(err)
+		}
+		approver := fixture.newRole(t, "Approver", true)
+		requester := fixture.newRole(t, "Requester", true)
+		if err := approver.ExcludeRole(requester); err != nil {
+			t.Fatal(err)
+		}
+		roleAssignmentService := fixture.roleAssignmentService()
+		if err := roleAssignmentService.AssignUser(approver, user); err != nil {
+			t.Fatal(err)
+		}
+		fixture.disable(t, user)
+
+		err := roleAssignmentService.AssignGroup(requester, group)
+		if !errors.As(err, &exclusiveConstraintError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&exclusiveConstraintError))
+		}
+	})
+	t.Run("fail group is assigned to exclusive role", func(t *testing.T) {
+		fixture := newFixture(t)
+		group := fixture.newGroup(t, "Staff")
+		approver := fixture.newRole(t, "Approver", true)
+		requester := fixture.newRole(t, "Requester", true)
+		if err := approver.ExcludeRole(requester); err != nil {
+			t.Fatal(err)
+		}
+		roleAssignmentService := access.NewRoleAssignmentService(fixture.roleRepository, fixture.groupMemberService())
+		if err := roleAssignmentService.AssignGroup(requester, group); err != nil {
+			t.Fatal(err)
+		}
+
+		err := roleAssignmentService.AssignGroup(approver, group)
+		if !errors.As(err, &exclusiveConstraintError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&exclusiveConstraintError))
+		}
+	})
+}
+
+func TestRoleAssignmentServiceAssertUserMayJoin(t *testing.T) {
+	t.Run("success group assigned to no role", func(t *testing.T) {
+		fixture := newFixture(t)
+		user := fixture.newUser(t, "zoeusername", true)
+		group := fixture.newGroup(t, "Staff")
+
+		if err := fixture.roleAssignmentService().AssertUserMayJoin(group, user); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("fail user holds role exclusive with that of group", func(t *testing.T) {
+		fixture := newFixture(t)
+		user := fixture.newUser(t, "zoeusername", true)
+		group := fixture.newGroup(t, "Approvers")
+		approver := fixture.newRole(t, "Approver", true)
+		requester := fixture.newRole(t, "Requester", true)
+		if err := approver.ExcludeRole(requester); err != nil {
+			t.Fatal(err)
+		}
+		roleAssignmentService := fixture.roleAssignmentService()
+		if err := roleAssignmentService.AssignGroup(approver, group); err != nil {
+			t.Fatal(err)
+		}
+		if err := roleAssignmentService.AssignUser(requester, user); err != nil {
+			t.Fatal(err)
+		}
+
+		err := roleAssignmentService.AssertUserMayJoin(group, user)
+		if !errors.As(err, &exclusiveConstraintError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&exclusiveConstraintError))
+		}
+	})
+}
+
+func TestRoleAssignmentServiceAssertGroupMayJoin(t *testing.T) {
+	t.Run("fail member of group holds role exclusive with that of group", func(t *testing.T) {
+		fixture := newFixture(t)
+		user := fixture.newUser(t, "zoeusername", true)
+		group := fixture.newGroup(t, "Approvers")
+		memberGroup := fixture.newGroup(t, "Requesters")
+		if err := memberGroup.AddUser(user); err != nil {
+			t.Fatal(err)
+		}
+		approver := fixture.newRole(t, "Approver", true)
+		requester := fixture.newRole(t, "Requester", true)
+		if err := approver.ExcludeRole(requester); err != nil {
+			t.Fatal(err)
+		}
+		roleAssignmentService := fixture.roleAssignmentService()
+		if err := roleAssignmentService.AssignGroup(approver, group); err != nil {
+			t.Fatal(err)
+		}
+		if err := roleAssignmentService.AssignGroup(requester, memberGroup); err != nil {
+			t.Fatal(err)
+		}
+
+		err := roleAssignmentService.AssertGroupMayJoin(group, memberGroup)
+		if !errors.As(err, &exclusiveConstraintError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&exclusiveConstraintError))
+		}
+	})
+}
@@ -0,0 +1,10 @@
+package access
+
+import "github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+
+type RoleRepository interface {
+	Add(aRole *Role) error
+	Remove(aRole *Role) error
+	AllRoles(aTenantId identity.TenantId) ([]*Role, error)
+	RoleNamed(aTenantId identity.TenantId, aRoleName string) (*Role, error)
+}
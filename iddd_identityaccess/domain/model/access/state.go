@@ -0,0 +1,39 @@
+package access
+
+import (
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+type RoleState struct {
+	TenantId           identity.TenantId
+	Name               string
+	Description        string
+	SupportsNesting    bool
+	ExclusiveRoleNames []string
+	Permissions        []Permission
+	Group              identity.GroupState
+}
+
+func (role *Role) State() RoleState {
+	return RoleState{
+		TenantId:           role.tenantId,
+		Name:               role.name,
+		Description:        role.description,
+		SupportsNesting:    role.supportsNesting,
+		ExclusiveRoleNames: role.ExclusiveRoleNames(),
+		Permissions:        role.Permissions(),
+		Group:              role.group.State(),
+	}
+}
+
+func NewRoleFromState(aState RoleState) *Role {
+	return &Role{
+		tenantId:           aState.TenantId,
+		name:               aState.Name,
+		description:        aState.Description,
+		supportsNesting:    aState.SupportsNesting,
+		exclusiveRoleNames: append([]string{}, aState.ExclusiveRoleNames...),
+		permissions:        append([]Permission{}, aState.Permissions...),
+		group:              *identity.NewGroupFromState(aState.Group),
+	}
+}
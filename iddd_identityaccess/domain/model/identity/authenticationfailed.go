@@ -0,0 +1,30 @@
+package identity
+
+import "time"
+
+type AuthenticationFailed struct {
+	eventVersion int
+	occurredOn   time.Time
+	tenantId     TenantId
+	username     string
+}
+
+func NewAuthenticationFailed(aTenantId TenantId, aUsername string) *AuthenticationFailed {
+	return &AuthenticationFailed{eventVersion: 1, occurredOn: time.Now(), tenantId: aTenantId, username: aUsername}
+}
+
+func (authenticationFailed *AuthenticationFailed) EventVersion() int {
+	return authenticationFailed.eventVersion
+}
+
+func (authenticationFailed *AuthenticationFailed) OccurredOn() time.Time {
+	return authenticationFailed.occurredOn
+}
+
+func (authenticationFailed *AuthenticationFailed) TenantId() TenantId {
+	return authenticationFailed.tenantId
+}
+
+func (authenticationFailed *AuthenticationFailed) Username() string {
+	return authenticationFailed.username
+}
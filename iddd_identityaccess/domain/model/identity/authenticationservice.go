@@ -0,0 +1,189 @@
+package identity
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/domain/model"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/totp"
+)
+
+// ErrAuthenticationFailed is reported alike for an unknown tenant or user, a wrong password or code, a lockout and a disabled user.
+var ErrAuthenticationFailed = ierrors.New(CODE_AUTHENTICATION_FAILED, MESSAGE_KEY_AUTHENTICATION_FAILED, "Authentication failed.")
+
+// ErrSecondFactorRequired, ErrSecondFactorEnrollmentRequired and ErrEmailAddressNotVerified are only reported once the password has been verified.
+var (
+	ErrSecondFactorRequired           = ierrors.New(CODE_SECOND_FACTOR_REQUIRED, MESSAGE_KEY_SECOND_FACTOR_REQUIRED, "A second authentication factor is required.")
+	ErrSecondFactorEnrollmentRequired = ierrors.New(CODE_SECOND_FACTOR_ENROLLMENT_REQUIRED, MESSAGE_KEY_SECOND_FACTOR_ENROLLMENT_REQUIRED, "The tenant requires multi-factor authentication to be enrolled.")
+	ErrEmailAddressNotVerified        = ierrors.New(CODE_EMAIL_ADDRESS_NOT_VERIFIED, MESSAGE_KEY_EMAIL_ADDRESS_NOT_VERIFIED, "The tenant requires a verified email address.")
+)
+
+// unknownUserPassword is compared against when there is no user to compare with, so that the response time does not reveal why.
+var (
+	unknownUserPassword     string
+	unknownUserPasswordOnce sync.Once
+)
+
+type AuthenticationService struct {
+	tenantRepository TenantRepository
+	userRepository   UserRepository
+	lockoutPolicy    LockoutPolicy
+}
+
+func NewAuthenticationService(aTenantRepository TenantRepository, aUserRepository UserRepository, aLockoutPolicy LockoutPolicy) *AuthenticationService {
+	return &AuthenticationService{tenantRepository: aTenantRepository, userRepository: aUserRepository, lockoutPolicy: aLockoutPolicy}
+}
+
+func (authenticationService *AuthenticationService) Authenticate(aTenantId TenantId, aUsername string, aPassword string) (_ *UserDescriptor, err error) {
+	defer ierrors.Wrap(&err, "authenticationservice.Authenticate(%v, %s)", aTenantId, aUsername)
+
+	tenant, user, err := authenticationService.authenticatePassword(aTenantId, aUsername, ierrors.Secret(aPassword))
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsTotpEnabled() {
+		return nil, ErrSecondFactorRequired
+	}
+	if tenant.IsMultiFactorAuthenticationRequired() {
+		return nil, ErrSecondFactorEnrollmentRequired
+	}
+
+	return authenticationService.succeed(user)
+}
+
+func (authenticationService *AuthenticationService) AuthenticateWithSecondFactor(aTenantId TenantId, aUsername string, aPassword string, aCode string) (_ *UserDescriptor, err error) {
+	defer ierrors.Wrap(&err, "authenticationservice.AuthenticateWithSecondFactor(%v, %s)", aTenantId, aUsername)
+
+	tenant, user, err := authenticationService.authenticatePassword(aTenantId, aUsername, ierrors.Secret(aPassword))
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsTotpEnabled() {
+		if tenant.IsMultiFactorAuthenticationRequired() {
+			return nil, ErrSecondFactorEnrollmentRequired
+		}
+		return nil, authenticationService.fail(aTenantId, aUsername)
+	}
+	if !user.isSecondFactorCorrect(aCode) {
+		return nil, authenticationService.failUser(user, isRecoveryCode(aCode))
+	}
+
+	return authenticationService.succeed(user)
+}
+
+func (authenticationService *AuthenticationService) EnrollSecondFactor(aTenantId TenantId, aUsername string, aPassword string, anAlgorithm totp.Algorithm) (_ *TotpEnrollment, err error) {
+	defer ierrors.Wrap(&err, "authenticationservice.EnrollSecondFactor(%v, %s, %v)", aTenantId, aUsername, anAlgorithm)
+
+	tenant, user, err := authenticationService.authenticatePassword(aTenantId, aUsername, ierrors.Secret(aPassword))
+	if err != nil {
+		return nil, err
+	}
+
+	totpEnrollment, err := user.EnrollTotp(tenant.Name(), anAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	if err := authenticationService.userRepository.Add(user); err != nil {
+		return nil, err
+	}
+
+	return totpEnrollment, nil
+}
+
+func (authenticationService *AuthenticationService) ConfirmSecondFactor(aTenantId TenantId, aUsername string, aPassword string, aCode string) (_ *UserDescriptor, err error) {
+	defer ierrors.Wrap(&err, "authenticationservice.ConfirmSecondFactor(%v, %s)", aTenantId, aUsername)
+
+	_, user, err := authenticationService.authenticatePassword(aTenantId, aUsername, ierrors.Secret(aPassword))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := user.ConfirmTotp(aCode); err != nil {
+		return nil, err
+	}
+
+	return authenticationService.succeed(user)
+}
+
+// authenticatePassword leaves the failed attempts as they are, so that the second factor cannot be guessed without limit.
+func (authenticationService *AuthenticationService) authenticatePassword(aTenantId TenantId, aUsername string, aPassword ierrors.Secret) (*Tenant, *User, error) {
+	tenant, err := authenticationService.tenantRepository.TenantOfId(aTenantId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if tenant == nil || !tenant.IsActive() {
+		compareUnknownUserPassword(aPassword)
+		return nil, nil, authenticationService.fail(aTenantId, aUsername)
+	}
+
+	user, err := authenticationService.userRepository.UserWithUsername(aTenantId, aUsername)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user == nil {
+		compareUnknownUserPassword(aPassword)
+		return nil, nil, authenticationService.fail(aTenantId, aUsername)
+	}
+
+	if user.IsLockedOut() {
+		_ = user.isPasswordCorrect(aPassword)
+		return nil, nil, authenticationService.fail(aTenantId, aUsername)
+	}
+
+	if !user.isPasswordCorrect(aPassword) {
+		return nil, nil, authenticationService.failUser(user, false)
+	}
+
+	if !user.IsEnabled() {
+		return nil, nil, authenticationService.fail(aTenantId, aUsername)
+	}
+
+	if tenant.IsVerifiedEmailAddressRequired() && !user.IsEmailAddressVerified() {
+		return nil, nil, ErrEmailAddressNotVerified
+	}
+
+	return tenant, user, nil
+}
+
+func compareUnknownUserPassword(aPassword ierrors.Secret) {
+	unknownUserPasswordOnce.Do(func() {
+		unknownUserPassword, _ = encryptionService.EncryptedValue("unknown user password")
+	})
+	_ = encryptionService.IsEncryptedValueEqual(aPassword.Reveal(), unknownUserPassword)
+}
+
+func (authenticationService *AuthenticationService) succeed(aUser *User) (*UserDescriptor, error) {
+	aUser.resetFailedAuthentications()
+	if err := authenticationService.userRepository.Add(aUser); err != nil {
+		return nil, err
+	}
+	return aUser.UserDescriptor(), nil
+}
+
+func (authenticationService *AuthenticationService) failUser(aUser *User, isRecoveryCode bool) error {
+	lockedOut := false
+	if isRecoveryCode {
+		lockedOut = aUser.recordFailedRecoveryCode(authenticationService.lockoutPolicy)
+	} else {
+		lockedOut = aUser.recordFailedAuthentication(authenticationService.lockoutPolicy)
+	}
+	if err := authenticationService.userRepository.Add(aUser); err != nil {
+		return err
+	}
+	if lockedOut {
+		if err := model.DomainEventPublisherInstance().Publish(NewUserLockedOut(aUser.tenantId, aUser.userName, aUser.lockedOutUntil)); err != nil {
+			return errors.Join(ErrAuthenticationFailed, err)
+		}
+	}
+	return authenticationService.fail(aUser.tenantId, aUser.userName)
+}
+
+func (authenticationService *AuthenticationService) fail(aTenantId TenantId, aUsername string) error {
+	if err := model.DomainEventPublisherInstance().Publish(NewAuthenticationFailed(aTenantId, aUsername)); err != nil {
+		return errors.Join(ErrAuthenticationFailed, err)
+	}
+	return ErrAuthenticationFailed
+}
@@ -0,0 +1,401 @@
+package identity_test
+
+import (
+	"encoding/base32"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/domain/model"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/totp"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/infrastructure/persistence"
+	"github.com/google/uuid"
+)
+
+const password = "qwerty!ASDFG#"
+
+type authenticationFixture struct {
+	tenant           *identity.Tenant
+	user             *identity.User
+	tenantRepository *persistence.InMemoryTenantRepository
+	userRepository   *persistence.InMemoryUserRepository
+	events           []model.DomainEvent
+}
+
+func newAuthenticationFixture(t *testing.T, anEnablement *identity.Enablement) *authenticationFixture {
+	t.Helper()
+
+	tenantId, err := identity.NewTenantId(uuid.New().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenant, err := identity.NewTenant(*tenantId, "TenantName", true, *identity.DefaultNameLengthPolicy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if anEnablement == nil {
+		anEnablement, err = identity.NewEnablement(true, time.Now().AddDate(-1, 0, 0), time.Now().AddDate(1, 0, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	user, err := identity.NewUser(*tenantId, "zoeusername", password, *anEnablement, *newPerson(t, *tenantId), *identity.DefaultNameLengthPolicy())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fixture := &authenticationFixture{
+		tenant:           tenant,
+		user:             user,
+		tenantRepository: persistence.NewInMemoryTenantRepository(),
+		userRepository:   persistence.NewInMemoryUserRepository(),
+	}
+	if err := fixture.tenantRepository.Add(tenant); err != nil {
+		t.Fatal(err)
+	}
+	if err := fixture.userRepository.Add(user); err != nil {
+		t.Fatal(err)
+	}
+
+	model.DomainEventPublisherInstance().Reset()
+	model.DomainEventPublisherInstance().Subscribe(model.DomainEventSubscriberFunc(func(aDomainEvent model.DomainEvent) error {
+		fixture.events = append(fixture.events, aDomainEvent)
+		return nil
+	}))
+	t.Cleanup(model.DomainEventPublisherInstance().Reset)
+
+	return fixture
+}
+
+func (fixture *authenticationFixture) authenticationService(t *testing.T, aMaximumFailedAttempts int, aLockoutDuration time.Duration) *identity.AuthenticationService {
+	t.Helper()
+
+	lockoutPolicy, err := identity.NewLockoutPolicy(aMaximumFailedAttempts, aLockoutDuration)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return identity.NewAuthenticationService(fixture.tenantRepository, fixture.userRepository, *lockoutPolicy)
+}
+
+func TestAuthenticate(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		authenticationService := fixture.authenticationService(t, 3, time.Minute)
+
+		userDescriptor, err := authenticationService.Authenticate(fixture.tenant.TenantId(), "zoeusername", password)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !userDescriptor.Equals(identity.NewUserDescriptor(fixture.tenant.TenantId(), "zoeusername", "zoe@saasovation.com")) {
+			t.Errorf("unexpected user descriptor %v", userDescriptor)
+		}
+		if len(fixture.events) != 0 {
+			t.Errorf("got %d events, want 0", len(fixture.events))
+		}
+	})
+	t.Run("success username in other case", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		authenticationService := fixture.authenticationService(t, 3, time.Minute)
+
+		userDescriptor, err := authenticationService.Authenticate(fixture.tenant.TenantId(), "ZoeUsername", password)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if userDescriptor.Username() != "zoeusername" {
+			t.Errorf("got %s, want zoeusername", userDescriptor.Username())
+		}
+	})
+
+	disablement, err := identity.NewEnablement(false, time.Now().AddDate(-1, 0, 0), time.Now().AddDate(1, 0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expiredEnablement, err := identity.NewEnablement(true, time.Now().AddDate(-2, 0, 0), time.Now().AddDate(-1, 0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name             string
+		enablement       *identity.Enablement
+		deactivateTenant bool
+		otherTenant      bool
+		username         string
+		password         string
+	}{
+		{name: "fail inactive tenant", deactivateTenant: true, username: "zoeusername", password: password},
+		{name: "fail unknown tenant", otherTenant: true, username: "zoeusername", password: password},
+		{name: "fail unknown user", username: "unknownusername", password: password},
+		{name: "fail wrong password", username: "zoeusername", password: "ASDFG#qwerty!"},
+		{name: "fail disabled user", enablement: disablement, username: "zoeusername", password: password},
+		{name: "fail time expired user", enablement: expiredEnablement, username: "zoeusername", password: password},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixture := newAuthenticationFixture(t, tt.enablement)
+			if tt.deactivateTenant {
+				if err := fixture.tenant.Deactivate(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			tenantId := fixture.tenant.TenantId()
+			if tt.otherTenant {
+				otherTenantId, err := identity.NewTenantId(uuid.New().String())
+				if err != nil {
+					t.Fatal(err)
+				}
+				tenantId = *otherTenantId
+			}
+			authenticationService := fixture.authenticationService(t, 3, time.Minute)
+
+			userDescriptor, err := authenticationService.Authenticate(tenantId, tt.username, tt.password)
+			if !errors.Is(err, identity.ErrAuthenticationFailed) {
+				t.Errorf("got %v, want %v", err, identity.ErrAuthenticationFailed)
+			}
+			if userDescriptor != nil {
+				t.Errorf("got %v, want nil", userDescriptor)
+			}
+			authenticationFailedEvents := 0
+			for _, event := range fixture.events {
+				if _, ok := event.(*identity.AuthenticationFailed); ok {
+					authenticationFailedEvents++
+				}
+			}
+			if authenticationFailedEvents != 1 {
+				t.Errorf("got %d AuthenticationFailed events, want 1", authenticationFailedEvents)
+			}
+		})
+	}
+}
+
+func TestAuthenticateLockout(t *testing.T) {
+	t.Run("locked out after maximum failed attempts", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		authenticationService := fixture.authenticationService(t, 3, time.Minute)
+
+		for i := 0; i < 3; i++ {
+			if _, err := authenticationService.Authenticate(fixture.tenant.TenantId(), "zoeusername", "wrong password"); !errors.Is(err, identity.ErrAuthenticationFailed) {
+				t.Fatalf("got %v, want %v", err, identity.ErrAuthenticationFailed)
+			}
+		}
+		if !fixture.user.IsLockedOut() {
+			t.Fatalf("user must be locked out")
+		}
+		lockedOutEvents := 0
+		for _, event := range fixture.events {
+			if _, ok := event.(*identity.UserLockedOut); ok {
+				lockedOutEvents++
+			}
+		}
+		if lockedOutEvents != 1 {
+			t.Errorf("got %d UserLockedOut events, want 1", lockedOutEvents)
+		}
+
+		_, err := authenticationService.Authenticate(fixture.tenant.TenantId(), "zoeusername", password)
+		if !errors.Is(err, identity.ErrAuthenticationFailed) {
+			t.Errorf("got %v, want %v", err, identity.ErrAuthenticationFailed)
+		}
+	})
+	t.Run("authenticate after lockout elapsed", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		authenticationService := fixture.authenticationService(t, 1, 50*time.Millisecond)
+
+		if _, err := authenticationService.Authenticate(fixture.tenant.TenantId(), "zoeusername", "wrong password"); !errors.Is(err, identity.ErrAuthenticationFailed) {
+			t.Fatalf("got %v, want %v", err, identity.ErrAuthenticationFailed)
+		}
+		time.Sleep(100 * time.Millisecond)
+
+		if _, err := authenticationService.Authenticate(fixture.tenant.TenantId(), "zoeusername", password); err != nil {
+			t.Fatal(err)
+		}
+		if got := fixture.user.FailedAuthenticationCount(); got != 0 {
+			t.Errorf("got %d failed authentications, want 0", got)
+		}
+	})
+	t.Run("successful authentication resets failed attempts", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		authenticationService := fixture.authenticationService(t, 3, time.Minute)
+
+		for i := 0; i < 2; i++ {
+			if _, err := authenticationService.Authenticate(fixture.tenant.TenantId(), "zoeusername", "wrong password"); !errors.Is(err, identity.ErrAuthenticationFailed) {
+				t.Fatalf("got %v, want %v", err, identity.ErrAuthenticationFailed)
+			}
+		}
+		if _, err := authenticationService.Authenticate(fixture.tenant.TenantId(), "zoeusername", password); err != nil {
+			t.Fatal(err)
+		}
+		if got := fixture.user.FailedAuthenticationCount(); got != 0 {
+			t.Errorf("got %d failed authentications, want 0", got)
+		}
+	})
+}
+
+func secondFactorCode(t *testing.T, aTotpEnrollment *identity.TotpEnrollment, anAlgorithm totp.Algorithm, aTime time.Time) string {
+	t.Helper()
+
+	secret, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(aTotpEnrollment.Secret())
+	if err != nil {
+		t.Fatal(err)
+	}
+	generator, err := totp.NewTotp(secret, anAlgorithm, totp.DEFAULT_DIGITS, totp.DEFAULT_PERIOD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return generator.CodeAt(aTime)
+}
+
+func TestAuthenticateWithSecondFactor(t *testing.T) {
+	t.Run("success after enrollment", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		authenticationService := fixture.authenticationService(t, 3, time.Minute)
+		tenantId := fixture.tenant.TenantId()
+
+		totpEnrollment, err := authenticationService.EnrollSecondFactor(tenantId, "zoeusername", password, totp.ALGORITHM_SHA256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := authenticationService.Authenticate(tenantId, "zoeusername", password); err != nil {
+			t.Fatalf("unconfirmed enrollment must not be required: %v", err)
+		}
+		if _, err := authenticationService.ConfirmSecondFactor(tenantId, "zoeusername", password, secondFactorCode(t, totpEnrollment, totp.ALGORITHM_SHA256, time.Now())); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := authenticationService.Authenticate(tenantId, "zoeusername", password); !errors.Is(err, identity.ErrSecondFactorRequired) {
+			t.Errorf("got %v, want %v", err, identity.ErrSecondFactorRequired)
+		}
+
+		code := secondFactorCode(t, totpEnrollment, totp.ALGORITHM_SHA256, time.Now().Add(totp.DEFAULT_PERIOD))
+		userDescriptor, err := authenticationService.AuthenticateWithSecondFactor(tenantId, "zoeusername", password, code)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if userDescriptor.Username() != "zoeusername" {
+			t.Errorf("unexpected user descriptor %v", userDescriptor)
+		}
+
+		if _, err := authenticationService.AuthenticateWithSecondFactor(tenantId, "zoeusername", password, code); !errors.Is(err, identity.ErrAuthenticationFailed) {
+			t.Errorf("replayed code: got %v, want %v", err, identity.ErrAuthenticationFailed)
+		}
+		if _, err := authenticationService.AuthenticateWithSecondFactor(tenantId, "zoeusername", password, totpEnrollment.RecoveryCodes()[0]); err != nil {
+			t.Errorf("recovery code must be accepted: %v", err)
+		}
+		if got := fixture.user.RemainingRecoveryCodes(); got != identity.RECOVERY_CODE_COUNT-1 {
+			t.Errorf("got %d remaining recovery codes, want %d", got, identity.RECOVERY_CODE_COUNT-1)
+		}
+	})
+	t.Run("tenant requires enrollment", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		fixture.tenant.RequireMultiFactorAuthentication()
+		authenticationService := fixture.authenticationService(t, 3, time.Minute)
+		tenantId := fixture.tenant.TenantId()
+
+		if _, err := authenticationService.Authenticate(tenantId, "zoeusername", password); !errors.Is(err, identity.ErrSecondFactorEnrollmentRequired) {
+			t.Errorf("got %v, want %v", err, identity.ErrSecondFactorEnrollmentRequired)
+		}
+		if _, err := authenticationService.AuthenticateWithSecondFactor(tenantId, "zoeusername", password, "123456"); !errors.Is(err, identity.ErrSecondFactorEnrollmentRequired) {
+			t.Errorf("got %v, want %v", err, identity.ErrSecondFactorEnrollmentRequired)
+		}
+
+		totpEnrollment, err := authenticationService.EnrollSecondFactor(tenantId, "zoeusername", password, totp.ALGORITHM_SHA1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		userDescriptor, err := authenticationService.ConfirmSecondFactor(tenantId, "zoeusername", password, secondFactorCode(t, totpEnrollment, totp.ALGORITHM_SHA1, time.Now()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if userDescriptor.Username() != "zoeusername" {
+			t.Errorf("unexpected user descriptor %v", userDescriptor)
+		}
+	})
+	t.Run("fail enroll with wrong password", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		authenticationService := fixture.authenticationService(t, 3, time.Minute)
+
+		totpEnrollment, err := authenticationService.EnrollSecondFactor(fixture.tenant.TenantId(), "zoeusername", "wrong password", totp.ALGORITHM_SHA1)
+		if !errors.Is(err, identity.ErrAuthenticationFailed) {
+			t.Errorf("got %v, want %v", err, identity.ErrAuthenticationFailed)
+		}
+		if totpEnrollment != nil {
+			t.Errorf("got %v, want nil", totpEnrollment)
+		}
+	})
+	t.Run("wrong recovery codes lock the user out", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		authenticationService := fixture.authenticationService(t, 10, time.Minute)
+		tenantId := fixture.tenant.TenantId()
+
+		totpEnrollment, err := authenticationService.EnrollSecondFactor(tenantId, "zoeusername", password, totp.ALGORITHM_SHA1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := authenticationService.ConfirmSecondFactor(tenantId, "zoeusername", password, secondFactorCode(t, totpEnrollment, totp.ALGORITHM_SHA1, time.Now())); err != nil {
+			t.Fatal(err)
+		}
+
+		for i := 0; i < identity.DEFAULT_MAXIMUM_FAILED_RECOVERY_CODES; i++ {
+			if _, err := authenticationService.AuthenticateWithSecondFactor(tenantId, "zoeusername", password, "aaaaaaaa-aaaaaaaa"); !errors.Is(err, identity.ErrAuthenticationFailed) {
+				t.Fatalf("got %v, want %v", err, identity.ErrAuthenticationFailed)
+			}
+		}
+		if !fixture.user.IsLockedOut() {
+			t.Errorf("user must be locked out")
+		}
+		if _, err := authenticationService.AuthenticateWithSecondFactor(tenantId, "zoeusername", password, totpEnrollment.RecoveryCodes()[0]); !errors.Is(err, identity.ErrAuthenticationFailed) {
+			t.Errorf("got %v, want %v", err, identity.ErrAuthenticationFailed)
+		}
+	})
+	t.Run("wrong codes lock the user out", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		authenticationService := fixture.authenticationService(t, 2, time.Minute)
+		tenantId := fixture.tenant.TenantId()
+
+		totpEnrollment, err := authenticationService.EnrollSecondFactor(tenantId, "zoeusername", password, totp.ALGORITHM_SHA1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := authenticationService.ConfirmSecondFactor(tenantId, "zoeusername", password, secondFactorCode(t, totpEnrollment, totp.ALGORITHM_SHA1, time.Now())); err != nil {
+			t.Fatal(err)
+		}
+
+		wrongCode := secondFactorCode(t, totpEnrollment, totp.ALGORITHM_SHA1, time.Now().Add(-time.Hour))
+		for i := 0; i < 2; i++ {
+			if _, err := authenticationService.AuthenticateWithSecondFactor(tenantId, "zoeusername", password, wrongCode); !errors.Is(err, identity.ErrAuthenticationFailed) {
+				t.Fatalf("got %v, want %v", err, identity.ErrAuthenticationFailed)
+			}
+		}
+		if !fixture.user.IsLockedOut() {
+			t.Errorf("user must be locked out")
+		}
+	})
+}
+
+func TestAuthenticateVerifiedEmailAddressRequired(t *testing.T) {
+	fixture := newAuthenticationFixture(t, nil)
+	fixture.tenant.RequireVerifiedEmailAddress()
+	authenticationService := fixture.authenticationService(t, 3, time.Minute)
+	tenantId := fixture.tenant.TenantId()
+
+	if _, err := authenticationService.Authenticate(tenantId, "zoeusername", password); !errors.Is(err, identity.ErrEmailAddressNotVerified) {
+		t.Errorf("got %v, want %v", err, identity.ErrEmailAddressNotVerified)
+	}
+	if _, err := authenticationService.Authenticate(tenantId, "zoeusername", "wrong password"); !errors.Is(err, identity.ErrAuthenticationFailed) {
+		t.Errorf("wrong password: got %v, want %v", err, identity.ErrAuthenticationFailed)
+	}
+
+	emailVerificationService := fixture.emailVerificationService(time.Minute)
+	plainToken, err := emailVerificationService.Issue(fixture.user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := emailVerificationService.Verify(plainToken); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := authenticationService.Authenticate(tenantId, "zoeusername", password); err != nil {
+		t.Error(err)
+	}
+}
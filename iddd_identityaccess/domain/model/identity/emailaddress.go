@@ -0,0 +1,58 @@
+package identity
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+)
+
+var emailAddressPattern = regexp.MustCompile(`^\w+([-+.']\w+)*@\w+([-.]\w+)*\.\w+([-.]\w+)*$`)
+
+type EmailAddress struct {
+	address  string
+	verified bool
+}
+
+func NewEmailAddress(anAddress string) (_ *EmailAddress, err error) {
+	defer ierrors.Wrap(&err, "emailaddress.NewEmailAddress(%s)", anAddress)
+
+	if err := validateEmailAddress(anAddress); err != nil {
+		return nil, ierrors.NewFieldError("emailAddress", err)
+	}
+
+	return &EmailAddress{address: anAddress}, nil
+}
+
+func validateEmailAddress(anAddress string) error {
+	if err := ierrors.NewArgumentNotEmptyError(anAddress, "The email address is required.").WithKey(MESSAGE_KEY_EMAIL_ADDRESS_REQUIRED).GetError(); err != nil {
+		return err
+	}
+	if err := ierrors.NewArgumentLengthError(anAddress, 1, 100, "Email address must be 100 characters or less.").WithKey(MESSAGE_KEY_EMAIL_ADDRESS_LENGTH).GetError(); err != nil {
+		return err
+	}
+	if err := ierrors.NewArgumentTrueErrorArguments(emailAddressPattern.MatchString(anAddress), "Email address format is invalid.").WithKey(MESSAGE_KEY_EMAIL_ADDRESS_FORMAT).GetError(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (emailAddress EmailAddress) Address() string {
+	return emailAddress.address
+}
+
+func (emailAddress EmailAddress) IsVerified() bool {
+	return emailAddress.verified
+}
+
+func (emailAddress EmailAddress) asVerified() EmailAddress {
+	return EmailAddress{address: emailAddress.address, verified: true}
+}
+
+func (emailAddress EmailAddress) Equals(otherEmailAddress EmailAddress) bool {
+	return emailAddress.address == otherEmailAddress.address
+}
+
+func (emailAddress EmailAddress) String() string {
+	return fmt.Sprintf("EmailAddress [address=%s, verified=%v]", emailAddress.address, emailAddress.verified)
+}
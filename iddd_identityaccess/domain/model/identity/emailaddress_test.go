@@ -0,0 +1,61 @@
+package identity
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/utils"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewEmailAddress(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		got, err := NewEmailAddress("zoe@saasovation.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := &EmailAddress{address: "zoe@saasovation.com"}
+		if diff := cmp.Diff(want, got, cmp.AllowUnexported(EmailAddress{})); diff != "" {
+			t.Errorf("mismatch (-want, +got):\n%s", diff)
+		}
+	})
+	t.Run("fail empty address", func(t *testing.T) {
+		_, err := NewEmailAddress("")
+		if !errors.As(err, &argumentNotEmptyError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentNotEmptyError))
+		}
+	})
+	t.Run("fail over 100 characters address", func(t *testing.T) {
+		_, err := NewEmailAddress(utils.RandString(90) + "@saasovation.com")
+		if !errors.As(err, &argumentLengthError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentLengthError))
+		}
+	})
+	t.Run("fail invalid format", func(t *testing.T) {
+		_, err := NewEmailAddress("zoe.saasovation.com")
+		if !errors.As(err, &argumentTrueError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentTrueError))
+		}
+	})
+}
+
+func TestEmailAddressAsVerified(t *testing.T) {
+	emailAddress, err := NewEmailAddress("zoe@saasovation.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if emailAddress.IsVerified() {
+		t.Errorf("new email address must not be verified")
+	}
+
+	verified := emailAddress.asVerified()
+
+	if !verified.IsVerified() || !verified.Equals(*emailAddress) {
+		t.Errorf("got %v, want verified %v", verified, emailAddress)
+	}
+	if emailAddress.IsVerified() {
+		t.Errorf("original email address must stay unverified")
+	}
+}
@@ -0,0 +1,94 @@
+package identity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+)
+
+var (
+	ErrEmailVerificationTokenInvalid = errors.New("The email verification token is invalid.")
+	ErrEmailVerificationThrottled    = errors.New("An email verification was sent too recently.")
+)
+
+type EmailVerificationService struct {
+	emailVerificationTokenRepository EmailVerificationTokenRepository
+	userRepository                   UserRepository
+	lifetime                         time.Duration
+	resendInterval                   time.Duration
+}
+
+func NewEmailVerificationService(anEmailVerificationTokenRepository EmailVerificationTokenRepository, aUserRepository UserRepository, aLifetime time.Duration, aResendInterval time.Duration) *EmailVerificationService {
+	return &EmailVerificationService{emailVerificationTokenRepository: anEmailVerificationTokenRepository, userRepository: aUserRepository, lifetime: aLifetime, resendInterval: aResendInterval}
+}
+
+// Issue refuses to issue again within the resend interval and invalidates any token issued before.
+func (emailVerificationService *EmailVerificationService) Issue(aUser *User) (_ string, err error) {
+	defer ierrors.Wrap(&err, "emailverificationservice.Issue(%s)", aUser.userName)
+
+	if err := ierrors.NewArgumentFalseError(aUser.IsEmailAddressVerified(), "The email address is already verified.").GetError(); err != nil {
+		return "", err
+	}
+
+	emailVerificationTokens, err := emailVerificationService.emailVerificationTokenRepository.AllEmailVerificationTokensOfUser(aUser.tenantId, aUser.userName)
+	if err != nil {
+		return "", err
+	}
+	for _, emailVerificationToken := range emailVerificationTokens {
+		if time.Since(emailVerificationToken.issuedOn) < emailVerificationService.resendInterval {
+			return "", ErrEmailVerificationThrottled
+		}
+	}
+	for _, emailVerificationToken := range emailVerificationTokens {
+		if emailVerificationToken.used {
+			continue
+		}
+		emailVerificationToken.use()
+		if err := emailVerificationService.emailVerificationTokenRepository.Add(emailVerificationToken); err != nil {
+			return "", err
+		}
+	}
+
+	emailVerificationToken, plainToken, err := newEmailVerificationToken(aUser.tenantId, aUser.userName, aUser.person.emailAddress.address, emailVerificationService.lifetime)
+	if err != nil {
+		return "", err
+	}
+	if err := emailVerificationService.emailVerificationTokenRepository.Add(emailVerificationToken); err != nil {
+		return "", err
+	}
+	return plainToken, nil
+}
+
+func (emailVerificationService *EmailVerificationService) Verify(aPlainToken string) (_ *User, err error) {
+	defer ierrors.Wrap(&err, "emailverificationservice.Verify()")
+
+	emailVerificationToken, err := emailVerificationService.emailVerificationTokenRepository.EmailVerificationTokenOfHash(hashToken(aPlainToken))
+	if err != nil {
+		return nil, err
+	}
+	if emailVerificationToken == nil || !emailVerificationToken.IsUsable() {
+		return nil, ErrEmailVerificationTokenInvalid
+	}
+
+	user, err := emailVerificationService.userRepository.UserWithUsername(emailVerificationToken.tenantId, emailVerificationToken.username)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.person.emailAddress.address != emailVerificationToken.emailAddress {
+		return nil, ErrEmailVerificationTokenInvalid
+	}
+
+	if err := user.verifyEmailAddress(); err != nil {
+		return nil, err
+	}
+
+	emailVerificationToken.use()
+	if err := emailVerificationService.emailVerificationTokenRepository.Add(emailVerificationToken); err != nil {
+		return nil, err
+	}
+	if err := emailVerificationService.userRepository.Add(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
@@ -0,0 +1,117 @@
+package identity_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/infrastructure/persistence"
+)
+
+func (fixture *authenticationFixture) emailVerificationService(aResendInterval time.Duration) *identity.EmailVerificationService {
+	return identity.NewEmailVerificationService(persistence.NewInMemoryEmailVerificationTokenRepository(), fixture.userRepository, time.Hour, aResendInterval)
+}
+
+func TestEmailVerificationServiceVerify(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		emailVerificationService := fixture.emailVerificationService(time.Minute)
+		plainToken, err := emailVerificationService.Issue(fixture.user)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		user, err := emailVerificationService.Verify(plainToken)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !user.IsEmailAddressVerified() {
+			t.Errorf("email address must be verified")
+		}
+		if len(fixture.events) != 1 {
+			t.Fatalf("got %d events, want 1", len(fixture.events))
+		}
+		event, ok := fixture.events[0].(*identity.UserEmailVerified)
+		if !ok {
+			t.Fatalf("got %T, want %T", fixture.events[0], &identity.UserEmailVerified{})
+		}
+		if event.EmailAddress() != "zoe@saasovation.com" || event.Username() != "zoeusername" {
+			t.Errorf("unexpected event %v", event)
+		}
+		if _, err := emailVerificationService.Verify(plainToken); !errors.Is(err, identity.ErrEmailVerificationTokenInvalid) {
+			t.Errorf("used token: got %v, want %v", err, identity.ErrEmailVerificationTokenInvalid)
+		}
+	})
+	t.Run("fail resend within interval", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		emailVerificationService := fixture.emailVerificationService(time.Minute)
+		if _, err := emailVerificationService.Issue(fixture.user); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := emailVerificationService.Issue(fixture.user); !errors.Is(err, identity.ErrEmailVerificationThrottled) {
+			t.Errorf("got %v, want %v", err, identity.ErrEmailVerificationThrottled)
+		}
+	})
+	t.Run("resend after interval supersedes the previous token", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		emailVerificationService := fixture.emailVerificationService(10 * time.Millisecond)
+		supersededToken, err := emailVerificationService.Issue(fixture.user)
+		if err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(20 * time.Millisecond)
+		plainToken, err := emailVerificationService.Issue(fixture.user)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := emailVerificationService.Verify(supersededToken); !errors.Is(err, identity.ErrEmailVerificationTokenInvalid) {
+			t.Errorf("got %v, want %v", err, identity.ErrEmailVerificationTokenInvalid)
+		}
+		if _, err := emailVerificationService.Verify(plainToken); err != nil {
+			t.Error(err)
+		}
+	})
+	t.Run("fail email address changed", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		emailVerificationService := fixture.emailVerificationService(time.Minute)
+		plainToken, err := emailVerificationService.Issue(fixture.user)
+		if err != nil {
+			t.Fatal(err)
+		}
+		emailAddress, err := identity.NewEmailAddress("zoe.doe@saasovation.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+		fixture.user.Person().ChangeEmailAddress(*emailAddress)
+
+		if _, err := emailVerificationService.Verify(plainToken); !errors.Is(err, identity.ErrEmailVerificationTokenInvalid) {
+			t.Errorf("got %v, want %v", err, identity.ErrEmailVerificationTokenInvalid)
+		}
+		if fixture.user.IsEmailAddressVerified() {
+			t.Errorf("email address must not be verified")
+		}
+	})
+	t.Run("fail already verified", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		emailVerificationService := fixture.emailVerificationService(0)
+		plainToken, err := emailVerificationService.Issue(fixture.user)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := emailVerificationService.Verify(plainToken); err != nil {
+			t.Fatal(err)
+		}
+
+		var argumentFalseError *ierrors.ArgumentFalseError
+		_, err = emailVerificationService.Issue(fixture.user)
+		if !errors.As(err, &argumentFalseError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentFalseError))
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package identity
+
+import (
+	"fmt"
+	"time"
+)
+
+type EmailVerificationToken struct {
+	tokenHash    string
+	tenantId     TenantId
+	username     string
+	emailAddress string
+	issuedOn     time.Time
+	expiresOn    time.Time
+	used         bool
+}
+
+// newEmailVerificationToken returns the token together with its plaintext, which is never stored.
+func newEmailVerificationToken(aTenantId TenantId, aUsername string, anEmailAddress string, aLifetime time.Duration) (*EmailVerificationToken, string, error) {
+	plainToken, err := randomToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	emailVerificationToken := &EmailVerificationToken{
+		tokenHash:    hashToken(plainToken),
+		tenantId:     aTenantId,
+		username:     aUsername,
+		emailAddress: anEmailAddress,
+		issuedOn:     now,
+		expiresOn:    now.Add(aLifetime),
+	}
+	return emailVerificationToken, plainToken, nil
+}
+
+func (emailVerificationToken *EmailVerificationToken) TokenHash() string {
+	return emailVerificationToken.tokenHash
+}
+
+func (emailVerificationToken *EmailVerificationToken) TenantId() TenantId {
+	return emailVerificationToken.tenantId
+}
+
+func (emailVerificationToken *EmailVerificationToken) Username() string {
+	return emailVerificationToken.username
+}
+
+// EmailAddress is the address the token was sent to; the token is void once the user's address changes.
+func (emailVerificationToken *EmailVerificationToken) EmailAddress() string {
+	return emailVerificationToken.emailAddress
+}
+
+func (emailVerificationToken *EmailVerificationToken) IssuedOn() time.Time {
+	return emailVerificationToken.issuedOn
+}
+
+func (emailVerificationToken *EmailVerificationToken) ExpiresOn() time.Time {
+	return emailVerificationToken.expiresOn
+}
+
+func (emailVerificationToken *EmailVerificationToken) IsUsed() bool {
+	return emailVerificationToken.used
+}
+
+func (emailVerificationToken *EmailVerificationToken) IsExpired() bool {
+	return !time.Now().Before(emailVerificationToken.expiresOn)
+}
+
+func (emailVerificationToken *EmailVerificationToken) IsUsable() bool {
+	return !emailVerificationToken.used && !emailVerificationToken.IsExpired()
+}
+
+func (emailVerificationToken *EmailVerificationToken) use() {
+	emailVerificationToken.used = true
+}
+
+func (emailVerificationToken *EmailVerificationToken) String() string {
+	return fmt.Sprintf("EmailVerificationToken [tenantId=%s, username=%s, emailAddress=%s, expiresOn=%v, used=%v]", emailVerificationToken.tenantId.Id(), emailVerificationToken.username, emailVerificationToken.emailAddress, emailVerificationToken.expiresOn, emailVerificationToken.used)
+}
@@ -0,0 +1,28 @@
+package identity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewEmailVerificationToken(t *testing.T) {
+	emailVerificationToken, plainToken, err := newEmailVerificationToken(*tenantId, userName, "zoe@saasovation.com", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if emailVerificationToken.TokenHash() == plainToken || emailVerificationToken.TokenHash() != hashToken(plainToken) {
+		t.Errorf("email verification token must store the hash of %s, but %s", plainToken, emailVerificationToken.TokenHash())
+	}
+	if emailVerificationToken.EmailAddress() != "zoe@saasovation.com" {
+		t.Errorf("got %s, want %s", emailVerificationToken.EmailAddress(), "zoe@saasovation.com")
+	}
+	if !emailVerificationToken.IsUsable() {
+		t.Errorf("email verification token %v must be usable", emailVerificationToken)
+	}
+
+	emailVerificationToken.use()
+	if emailVerificationToken.IsUsable() {
+		t.Errorf("used email verification token %v must not be usable", emailVerificationToken)
+	}
+}
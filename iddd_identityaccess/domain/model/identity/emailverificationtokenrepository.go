@@ -0,0 +1,7 @@
+package identity
+
+type EmailVerificationTokenRepository interface {
+	Add(anEmailVerificationToken *EmailVerificationToken) error
+	EmailVerificationTokenOfHash(aTokenHash string) (*EmailVerificationToken, error)
+	AllEmailVerificationTokensOfUser(aTenantId TenantId, aUsername string) ([]*EmailVerificationToken, error)
+}
@@ -8,6 +8,10 @@ import (
 	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
 )
 
+var INDEFINITE_END_DATE = time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC)
+
+var ErrEnablementWindowInvalid = ierrors.New(CODE_ENABLEMENT_WINDOW_INVALID, "", "Enablement start and/or end date is invalid.")
+
 type Enablement struct {
 	enabled   bool
 	startDate time.Time
@@ -15,13 +19,22 @@ type Enablement struct {
 }
 
 func NewEnablement(aEnabled bool, aStartDate time.Time, aEndDate time.Time) (*Enablement, error) {
-	if err := ierrors.NewArgumentFalseError(aStartDate.After(aEndDate), "Enablement start and/or end date is invalid.").GetError(); err != nil {
-		return nil, err
+	if err := validateEnablementPeriod(aStartDate, aEndDate); err != nil {
+		return nil, ierrors.NewFieldError("endDate", err)
 	}
 
 	return &Enablement{enabled: aEnabled, startDate: aStartDate, endDate: aEndDate}, nil
 }
 
+func validateEnablementPeriod(aStartDate time.Time, anEndDate time.Time) error {
+	return ierrors.NewAssertionConcern("endDate").WithCause(ErrEnablementWindowInvalid).ArgumentTrue(!aStartDate.After(anEndDate), ErrEnablementWindowInvalid.Error())
+}
+
+// NewIndefiniteEnablement starts now and ends so far ahead that it never expires in practice, as a zero end date would expire at once.
+func NewIndefiniteEnablement(aEnabled bool) *Enablement {
+	return &Enablement{enabled: aEnabled, startDate: time.Now(), endDate: INDEFINITE_END_DATE}
+}
+
 func (enablement *Enablement) IsEnabled() bool {
 	return enablement.enabled
 }
@@ -34,6 +47,10 @@ func (enablement *Enablement) StartDate() time.Time {
 	return enablement.startDate
 }
 
+func (enablement *Enablement) IsEnablementEnabled() bool {
+	return enablement.IsEnabled() && !enablement.IsTimeExpired()
+}
+
 func (enablement *Enablement) IsTimeExpired() bool {
 	timeExpired := false
 
@@ -54,8 +54,11 @@ func TestNewEnablement(t *testing.T) {
 			t.Fatal(err)
 		}
 		_, err = NewEnablement(enabled, startDate, endDate)
-		if !errors.As(err, &argumentFalseError) {
-			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(err), reflect.TypeOf(&argumentFalseError))
+		if !errors.As(err, &assertionError) || assertionError.Code() != CODE_ENABLEMENT_WINDOW_INVALID {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(err), reflect.TypeOf(&assertionError))
+		}
+		if !errors.Is(err, ErrEnablementWindowInvalid) {
+			t.Errorf("got %v, want it to match %v", err, ErrEnablementWindowInvalid)
 		}
 	})
 }
@@ -113,3 +116,14 @@ func TestEnablementString(t *testing.T) {
 		t.Errorf("got %s, want %s", got, want)
 	}
 }
+
+func TestNewIndefiniteEnablement(t *testing.T) {
+	enablement := NewIndefiniteEnablement(true)
+
+	if !enablement.IsEnablementEnabled() {
+		t.Errorf("got %v, want an enablement in effect", enablement)
+	}
+	if enablement.EndDate() != INDEFINITE_END_DATE {
+		t.Errorf("got %v, want %v", enablement.EndDate(), INDEFINITE_END_DATE)
+	}
+}
@@ -0,0 +1,33 @@
+package identity
+
+import "golang.org/x/crypto/bcrypt"
+
+const BCRYPT_COST = 12
+
+type EncryptionService interface {
+	EncryptedValue(aPlainTextValue string) (string, error)
+	IsEncryptedValueEqual(aPlainTextValue string, anEncryptedValue string) bool
+}
+
+type BcryptEncryptionService struct {
+	cost int
+}
+
+func NewBcryptEncryptionService(aCost int) *BcryptEncryptionService {
+	return &BcryptEncryptionService{cost: aCost}
+}
+
+func (bcryptEncryptionService *BcryptEncryptionService) EncryptedValue(aPlainTextValue string) (string, error) {
+	encryptedValue, err := bcrypt.GenerateFromPassword([]byte(aPlainTextValue), bcryptEncryptionService.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(encryptedValue), nil
+}
+
+func (bcryptEncryptionService *BcryptEncryptionService) IsEncryptedValueEqual(aPlainTextValue string, anEncryptedValue string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(anEncryptedValue), []byte(aPlainTextValue)) == nil
+}
+
+// encryptionService hashes both passwords and recovery codes.
+var encryptionService EncryptionService = NewBcryptEncryptionService(BCRYPT_COST)
@@ -0,0 +1,26 @@
+package identity
+
+import (
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestBcryptEncryptionService(t *testing.T) {
+	bcryptEncryptionService := NewBcryptEncryptionService(bcrypt.MinCost)
+
+	encryptedValue, err := bcryptEncryptionService.EncryptedValue("qwerty!ASDFG#")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if encryptedValue == "qwerty!ASDFG#" {
+		t.Errorf("value must be encrypted")
+	}
+	if !bcryptEncryptionService.IsEncryptedValueEqual("qwerty!ASDFG#", encryptedValue) {
+		t.Errorf("plain text value must match its encrypted value")
+	}
+	if bcryptEncryptionService.IsEncryptedValueEqual("ASDFG#qwerty!", encryptedValue) {
+		t.Errorf("other plain text value must not match")
+	}
+}
@@ -0,0 +1,65 @@
+package identity
+
+import (
+	"fmt"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+)
+
+type FullName struct {
+	firstName string
+	lastName  string
+}
+
+func NewFullName(aFirstName string, aLastName string) (_ *FullName, err error) {
+	defer ierrors.Wrap(&err, "fullname.NewFullName(%s, %s)", aFirstName, aLastName)
+
+	if err := validateFirstName(aFirstName); err != nil {
+		return nil, ierrors.NewFieldError("firstName", err)
+	}
+	if err := validateLastName(aLastName); err != nil {
+		return nil, ierrors.NewFieldError("lastName", err)
+	}
+
+	return &FullName{firstName: aFirstName, lastName: aLastName}, nil
+}
+
+func validateFirstName(aFirstName string) error {
+	if err := ierrors.NewArgumentNotEmptyError(aFirstName, "First name is required.").WithKey(MESSAGE_KEY_FIRST_NAME_REQUIRED).GetError(); err != nil {
+		return err
+	}
+	if err := ierrors.NewArgumentLengthError(aFirstName, 1, 50, "First name must be 50 characters or less.").WithKey(MESSAGE_KEY_FIRST_NAME_LENGTH).GetError(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func validateLastName(aLastName string) error {
+	if err := ierrors.NewArgumentNotEmptyError(aLastName, "Last name is required.").WithKey(MESSAGE_KEY_LAST_NAME_REQUIRED).GetError(); err != nil {
+		return err
+	}
+	if err := ierrors.NewArgumentLengthError(aLastName, 1, 50, "Last name must be 50 characters or less.").WithKey(MESSAGE_KEY_LAST_NAME_LENGTH).GetError(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (fullName FullName) FirstName() string {
+	return fullName.firstName
+}
+
+func (fullName FullName) LastName() string {
+	return fullName.lastName
+}
+
+func (fullName FullName) AsFormattedName() string {
+	return fullName.firstName + " " + fullName.lastName
+}
+
+func (fullName FullName) Equals(otherFullName FullName) bool {
+	return fullName == otherFullName
+}
+
+func (fullName FullName) String() string {
+	return fmt.Sprintf("FullName [firstName=%s, lastName=%s]", fullName.firstName, fullName.lastName)
+}
@@ -0,0 +1,38 @@
+package identity
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewFullName(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		got, err := NewFullName("Zoe", "Doe")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := &FullName{firstName: "Zoe", lastName: "Doe"}
+		if diff := cmp.Diff(want, got, cmp.AllowUnexported(FullName{})); diff != "" {
+			t.Errorf("mismatch (-want, +got):\n%s", diff)
+		}
+		if got := got.AsFormattedName(); got != "Zoe Doe" {
+			t.Errorf("got %s, want %s", got, "Zoe Doe")
+		}
+	})
+	t.Run("fail empty first name", func(t *testing.T) {
+		_, err := NewFullName("", "Doe")
+		if !errors.As(err, &argumentNotEmptyError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentNotEmptyError))
+		}
+	})
+	t.Run("fail empty last name", func(t *testing.T) {
+		_, err := NewFullName("Zoe", "")
+		if !errors.As(err, &argumentNotEmptyError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentNotEmptyError))
+		}
+	})
+}
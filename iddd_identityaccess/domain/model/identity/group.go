@@ -0,0 +1,200 @@
+package identity
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/domain/model"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+)
+
+const ROLE_GROUP_PREFIX = "ROLE-INTERNAL-GROUP: "
+
+type Group struct {
+	model.ConcurrencySafeEntity
+
+	tenantId     TenantId
+	name         string
+	description  string
+	groupMembers []GroupMember
+}
+
+func NewGroup(aTenantId TenantId, aName string, aDescription string) (_ *Group, err error) {
+	defer ierrors.Wrap(&err, "group.NewGroup(%v, %s, %s)", aTenantId, aName, aDescription)
+	// validate name
+	if err := ierrors.NewArgumentNotEmptyError(aName, "Group name is required.").WithKey(MESSAGE_KEY_GROUP_NAME_REQUIRED).GetError(); err != nil {
+		return nil, ierrors.NewFieldError("name", err)
+	}
+	if err := ierrors.NewArgumentLengthError(aName, 1, 100, "Group name must be 100 characters or less.").WithKey(MESSAGE_KEY_GROUP_NAME_LENGTH).GetError(); err != nil {
+		return nil, ierrors.NewFieldError("name", err)
+	}
+	// validate description
+	if err := ierrors.NewArgumentNotEmptyError(aDescription, "Group description is required.").WithKey(MESSAGE_KEY_GROUP_DESCRIPTION_REQUIRED).GetError(); err != nil {
+		return nil, ierrors.NewFieldError("description", err)
+	}
+	if err := ierrors.NewArgumentLengthError(aDescription, 1, 250, "Group description must be 250 characters or less.").WithKey(MESSAGE_KEY_GROUP_DESCRIPTION_LENGTH).GetError(); err != nil {
+		return nil, ierrors.NewFieldError("description", err)
+	}
+
+	return &Group{tenantId: aTenantId, name: aName, description: aDescription, groupMembers: []GroupMember{}}, nil
+}
+
+func (group *Group) TenantId() TenantId {
+	return group.tenantId
+}
+
+func (group *Group) Name() string {
+	return group.name
+}
+
+func (group *Group) Description() string {
+	return group.description
+}
+
+func (group *Group) GroupMembers() []GroupMember {
+	groupMembers := make([]GroupMember, len(group.groupMembers))
+	copy(groupMembers, group.groupMembers)
+	return groupMembers
+}
+
+func (group *Group) IsInternalGroup() bool {
+	return strings.HasPrefix(group.name, ROLE_GROUP_PREFIX)
+}
+
+func (group *Group) AddGroup(aGroup *Group, aGroupMemberService *GroupMemberService) (err error) {
+	defer ierrors.Wrap(&err, "group.AddGroup(%s)", aGroup.name)
+
+	if err := ierrors.NewArgumentTrueErrorArguments(group.tenantId == aGroup.tenantId, "Wrong tenant for this group.").GetError(); err != nil {
+		return err
+	}
+	isMemberGroup, err := aGroupMemberService.IsMemberGroup(aGroup, group.toGroupMember())
+	if err != nil {
+		return err
+	}
+	if err := ierrors.NewArgumentFalseError(isMemberGroup || group.name == aGroup.name, "Group recursion.").WithKey(MESSAGE_KEY_GROUP_RECURSION).GetError(); err != nil {
+		return err
+	}
+
+	group.addGroupMember(aGroup.toGroupMember())
+	return nil
+}
+
+func (group *Group) AddUser(aUser *User) (err error) {
+	defer ierrors.Wrap(&err, "group.AddUser(%s)", aUser.userName)
+
+	if err := ierrors.NewArgumentTrueErrorArguments(group.tenantId == aUser.tenantId, "Wrong tenant for this group.").GetError(); err != nil {
+		return err
+	}
+	if err := ierrors.NewArgumentTrueErrorArguments(aUser.IsEnabled(), "User is not enabled.").GetError(); err != nil {
+		return err
+	}
+
+	group.addGroupMember(aUser.toGroupMember())
+	return nil
+}
+
+func (group *Group) RemoveGroup(aGroup *Group) (err error) {
+	defer ierrors.Wrap(&err, "group.RemoveGroup(%s)", aGroup.name)
+
+	if err := ierrors.NewArgumentTrueErrorArguments(group.tenantId == aGroup.tenantId, "Wrong tenant for this group.").GetError(); err != nil {
+		return err
+	}
+
+	group.removeGroupMember(aGroup.toGroupMember())
+	return nil
+}
+
+func (group *Group) RemoveUser(aUser *User) (err error) {
+	defer ierrors.Wrap(&err, "group.RemoveUser(%s)", aUser.userName)
+
+	if err := ierrors.NewArgumentTrueErrorArguments(group.tenantId == aUser.tenantId, "Wrong tenant for this group.").GetError(); err != nil {
+		return err
+	}
+
+	group.removeGroupMember(aUser.toGroupMember())
+	return nil
+}
+
+func (group *Group) IsMember(aUser *User, aGroupMemberService *GroupMemberService) (_ bool, err error) {
+	defer ierrors.Wrap(&err, "group.IsMember(%s)", aUser.userName)
+
+	if group.tenantId != aUser.tenantId {
+		return false, nil
+	}
+
+	if group.hasGroupMember(aUser.toGroupMember()) {
+		return aGroupMemberService.ConfirmUser(group, aUser)
+	}
+	return aGroupMemberService.IsUserInNestedGroup(group, aUser)
+}
+
+func (group *Group) IsDirectMember(aUser *User, aGroupMemberService *GroupMemberService) (_ bool, err error) {
+	defer ierrors.Wrap(&err, "group.IsDirectMember(%s)", aUser.userName)
+
+	if group.tenantId != aUser.tenantId || !group.hasGroupMember(aUser.toGroupMember()) {
+		return false, nil
+	}
+	return aGroupMemberService.ConfirmUser(group, aUser)
+}
+
+// HasMember is IsMember for users who are disabled as well.
+func (group *Group) HasMember(aUser *User, aGroupMemberService *GroupMemberService) (_ bool, err error) {
+	defer ierrors.Wrap(&err, "group.HasMember(%s)", aUser.userName)
+
+	if group.tenantId != aUser.tenantId {
+		return false, nil
+	}
+
+	if group.hasGroupMember(aUser.toGroupMember()) {
+		return true, nil
+	}
+	return aGroupMemberService.HasUserInNestedGroup(group, aUser)
+}
+
+// HasDirectMember is IsDirectMember for users who are disabled as well.
+func (group *Group) HasDirectMember(aUser *User) bool {
+	return group.tenantId == aUser.tenantId && group.hasGroupMember(aUser.toGroupMember())
+}
+
+func (group *Group) IsMemberGroup(aGroup *Group, aGroupMemberService *GroupMemberService) (bool, error) {
+	if group.tenantId != aGroup.tenantId {
+		return false, nil
+	}
+	return aGroupMemberService.IsMemberGroup(group, aGroup.toGroupMember())
+}
+
+func (group *Group) hasGroupMember(aGroupMember GroupMember) bool {
+	for _, groupMember := range group.groupMembers {
+		if groupMember.Equals(aGroupMember) {
+			return true
+		}
+	}
+	return false
+}
+
+func (group *Group) addGroupMember(aGroupMember GroupMember) {
+	if !group.hasGroupMember(aGroupMember) {
+		group.groupMembers = append(group.groupMembers, aGroupMember)
+	}
+}
+
+func (group *Group) removeGroupMember(aGroupMember GroupMember) {
+	for i, groupMember := range group.groupMembers {
+		if groupMember.Equals(aGroupMember) {
+			group.groupMembers = append(group.groupMembers[:i], group.groupMembers[i+1:]...)
+			return
+		}
+	}
+}
+
+func (group *Group) toGroupMember() GroupMember {
+	return GroupMember{tenantId: group.tenantId, name: group.name, memberType: GROUP_MEMBER_TYPE_GROUP}
+}
+
+func (group *Group) Equals(otherGroup *Group) bool {
+	return group.tenantId == otherGroup.tenantId && group.name == otherGroup.name
+}
+
+func (group *Group) String() string {
+	return fmt.Sprintf("Group [description=%s, name=%s, tenantId=%s]", group.description, group.name, group.tenantId.Id())
+}
@@ -0,0 +1,115 @@
+package identity
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/domain/model"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/utils"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+var argumentTrueError *ierrors.ArgumentTrueError
+
+func TestNewGroup(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		got, err := NewGroup(*tenantId, "GroupName", "A group description.")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := &Group{tenantId: *tenantId, name: "GroupName", description: "A group description.", groupMembers: []GroupMember{}}
+		if diff := cmp.Diff(want, got, cmp.AllowUnexported(model.ConcurrencySafeEntity{}, Group{}, TenantId{})); diff != "" {
+			t.Errorf("mismatch (-want, +got):\n%s", diff)
+		}
+	})
+	t.Run("fail empty name", func(t *testing.T) {
+		_, err := NewGroup(*tenantId, "", "A group description.")
+		if !errors.As(err, &argumentNotEmptyError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentNotEmptyError))
+		}
+	})
+	t.Run("fail over 100 characters name", func(t *testing.T) {
+		_, err := NewGroup(*tenantId, utils.RandString(101), "A group description.")
+		if !errors.As(err, &argumentLengthError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentLengthError))
+		}
+	})
+	t.Run("fail empty description", func(t *testing.T) {
+		_, err := NewGroup(*tenantId, "GroupName", "")
+		if !errors.As(err, &argumentNotEmptyError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentNotEmptyError))
+		}
+	})
+}
+
+func TestGroupAddUser(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		group := &Group{tenantId: *tenantId, name: "GroupName", description: "A group description."}
+		user := &User{tenantId: *tenantId, userName: userName, password: string(bcryptedPassword), enablement: *enablement}
+
+		if err := group.AddUser(user); err != nil {
+			t.Fatal(err)
+		}
+		if err := group.AddUser(user); err != nil {
+			t.Fatal(err)
+		}
+
+		want := []GroupMember{{tenantId: *tenantId, name: userName, memberType: GROUP_MEMBER_TYPE_USER}}
+		if diff := cmp.Diff(want, group.GroupMembers(), cmp.AllowUnexported(GroupMember{}, TenantId{})); diff != "" {
+			t.Errorf("mismatch (-want, +got):\n%s", diff)
+		}
+	})
+	t.Run("fail wrong tenant", func(t *testing.T) {
+		otherTenantId, err := NewTenantId(uuid.New().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		group := &Group{tenantId: *otherTenantId, name: "GroupName", description: "A group description."}
+		user := &User{tenantId: *tenantId, userName: userName, password: string(bcryptedPassword), enablement: *enablement}
+
+		err = group.AddUser(user)
+		if !errors.As(err, &argumentTrueError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentTrueError))
+		}
+	})
+	t.Run("fail user is not enabled", func(t *testing.T) {
+		disablement, err := NewEnablement(false, enablement.StartDate(), enablement.EndDate())
+		if err != nil {
+			t.Fatal(err)
+		}
+		group := &Group{tenantId: *tenantId, name: "GroupName", description: "A group description."}
+		user := &User{tenantId: *tenantId, userName: userName, password: string(bcryptedPassword), enablement: *disablement}
+
+		err = group.AddUser(user)
+		if !errors.As(err, &argumentTrueError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentTrueError))
+		}
+	})
+}
+
+func TestGroupRemoveUser(t *testing.T) {
+	group := &Group{tenantId: *tenantId, name: "GroupName", description: "A group description."}
+	user := &User{tenantId: *tenantId, userName: userName, password: string(bcryptedPassword), enablement: *enablement}
+	if err := group.AddUser(user); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := group.RemoveUser(user); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := len(group.GroupMembers()); got != 0 {
+		t.Errorf("group members must be empty, but %d", got)
+	}
+}
+
+func TestIsInternalGroup(t *testing.T) {
+	group := &Group{tenantId: *tenantId, name: ROLE_GROUP_PREFIX + uuid.New().String(), description: "Role backing group"}
+	if !group.IsInternalGroup() {
+		t.Errorf("group %v must be internal group", group)
+	}
+}
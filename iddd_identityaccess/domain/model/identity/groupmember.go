@@ -0,0 +1,62 @@
+package identity
+
+import "fmt"
+
+type GroupMemberType int
+
+const (
+	GROUP_MEMBER_TYPE_GROUP GroupMemberType = iota + 1
+	GROUP_MEMBER_TYPE_USER
+)
+
+func (groupMemberType GroupMemberType) IsGroup() bool {
+	return groupMemberType == GROUP_MEMBER_TYPE_GROUP
+}
+
+func (groupMemberType GroupMemberType) IsUser() bool {
+	return groupMemberType == GROUP_MEMBER_TYPE_USER
+}
+
+func (groupMemberType GroupMemberType) String() string {
+	switch groupMemberType {
+	case GROUP_MEMBER_TYPE_GROUP:
+		return "Group"
+	case GROUP_MEMBER_TYPE_USER:
+		return "User"
+	}
+	return "Unknown"
+}
+
+type GroupMember struct {
+	tenantId   TenantId
+	name       string
+	memberType GroupMemberType
+}
+
+func (groupMember GroupMember) TenantId() TenantId {
+	return groupMember.tenantId
+}
+
+func (groupMember GroupMember) Name() string {
+	return groupMember.name
+}
+
+func (groupMember GroupMember) Type() GroupMemberType {
+	return groupMember.memberType
+}
+
+func (groupMember GroupMember) IsGroup() bool {
+	return groupMember.memberType.IsGroup()
+}
+
+func (groupMember GroupMember) IsUser() bool {
+	return groupMember.memberType.IsUser()
+}
+
+func (groupMember GroupMember) Equals(otherGroupMember GroupMember) bool {
+	return groupMember == otherGroupMember
+}
+
+func (groupMember GroupMember) String() string {
+	return fmt.Sprintf("GroupMember [name=%s, tenantId=%s, type=%v]", groupMember.name, groupMember.tenantId.Id(), groupMember.memberType)
+}
@@ -0,0 +1,161 @@
+package identity
+
+import "github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+
+type GroupMemberService struct {
+	userRepository  UserRepository
+	groupRepository GroupRepository
+}
+
+func NewGroupMemberService(aUserRepository UserRepository, aGroupRepository GroupRepository) *GroupMemberService {
+	return &GroupMemberService{userRepository: aUserRepository, groupRepository: aGroupRepository}
+}
+
+func (groupMemberService *GroupMemberService) ConfirmUser(aGroup *Group, aUser *User) (_ bool, err error) {
+	defer ierrors.Wrap(&err, "groupmemberservice.ConfirmUser(%s, %s)", aGroup.name, aUser.userName)
+
+	user, err := groupMemberService.userRepository.UserWithUsername(aGroup.tenantId, aUser.userName)
+	if err != nil {
+		return false, err
+	}
+
+	return user != nil && user.IsEnabled(), nil
+}
+
+func (groupMemberService *GroupMemberService) IsMemberGroup(aGroup *Group, aMemberGroup GroupMember) (_ bool, err error) {
+	defer ierrors.Wrap(&err, "groupmemberservice.IsMemberGroup(%s, %s)", aGroup.name, aMemberGroup.name)
+
+	return groupMemberService.isMemberGroup(aGroup, aMemberGroup, map[GroupMember]bool{aGroup.toGroupMember(): true})
+}
+
+func (groupMemberService *GroupMemberService) isMemberGroup(aGroup *Group, aMemberGroup GroupMember, visited map[GroupMember]bool) (bool, error) {
+	for _, member := range aGroup.groupMembers {
+		if !member.IsGroup() {
+			continue
+		}
+		if member.Equals(aMemberGroup) {
+			return true, nil
+		}
+		if visited[member] {
+			continue
+		}
+		visited[member] = true
+
+		group, err := groupMemberService.groupRepository.GroupNamed(member.tenantId, member.name)
+		if err != nil {
+			return false, err
+		}
+		if group == nil {
+			continue
+		}
+		isMember, err := groupMemberService.isMemberGroup(group, aMemberGroup, visited)
+		if err != nil {
+			return false, err
+		}
+		if isMember {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// IsUserInNestedGroup visits each group once, as stored groups may nest in a cycle.
+func (groupMemberService *GroupMemberService) IsUserInNestedGroup(aGroup *Group, aUser *User) (_ bool, err error) {
+	defer ierrors.Wrap(&err, "groupmemberservice.IsUserInNestedGroup(%s, %s)", aGroup.name, aUser.userName)
+
+	return groupMemberService.isUserInNestedGroup(aGroup, aUser, true, map[GroupMember]bool{aGroup.toGroupMember(): true})
+}
+
+// HasUserInNestedGroup is IsUserInNestedGroup for users who are disabled as well.
+func (groupMemberService *GroupMemberService) HasUserInNestedGroup(aGroup *Group, aUser *User) (_ bool, err error) {
+	defer ierrors.Wrap(&err, "groupmemberservice.HasUserInNestedGroup(%s, %s)", aGroup.name, aUser.userName)
+
+	return groupMemberService.isUserInNestedGroup(aGroup, aUser, false, map[GroupMember]bool{aGroup.toGroupMember(): true})
+}
+
+func (groupMemberService *GroupMemberService) isUserInNestedGroup(aGroup *Group, aUser *User, anEnabledOnly bool, visited map[GroupMember]bool) (bool, error) {
+	for _, member := range aGroup.groupMembers {
+		if !member.IsGroup() || visited[member] {
+			continue
+		}
+		visited[member] = true
+
+		group, err := groupMemberService.groupRepository.GroupNamed(member.tenantId, member.name)
+		if err != nil {
+			return false, err
+		}
+		if group == nil {
+			continue
+		}
+		if group.hasGroupMember(aUser.toGroupMember()) {
+			if !anEnabledOnly {
+				return true, nil
+			}
+			return groupMemberService.ConfirmUser(group, aUser)
+		}
+		isMember, err := groupMemberService.isUserInNestedGroup(group, aUser, anEnabledOnly, visited)
+		if err != nil {
+			return false, err
+		}
+		if isMember {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (groupMemberService *GroupMemberService) MemberUsers(aGroup *Group) (_ []*User, err error) {
+	defer ierrors.Wrap(&err, "groupmemberservice.MemberUsers(%s)", aGroup.name)
+
+	return groupMemberService.memberUsers(aGroup, true)
+}
+
+// AllMemberUsers is MemberUsers with the users who are disabled as well.
+func (groupMemberService *GroupMemberService) AllMemberUsers(aGroup *Group) (_ []*User, err error) {
+	defer ierrors.Wrap(&err, "groupmemberservice.AllMemberUsers(%s)", aGroup.name)
+
+	return groupMemberService.memberUsers(aGroup, false)
+}
+
+func (groupMemberService *GroupMemberService) memberUsers(aGroup *Group, anEnabledOnly bool) ([]*User, error) {
+	users := []*User{}
+	visited := map[GroupMember]bool{aGroup.toGroupMember(): true}
+	if err := groupMemberService.collectMemberUsers(aGroup, anEnabledOnly, visited, &users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (groupMemberService *GroupMemberService) collectMemberUsers(aGroup *Group, anEnabledOnly bool, visited map[GroupMember]bool, users *[]*User) error {
+	for _, member := range aGroup.groupMembers {
+		if visited[member] {
+			continue
+		}
+		visited[member] = true
+
+		if member.IsUser() {
+			user, err := groupMemberService.userRepository.UserWithUsername(member.tenantId, member.name)
+			if err != nil {
+				return err
+			}
+			if user != nil && (!anEnabledOnly || user.IsEnabled()) {
+				*users = append(*users, user)
+			}
+			continue
+		}
+
+		group, err := groupMemberService.groupRepository.GroupNamed(member.tenantId, member.name)
+		if err != nil {
+			return err
+		}
+		if group == nil {
+			continue
+		}
+		if err := groupMemberService.collectMemberUsers(group, anEnabledOnly, visited, users); err != nil {
+			return err
+		}
+	}
+	return nil
+}
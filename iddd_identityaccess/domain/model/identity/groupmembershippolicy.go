@@ -0,0 +1,7 @@
+package identity
+
+// GroupMembershipPolicy vets a new member of a group against the constraints of other models, such as exclusive roles.
+type GroupMembershipPolicy interface {
+	AssertUserMayJoin(aGroup *Group, aUser *User) error
+	AssertGroupMayJoin(aGroup *Group, aMemberGroup *Group) error
+}
@@ -0,0 +1,8 @@
+package identity
+
+type GroupRepository interface {
+	Add(aGroup *Group) error
+	Remove(aGroup *Group) error
+	AllGroups(aTenantId TenantId) ([]*Group, error)
+	GroupNamed(aTenantId TenantId, aName string) (*Group, error)
+}
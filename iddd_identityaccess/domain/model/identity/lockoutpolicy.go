@@ -0,0 +1,50 @@
+package identity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+)
+
+// DEFAULT_MAXIMUM_FAILED_RECOVERY_CODES is lower than most maximums of failed attempts, as each wrong recovery code is compared with every remaining one.
+const DEFAULT_MAXIMUM_FAILED_RECOVERY_CODES = 3
+
+type LockoutPolicy struct {
+	maximumFailedAttempts      int
+	maximumFailedRecoveryCodes int
+	lockoutDuration            time.Duration
+}
+
+func NewLockoutPolicy(aMaximumFailedAttempts int, aLockoutDuration time.Duration) (_ *LockoutPolicy, err error) {
+	defer ierrors.Wrap(&err, "lockoutpolicy.NewLockoutPolicy(%d, %v)", aMaximumFailedAttempts, aLockoutDuration)
+
+	if err := ierrors.NewArgumentTrueErrorArguments(aMaximumFailedAttempts > 0, "The maximum failed attempts must be positive.").GetError(); err != nil {
+		return nil, err
+	}
+	if err := ierrors.NewArgumentTrueErrorArguments(aLockoutDuration > 0, "The lockout duration must be positive.").GetError(); err != nil {
+		return nil, err
+	}
+
+	maximumFailedRecoveryCodes := DEFAULT_MAXIMUM_FAILED_RECOVERY_CODES
+	if aMaximumFailedAttempts < maximumFailedRecoveryCodes {
+		maximumFailedRecoveryCodes = aMaximumFailedAttempts
+	}
+	return &LockoutPolicy{maximumFailedAttempts: aMaximumFailedAttempts, maximumFailedRecoveryCodes: maximumFailedRecoveryCodes, lockoutDuration: aLockoutDuration}, nil
+}
+
+func (lockoutPolicy LockoutPolicy) MaximumFailedAttempts() int {
+	return lockoutPolicy.maximumFailedAttempts
+}
+
+func (lockoutPolicy LockoutPolicy) MaximumFailedRecoveryCodes() int {
+	return lockoutPolicy.maximumFailedRecoveryCodes
+}
+
+func (lockoutPolicy LockoutPolicy) LockoutDuration() time.Duration {
+	return lockoutPolicy.lockoutDuration
+}
+
+func (lockoutPolicy LockoutPolicy) String() string {
+	return fmt.Sprintf("LockoutPolicy [maximumFailedAttempts=%d, maximumFailedRecoveryCodes=%d, lockoutDuration=%v]", lockoutPolicy.maximumFailedAttempts, lockoutPolicy.maximumFailedRecoveryCodes, lockoutPolicy.lockoutDuration)
+}
@@ -0,0 +1,46 @@
+package identity
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewLockoutPolicy(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		got, err := NewLockoutPolicy(5, 15*time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := &LockoutPolicy{maximumFailedAttempts: 5, maximumFailedRecoveryCodes: DEFAULT_MAXIMUM_FAILED_RECOVERY_CODES, lockoutDuration: 15 * time.Minute}
+		if diff := cmp.Diff(want, got, cmp.AllowUnexported(LockoutPolicy{})); diff != "" {
+			t.Errorf("mismatch (-want, +got):\n%s", diff)
+		}
+	})
+	t.Run("success fewer failed attempts than recovery codes", func(t *testing.T) {
+		got, err := NewLockoutPolicy(2, 15*time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got.MaximumFailedRecoveryCodes() != 2 {
+			t.Errorf("got %d, want %d", got.MaximumFailedRecoveryCodes(), 2)
+		}
+	})
+	t.Run("fail maximum failed attempts is not positive", func(t *testing.T) {
+		_, err := NewLockoutPolicy(0, 15*time.Minute)
+		if !errors.As(err, &argumentTrueError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentTrueError))
+		}
+	})
+	t.Run("fail lockout duration is not positive", func(t *testing.T) {
+		_, err := NewLockoutPolicy(5, 0)
+		if !errors.As(err, &argumentTrueError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentTrueError))
+		}
+	})
+}
@@ -0,0 +1,101 @@
+package identity
+
+import "github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+
+const (
+	MESSAGE_KEY_TENANT_NAME_REQUIRED              ierrors.MessageKey = "TENANT_NAME_REQUIRED"
+	MESSAGE_KEY_TENANT_NAME_LENGTH                ierrors.MessageKey = "TENANT_NAME_LENGTH"
+	MESSAGE_KEY_USERNAME_REQUIRED                 ierrors.MessageKey = "USERNAME_REQUIRED"
+	MESSAGE_KEY_USERNAME_LENGTH                   ierrors.MessageKey = "USERNAME_LENGTH"
+	MESSAGE_KEY_USERNAME_CHARACTERS               ierrors.MessageKey = "USERNAME_CHARACTERS"
+	MESSAGE_KEY_USERNAME_RESERVED                 ierrors.MessageKey = "USERNAME_RESERVED"
+	MESSAGE_KEY_USER_ALREADY_EXISTS               ierrors.MessageKey = "USER_ALREADY_EXISTS"
+	MESSAGE_KEY_USERNAME_CONFUSABLE               ierrors.MessageKey = "USERNAME_CONFUSABLE"
+	MESSAGE_KEY_FIRST_NAME_REQUIRED               ierrors.MessageKey = "FIRST_NAME_REQUIRED"
+	MESSAGE_KEY_FIRST_NAME_LENGTH                 ierrors.MessageKey = "FIRST_NAME_LENGTH"
+	MESSAGE_KEY_LAST_NAME_REQUIRED                ierrors.MessageKey = "LAST_NAME_REQUIRED"
+	MESSAGE_KEY_LAST_NAME_LENGTH                  ierrors.MessageKey = "LAST_NAME_LENGTH"
+	MESSAGE_KEY_EMAIL_ADDRESS_REQUIRED            ierrors.MessageKey = "EMAIL_ADDRESS_REQUIRED"
+	MESSAGE_KEY_EMAIL_ADDRESS_LENGTH              ierrors.MessageKey = "EMAIL_ADDRESS_LENGTH"
+	MESSAGE_KEY_EMAIL_ADDRESS_FORMAT              ierrors.MessageKey = "EMAIL_ADDRESS_FORMAT"
+	MESSAGE_KEY_INVITATION_NOT_AVAILABLE          ierrors.MessageKey = "INVITATION_NOT_AVAILABLE"
+	MESSAGE_KEY_CURRENT_PASSWORD_NOT_CONFIRMED    ierrors.MessageKey = "CURRENT_PASSWORD_NOT_CONFIRMED"
+	MESSAGE_KEY_GROUP_NAME_REQUIRED               ierrors.MessageKey = "GROUP_NAME_REQUIRED"
+	MESSAGE_KEY_GROUP_NAME_LENGTH                 ierrors.MessageKey = "GROUP_NAME_LENGTH"
+	MESSAGE_KEY_GROUP_DESCRIPTION_REQUIRED        ierrors.MessageKey = "GROUP_DESCRIPTION_REQUIRED"
+	MESSAGE_KEY_GROUP_DESCRIPTION_LENGTH          ierrors.MessageKey = "GROUP_DESCRIPTION_LENGTH"
+	MESSAGE_KEY_GROUP_RECURSION                   ierrors.MessageKey = "GROUP_RECURSION"
+	MESSAGE_KEY_AUTHENTICATION_CODE_INVALID       ierrors.MessageKey = "AUTHENTICATION_CODE_INVALID"
+	MESSAGE_KEY_AUTHENTICATION_FAILED             ierrors.MessageKey = "AUTHENTICATION_FAILED"
+	MESSAGE_KEY_SECOND_FACTOR_REQUIRED            ierrors.MessageKey = "SECOND_FACTOR_REQUIRED"
+	MESSAGE_KEY_EMAIL_ADDRESS_NOT_VERIFIED        ierrors.MessageKey = "EMAIL_ADDRESS_NOT_VERIFIED"
+	MESSAGE_KEY_SECOND_FACTOR_ENROLLMENT_REQUIRED ierrors.MessageKey = "SECOND_FACTOR_ENROLLMENT_REQUIRED"
+)
+
+// The errors below have codes of their own in place of those of their assertion kinds, and are translated by them.
+const (
+	CODE_TENANT_INACTIVE           ierrors.Code = "TENANT_INACTIVE"
+	CODE_ENABLEMENT_WINDOW_INVALID ierrors.Code = "ENABLEMENT_WINDOW_INVALID"
+	CODE_PASSWORD_EMPTY            ierrors.Code = "PASSWORD_EMPTY"
+	CODE_PASSWORD_WEAK             ierrors.Code = "PASSWORD_WEAK"
+	CODE_PASSWORD_UNCHANGED        ierrors.Code = "PASSWORD_UNCHANGED"
+	CODE_PASSWORD_EQUALS_USERNAME  ierrors.Code = "PASSWORD_EQUALS_USERNAME"
+)
+
+const (
+	CODE_USER_ALREADY_EXISTS               ierrors.Code = "USER_ALREADY_EXISTS"
+	CODE_USERNAME_CONFUSABLE               ierrors.Code = "USERNAME_CONFUSABLE"
+	CODE_AUTHENTICATION_FAILED             ierrors.Code = "AUTHENTICATION_FAILED"
+	CODE_SECOND_FACTOR_REQUIRED            ierrors.Code = "SECOND_FACTOR_REQUIRED"
+	CODE_SECOND_FACTOR_ENROLLMENT_REQUIRED ierrors.Code = "SECOND_FACTOR_ENROLLMENT_REQUIRED"
+	CODE_EMAIL_ADDRESS_NOT_VERIFIED        ierrors.Code = "EMAIL_ADDRESS_NOT_VERIFIED"
+)
+
+// japaneseMessages translates the messages users see when they register or change their own details.
+var japaneseMessages = map[ierrors.MessageKey]string{
+	MESSAGE_KEY_TENANT_NAME_REQUIRED:              "テナント名を入力してください。",
+	MESSAGE_KEY_TENANT_NAME_LENGTH:                "テナント名は{maximum}文字以下で入力してください。",
+	MESSAGE_KEY_USERNAME_REQUIRED:                 "ユーザー名を入力してください。",
+	MESSAGE_KEY_USERNAME_LENGTH:                   "ユーザー名は{minimum}文字以上{maximum}文字以下で入力してください。",
+	MESSAGE_KEY_USERNAME_CHARACTERS:               "ユーザー名は英数字で始め、英数字と . _ - @ + のみで入力してください。",
+	MESSAGE_KEY_USERNAME_RESERVED:                 "このユーザー名は使用できません。",
+	MESSAGE_KEY_USER_ALREADY_EXISTS:               "ユーザーは既に存在します。",
+	MESSAGE_KEY_USERNAME_CONFUSABLE:               "このユーザー名は他のユーザーのものと紛らわしいため使用できません。",
+	MESSAGE_KEY_FIRST_NAME_REQUIRED:               "名を入力してください。",
+	MESSAGE_KEY_FIRST_NAME_LENGTH:                 "名は{maximum}文字以下で入力してください。",
+	MESSAGE_KEY_LAST_NAME_REQUIRED:                "姓を入力してください。",
+	MESSAGE_KEY_LAST_NAME_LENGTH:                  "姓は{maximum}文字以下で入力してください。",
+	MESSAGE_KEY_EMAIL_ADDRESS_REQUIRED:            "メールアドレスを入力してください。",
+	MESSAGE_KEY_EMAIL_ADDRESS_LENGTH:              "メールアドレスは{maximum}文字以下で入力してください。",
+	MESSAGE_KEY_EMAIL_ADDRESS_FORMAT:              "メールアドレスの形式が正しくありません。",
+	MESSAGE_KEY_INVITATION_NOT_AVAILABLE:          "招待は利用できません。",
+	MESSAGE_KEY_CURRENT_PASSWORD_NOT_CONFIRMED:    "現在のパスワードが正しくありません。",
+	MESSAGE_KEY_GROUP_NAME_REQUIRED:               "グループ名を入力してください。",
+	MESSAGE_KEY_GROUP_NAME_LENGTH:                 "グループ名は{maximum}文字以下で入力してください。",
+	MESSAGE_KEY_GROUP_DESCRIPTION_REQUIRED:        "グループの説明を入力してください。",
+	MESSAGE_KEY_GROUP_DESCRIPTION_LENGTH:          "グループの説明は{maximum}文字以下で入力してください。",
+	MESSAGE_KEY_GROUP_RECURSION:                   "グループを循環させることはできません。",
+	MESSAGE_KEY_AUTHENTICATION_CODE_INVALID:       "認証コードが正しくありません。",
+	MESSAGE_KEY_AUTHENTICATION_FAILED:             "認証に失敗しました。",
+	MESSAGE_KEY_SECOND_FACTOR_REQUIRED:            "二要素認証が必要です。",
+	MESSAGE_KEY_EMAIL_ADDRESS_NOT_VERIFIED:        "メールアドレスの確認が必要です。",
+	MESSAGE_KEY_SECOND_FACTOR_ENROLLMENT_REQUIRED: "多要素認証の登録が必要です。",
+}
+
+var japaneseCodes = map[ierrors.Code]string{
+	CODE_TENANT_INACTIVE:           "テナントが有効ではありません。",
+	CODE_ENABLEMENT_WINDOW_INVALID: "有効期間の開始日または終了日が正しくありません。",
+	CODE_PASSWORD_EMPTY:            "パスワードを入力してください。",
+	CODE_PASSWORD_WEAK:             "より強力なパスワードを入力してください。",
+	CODE_PASSWORD_UNCHANGED:        "新しいパスワードが現在のパスワードと同じです。",
+	CODE_PASSWORD_EQUALS_USERNAME:  "ユーザー名と同じパスワードは使用できません。",
+}
+
+func init() {
+	for key, template := range japaneseMessages {
+		ierrors.DEFAULT_CATALOG.SetMessage("ja", key, template)
+	}
+	for code, template := range japaneseCodes {
+		ierrors.DEFAULT_CATALOG.SetCode("ja", code, template)
+	}
+}
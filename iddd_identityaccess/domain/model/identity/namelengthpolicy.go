@@ -0,0 +1,29 @@
+package identity
+
+import "github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+
+// NameLengthPolicy measures tenant names and usernames, and normalizes tenant names as they are stored.
+type NameLengthPolicy struct {
+	tenantName ierrors.LengthPolicy
+	username   ierrors.LengthPolicy
+}
+
+func NewNameLengthPolicy(aTenantName ierrors.LengthPolicy, aUsername ierrors.LengthPolicy) *NameLengthPolicy {
+	return &NameLengthPolicy{tenantName: aTenantName, username: aUsername}
+}
+
+// DefaultNameLengthPolicy counts tenant names in characters as a reader sees them, and usernames in runes after folding compatibility characters.
+func DefaultNameLengthPolicy() *NameLengthPolicy {
+	return NewNameLengthPolicy(
+		ierrors.NewLengthPolicy(ierrors.NORMALIZATION_FORM_NFC, ierrors.LENGTH_MODE_GRAPHEMES),
+		ierrors.NewLengthPolicy(ierrors.NORMALIZATION_FORM_NFKC, ierrors.LENGTH_MODE_RUNES),
+	)
+}
+
+func (nameLengthPolicy NameLengthPolicy) TenantName() ierrors.LengthPolicy {
+	return nameLengthPolicy.tenantName
+}
+
+func (nameLengthPolicy NameLengthPolicy) Username() ierrors.LengthPolicy {
+	return nameLengthPolicy.username
+}
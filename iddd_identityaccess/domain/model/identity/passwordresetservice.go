@@ -0,0 +1,82 @@
+package identity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+)
+
+var ErrPasswordResetTokenInvalid = errors.New("The password reset token is invalid.")
+
+type PasswordResetService struct {
+	passwordResetTokenRepository PasswordResetTokenRepository
+	userRepository               UserRepository
+	lifetime                     time.Duration
+}
+
+func NewPasswordResetService(aPasswordResetTokenRepository PasswordResetTokenRepository, aUserRepository UserRepository, aLifetime time.Duration) *PasswordResetService {
+	return &PasswordResetService{passwordResetTokenRepository: aPasswordResetTokenRepository, userRepository: aUserRepository, lifetime: aLifetime}
+}
+
+// Issue invalidates any token issued to the user before, so only the latest one can be used.
+func (passwordResetService *PasswordResetService) Issue(aUser *User) (_ string, err error) {
+	defer ierrors.Wrap(&err, "passwordresetservice.Issue(%s)", aUser.userName)
+
+	passwordResetTokens, err := passwordResetService.passwordResetTokenRepository.AllPasswordResetTokensOfUser(aUser.tenantId, aUser.userName)
+	if err != nil {
+		return "", err
+	}
+	for _, passwordResetToken := range passwordResetTokens {
+		if passwordResetToken.used {
+			continue
+		}
+		passwordResetToken.use()
+		if err := passwordResetService.passwordResetTokenRepository.Add(passwordResetToken); err != nil {
+			return "", err
+		}
+	}
+
+	passwordResetToken, plainToken, err := newPasswordResetToken(aUser.tenantId, aUser.userName, passwordResetService.lifetime)
+	if err != nil {
+		return "", err
+	}
+	if err := passwordResetService.passwordResetTokenRepository.Add(passwordResetToken); err != nil {
+		return "", err
+	}
+	return plainToken, nil
+}
+
+// ResetPassword leaves the token usable when the new password is rejected, so the user can try another one.
+func (passwordResetService *PasswordResetService) ResetPassword(aPlainToken string, aNewPassword string) (_ *User, err error) {
+	defer ierrors.Wrap(&err, "passwordresetservice.ResetPassword()")
+
+	passwordResetToken, err := passwordResetService.passwordResetTokenRepository.PasswordResetTokenOfHash(hashToken(aPlainToken))
+	if err != nil {
+		return nil, err
+	}
+	if passwordResetToken == nil || !passwordResetToken.IsUsable() {
+		return nil, ErrPasswordResetTokenInvalid
+	}
+
+	user, err := passwordResetService.userRepository.UserWithUsername(passwordResetToken.tenantId, passwordResetToken.username)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrPasswordResetTokenInvalid
+	}
+
+	if err := user.ResetPassword(aNewPassword); err != nil {
+		return nil, err
+	}
+
+	passwordResetToken.use()
+	if err := passwordResetService.passwordResetTokenRepository.Add(passwordResetToken); err != nil {
+		return nil, err
+	}
+	if err := passwordResetService.userRepository.Add(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
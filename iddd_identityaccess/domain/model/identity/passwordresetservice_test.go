@@ -0,0 +1,98 @@
+package identity_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/infrastructure/persistence"
+)
+
+const newPassword = "ASDFG#qwerty!12"
+
+func TestPasswordResetServiceResetPassword(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		passwordResetService := identity.NewPasswordResetService(persistence.NewInMemoryPasswordResetTokenRepository(), fixture.userRepository, time.Hour)
+		plainToken, err := passwordResetService.Issue(fixture.user)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		user, err := passwordResetService.ResetPassword(plainToken, newPassword)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if user != fixture.user {
+			t.Errorf("got %v, want %v", user, fixture.user)
+		}
+		authenticationService := fixture.authenticationService(t, 3, time.Minute)
+		if _, err := authenticationService.Authenticate(fixture.tenant.TenantId(), "zoeusername", newPassword); err != nil {
+			t.Errorf("new password must authenticate: %v", err)
+		}
+		passwordChangedEvents := 0
+		for _, event := range fixture.events {
+			if _, ok := event.(*identity.UserPasswordChanged); ok {
+				passwordChangedEvents++
+			}
+		}
+		if passwordChangedEvents != 1 {
+			t.Errorf("got %d UserPasswordChanged events, want 1", passwordChangedEvents)
+		}
+
+		if _, err := passwordResetService.ResetPassword(plainToken, "ZXCVB#qwerty!34"); !errors.Is(err, identity.ErrPasswordResetTokenInvalid) {
+			t.Errorf("used token: got %v, want %v", err, identity.ErrPasswordResetTokenInvalid)
+		}
+	})
+	t.Run("weak password keeps the token usable", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		passwordResetService := identity.NewPasswordResetService(persistence.NewInMemoryPasswordResetTokenRepository(), fixture.userRepository, time.Hour)
+		plainToken, err := passwordResetService.Issue(fixture.user)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := passwordResetService.ResetPassword(plainToken, "weak"); err == nil {
+			t.Fatalf("weak password must be rejected")
+		}
+		if _, err := passwordResetService.ResetPassword(plainToken, newPassword); err != nil {
+			t.Error(err)
+		}
+	})
+	t.Run("fail superseded token", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		passwordResetService := identity.NewPasswordResetService(persistence.NewInMemoryPasswordResetTokenRepository(), fixture.userRepository, time.Hour)
+		supersededToken, err := passwordResetService.Issue(fixture.user)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := passwordResetService.Issue(fixture.user); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := passwordResetService.ResetPassword(supersededToken, newPassword); !errors.Is(err, identity.ErrPasswordResetTokenInvalid) {
+			t.Errorf("got %v, want %v", err, identity.ErrPasswordResetTokenInvalid)
+		}
+	})
+	t.Run("fail expired token", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		passwordResetService := identity.NewPasswordResetService(persistence.NewInMemoryPasswordResetTokenRepository(), fixture.userRepository, -time.Second)
+		plainToken, err := passwordResetService.Issue(fixture.user)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := passwordResetService.ResetPassword(plainToken, newPassword); !errors.Is(err, identity.ErrPasswordResetTokenInvalid) {
+			t.Errorf("got %v, want %v", err, identity.ErrPasswordResetTokenInvalid)
+		}
+	})
+	t.Run("fail unknown token", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		passwordResetService := identity.NewPasswordResetService(persistence.NewInMemoryPasswordResetTokenRepository(), fixture.userRepository, time.Hour)
+
+		if _, err := passwordResetService.ResetPassword("unknown", newPassword); !errors.Is(err, identity.ErrPasswordResetTokenInvalid) {
+			t.Errorf("got %v, want %v", err, identity.ErrPasswordResetTokenInvalid)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package identity
+
+import (
+	"fmt"
+	"time"
+)
+
+type PasswordResetToken struct {
+	tokenHash string
+	tenantId  TenantId
+	username  string
+	issuedOn  time.Time
+	expiresOn time.Time
+	used      bool
+}
+
+// newPasswordResetToken returns the token together with its plaintext, which is never stored.
+func newPasswordResetToken(aTenantId TenantId, aUsername string, aLifetime time.Duration) (*PasswordResetToken, string, error) {
+	plainToken, err := randomToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	passwordResetToken := &PasswordResetToken{
+		tokenHash: hashToken(plainToken),
+		tenantId:  aTenantId,
+		username:  aUsername,
+		issuedOn:  now,
+		expiresOn: now.Add(aLifetime),
+	}
+	return passwordResetToken, plainToken, nil
+}
+
+func (passwordResetToken *PasswordResetToken) TokenHash() string {
+	return passwordResetToken.tokenHash
+}
+
+func (passwordResetToken *PasswordResetToken) TenantId() TenantId {
+	return passwordResetToken.tenantId
+}
+
+func (passwordResetToken *PasswordResetToken) Username() string {
+	return passwordResetToken.username
+}
+
+func (passwordResetToken *PasswordResetToken) IssuedOn() time.Time {
+	return passwordResetToken.issuedOn
+}
+
+func (passwordResetToken *PasswordResetToken) ExpiresOn() time.Time {
+	return passwordResetToken.expiresOn
+}
+
+func (passwordResetToken *PasswordResetToken) IsUsed() bool {
+	return passwordResetToken.used
+}
+
+func (passwordResetToken *PasswordResetToken) IsExpired() bool {
+	return !time.Now().Before(passwordResetToken.expiresOn)
+}
+
+func (passwordResetToken *PasswordResetToken) IsUsable() bool {
+	return !passwordResetToken.used && !passwordResetToken.IsExpired()
+}
+
+func (passwordResetToken *PasswordResetToken) use() {
+	passwordResetToken.used = true
+}
+
+func (passwordResetToken *PasswordResetToken) String() string {
+	return fmt.Sprintf("PasswordResetToken [tenantId=%s, username=%s, expiresOn=%v, used=%v]", passwordResetToken.tenantId.Id(), passwordResetToken.username, passwordResetToken.expiresOn, passwordResetToken.used)
+}
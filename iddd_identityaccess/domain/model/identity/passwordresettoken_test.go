@@ -0,0 +1,33 @@
+package identity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewPasswordResetToken(t *testing.T) {
+	passwordResetToken, plainToken, err := newPasswordResetToken(*tenantId, userName, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if passwordResetToken.TokenHash() == plainToken || passwordResetToken.TokenHash() != hashToken(plainToken) {
+		t.Errorf("password reset token must store the hash of %s, but %s", plainToken, passwordResetToken.TokenHash())
+	}
+	if !passwordResetToken.IsUsable() {
+		t.Errorf("password reset token %v must be usable", passwordResetToken)
+	}
+
+	passwordResetToken.use()
+	if passwordResetToken.IsUsable() {
+		t.Errorf("used password reset token %v must not be usable", passwordResetToken)
+	}
+
+	expiredPasswordResetToken, _, err := newPasswordResetToken(*tenantId, userName, -time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expiredPasswordResetToken.IsUsable() {
+		t.Errorf("expired password reset token %v must not be usable", expiredPasswordResetToken)
+	}
+}
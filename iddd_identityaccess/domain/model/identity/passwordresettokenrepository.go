@@ -0,0 +1,7 @@
+package identity
+
+type PasswordResetTokenRepository interface {
+	Add(aPasswordResetToken *PasswordResetToken) error
+	PasswordResetTokenOfHash(aTokenHash string) (*PasswordResetToken, error)
+	AllPasswordResetTokensOfUser(aTenantId TenantId, aUsername string) ([]*PasswordResetToken, error)
+}
@@ -0,0 +1,41 @@
+package identity
+
+import "fmt"
+
+type Person struct {
+	tenantId     TenantId
+	name         FullName
+	emailAddress EmailAddress
+}
+
+func NewPerson(aTenantId TenantId, aName FullName, anEmailAddress EmailAddress) *Person {
+	return &Person{tenantId: aTenantId, name: aName, emailAddress: anEmailAddress}
+}
+
+func (person *Person) TenantId() TenantId {
+	return person.tenantId
+}
+
+func (person *Person) Name() FullName {
+	return person.name
+}
+
+func (person *Person) EmailAddress() EmailAddress {
+	return person.emailAddress
+}
+
+func (person *Person) ChangeName(aName FullName) {
+	person.name = aName
+}
+
+// ChangeEmailAddress keeps the verification only when the address itself is unchanged.
+func (person *Person) ChangeEmailAddress(anEmailAddress EmailAddress) {
+	if person.emailAddress.Equals(anEmailAddress) {
+		return
+	}
+	person.emailAddress = anEmailAddress
+}
+
+func (person *Person) String() string {
+	return fmt.Sprintf("Person [tenantId=%s, name=%v, emailAddress=%v]", person.tenantId.Id(), person.name, person.emailAddress)
+}
@@ -0,0 +1,35 @@
+package identity
+
+import "testing"
+
+func TestPersonChangeEmailAddress(t *testing.T) {
+	person := NewPerson(*tenantId, person.Name(), person.EmailAddress())
+	emailAddress, err := NewEmailAddress("zoe.doe@saasovation.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	person.ChangeEmailAddress(*emailAddress)
+
+	if !person.EmailAddress().Equals(*emailAddress) {
+		t.Errorf("got %v, want %v", person.EmailAddress(), emailAddress)
+	}
+}
+
+func TestPersonChangeEmailAddressVerification(t *testing.T) {
+	person := NewPerson(*tenantId, person.Name(), person.EmailAddress().asVerified())
+
+	person.ChangeEmailAddress(EmailAddress{address: person.EmailAddress().Address()})
+	if !person.EmailAddress().IsVerified() {
+		t.Errorf("verification must be kept for the same address")
+	}
+
+	emailAddress, err := NewEmailAddress("zoe.doe@saasovation.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	person.ChangeEmailAddress(*emailAddress)
+	if person.EmailAddress().IsVerified() {
+		t.Errorf("verification must be dropped for another address")
+	}
+}
@@ -0,0 +1,101 @@
+package identity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+type RefreshToken struct {
+	tokenHash string
+	familyId  string
+	tenantId  TenantId
+	username  string
+	issuedOn  time.Time
+	expiresOn time.Time
+	rotated   bool
+	revoked   bool
+}
+
+// newRefreshToken returns the token together with its plaintext, which is never stored.
+func newRefreshToken(aFamilyId string, aTenantId TenantId, aUsername string, aLifetime time.Duration) (*RefreshToken, string, error) {
+	plainToken, err := randomToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	refreshToken := &RefreshToken{
+		tokenHash: hashToken(plainToken),
+		familyId:  aFamilyId,
+		tenantId:  aTenantId,
+		username:  aUsername,
+		issuedOn:  now,
+		expiresOn: now.Add(aLifetime),
+	}
+	return refreshToken, plainToken, nil
+}
+
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashToken(aPlainToken string) string {
+	sum := sha256.Sum256([]byte(aPlainToken))
+	return hex.EncodeToString(sum[:])
+}
+
+func (refreshToken *RefreshToken) TokenHash() string {
+	return refreshToken.tokenHash
+}
+
+func (refreshToken *RefreshToken) FamilyId() string {
+	return refreshToken.familyId
+}
+
+func (refreshToken *RefreshToken) TenantId() TenantId {
+	return refreshToken.tenantId
+}
+
+func (refreshToken *RefreshToken) Username() string {
+	return refreshToken.username
+}
+
+func (refreshToken *RefreshToken) IssuedOn() time.Time {
+	return refreshToken.issuedOn
+}
+
+func (refreshToken *RefreshToken) ExpiresOn() time.Time {
+	return refreshToken.expiresOn
+}
+
+func (refreshToken *RefreshToken) IsRotated() bool {
+	return refreshToken.rotated
+}
+
+func (refreshToken *RefreshToken) IsRevoked() bool {
+	return refreshToken.revoked
+}
+
+func (refreshToken *RefreshToken) IsExpired() bool {
+	return !time.Now().Before(refreshToken.expiresOn)
+}
+
+func (refreshToken *RefreshToken) IsUsable() bool {
+	return !refreshToken.rotated && !refreshToken.revoked && !refreshToken.IsExpired()
+}
+
+func (refreshToken *RefreshToken) rotate() {
+	refreshToken.rotated = true
+}
+
+func (refreshToken *RefreshToken) String() string {
+	return fmt.Sprintf("RefreshToken [familyId=%s, tenantId=%s, username=%s, expiresOn=%v, rotated=%v, revoked=%v]", refreshToken.familyId, refreshToken.tenantId.Id(), refreshToken.username, refreshToken.expiresOn, refreshToken.rotated, refreshToken.revoked)
+}
@@ -0,0 +1,25 @@
+package identity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewRefreshToken(t *testing.T) {
+	refreshToken, plainToken, err := newRefreshToken("family", *tenantId, userName, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if refreshToken.TokenHash() == plainToken || refreshToken.TokenHash() != hashToken(plainToken) {
+		t.Errorf("refresh token must store the hash of %s, but %s", plainToken, refreshToken.TokenHash())
+	}
+	if !refreshToken.IsUsable() {
+		t.Errorf("refresh token %v must be usable", refreshToken)
+	}
+
+	refreshToken.rotate()
+	if refreshToken.IsUsable() {
+		t.Errorf("rotated refresh token %v must not be usable", refreshToken)
+	}
+}
@@ -0,0 +1,13 @@
+package identity
+
+type RefreshTokenRepository interface {
+	Add(aRefreshToken *RefreshToken) error
+	// RotateRefreshToken stores aRotated, the rotated copy of a stored token, together with its successor in one step. It does so only if the stored token is neither rotated nor revoked and its family was not revoked, and reports whether it did.
+	RotateRefreshToken(aRotated *RefreshToken, aSuccessor *RefreshToken) (bool, error)
+	// RevokeRefreshTokenFamily revokes the stored tokens of aFamilyId and marks the family revoked, so that none of its tokens is rotated afterwards.
+	RevokeRefreshTokenFamily(aFamilyId string) error
+	RefreshTokenOfHash(aTokenHash string) (*RefreshToken, error)
+	AllRefreshTokensOfFamily(aFamilyId string) ([]*RefreshToken, error)
+	AllRefreshTokensOfUser(aTenantId TenantId, aUsername string) ([]*RefreshToken, error)
+	AllRefreshTokensOfTenant(aTenantId TenantId) ([]*RefreshToken, error)
+}
@@ -0,0 +1,167 @@
+package identity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/domain/model"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrRefreshTokenInvalid = errors.New("The refresh token is invalid.")
+	ErrRefreshTokenReused  = errors.New("The refresh token was already used; its token family has been revoked.")
+)
+
+type RefreshTokenService struct {
+	tenantRepository       TenantRepository
+	userRepository         UserRepository
+	refreshTokenRepository RefreshTokenRepository
+	lifetime               time.Duration
+}
+
+func NewRefreshTokenService(aTenantRepository TenantRepository, aUserRepository UserRepository, aRefreshTokenRepository RefreshTokenRepository, aLifetime time.Duration) *RefreshTokenService {
+	return &RefreshTokenService{tenantRepository: aTenantRepository, userRepository: aUserRepository, refreshTokenRepository: aRefreshTokenRepository, lifetime: aLifetime}
+}
+
+func (refreshTokenService *RefreshTokenService) Issue(aUser *User) (_ string, err error) {
+	defer ierrors.Wrap(&err, "refreshtokenservice.Issue(%s)", aUser.userName)
+
+	if err := ierrors.NewArgumentTrueErrorArguments(aUser.IsEnabled(), "User is not enabled.").GetError(); err != nil {
+		return "", err
+	}
+	familyId, err := uuid.NewRandom()
+	if err != nil {
+		return "", err
+	}
+
+	refreshToken, plainToken, err := newRefreshToken(familyId.String(), aUser.tenantId, aUser.userName, refreshTokenService.lifetime)
+	if err != nil {
+		return "", err
+	}
+	if err := refreshTokenService.refreshTokenRepository.Add(refreshToken); err != nil {
+		return "", err
+	}
+	return plainToken, nil
+}
+
+// Rotate exchanges a usable refresh token for a new one of the same family. Presenting a token that was already rotated revokes the whole family.
+func (refreshTokenService *RefreshTokenService) Rotate(aPlainToken string) (_ string, _ *RefreshToken, err error) {
+	defer ierrors.Wrap(&err, "refreshtokenservice.Rotate()")
+
+	refreshToken, err := refreshTokenService.refreshTokenRepository.RefreshTokenOfHash(hashToken(aPlainToken))
+	if err != nil {
+		return "", nil, err
+	}
+	if refreshToken == nil || refreshToken.revoked || refreshToken.IsExpired() {
+		return "", nil, ErrRefreshTokenInvalid
+	}
+	if refreshToken.rotated {
+		if err := refreshTokenService.RevokeFamily(refreshToken.familyId); err != nil {
+			return "", nil, err
+		}
+		return "", nil, ErrRefreshTokenReused
+	}
+
+	isHolderActive, err := refreshTokenService.isHolderActive(refreshToken)
+	if err != nil {
+		return "", nil, err
+	}
+	if !isHolderActive {
+		if err := refreshTokenService.RevokeFamily(refreshToken.familyId); err != nil {
+			return "", nil, err
+		}
+		return "", nil, ErrRefreshTokenInvalid
+	}
+
+	rotatedToken := *refreshToken
+	rotatedToken.rotate()
+	successor, plainToken, err := newRefreshToken(refreshToken.familyId, refreshToken.tenantId, refreshToken.username, refreshTokenService.lifetime)
+	if err != nil {
+		return "", nil, err
+	}
+	rotated, err := refreshTokenService.refreshTokenRepository.RotateRefreshToken(&rotatedToken, successor)
+	if err != nil {
+		return "", nil, err
+	}
+	if !rotated {
+		// the token was rotated or its family revoked concurrently
+		if err := refreshTokenService.RevokeFamily(refreshToken.familyId); err != nil {
+			return "", nil, err
+		}
+		return "", nil, ErrRefreshTokenReused
+	}
+	return plainToken, successor, nil
+}
+
+func (refreshTokenService *RefreshTokenService) isHolderActive(aRefreshToken *RefreshToken) (bool, error) {
+	tenant, err := refreshTokenService.tenantRepository.TenantOfId(aRefreshToken.tenantId)
+	if err != nil {
+		return false, err
+	}
+	if tenant == nil || !tenant.IsActive() {
+		return false, nil
+	}
+	user, err := refreshTokenService.userRepository.UserWithUsername(aRefreshToken.tenantId, aRefreshToken.username)
+	if err != nil {
+		return false, err
+	}
+	return user != nil && user.IsEnabled(), nil
+}
+
+func (refreshTokenService *RefreshTokenService) RevokeFamily(aFamilyId string) (err error) {
+	defer ierrors.Wrap(&err, "refreshtokenservice.RevokeFamily(%s)", aFamilyId)
+
+	return refreshTokenService.refreshTokenRepository.RevokeRefreshTokenFamily(aFamilyId)
+}
+
+func (refreshTokenService *RefreshTokenService) RevokeAllOfUser(aTenantId TenantId, aUsername string) (err error) {
+	defer ierrors.Wrap(&err, "refreshtokenservice.RevokeAllOfUser(%v, %s)", aTenantId, aUsername)
+
+	refreshTokens, err := refreshTokenService.refreshTokenRepository.AllRefreshTokensOfUser(aTenantId, aUsername)
+	if err != nil {
+		return err
+	}
+	return refreshTokenService.revokeAll(refreshTokens)
+}
+
+func (refreshTokenService *RefreshTokenService) RevokeAllOfTenant(aTenantId TenantId) (err error) {
+	defer ierrors.Wrap(&err, "refreshtokenservice.RevokeAllOfTenant(%v)", aTenantId)
+
+	refreshTokens, err := refreshTokenService.refreshTokenRepository.AllRefreshTokensOfTenant(aTenantId)
+	if err != nil {
+		return err
+	}
+	return refreshTokenService.revokeAll(refreshTokens)
+}
+
+// revokeAll revokes the families of aRefreshTokens, so that a token rotated from one of them meanwhile is revoked as well.
+func (refreshTokenService *RefreshTokenService) revokeAll(aRefreshTokens []*RefreshToken) error {
+	revokedFamilies := map[string]bool{}
+	for _, refreshToken := range aRefreshTokens {
+		if revokedFamilies[refreshToken.familyId] {
+			continue
+		}
+		revokedFamilies[refreshToken.familyId] = true
+		if err := refreshTokenService.refreshTokenRepository.RevokeRefreshTokenFamily(refreshToken.familyId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HandleEvent cascades revocation when a tenant is deactivated, a user's enablement is disabled or a user's password is changed.
+func (refreshTokenService *RefreshTokenService) HandleEvent(aDomainEvent model.DomainEvent) error {
+	switch event := aDomainEvent.(type) {
+	case *TenantDeactivated:
+		return refreshTokenService.RevokeAllOfTenant(event.tenantId)
+	case *UserEnablementChanged:
+		if !event.enablement.IsEnablementEnabled() {
+			return refreshTokenService.RevokeAllOfUser(event.tenantId, event.username)
+		}
+	case *UserPasswordChanged:
+		return refreshTokenService.RevokeAllOfUser(event.tenantId, event.username)
+	}
+	return nil
+}
@@ -0,0 +1,285 @@
+package identity_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/domain/model"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/infrastructure/persistence"
+)
+
+func (fixture *authenticationFixture) refreshTokenService(aRefreshTokenRepository identity.RefreshTokenRepository, aLifetime time.Duration) *identity.RefreshTokenService {
+	return identity.NewRefreshTokenService(fixture.tenantRepository, fixture.userRepository, aRefreshTokenRepository, aLifetime)
+}
+
+func newRefreshTokenService(t *testing.T, aFixture *authenticationFixture) (*identity.RefreshTokenService, *persistence.InMemoryRefreshTokenRepository) {
+	t.Helper()
+
+	refreshTokenRepository := persistence.NewInMemoryRefreshTokenRepository()
+	return aFixture.refreshTokenService(refreshTokenRepository, time.Hour), refreshTokenRepository
+}
+
+func TestRefreshTokenServiceRotate(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		refreshTokenService, refreshTokenRepository := newRefreshTokenService(t, fixture)
+		plainToken, err := refreshTokenService.Issue(fixture.user)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rotatedToken, refreshToken, err := refreshTokenService.Rotate(plainToken)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rotatedToken == plainToken {
+			t.Errorf("rotated token must differ from %s", plainToken)
+		}
+		if refreshToken.Username() != fixture.user.Username() {
+			t.Errorf("got %s, want %s", refreshToken.Username(), fixture.user.Username())
+		}
+		family, err := refreshTokenRepository.AllRefreshTokensOfFamily(refreshToken.FamilyId())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(family) != 2 || !family[0].IsRotated() || !family[1].IsUsable() {
+			t.Errorf("unexpected token family %v", family)
+		}
+	})
+	t.Run("fail reused token revokes family", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		refreshTokenService, _ := newRefreshTokenService(t, fixture)
+		plainToken, err := refreshTokenService.Issue(fixture.user)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rotatedToken, _, err := refreshTokenService.Rotate(plainToken)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, _, err := refreshTokenService.Rotate(plainToken); !errors.Is(err, identity.ErrRefreshTokenReused) {
+			t.Fatalf("got %v, want %v", err, identity.ErrRefreshTokenReused)
+		}
+		if _, _, err := refreshTokenService.Rotate(rotatedToken); !errors.Is(err, identity.ErrRefreshTokenInvalid) {
+			t.Errorf("got %v, want %v", err, identity.ErrRefreshTokenInvalid)
+		}
+	})
+	t.Run("fail unknown token", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		refreshTokenService, _ := newRefreshTokenService(t, fixture)
+
+		if _, _, err := refreshTokenService.Rotate("unknown"); !errors.Is(err, identity.ErrRefreshTokenInvalid) {
+			t.Errorf("got %v, want %v", err, identity.ErrRefreshTokenInvalid)
+		}
+	})
+	t.Run("fail tenant inactive", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		refreshTokenService, _ := newRefreshTokenService(t, fixture)
+		plainToken, err := refreshTokenService.Issue(fixture.user)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := fixture.tenant.Deactivate(); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, _, err := refreshTokenService.Rotate(plainToken); !errors.Is(err, identity.ErrRefreshTokenInvalid) {
+			t.Errorf("got %v, want %v", err, identity.ErrRefreshTokenInvalid)
+		}
+	})
+	t.Run("fail user disabled", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		refreshTokenService, _ := newRefreshTokenService(t, fixture)
+		plainToken, err := refreshTokenService.Issue(fixture.user)
+		if err != nil {
+			t.Fatal(err)
+		}
+		enablement := fixture.user.Enablement()
+		disablement, err := identity.NewEnablement(false, enablement.StartDate(), enablement.EndDate())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := fixture.user.DefineEnablement(*disablement); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, _, err := refreshTokenService.Rotate(plainToken); !errors.Is(err, identity.ErrRefreshTokenInvalid) {
+			t.Errorf("got %v, want %v", err, identity.ErrRefreshTokenInvalid)
+		}
+	})
+	t.Run("concurrent rotations", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		refreshTokenService, _ := newRefreshTokenService(t, fixture)
+		plainToken, err := refreshTokenService.Issue(fixture.user)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		errs := make(chan error, 8)
+		for i := 0; i < cap(errs); i++ {
+			go func() {
+				_, _, err := refreshTokenService.Rotate(plainToken)
+				errs <- err
+			}()
+		}
+		rotated := 0
+		for i := 0; i < cap(errs); i++ {
+			err := <-errs
+			if err == nil {
+				rotated++
+			} else if !errors.Is(err, identity.ErrRefreshTokenReused) && !errors.Is(err, identity.ErrRefreshTokenInvalid) {
+				t.Errorf("got %v, want %v or %v", err, identity.ErrRefreshTokenReused, identity.ErrRefreshTokenInvalid)
+			}
+		}
+		if rotated != 1 {
+			t.Errorf("got %d rotations, want 1", rotated)
+		}
+	})
+	t.Run("fail family revoked during rotation", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		refreshTokenRepository := revokingRefreshTokenRepository{persistence.NewInMemoryRefreshTokenRepository()}
+		refreshTokenService := fixture.refreshTokenService(refreshTokenRepository, time.Hour)
+		plainToken, err := refreshTokenService.Issue(fixture.user)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, _, err := refreshTokenService.Rotate(plainToken); !errors.Is(err, identity.ErrRefreshTokenReused) {
+			t.Fatalf("got %v, want %v", err, identity.ErrRefreshTokenReused)
+		}
+		ofUser, err := refreshTokenRepository.AllRefreshTokensOfUser(fixture.user.TenantId(), fixture.user.Username())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, refreshToken := range ofUser {
+			if refreshToken.IsUsable() {
+				t.Errorf("got usable %v, want every token revoked", refreshToken)
+			}
+		}
+	})
+	t.Run("fail expired token", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		refreshTokenService := fixture.refreshTokenService(persistence.NewInMemoryRefreshTokenRepository(), time.Nanosecond)
+		plainToken, err := refreshTokenService.Issue(fixture.user)
+		if err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(time.Millisecond)
+
+		if _, _, err := refreshTokenService.Rotate(plainToken); !errors.Is(err, identity.ErrRefreshTokenInvalid) {
+			t.Errorf("got %v, want %v", err, identity.ErrRefreshTokenInvalid)
+		}
+	})
+}
+
+func TestRefreshTokenServiceCascadingRevocation(t *testing.T) {
+	t.Run("tenant deactivated", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		refreshTokenService, _ := newRefreshTokenService(t, fixture)
+		model.DomainEventPublisherInstance().Subscribe(refreshTokenService)
+		plainToken, err := refreshTokenService.Issue(fixture.user)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := fixture.tenant.Deactivate(); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, _, err := refreshTokenService.Rotate(plainToken); !errors.Is(err, identity.ErrRefreshTokenInvalid) {
+			t.Errorf("got %v, want %v", err, identity.ErrRefreshTokenInvalid)
+		}
+	})
+	t.Run("user enablement disabled", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		refreshTokenService, _ := newRefreshTokenService(t, fixture)
+		model.DomainEventPublisherInstance().Subscribe(refreshTokenService)
+		plainToken, err := refreshTokenService.Issue(fixture.user)
+		if err != nil {
+			t.Fatal(err)
+		}
+		enablement := fixture.user.Enablement()
+		disablement, err := identity.NewEnablement(false, enablement.StartDate(), enablement.EndDate())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := fixture.user.DefineEnablement(*disablement); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, _, err := refreshTokenService.Rotate(plainToken); !errors.Is(err, identity.ErrRefreshTokenInvalid) {
+			t.Errorf("got %v, want %v", err, identity.ErrRefreshTokenInvalid)
+		}
+	})
+	t.Run("user password reset", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		refreshTokenService, _ := newRefreshTokenService(t, fixture)
+		model.DomainEventPublisherInstance().Subscribe(refreshTokenService)
+		plainToken, err := refreshTokenService.Issue(fixture.user)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := fixture.user.ResetPassword("ASDFG#qwerty!12"); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, _, err := refreshTokenService.Rotate(plainToken); !errors.Is(err, identity.ErrRefreshTokenInvalid) {
+			t.Errorf("got %v, want %v", err, identity.ErrRefreshTokenInvalid)
+		}
+	})
+	t.Run("user enablement still enabled", func(t *testing.T) {
+		fixture := newAuthenticationFixture(t, nil)
+		refreshTokenService, _ := newRefreshTokenService(t, fixture)
+		model.DomainEventPublisherInstance().Subscribe(refreshTokenService)
+		plainToken, err := refreshTokenService.Issue(fixture.user)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := fixture.user.DefineEnablement(fixture.user.Enablement()); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, _, err := refreshTokenService.Rotate(plainToken); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+// revokingRefreshTokenRepository revokes the family of a token after loading it, as a concurrent reuse of the token would.
+type revokingRefreshTokenRepository struct {
+	*persistence.InMemoryRefreshTokenRepository
+}
+
+func (revokingRefreshTokenRepository revokingRefreshTokenRepository) RefreshTokenOfHash(aTokenHash string) (*identity.RefreshToken, error) {
+	refreshToken, err := revokingRefreshTokenRepository.InMemoryRefreshTokenRepository.RefreshTokenOfHash(aTokenHash)
+	if err != nil || refreshToken == nil {
+		return refreshToken, err
+	}
+	return refreshToken, revokingRefreshTokenRepository.RevokeRefreshTokenFamily(refreshToken.FamilyId())
+}
+
+type failingRefreshTokenRepository struct {
+	*persistence.InMemoryRefreshTokenRepository
+	err error
+}
+
+func (failingRefreshTokenRepository failingRefreshTokenRepository) AllRefreshTokensOfTenant(aTenantId identity.TenantId) ([]*identity.RefreshToken, error) {
+	return nil, failingRefreshTokenRepository.err
+}
+
+func TestRefreshTokenServiceCascadingRevocationFails(t *testing.T) {
+	fixture := newAuthenticationFixture(t, nil)
+	repositoryError := errors.New("unavailable")
+	refreshTokenService := fixture.refreshTokenService(failingRefreshTokenRepository{InMemoryRefreshTokenRepository: persistence.NewInMemoryRefreshTokenRepository(), err: repositoryError}, time.Hour)
+	model.DomainEventPublisherInstance().Subscribe(refreshTokenService)
+
+	if err := fixture.tenant.Deactivate(); !errors.Is(err, repositoryError) {
+		t.Errorf("got %v, want %v", err, repositoryError)
+	}
+}
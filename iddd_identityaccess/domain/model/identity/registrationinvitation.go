@@ -0,0 +1,86 @@
+package identity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+)
+
+type RegistrationInvitation struct {
+	tenantId     TenantId
+	invitationId string
+	description  string
+	startingOn   time.Time
+	until        time.Time
+}
+
+func newRegistrationInvitation(aTenantId TenantId, anInvitationId string, aDescription string) (_ *RegistrationInvitation, err error) {
+	defer ierrors.Wrap(&err, "registrationinvitation.newRegistrationInvitation(%v, %s, %s)", aTenantId, anInvitationId, aDescription)
+
+	if err := ierrors.NewArgumentNotEmptyError(aDescription, "The invitation description is required.").GetError(); err != nil {
+		return nil, ierrors.NewFieldError("description", err)
+	}
+	if err := ierrors.NewArgumentLengthError(aDescription, 1, 100, "The invitation description must be 100 characters or less.").GetError(); err != nil {
+		return nil, ierrors.NewFieldError("description", err)
+	}
+
+	return &RegistrationInvitation{tenantId: aTenantId, invitationId: anInvitationId, description: aDescription}, nil
+}
+
+func (registrationInvitation *RegistrationInvitation) TenantId() TenantId {
+	return registrationInvitation.tenantId
+}
+
+func (registrationInvitation *RegistrationInvitation) InvitationId() string {
+	return registrationInvitation.invitationId
+}
+
+func (registrationInvitation *RegistrationInvitation) Description() string {
+	return registrationInvitation.description
+}
+
+func (registrationInvitation *RegistrationInvitation) StartingOn() time.Time {
+	return registrationInvitation.startingOn
+}
+
+func (registrationInvitation *RegistrationInvitation) Until() time.Time {
+	return registrationInvitation.until
+}
+
+// IsAvailable treats an invitation without a window as open-ended.
+func (registrationInvitation *RegistrationInvitation) IsAvailable() bool {
+	if registrationInvitation.startingOn.IsZero() && registrationInvitation.until.IsZero() {
+		return true
+	}
+	now := time.Now()
+	return !now.Before(registrationInvitation.startingOn) && !now.After(registrationInvitation.until)
+}
+
+func (registrationInvitation *RegistrationInvitation) IsIdentifiedBy(anInvitationIdentifier string) bool {
+	return registrationInvitation.invitationId == anInvitationIdentifier || registrationInvitation.description == anInvitationIdentifier
+}
+
+func (registrationInvitation *RegistrationInvitation) LimitTo(aStartingOn time.Time, anUntil time.Time) (err error) {
+	defer ierrors.Wrap(&err, "registrationinvitation.LimitTo(%v, %v)", aStartingOn, anUntil)
+
+	if err := ierrors.NewArgumentFalseError(aStartingOn.IsZero() || anUntil.IsZero(), "The invitation starting and until dates are required.").GetError(); err != nil {
+		return ierrors.NewFieldError("until", err)
+	}
+	if err := ierrors.NewArgumentFalseError(aStartingOn.After(anUntil), "The invitation must start before it ends.").GetError(); err != nil {
+		return ierrors.NewFieldError("until", err)
+	}
+
+	registrationInvitation.startingOn = aStartingOn
+	registrationInvitation.until = anUntil
+	return nil
+}
+
+func (registrationInvitation *RegistrationInvitation) OpenEnded() {
+	registrationInvitation.startingOn = time.Time{}
+	registrationInvitation.until = time.Time{}
+}
+
+func (registrationInvitation *RegistrationInvitation) String() string {
+	return fmt.Sprintf("RegistrationInvitation [tenantId=%s, invitationId=%s, description=%s, startingOn=%v, until=%v]", registrationInvitation.tenantId.Id(), registrationInvitation.invitationId, registrationInvitation.description, registrationInvitation.startingOn, registrationInvitation.until)
+}
@@ -0,0 +1,71 @@
+package identity
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRegistrationInvitationIsAvailable(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name       string
+		startingOn time.Time
+		until      time.Time
+		want       bool
+	}{
+		{name: "open-ended", want: true},
+		{name: "within window", startingOn: now.Add(-time.Hour), until: now.Add(time.Hour), want: true},
+		{name: "not yet started", startingOn: now.Add(time.Hour), until: now.Add(2 * time.Hour), want: false},
+		{name: "already ended", startingOn: now.Add(-2 * time.Hour), until: now.Add(-time.Hour), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registrationInvitation := &RegistrationInvitation{tenantId: *tenantId, invitationId: "id", description: "description", startingOn: tt.startingOn, until: tt.until}
+
+			if got := registrationInvitation.IsAvailable(); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistrationInvitationLimitTo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		registrationInvitation := &RegistrationInvitation{tenantId: *tenantId, invitationId: "id", description: "description"}
+		startingOn := time.Now().Add(time.Hour)
+
+		if err := registrationInvitation.LimitTo(startingOn, startingOn.Add(time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+		if registrationInvitation.IsAvailable() {
+			t.Errorf("invitation %v must not be available yet", registrationInvitation)
+		}
+
+		registrationInvitation.OpenEnded()
+		if !registrationInvitation.IsAvailable() {
+			t.Errorf("invitation %v must be available", registrationInvitation)
+		}
+	})
+	t.Run("fail until before starting on", func(t *testing.T) {
+		registrationInvitation := &RegistrationInvitation{tenantId: *tenantId, invitationId: "id", description: "description"}
+		startingOn := time.Now()
+
+		err := registrationInvitation.LimitTo(startingOn, startingOn.Add(-time.Hour))
+		if !errors.As(err, &argumentFalseError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentFalseError))
+		}
+	})
+}
+
+func TestRegistrationInvitationIsIdentifiedBy(t *testing.T) {
+	registrationInvitation := &RegistrationInvitation{tenantId: *tenantId, invitationId: "id", description: "description"}
+
+	if !registrationInvitation.IsIdentifiedBy("id") || !registrationInvitation.IsIdentifiedBy("description") {
+		t.Errorf("invitation %v must be identified by its id and description", registrationInvitation)
+	}
+	if registrationInvitation.IsIdentifiedBy("other") {
+		t.Errorf("invitation %v must not be identified by other", registrationInvitation)
+	}
+}
@@ -0,0 +1,331 @@
+package identity
+
+import (
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/totp"
+)
+
+// The states are what repositories persist the aggregates as and reconstitute them from; they are mapped to a storage format outside this package.
+
+type TenantState struct {
+	TenantId                          TenantId
+	Name                              string
+	Active                            bool
+	MultiFactorAuthenticationRequired bool
+	VerifiedEmailAddressRequired      bool
+	RegistrationInvitations           []RegistrationInvitationState
+	ProvisioningTokenHash             string
+}
+
+type RegistrationInvitationState struct {
+	InvitationId string
+	Description  string
+	StartingOn   time.Time
+	Until        time.Time
+}
+
+func (tenant *Tenant) State() TenantState {
+	state := TenantState{
+		TenantId:                          tenant.tenantId,
+		Name:                              tenant.name,
+		Active:                            tenant.active,
+		MultiFactorAuthenticationRequired: tenant.multiFactorAuthenticationRequired,
+		VerifiedEmailAddressRequired:      tenant.verifiedEmailAddressRequired,
+		ProvisioningTokenHash:             tenant.provisioningTokenHash,
+	}
+	for _, registrationInvitation := range tenant.registrationInvitations {
+		state.RegistrationInvitations = append(state.RegistrationInvitations, RegistrationInvitationState{
+			InvitationId: registrationInvitation.invitationId,
+			Description:  registrationInvitation.description,
+			StartingOn:   registrationInvitation.startingOn,
+			Until:        registrationInvitation.until,
+		})
+	}
+	return state
+}
+
+func NewTenantFromState(aState TenantState) *Tenant {
+	tenant := &Tenant{
+		tenantId:                          aState.TenantId,
+		name:                              aState.Name,
+		active:                            aState.Active,
+		multiFactorAuthenticationRequired: aState.MultiFactorAuthenticationRequired,
+		verifiedEmailAddressRequired:      aState.VerifiedEmailAddressRequired,
+		provisioningTokenHash:             aState.ProvisioningTokenHash,
+	}
+	for _, registrationInvitation := range aState.RegistrationInvitations {
+		tenant.registrationInvitations = append(tenant.registrationInvitations, &RegistrationInvitation{
+			tenantId:     aState.TenantId,
+			invitationId: registrationInvitation.InvitationId,
+			description:  registrationInvitation.Description,
+			startingOn:   registrationInvitation.StartingOn,
+			until:        registrationInvitation.Until,
+		})
+	}
+	return tenant
+}
+
+type UserState struct {
+	ConcurrencyVersion        int
+	TenantId                  TenantId
+	Username                  string
+	Password                  string
+	Enablement                EnablementState
+	Person                    PersonState
+	Attributes                map[string]string
+	FailedAuthenticationCount int
+	FailedRecoveryCodeCount   int
+	LockedOutUntil            time.Time
+	TotpAuthenticator         *TotpAuthenticatorState
+	WebAuthnCredentials       []WebAuthnCredentialState
+}
+
+type EnablementState struct {
+	Enabled   bool
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+type PersonState struct {
+	FirstName            string
+	LastName             string
+	EmailAddress         string
+	EmailAddressVerified bool
+}
+
+type TotpAuthenticatorState struct {
+	Secret        []byte
+	Algorithm     totp.Algorithm
+	Confirmed     bool
+	LastUsedStep  int64
+	RecoveryCodes []string
+}
+
+type WebAuthnCredentialState struct {
+	CredentialId []byte
+	PublicKey    []byte
+	SignCount    uint32
+	Transports   []string
+	RegisteredOn time.Time
+	LastUsedOn   time.Time
+}
+
+func (user *User) State() UserState {
+	state := UserState{
+		ConcurrencyVersion: user.ConcurrencyVersion(),
+		TenantId:           user.tenantId,
+		Username:           user.userName,
+		Password:           user.password,
+		Enablement: EnablementState{
+			Enabled:   user.enablement.enabled,
+			StartDate: user.enablement.startDate,
+			EndDate:   user.enablement.endDate,
+		},
+		Person: PersonState{
+			FirstName:            user.person.name.firstName,
+			LastName:             user.person.name.lastName,
+			EmailAddress:         user.person.emailAddress.address,
+			EmailAddressVerified: user.person.emailAddress.verified,
+		},
+		Attributes:                user.Attributes(),
+		FailedAuthenticationCount: user.failedAuthenticationCount,
+		FailedRecoveryCodeCount:   user.failedRecoveryCodeCount,
+		LockedOutUntil:            user.lockedOutUntil,
+	}
+	if totpAuthenticator := user.totpAuthenticator; totpAuthenticator != nil {
+		state.TotpAuthenticator = &TotpAuthenticatorState{
+			Secret:        append([]byte{}, totpAuthenticator.secret...),
+			Algorithm:     totpAuthenticator.algorithm,
+			Confirmed:     totpAuthenticator.confirmed,
+			LastUsedStep:  totpAuthenticator.lastUsedStep,
+			RecoveryCodes: append([]string{}, totpAuthenticator.recoveryCodes...),
+		}
+	}
+	for _, webAuthnCredential := range user.webAuthnCredentials {
+		state.WebAuthnCredentials = append(state.WebAuthnCredentials, WebAuthnCredentialState{
+			CredentialId: webAuthnCredential.credentialId,
+			PublicKey:    webAuthnCredential.publicKey,
+			SignCount:    webAuthnCredential.signCount,
+			Transports:   webAuthnCredential.transports,
+			RegisteredOn: webAuthnCredential.registeredOn,
+			LastUsedOn:   webAuthnCredential.lastUsedOn,
+		})
+	}
+	return state
+}
+
+func NewUserFromState(aState UserState) *User {
+	user := &User{
+		tenantId: aState.TenantId,
+		userName: aState.Username,
+		password: aState.Password,
+		enablement: Enablement{
+			enabled:   aState.Enablement.Enabled,
+			startDate: aState.Enablement.StartDate,
+			endDate:   aState.Enablement.EndDate,
+		},
+		person: Person{
+			tenantId:     aState.TenantId,
+			name:         FullName{firstName: aState.Person.FirstName, lastName: aState.Person.LastName},
+			emailAddress: EmailAddress{address: aState.Person.EmailAddress, verified: aState.Person.EmailAddressVerified},
+		},
+		attributes:                aState.Attributes,
+		failedAuthenticationCount: aState.FailedAuthenticationCount,
+		failedRecoveryCodeCount:   aState.FailedRecoveryCodeCount,
+		lockedOutUntil:            aState.LockedOutUntil,
+	}
+	user.SetConcurrencyVersion(aState.ConcurrencyVersion)
+	if totpAuthenticator := aState.TotpAuthenticator; totpAuthenticator != nil {
+		user.totpAuthenticator = &TotpAuthenticator{
+			secret:        totpAuthenticator.Secret,
+			algorithm:     totpAuthenticator.Algorithm,
+			confirmed:     totpAuthenticator.Confirmed,
+			lastUsedStep:  totpAuthenticator.LastUsedStep,
+			recoveryCodes: totpAuthenticator.RecoveryCodes,
+		}
+	}
+	for _, webAuthnCredential := range aState.WebAuthnCredentials {
+		user.webAuthnCredentials = append(user.webAuthnCredentials, &WebAuthnCredential{
+			credentialId: webAuthnCredential.CredentialId,
+			publicKey:    webAuthnCredential.PublicKey,
+			signCount:    webAuthnCredential.SignCount,
+			transports:   webAuthnCredential.Transports,
+			registeredOn: webAuthnCredential.RegisteredOn,
+			lastUsedOn:   webAuthnCredential.LastUsedOn,
+		})
+	}
+	return user
+}
+
+type GroupState struct {
+	ConcurrencyVersion int
+	TenantId           TenantId
+	Name               string
+	Description        string
+	GroupMembers       []GroupMemberState
+}
+
+type GroupMemberState struct {
+	Name string
+	Type GroupMemberType
+}
+
+func (group *Group) State() GroupState {
+	state := GroupState{ConcurrencyVersion: group.ConcurrencyVersion(), TenantId: group.tenantId, Name: group.name, Description: group.description}
+	for _, groupMember := range group.groupMembers {
+		state.GroupMembers = append(state.GroupMembers, GroupMemberState{Name: groupMember.name, Type: groupMember.memberType})
+	}
+	return state
+}
+
+func NewGroupFromState(aState GroupState) *Group {
+	group := &Group{tenantId: aState.TenantId, name: aState.Name, description: aState.Description}
+	group.SetConcurrencyVersion(aState.ConcurrencyVersion)
+	for _, groupMember := range aState.GroupMembers {
+		group.groupMembers = append(group.groupMembers, GroupMember{tenantId: aState.TenantId, name: groupMember.Name, memberType: groupMember.Type})
+	}
+	return group
+}
+
+type RefreshTokenState struct {
+	TokenHash string
+	FamilyId  string
+	TenantId  TenantId
+	Username  string
+	IssuedOn  time.Time
+	ExpiresOn time.Time
+	Rotated   bool
+	Revoked   bool
+}
+
+func (refreshToken *RefreshToken) State() RefreshTokenState {
+	return RefreshTokenState{
+		TokenHash: refreshToken.tokenHash,
+		FamilyId:  refreshToken.familyId,
+		TenantId:  refreshToken.tenantId,
+		Username:  refreshToken.username,
+		IssuedOn:  refreshToken.issuedOn,
+		ExpiresOn: refreshToken.expiresOn,
+		Rotated:   refreshToken.rotated,
+		Revoked:   refreshToken.revoked,
+	}
+}
+
+func NewRefreshTokenFromState(aState RefreshTokenState) *RefreshToken {
+	return &RefreshToken{
+		tokenHash: aState.TokenHash,
+		familyId:  aState.FamilyId,
+		tenantId:  aState.TenantId,
+		username:  aState.Username,
+		issuedOn:  aState.IssuedOn,
+		expiresOn: aState.ExpiresOn,
+		rotated:   aState.Rotated,
+		revoked:   aState.Revoked,
+	}
+}
+
+type PasswordResetTokenState struct {
+	TokenHash string
+	TenantId  TenantId
+	Username  string
+	IssuedOn  time.Time
+	ExpiresOn time.Time
+	Used      bool
+}
+
+func (passwordResetToken *PasswordResetToken) State() PasswordResetTokenState {
+	return PasswordResetTokenState{
+		TokenHash: passwordResetToken.tokenHash,
+		TenantId:  passwordResetToken.tenantId,
+		Username:  passwordResetToken.username,
+		IssuedOn:  passwordResetToken.issuedOn,
+		ExpiresOn: passwordResetToken.expiresOn,
+		Used:      passwordResetToken.used,
+	}
+}
+
+func NewPasswordResetTokenFromState(aState PasswordResetTokenState) *PasswordResetToken {
+	return &PasswordResetToken{
+		tokenHash: aState.TokenHash,
+		tenantId:  aState.TenantId,
+		username:  aState.Username,
+		issuedOn:  aState.IssuedOn,
+		expiresOn: aState.ExpiresOn,
+		used:      aState.Used,
+	}
+}
+
+type EmailVerificationTokenState struct {
+	TokenHash    string
+	TenantId     TenantId
+	Username     string
+	EmailAddress string
+	IssuedOn     time.Time
+	ExpiresOn    time.Time
+	Used         bool
+}
+
+func (emailVerificationToken *EmailVerificationToken) State() EmailVerificationTokenState {
+	return EmailVerificationTokenState{
+		TokenHash:    emailVerificationToken.tokenHash,
+		TenantId:     emailVerificationToken.tenantId,
+		Username:     emailVerificationToken.username,
+		EmailAddress: emailVerificationToken.emailAddress,
+		IssuedOn:     emailVerificationToken.issuedOn,
+		ExpiresOn:    emailVerificationToken.expiresOn,
+		Used:         emailVerificationToken.used,
+	}
+}
+
+func NewEmailVerificationTokenFromState(aState EmailVerificationTokenState) *EmailVerificationToken {
+	return &EmailVerificationToken{
+		tokenHash:    aState.TokenHash,
+		tenantId:     aState.TenantId,
+		username:     aState.Username,
+		emailAddress: aState.EmailAddress,
+		issuedOn:     aState.IssuedOn,
+		expiresOn:    aState.ExpiresOn,
+		used:         aState.Used,
+	}
+}
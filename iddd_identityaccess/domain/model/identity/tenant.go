@@ -1,50 +1,223 @@
 package identity
 
 import (
+	"crypto/subtle"
 	"reflect"
 
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/domain/model"
 	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/google/uuid"
 )
 
+var ErrTenantInactive = ierrors.New(CODE_TENANT_INACTIVE, "", "Tenant is not active.")
+
 type Tenant struct {
 	tenantId TenantId
 	name     string
 	active   bool
+
+	multiFactorAuthenticationRequired bool
+	verifiedEmailAddressRequired      bool
+
+	registrationInvitations []*RegistrationInvitation
+
+	provisioningTokenHash string
 }
 
-func NewTenant(aTenantId TenantId, aName string, anActive bool) (_ *Tenant, err error) {
+func NewTenant(aTenantId TenantId, aName string, anActive bool, aNameLengthPolicy NameLengthPolicy) (_ *Tenant, err error) {
 	defer ierrors.Wrap(&err, "tenant.NewTenant(%v, %v, %v)", aTenantId, aName, anActive)
-	// validate name
-	if err := ierrors.NewArgumentNotEmptyError(aName, "The tenant name is required.").GetError(); err != nil {
-		return nil, err
+	if err := validateTenantName(aName, aNameLengthPolicy); err != nil {
+		return nil, ierrors.NewFieldError("name", err)
 	}
-	if err := ierrors.NewArgumentLengthError(aName, 1, 100, "The tenant description must be 100 characters or less.").GetError(); err != nil {
-		return nil, err
+
+	return &Tenant{tenantId: aTenantId, name: aNameLengthPolicy.tenantName.Normalize(aName), active: anActive}, nil
+}
+
+func validateTenantName(aName string, aNameLengthPolicy NameLengthPolicy) error {
+	if err := ierrors.NewArgumentNotEmptyError(aName, "The tenant name is required.").WithKey(MESSAGE_KEY_TENANT_NAME_REQUIRED).GetError(); err != nil {
+		return err
 	}
+	if err := ierrors.NewAssertionConcern("name").WithKey(MESSAGE_KEY_TENANT_NAME_LENGTH).ArgumentLengthWithPolicy(aName, 1, 100, aNameLengthPolicy.tenantName, "The tenant description must be 100 characters or less."); err != nil {
+		return err
+	}
+	return nil
+}
 
-	return &Tenant{tenantId: aTenantId, name: aName, active: anActive}, nil
+func (tenant *Tenant) TenantId() TenantId {
+	return tenant.tenantId
+}
+
+func (tenant *Tenant) Name() string {
+	return tenant.name
+}
+
+func (tenant *Tenant) ChangeName(aName string, aNameLengthPolicy NameLengthPolicy) (err error) {
+	defer ierrors.Wrap(&err, "tenant.ChangeName(%s)", aName)
+
+	if err := validateTenantName(aName, aNameLengthPolicy); err != nil {
+		return ierrors.NewFieldError("name", err)
+	}
+
+	tenant.name = aNameLengthPolicy.tenantName.Normalize(aName)
+	return nil
 }
 
 func (tenant *Tenant) setActive(active bool) {
 	tenant.active = active
 }
 
-func (tenant *Tenant) Activate() {
-	if !tenant.IsActive() {
-		tenant.setActive(true)
+func (tenant *Tenant) Activate() error {
+	if tenant.IsActive() {
+		return nil
 	}
+	tenant.setActive(true)
+	return model.DomainEventPublisherInstance().Publish(NewTenantActivated(tenant.tenantId))
 }
 
-func (tenant *Tenant) Deactivate() {
-	if tenant.IsActive() {
-		tenant.setActive(false)
+func (tenant *Tenant) Deactivate() error {
+	if !tenant.IsActive() {
+		return nil
 	}
+	tenant.setActive(false)
+	return model.DomainEventPublisherInstance().Publish(NewTenantDeactivated(tenant.tenantId))
 }
 
 func (tenant *Tenant) IsActive() bool {
 	return tenant.active
 }
 
+func (tenant *Tenant) RequireMultiFactorAuthentication() {
+	tenant.multiFactorAuthenticationRequired = true
+}
+
+func (tenant *Tenant) WaiveMultiFactorAuthentication() {
+	tenant.multiFactorAuthenticationRequired = false
+}
+
+func (tenant *Tenant) IsMultiFactorAuthenticationRequired() bool {
+	return tenant.multiFactorAuthenticationRequired
+}
+
 func (tenant *Tenant) Equals(otherTenant Tenant) bool {
 	return reflect.DeepEqual(tenant.tenantId, otherTenant.tenantId)
 }
+
+func (tenant *Tenant) RequireVerifiedEmailAddress() {
+	tenant.verifiedEmailAddressRequired = true
+}
+
+func (tenant *Tenant) WaiveVerifiedEmailAddress() {
+	tenant.verifiedEmailAddressRequired = false
+}
+
+func (tenant *Tenant) IsVerifiedEmailAddressRequired() bool {
+	return tenant.verifiedEmailAddressRequired
+}
+
+func (tenant *Tenant) OfferRegistrationInvitation(aDescription string) (_ *RegistrationInvitation, err error) {
+	defer ierrors.Wrap(&err, "tenant.OfferRegistrationInvitation(%s)", aDescription)
+
+	if err := tenant.assertActive(); err != nil {
+		return nil, err
+	}
+	if err := ierrors.NewArgumentFalseError(tenant.registrationInvitation(aDescription) != nil, "Invitation already exists.").GetError(); err != nil {
+		return nil, ierrors.NewFieldError("description", err)
+	}
+
+	registrationInvitation, err := newRegistrationInvitation(tenant.tenantId, uuid.New().String(), aDescription)
+	if err != nil {
+		return nil, err
+	}
+	tenant.registrationInvitations = append(tenant.registrationInvitations, registrationInvitation)
+	return registrationInvitation, nil
+}
+
+func (tenant *Tenant) AllAvailableRegistrationInvitations() []RegistrationInvitation {
+	registrationInvitations := []RegistrationInvitation{}
+	for _, registrationInvitation := range tenant.registrationInvitations {
+		if registrationInvitation.IsAvailable() {
+			registrationInvitations = append(registrationInvitations, *registrationInvitation)
+		}
+	}
+	return registrationInvitations
+}
+
+func (tenant *Tenant) IsRegistrationAvailableThrough(anInvitationIdentifier string) bool {
+	if !tenant.IsActive() {
+		return false
+	}
+	registrationInvitation := tenant.registrationInvitation(anInvitationIdentifier)
+	return registrationInvitation != nil && registrationInvitation.IsAvailable()
+}
+
+func (tenant *Tenant) WithdrawInvitation(anInvitationIdentifier string) {
+	for i, registrationInvitation := range tenant.registrationInvitations {
+		if registrationInvitation.IsIdentifiedBy(anInvitationIdentifier) {
+			tenant.registrationInvitations = append(tenant.registrationInvitations[:i], tenant.registrationInvitations[i+1:]...)
+			return
+		}
+	}
+}
+
+func (tenant *Tenant) RegisterUser(anInvitationIdentifier string, aUsername string, aPassword string, anEnablement Enablement, aPerson Person, aNameLengthPolicy NameLengthPolicy) (_ *User, err error) {
+	defer ierrors.Wrap(&err, "tenant.RegisterUser(%s, %s)", anInvitationIdentifier, aUsername)
+
+	if err := tenant.assertActive(); err != nil {
+		return nil, err
+	}
+	if err := ierrors.NewArgumentTrueErrorArguments(tenant.IsRegistrationAvailableThrough(anInvitationIdentifier), "The invitation is not available.").WithKey(MESSAGE_KEY_INVITATION_NOT_AVAILABLE).GetError(); err != nil {
+		return nil, ierrors.NewFieldError("invitationId", err)
+	}
+
+	return NewUser(tenant.tenantId, aUsername, aPassword, anEnablement, aPerson, aNameLengthPolicy)
+}
+
+// ProvisionUser registers a user pushed by the tenant's own identity provider, whose provisioning token stands in for an invitation.
+func (tenant *Tenant) ProvisionUser(aUsername string, aPassword string, anEnablement Enablement, aPerson Person, aNameLengthPolicy NameLengthPolicy) (_ *User, err error) {
+	defer ierrors.Wrap(&err, "tenant.ProvisionUser(%s)", aUsername)
+
+	if err := tenant.assertActive(); err != nil {
+		return nil, err
+	}
+
+	return NewUser(tenant.tenantId, aUsername, aPassword, anEnablement, aPerson, aNameLengthPolicy)
+}
+
+// IssueProvisioningToken replaces any token issued before and returns its plaintext, which is never stored.
+func (tenant *Tenant) IssueProvisioningToken() (_ string, err error) {
+	defer ierrors.Wrap(&err, "tenant.IssueProvisioningToken()")
+
+	if err := tenant.assertActive(); err != nil {
+		return "", err
+	}
+	plainToken, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+	tenant.provisioningTokenHash = hashToken(plainToken)
+	return plainToken, nil
+}
+
+func (tenant *Tenant) RevokeProvisioningToken() {
+	tenant.provisioningTokenHash = ""
+}
+
+func (tenant *Tenant) IsProvisioningTokenValid(aPlainToken string) bool {
+	if !tenant.IsActive() || tenant.provisioningTokenHash == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hashToken(aPlainToken)), []byte(tenant.provisioningTokenHash)) == 1
+}
+
+func (tenant *Tenant) registrationInvitation(anInvitationIdentifier string) *RegistrationInvitation {
+	for _, registrationInvitation := range tenant.registrationInvitations {
+		if registrationInvitation.IsIdentifiedBy(anInvitationIdentifier) {
+			return registrationInvitation
+		}
+	}
+	return nil
+}
+
+func (tenant *Tenant) assertActive() error {
+	return ierrors.NewAssertionConcern("").WithCause(ErrTenantInactive).StateTrue(tenant.IsActive(), ErrTenantInactive.Error())
+}
@@ -3,8 +3,11 @@ package identity
 import (
 	"errors"
 	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/domain/model"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
 	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/utils"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
@@ -20,7 +23,7 @@ func TestNewTenant(t *testing.T) {
 		}
 
 		name := "TenantName"
-		got, err := NewTenant(*tenantId, name, true)
+		got, err := NewTenant(*tenantId, name, true, *DefaultNameLengthPolicy())
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -31,6 +34,16 @@ func TestNewTenant(t *testing.T) {
 			t.Errorf("mismatch (-want, +got):\n%s", diff)
 		}
 	})
+	t.Run("success 100 Japanese characters name", func(t *testing.T) {
+		tenantId, err := NewTenantId(uuidV4)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := NewTenant(*tenantId, strings.Repeat("山", 100), true, *DefaultNameLengthPolicy()); err != nil {
+			t.Error(err)
+		}
+	})
 	t.Run("fail empty name", func(t *testing.T) {
 		tenantId, err := NewTenantId(uuidV4)
 		if err != nil {
@@ -39,7 +52,7 @@ func TestNewTenant(t *testing.T) {
 
 		name := ""
 		active := true
-		_, err = NewTenant(*tenantId, name, active)
+		_, err = NewTenant(*tenantId, name, active, *DefaultNameLengthPolicy())
 		if !errors.As(err, &argumentNotEmptyError) {
 			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentNotEmptyError))
 		}
@@ -52,9 +65,35 @@ func TestNewTenant(t *testing.T) {
 
 		name := utils.RandString(101)
 		active := true
-		_, err = NewTenant(*tenantId, name, active)
-		if !errors.As(err, &argumentLengthError) {
-			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentLengthError))
+		_, err = NewTenant(*tenantId, name, active, *DefaultNameLengthPolicy())
+		if !errors.As(err, &assertionError) || assertionError.Kind() != ierrors.ASSERTION_KIND_ARGUMENT_LENGTH {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&assertionError))
+		}
+	})
+	t.Run("success decomposed name stored composed", func(t *testing.T) {
+		tenantId, err := NewTenantId(uuidV4)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := NewTenant(*tenantId, "\u30cf\u309a\u30fc\u30c6\u30a3", true, *DefaultNameLengthPolicy())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "\u30d1\u30fc\u30c6\u30a3"; got.Name() != want {
+			t.Errorf("got %q, want %q", got.Name(), want)
+		}
+	})
+	t.Run("fail 100 Japanese characters name measured in bytes", func(t *testing.T) {
+		tenantId, err := NewTenantId(uuidV4)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		nameLengthPolicy := NewNameLengthPolicy(ierrors.NewLengthPolicy(ierrors.NORMALIZATION_FORM_NONE, ierrors.LENGTH_MODE_BYTES), ierrors.LengthPolicy{})
+		_, err = NewTenant(*tenantId, strings.Repeat("山", 100), true, *nameLengthPolicy)
+		if !errors.As(err, &assertionError) || assertionError.Kind() != ierrors.ASSERTION_KIND_ARGUMENT_LENGTH {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&assertionError))
 		}
 	})
 }
@@ -69,7 +108,9 @@ func TestDeactivate(t *testing.T) {
 		acitve := true
 		tenant := Tenant{tenantId: *tenantId, name: name, active: acitve}
 
-		tenant.Deactivate()
+		if err := tenant.Deactivate(); err != nil {
+			t.Fatal(err)
+		}
 
 		if tenant.active {
 			t.Errorf("tenant.activa must be false, but true")
@@ -84,7 +125,9 @@ func TestDeactivate(t *testing.T) {
 		acitve := false
 		tenant := Tenant{tenantId: *tenantId, name: name, active: acitve}
 
-		tenant.Deactivate()
+		if err := tenant.Deactivate(); err != nil {
+			t.Fatal(err)
+		}
 
 		if tenant.active {
 			t.Errorf("tenant.activa must be false, but true")
@@ -102,7 +145,9 @@ func TestActivate(t *testing.T) {
 		acitve := false
 		tenant := Tenant{tenantId: *tenantId, name: name, active: acitve}
 
-		tenant.Activate()
+		if err := tenant.Activate(); err != nil {
+			t.Fatal(err)
+		}
 
 		if !tenant.active {
 			t.Errorf("tenant.activa must be true, but false")
@@ -117,7 +162,9 @@ func TestActivate(t *testing.T) {
 		acitve := true
 		tenant := Tenant{tenantId: *tenantId, name: name, active: acitve}
 
-		tenant.Activate()
+		if err := tenant.Activate(); err != nil {
+			t.Fatal(err)
+		}
 
 		if !tenant.active {
 			t.Errorf("tenant.activa must be true, but false")
@@ -163,3 +210,230 @@ func TestTenantEquals(t *testing.T) {
 		}
 	})
 }
+
+func TestTenantDeactivatePublishesEvent(t *testing.T) {
+	tenantId, err := NewTenantId(uuidV4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenant := Tenant{tenantId: *tenantId, name: "TenantName", active: true}
+	handled := []model.DomainEvent{}
+	model.DomainEventPublisherInstance().Reset()
+	model.DomainEventPublisherInstance().Subscribe(model.DomainEventSubscriberFunc(func(aDomainEvent model.DomainEvent) error {
+		handled = append(handled, aDomainEvent)
+		return nil
+	}))
+	defer model.DomainEventPublisherInstance().Reset()
+
+	if err := tenant.Deactivate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tenant.Deactivate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tenant.Activate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(handled) != 2 {
+		t.Fatalf("got %d events, want 2", len(handled))
+	}
+	if _, ok := handled[0].(*TenantDeactivated); !ok {
+		t.Errorf("got %T, want %T", handled[0], &TenantDeactivated{})
+	}
+	if _, ok := handled[1].(*TenantActivated); !ok {
+		t.Errorf("got %T, want %T", handled[1], &TenantActivated{})
+	}
+}
+
+func TestRequireMultiFactorAuthentication(t *testing.T) {
+	tenantId, err := NewTenantId(uuidV4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenant := Tenant{tenantId: *tenantId, name: "TenantName", active: true}
+
+	tenant.RequireMultiFactorAuthentication()
+	if !tenant.IsMultiFactorAuthenticationRequired() {
+		t.Errorf("multi-factor authentication must be required")
+	}
+
+	tenant.WaiveMultiFactorAuthentication()
+	if tenant.IsMultiFactorAuthenticationRequired() {
+		t.Errorf("multi-factor authentication must not be required")
+	}
+}
+
+func TestRequireVerifiedEmailAddress(t *testing.T) {
+	tenantId, err := NewTenantId(uuidV4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenant := Tenant{tenantId: *tenantId, name: "TenantName", active: true}
+
+	tenant.RequireVerifiedEmailAddress()
+	if !tenant.IsVerifiedEmailAddressRequired() {
+		t.Errorf("verified email address must be required")
+	}
+
+	tenant.WaiveVerifiedEmailAddress()
+	if tenant.IsVerifiedEmailAddressRequired() {
+		t.Errorf("verified email address must not be required")
+	}
+}
+
+func TestTenantChangeName(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tenant := Tenant{tenantId: *tenantId, name: "TenantName", active: true}
+
+		if err := tenant.ChangeName("OtherName", *DefaultNameLengthPolicy()); err != nil {
+			t.Fatal(err)
+		}
+		if tenant.Name() != "OtherName" {
+			t.Errorf("got %s, want %s", tenant.Name(), "OtherName")
+		}
+	})
+	t.Run("fail empty name", func(t *testing.T) {
+		tenant := Tenant{tenantId: *tenantId, name: "TenantName", active: true}
+
+		err := tenant.ChangeName("", *DefaultNameLengthPolicy())
+		if !errors.As(err, &argumentNotEmptyError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentNotEmptyError))
+		}
+		if tenant.Name() != "TenantName" {
+			t.Errorf("got %s, want %s", tenant.Name(), "TenantName")
+		}
+	})
+}
+
+func TestTenantOfferRegistrationInvitation(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tenant := Tenant{tenantId: *tenantId, name: "TenantName", active: true}
+
+		registrationInvitation, err := tenant.OfferRegistrationInvitation("Today-and-Tomorrow")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !tenant.IsRegistrationAvailableThrough(registrationInvitation.InvitationId()) {
+			t.Errorf("registration must be available through %v", registrationInvitation)
+		}
+		if got := len(tenant.AllAvailableRegistrationInvitations()); got != 1 {
+			t.Errorf("got %d invitations, want 1", got)
+		}
+
+		tenant.WithdrawInvitation("Today-and-Tomorrow")
+		if tenant.IsRegistrationAvailableThrough(registrationInvitation.InvitationId()) {
+			t.Errorf("registration must not be available through withdrawn %v", registrationInvitation)
+		}
+	})
+	t.Run("fail duplicate description", func(t *testing.T) {
+		tenant := Tenant{tenantId: *tenantId, name: "TenantName", active: true}
+		if _, err := tenant.OfferRegistrationInvitation("Today-and-Tomorrow"); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := tenant.OfferRegistrationInvitation("Today-and-Tomorrow")
+		if !errors.As(err, &argumentFalseError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentFalseError))
+		}
+	})
+	t.Run("fail inactive tenant", func(t *testing.T) {
+		tenant := Tenant{tenantId: *tenantId, name: "TenantName", active: false}
+
+		_, err := tenant.OfferRegistrationInvitation("Today-and-Tomorrow")
+		if !errors.As(err, &assertionError) || assertionError.Kind() != ierrors.ASSERTION_KIND_STATE_TRUE {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&assertionError))
+		}
+		if !errors.Is(err, ErrTenantInactive) {
+			t.Errorf("got %v, want it to match %v", err, ErrTenantInactive)
+		}
+	})
+}
+
+func TestTenantRegisterUser(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tenant := Tenant{tenantId: *tenantId, name: "TenantName", active: true}
+		registrationInvitation, err := tenant.OfferRegistrationInvitation("Today-and-Tomorrow")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		user, err := tenant.RegisterUser(registrationInvitation.InvitationId(), userName, password, *enablement, *person, *DefaultNameLengthPolicy())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if user.Username() != userName {
+			t.Errorf("got %s, want %s", user.Username(), userName)
+		}
+	})
+	t.Run("fail unknown invitation", func(t *testing.T) {
+		tenant := Tenant{tenantId: *tenantId, name: "TenantName", active: true}
+
+		_, err := tenant.RegisterUser("unknown", userName, password, *enablement, *person, *DefaultNameLengthPolicy())
+		var fieldError *ierrors.FieldError
+		if !errors.As(err, &fieldError) || fieldError.Field() != "invitationId" {
+			t.Errorf("got %v, want field error of invitationId", err)
+		}
+	})
+}
+
+func TestTenantProvisionUser(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tenant := Tenant{tenantId: *tenantId, name: "TenantName", active: true}
+
+		user, err := tenant.ProvisionUser(userName, password, *enablement, *person, *DefaultNameLengthPolicy())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if user.Username() != userName {
+			t.Errorf("got %s, want %s", user.Username(), userName)
+		}
+	})
+	t.Run("fail inactive", func(t *testing.T) {
+		tenant := Tenant{tenantId: *tenantId, name: "TenantName", active: false}
+
+		if _, err := tenant.ProvisionUser(userName, password, *enablement, *person, *DefaultNameLengthPolicy()); err == nil {
+			t.Errorf("an inactive tenant must not provision users")
+		}
+	})
+}
+
+func TestTenantProvisioningToken(t *testing.T) {
+	tenant := Tenant{tenantId: *tenantId, name: "TenantName", active: true}
+	if tenant.IsProvisioningTokenValid("") {
+		t.Errorf("no token must be valid before one is issued")
+	}
+
+	plainToken, err := tenant.IssueProvisioningToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tenant.IsProvisioningTokenValid(plainToken) {
+		t.Errorf("the issued token must be valid")
+	}
+	if tenant.provisioningTokenHash == plainToken {
+		t.Errorf("the plaintext must not be stored")
+	}
+
+	reissued, err := tenant.IssueProvisioningToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tenant.IsProvisioningTokenValid(plainToken) || !tenant.IsProvisioningTokenValid(reissued) {
+		t.Errorf("only the latest token must be valid")
+	}
+
+	if err := tenant.Deactivate(); err != nil {
+		t.Fatal(err)
+	}
+	if tenant.IsProvisioningTokenValid(reissued) {
+		t.Errorf("no token must be valid while the tenant is inactive")
+	}
+	if err := tenant.Activate(); err != nil {
+		t.Fatal(err)
+	}
+	tenant.RevokeProvisioningToken()
+	if tenant.IsProvisioningTokenValid(reissued) {
+		t.Errorf("a revoked token must not be valid")
+	}
+}
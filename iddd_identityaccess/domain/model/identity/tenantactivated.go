@@ -0,0 +1,25 @@
+package identity
+
+import "time"
+
+type TenantActivated struct {
+	eventVersion int
+	occurredOn   time.Time
+	tenantId     TenantId
+}
+
+func NewTenantActivated(aTenantId TenantId) *TenantActivated {
+	return &TenantActivated{eventVersion: 1, occurredOn: time.Now(), tenantId: aTenantId}
+}
+
+func (tenantActivated *TenantActivated) EventVersion() int {
+	return tenantActivated.eventVersion
+}
+
+func (tenantActivated *TenantActivated) OccurredOn() time.Time {
+	return tenantActivated.occurredOn
+}
+
+func (tenantActivated *TenantActivated) TenantId() TenantId {
+	return tenantActivated.tenantId
+}
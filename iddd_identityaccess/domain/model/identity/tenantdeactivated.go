@@ -0,0 +1,25 @@
+package identity
+
+import "time"
+
+type TenantDeactivated struct {
+	eventVersion int
+	occurredOn   time.Time
+	tenantId     TenantId
+}
+
+func NewTenantDeactivated(aTenantId TenantId) *TenantDeactivated {
+	return &TenantDeactivated{eventVersion: 1, occurredOn: time.Now(), tenantId: aTenantId}
+}
+
+func (tenantDeactivated *TenantDeactivated) EventVersion() int {
+	return tenantDeactivated.eventVersion
+}
+
+func (tenantDeactivated *TenantDeactivated) OccurredOn() time.Time {
+	return tenantDeactivated.occurredOn
+}
+
+func (tenantDeactivated *TenantDeactivated) TenantId() TenantId {
+	return tenantDeactivated.tenantId
+}
@@ -1,31 +1,46 @@
 package identity
 
 import (
-	"fmt"
-
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/domain/model"
 	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
-	"github.com/google/uuid"
 )
 
-type TenantId struct {
-	id string
+// TENANT_ID_UUID_VERSIONS are the versions NewTenantId accepts. Set it at startup; an empty list accepts any UUID but the nil UUID.
+var TENANT_ID_UUID_VERSIONS = model.UUIDVersions{4, 7}
+
+// TENANT_ID_GENERATOR issues the identities of new tenants in the order they are provisioned.
+var TENANT_ID_GENERATOR model.IdentityStrategy = model.NewUUIDv7Generator()
+
+type tenantIdKind struct{}
+
+func (kind tenantIdKind) IdentityName() string {
+	return "TenantId"
+}
+
+func (kind tenantIdKind) ValidateIdentity(anId string) error {
+	return TENANT_ID_UUID_VERSIONS.Validate(anId)
 }
 
+type TenantId = model.Identity[tenantIdKind]
+
 func NewTenantId(uu string) (_ *TenantId, err error) {
 	defer ierrors.Wrap(&err, "tenantid.NewTenantId(%s)", uu)
 
-	// setId
-	if _, err := uuid.Parse(uu); err != nil {
+	tenantId, err := model.NewIdentity[tenantIdKind](uu)
+	if err != nil {
 		return nil, err
 	}
 
-	return &TenantId{id: uu}, nil
+	return &tenantId, nil
 }
 
-func (tenantId *TenantId) Equals(otherTeanntId *TenantId) bool {
-	return tenantId.id == otherTeanntId.id
-}
+func NextTenantId() (_ *TenantId, err error) {
+	defer ierrors.Wrap(&err, "tenantid.NextTenantId()")
+
+	tenantId, err := model.NextIdentity[tenantIdKind](TENANT_ID_GENERATOR)
+	if err != nil {
+		return nil, err
+	}
 
-func (tenantId *TenantId) String() string {
-	return fmt.Sprintf("TenantId [id= %s ]", tenantId.id)
+	return &tenantId, nil
 }
@@ -1,10 +1,12 @@
 package identity
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
-	"github.com/google/go-cmp/cmp"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/domain/model"
+	"github.com/google/uuid"
 )
 
 func TestNewTenantId(t *testing.T) {
@@ -14,10 +16,8 @@ func TestNewTenantId(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		want := &TenantId{id: uuidV4}
-
-		if diff := cmp.Diff(want, got, cmp.AllowUnexported(TenantId{})); diff != "" {
-			t.Errorf("mismatch (-want, +got):\n%s", diff)
+		if got.Id() != uuidV4 {
+			t.Errorf("got %s, want %s", got.Id(), uuidV4)
 		}
 	})
 	t.Run("fail invalid UUID length", func(t *testing.T) {
@@ -33,6 +33,21 @@ func TestNewTenantId(t *testing.T) {
 			t.Errorf("tenantId should be equal to nil, but %v", tenatId)
 		}
 	})
+	t.Run("success v7", func(t *testing.T) {
+		if _, err := NewTenantId(uuid.Must(uuid.NewV7()).String()); err != nil {
+			t.Error(err)
+		}
+	})
+	t.Run("fail nil UUID", func(t *testing.T) {
+		if _, err := NewTenantId(uuid.Nil.String()); !errors.Is(err, model.ErrNilUUID) {
+			t.Errorf("got %v, want %v", err, model.ErrNilUUID)
+		}
+	})
+	t.Run("fail v1", func(t *testing.T) {
+		if _, err := NewTenantId("6ba7b810-9dad-11d1-80b4-00c04fd430c8"); !errors.Is(err, model.ErrUUIDVersionNotAccepted) {
+			t.Errorf("got %v, want %v", err, model.ErrUUIDVersionNotAccepted)
+		}
+	})
 }
 
 func TestTenantIdEquals(t *testing.T) {
@@ -41,9 +56,40 @@ func TestTenantIdEquals(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	otherTenantId := &TenantId{id: uuidV4}
+	otherTenantId, err := NewTenantId(uuidV4)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if !tenantId.Equals(otherTenantId) {
 		t.Errorf("tenantId: %v must be euqal to otherTenantId %v", tenantId, otherTenantId)
 	}
 }
+
+func TestNextTenantId(t *testing.T) {
+	tenantId, err := NextTenantId()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherTenantId, err := NextTenantId()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if uuId := uuid.MustParse(tenantId.Id()); uuId.Version() != 7 {
+		t.Errorf("got version %v of %v, want 7", uuId.Version(), tenantId)
+	}
+	if otherTenantId.Id() <= tenantId.Id() {
+		t.Errorf("got %v after %v, want it to sort after", otherTenantId, tenantId)
+	}
+}
+
+func TestNextTenantIdUUIDVersions(t *testing.T) {
+	generator := TENANT_ID_GENERATOR
+	t.Cleanup(func() { TENANT_ID_GENERATOR = generator })
+	TENANT_ID_GENERATOR = model.IdentityStrategyFunc(func() (string, error) { return "6ba7b810-9dad-11d1-80b4-00c04fd430c8", nil })
+
+	if _, err := NextTenantId(); !errors.Is(err, model.ErrUUIDVersionNotAccepted) {
+		t.Errorf("got %v, want %v", err, model.ErrUUIDVersionNotAccepted)
+	}
+}
@@ -0,0 +1,8 @@
+package identity
+
+type TenantRepository interface {
+	Add(aTenant *Tenant) error
+	Remove(aTenant *Tenant) error
+	AllTenants() ([]*Tenant, error)
+	TenantOfId(aTenantId TenantId) (*Tenant, error)
+}
@@ -0,0 +1,17 @@
+package identity
+
+import "github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+
+// TenantValidator reports every invalid value a tenant would be provisioned with, under the field names NewTenant uses.
+type TenantValidator struct {
+	name             string
+	nameLengthPolicy NameLengthPolicy
+}
+
+func NewTenantValidator(aName string, aNameLengthPolicy NameLengthPolicy) *TenantValidator {
+	return &TenantValidator{name: aName, nameLengthPolicy: aNameLengthPolicy}
+}
+
+func (tenantValidator *TenantValidator) Validate(aValidationNotification *ierrors.ValidationNotification) {
+	aValidationNotification.Add("name", validateTenantName(tenantValidator.name, tenantValidator.nameLengthPolicy))
+}
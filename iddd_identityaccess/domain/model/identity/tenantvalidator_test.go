@@ -0,0 +1,42 @@
+package identity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+)
+
+func TestTenantValidator(t *testing.T) {
+	tests := []struct {
+		name       string
+		tenantName string
+		wantFields []string
+	}{
+		{name: "success", tenantName: "TenantName"},
+		{name: "fail empty name", tenantName: "", wantFields: []string{"name"}},
+		{name: "fail long name", tenantName: strings.Repeat("a", 101), wantFields: []string{"name"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validationNotification := ierrors.NewValidationNotification()
+			NewTenantValidator(tt.tenantName, *DefaultNameLengthPolicy()).Validate(validationNotification)
+
+			assertValidationFields(t, validationNotification, tt.wantFields)
+		})
+	}
+}
+
+func assertValidationFields(t *testing.T, aValidationNotification *ierrors.ValidationNotification, aWantFields []string) {
+	t.Helper()
+
+	errs := aValidationNotification.Errors()
+	if len(errs) != len(aWantFields) {
+		t.Errorf("got %v, want errors of %v", errs, aWantFields)
+	}
+	for _, field := range aWantFields {
+		if len(errs[field]) == 0 {
+			t.Errorf("got %v, want an error of %s", errs, field)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package identity
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"strings"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/totp"
+)
+
+const (
+	TOTP_ALLOWED_SKEW_STEPS = 1
+	RECOVERY_CODE_COUNT     = 8
+	recoveryCodeLength      = 16
+)
+
+const recoveryCodeAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"
+
+var recoveryCodeEncoding = base32.NewEncoding(recoveryCodeAlphabet).WithPadding(base32.NoPadding)
+
+type TotpAuthenticator struct {
+	secret        []byte
+	algorithm     totp.Algorithm
+	confirmed     bool
+	lastUsedStep  int64
+	recoveryCodes []string
+}
+
+type TotpEnrollment struct {
+	secret          string
+	provisioningUri string
+	recoveryCodes   []string
+}
+
+func (totpEnrollment *TotpEnrollment) Secret() string {
+	return totpEnrollment.secret
+}
+
+func (totpEnrollment *TotpEnrollment) ProvisioningUri() string {
+	return totpEnrollment.provisioningUri
+}
+
+func (totpEnrollment *TotpEnrollment) RecoveryCodes() []string {
+	recoveryCodes := make([]string, len(totpEnrollment.recoveryCodes))
+	copy(recoveryCodes, totpEnrollment.recoveryCodes)
+	return recoveryCodes
+}
+
+func newTotpAuthenticator(anAlgorithm totp.Algorithm) (_ *TotpAuthenticator, _ []string, err error) {
+	defer ierrors.Wrap(&err, "totpauthenticator.newTotpAuthenticator(%v)", anAlgorithm)
+
+	secret, err := totp.GenerateSecret(anAlgorithm)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := totp.NewTotp(secret, anAlgorithm, totp.DEFAULT_DIGITS, totp.DEFAULT_PERIOD); err != nil {
+		return nil, nil, err
+	}
+
+	plainRecoveryCodes := make([]string, RECOVERY_CODE_COUNT)
+	recoveryCodes := make([]string, RECOVERY_CODE_COUNT)
+	for i := range plainRecoveryCodes {
+		plainRecoveryCodes[i], err = randomRecoveryCode()
+		if err != nil {
+			return nil, nil, err
+		}
+		recoveryCodes[i], err = encryptionService.EncryptedValue(normalizeRecoveryCode(plainRecoveryCodes[i]))
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return &TotpAuthenticator{secret: secret, algorithm: anAlgorithm, recoveryCodes: recoveryCodes}, plainRecoveryCodes, nil
+}
+
+func (totpAuthenticator *TotpAuthenticator) Algorithm() totp.Algorithm {
+	return totpAuthenticator.algorithm
+}
+
+func (totpAuthenticator *TotpAuthenticator) IsConfirmed() bool {
+	return totpAuthenticator.confirmed
+}
+
+func (totpAuthenticator *TotpAuthenticator) RemainingRecoveryCodes() int {
+	return len(totpAuthenticator.recoveryCodes)
+}
+
+func (totpAuthenticator *TotpAuthenticator) totp() *totp.Totp {
+	// the secret and algorithm were validated on enrollment
+	generator, _ := totp.NewTotp(totpAuthenticator.secret, totpAuthenticator.algorithm, totp.DEFAULT_DIGITS, totp.DEFAULT_PERIOD)
+	return generator
+}
+
+func (totpAuthenticator *TotpAuthenticator) verify(aCode string, aTime time.Time) bool {
+	if !isRecoveryCode(aCode) {
+		return totpAuthenticator.verifyCode(aCode, aTime)
+	}
+	return totpAuthenticator.redeemRecoveryCode(aCode)
+}
+
+func isRecoveryCode(aCode string) bool {
+	return len(aCode) != totp.DEFAULT_DIGITS
+}
+
+func (totpAuthenticator *TotpAuthenticator) verifyCode(aCode string, aTime time.Time) bool {
+	step, ok := totpAuthenticator.totp().Validate(aCode, aTime, TOTP_ALLOWED_SKEW_STEPS)
+	if !ok || step <= totpAuthenticator.lastUsedStep {
+		return false
+	}
+	totpAuthenticator.lastUsedStep = step
+	return true
+}
+
+func (totpAuthenticator *TotpAuthenticator) redeemRecoveryCode(aRecoveryCode string) bool {
+	recoveryCode := normalizeRecoveryCode(aRecoveryCode)
+	// a code that cannot be one of them is not compared with any
+	if len(recoveryCode) != recoveryCodeLength || strings.Trim(strings.ToUpper(recoveryCode), recoveryCodeAlphabet) != "" {
+		return false
+	}
+	for i, encryptedRecoveryCode := range totpAuthenticator.recoveryCodes {
+		if encryptionService.IsEncryptedValueEqual(recoveryCode, encryptedRecoveryCode) {
+			totpAuthenticator.recoveryCodes = append(totpAuthenticator.recoveryCodes[:i], totpAuthenticator.recoveryCodes[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func randomRecoveryCode() (string, error) {
+	random := make([]byte, 10)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	code := strings.ToLower(recoveryCodeEncoding.EncodeToString(random))
+	return code[:8] + "-" + code[8:], nil
+}
+
+func normalizeRecoveryCode(aRecoveryCode string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(aRecoveryCode))
+}
@@ -0,0 +1,115 @@
+package identity
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/totp"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// useMinimumCostEncryption keeps the recovery code hashing of these tests fast.
+func useMinimumCostEncryption(t *testing.T) {
+	t.Helper()
+
+	original := encryptionService
+	encryptionService = NewBcryptEncryptionService(bcrypt.MinCost)
+	t.Cleanup(func() { encryptionService = original })
+}
+
+func totpCodeAt(t *testing.T, aTotpEnrollment *TotpEnrollment, anAlgorithm totp.Algorithm, aTime time.Time) string {
+	t.Helper()
+
+	secret, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(aTotpEnrollment.Secret())
+	if err != nil {
+		t.Fatal(err)
+	}
+	generator, err := totp.NewTotp(secret, anAlgorithm, totp.DEFAULT_DIGITS, totp.DEFAULT_PERIOD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return generator.CodeAt(aTime)
+}
+
+func TestNewTotpAuthenticator(t *testing.T) {
+	useMinimumCostEncryption(t)
+
+	t.Run("success", func(t *testing.T) {
+		totpAuthenticator, recoveryCodes, err := newTotpAuthenticator(totp.ALGORITHM_SHA256)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(totpAuthenticator.secret) != totp.ALGORITHM_SHA256.SecretSize() {
+			t.Errorf("got %d bytes secret, want %d", len(totpAuthenticator.secret), totp.ALGORITHM_SHA256.SecretSize())
+		}
+		if len(recoveryCodes) != RECOVERY_CODE_COUNT {
+			t.Errorf("got %d recovery codes, want %d", len(recoveryCodes), RECOVERY_CODE_COUNT)
+		}
+		for i, recoveryCode := range recoveryCodes {
+			if totpAuthenticator.recoveryCodes[i] == recoveryCode {
+				t.Errorf("recovery code %s must be stored encrypted", recoveryCode)
+			}
+		}
+		if totpAuthenticator.IsConfirmed() {
+			t.Errorf("new authenticator must not be confirmed")
+		}
+	})
+	t.Run("fail unknown algorithm", func(t *testing.T) {
+		if _, _, err := newTotpAuthenticator(totp.Algorithm(0)); err == nil {
+			t.Errorf("unknown algorithm must be rejected")
+		}
+	})
+}
+
+func TestTotpAuthenticatorVerify(t *testing.T) {
+	useMinimumCostEncryption(t)
+
+	totpAuthenticator, recoveryCodes, err := newTotpAuthenticator(totp.ALGORITHM_SHA1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	generator := totpAuthenticator.totp()
+	now := time.Now()
+
+	t.Run("code within skew", func(t *testing.T) {
+		if !totpAuthenticator.verify(generator.CodeAt(now.Add(-totp.DEFAULT_PERIOD)), now) {
+			t.Errorf("code of previous step must be accepted")
+		}
+	})
+	t.Run("replayed code", func(t *testing.T) {
+		if totpAuthenticator.verify(generator.CodeAt(now.Add(-totp.DEFAULT_PERIOD)), now) {
+			t.Errorf("code already used must be rejected")
+		}
+	})
+	t.Run("code outside skew", func(t *testing.T) {
+		if totpAuthenticator.verify(generator.CodeAt(now.Add(3*totp.DEFAULT_PERIOD)), now) {
+			t.Errorf("code outside skew must be rejected")
+		}
+	})
+	t.Run("recovery code is single-use", func(t *testing.T) {
+		if !totpAuthenticator.verify(recoveryCodes[0], now) {
+			t.Fatalf("recovery code must be accepted")
+		}
+		if totpAuthenticator.verify(recoveryCodes[0], now) {
+			t.Errorf("recovery code must not be accepted twice")
+		}
+		if got := totpAuthenticator.RemainingRecoveryCodes(); got != RECOVERY_CODE_COUNT-1 {
+			t.Errorf("got %d remaining recovery codes, want %d", got, RECOVERY_CODE_COUNT-1)
+		}
+	})
+	t.Run("malformed recovery code", func(t *testing.T) {
+		if totpAuthenticator.verify(recoveryCodes[2]+"0", now) || totpAuthenticator.verify("0000000000000000", now) {
+			t.Errorf("malformed recovery code must be rejected")
+		}
+		if got := totpAuthenticator.RemainingRecoveryCodes(); got != RECOVERY_CODE_COUNT-1 {
+			t.Errorf("got %d remaining recovery codes, want %d", got, RECOVERY_CODE_COUNT-1)
+		}
+	})
+	t.Run("recovery code ignores case and separator", func(t *testing.T) {
+		if !totpAuthenticator.verify(normalizeRecoveryCode(recoveryCodes[1])+" ", now) {
+			t.Errorf("normalized recovery code must be accepted")
+		}
+	})
+}
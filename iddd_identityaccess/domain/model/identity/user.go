@@ -1,88 +1,411 @@
 package identity
 
 import (
-	"fmt"
+	"bytes"
+	"time"
 	"unicode"
 
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/domain/model"
 	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
-	"golang.org/x/crypto/bcrypt"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/totp"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/webauthn"
 )
 
 type User struct {
+	model.ConcurrencySafeEntity
+
 	tenantId   TenantId
 	userName   string
 	password   string
 	enablement Enablement
+	person     Person
+	attributes map[string]string
+
+	failedAuthenticationCount int
+	failedRecoveryCodeCount   int
+	lockedOutUntil            time.Time
+
+	totpAuthenticator   *TotpAuthenticator
+	webAuthnCredentials []*WebAuthnCredential
 }
 
 const STRONG_THRESHOL = 20
 
-func NewUser(aTenantId TenantId, aUserName string, aPassword string, anEnablement Enablement) (_ *User, err error) {
+// The password errors are wrapped by the assertion errors of the password, which take their codes.
+var (
+	ErrPasswordEmpty          = ierrors.New(CODE_PASSWORD_EMPTY, "", "The password must not be empty")
+	ErrPasswordUnchanged      = ierrors.New(CODE_PASSWORD_UNCHANGED, "", "The password is unchanged")
+	ErrPasswordEqualsUsername = ierrors.New(CODE_PASSWORD_EQUALS_USERNAME, "", "The username and password must not be the same.")
+)
+
+// ErrPasswordWeak matches every PasswordWeakError, whichever score the password reached.
+var ErrPasswordWeak = ierrors.New(CODE_PASSWORD_WEAK, "", "The password must be stronger.")
+
+type PasswordWeakError struct {
+	score int
+}
+
+func (passwordWeakError *PasswordWeakError) Score() int {
+	return passwordWeakError.score
+}
+
+func (passwordWeakError *PasswordWeakError) Threshold() int {
+	return STRONG_THRESHOL
+}
+
+func (passwordWeakError *PasswordWeakError) Error() string {
+	return ErrPasswordWeak.Error()
+}
+
+func (passwordWeakError *PasswordWeakError) Unwrap() error {
+	return ErrPasswordWeak
+}
+
+func NewUser(aTenantId TenantId, aUserName string, aPassword string, anEnablement Enablement, aPerson Person, aNameLengthPolicy NameLengthPolicy) (_ *User, err error) {
 	defer ierrors.Wrap(&err, "user.NewUser()")
 
-	if err := validateUsername(aUserName); err != nil {
+	if err := validateUsername(aUserName, aNameLengthPolicy); err != nil {
+		return nil, ierrors.NewFieldError("username", err)
+	}
+
+	if err := ierrors.NewArgumentTrueErrorArguments(aTenantId == aPerson.tenantId, "The person must belong to the tenant of the user.").GetError(); err != nil {
 		return nil, err
 	}
 
-	user := &User{tenantId: aTenantId, userName: aUserName, password: "", enablement: anEnablement}
+	user := &User{tenantId: aTenantId, userName: USERNAME_POLICY.Canonicalize(aUserName), password: "", enablement: anEnablement, person: aPerson}
 
-	if err := user.protectPassword("", aPassword); err != nil {
+	if err := user.protectPassword("", ierrors.Secret(aPassword)); err != nil {
 		return nil, err
 	}
 
 	return user, nil
 }
 
-func validateUsername(aUserName string) error {
-	if err := ierrors.NewArgumentNotEmptyError(aUserName, "First name is required.").GetError(); err != nil {
+func validateUsername(aUserName string, aNameLengthPolicy NameLengthPolicy) error {
+	if err := ierrors.NewArgumentNotEmptyError(aUserName, "The username is required.").WithKey(MESSAGE_KEY_USERNAME_REQUIRED).GetError(); err != nil {
 		return err
 	}
-	if err := ierrors.NewArgumentLengthError(aUserName, 3, 250, "The username must be 3 to 250 characters.").GetError(); err != nil {
+	if err := ierrors.NewAssertionConcern("username").WithKey(MESSAGE_KEY_USERNAME_LENGTH).ArgumentLengthWithPolicy(aUserName, 3, 250, aNameLengthPolicy.username, "The username must be 3 to 250 characters."); err != nil {
 		return err
 	}
-	return nil
+	return USERNAME_POLICY.validate(aUserName)
 }
 
-func (user *User) protectPassword(currentPassword string, changedPassword string) error {
-	if err := user.assertPasswordNotSame(currentPassword, changedPassword); err != nil {
+func (user *User) TenantId() TenantId {
+	return user.tenantId
+}
+
+func (user *User) Username() string {
+	return user.userName
+}
+
+func (user *User) Enablement() Enablement {
+	return user.enablement
+}
+
+func (user *User) DefineEnablement(anEnablement Enablement) error {
+	user.enablement = anEnablement
+	return model.DomainEventPublisherInstance().Publish(NewUserEnablementChanged(user.tenantId, user.userName, anEnablement))
+}
+
+func (user *User) Person() *Person {
+	return &user.person
+}
+
+func (user *User) IsEnabled() bool {
+	return user.enablement.IsEnablementEnabled()
+}
+
+func (user *User) IsEmailAddressVerified() bool {
+	return user.person.emailAddress.IsVerified()
+}
+
+func (user *User) verifyEmailAddress() error {
+	if user.IsEmailAddressVerified() {
+		return nil
+	}
+	user.person.emailAddress = user.person.emailAddress.asVerified()
+	return model.DomainEventPublisherInstance().Publish(NewUserEmailVerified(user.tenantId, user.userName, user.person.emailAddress.address))
+}
+
+// ResetPassword replaces a forgotten password, so unlike a change it cannot be checked against the current one.
+func (user *User) ResetPassword(aNewPassword string) (err error) {
+	defer ierrors.Wrap(&err, "user.ResetPassword()")
+
+	if err := user.protectPassword("", ierrors.Secret(aNewPassword)); err != nil {
 		return err
 	}
+	user.resetFailedAuthentications()
 
-	if err := user.assertPasswordNotWeak(changedPassword); err != nil {
+	return model.DomainEventPublisherInstance().Publish(NewUserPasswordChanged(user.tenantId, user.userName))
+}
+
+func (user *User) ChangePassword(aCurrentPassword string, aChangedPassword string) (err error) {
+	defer ierrors.Wrap(&err, "user.ChangePassword()")
+
+	if err := ierrors.NewArgumentTrueErrorArguments(user.isPasswordCorrect(ierrors.Secret(aCurrentPassword)), "Current password not confirmed.").WithKey(MESSAGE_KEY_CURRENT_PASSWORD_NOT_CONFIRMED).GetError(); err != nil {
+		return ierrors.NewFieldError("currentPassword", err)
+	}
+	if err := user.protectPassword(ierrors.Secret(aCurrentPassword), ierrors.Secret(aChangedPassword)); err != nil {
 		return err
 	}
 
-	if err := user.assertUsernamePasswordNotSame(changedPassword); err != nil {
+	return model.DomainEventPublisherInstance().Publish(NewUserPasswordChanged(user.tenantId, user.userName))
+}
+
+func (user *User) Attribute(aKey string) (string, bool) {
+	value, ok := user.attributes[aKey]
+	return value, ok
+}
+
+func (user *User) Attributes() map[string]string {
+	attributes := make(map[string]string, len(user.attributes))
+	for key, value := range user.attributes {
+		attributes[key] = value
+	}
+	return attributes
+}
+
+func (user *User) ChangeAttribute(aKey string, aValue string) (err error) {
+	defer ierrors.Wrap(&err, "user.ChangeAttribute(%s, %s)", aKey, aValue)
+
+	if err := ierrors.NewArgumentNotEmptyError(aKey, "The attribute key is required.").GetError(); err != nil {
+		return err
+	}
+	if err := ierrors.NewArgumentLengthError(aKey, 1, 100, "The attribute key must be 100 characters or less.").GetError(); err != nil {
 		return err
 	}
 
-	bcryptedPassword, err := bcrypt.GenerateFromPassword([]byte(changedPassword), 12)
+	if user.attributes == nil {
+		user.attributes = map[string]string{}
+	}
+	user.attributes[aKey] = aValue
+	return nil
+}
+
+func (user *User) RemoveAttribute(aKey string) {
+	delete(user.attributes, aKey)
+}
+
+func (user *User) UserDescriptor() *UserDescriptor {
+	return &UserDescriptor{tenantId: user.tenantId, username: user.userName, emailAddress: user.person.emailAddress.address}
+}
+
+func (user *User) FailedAuthenticationCount() int {
+	return user.failedAuthenticationCount
+}
+
+func (user *User) FailedRecoveryCodeCount() int {
+	return user.failedRecoveryCodeCount
+}
+
+func (user *User) LockedOutUntil() time.Time {
+	return user.lockedOutUntil
+}
+
+func (user *User) IsLockedOut() bool {
+	return time.Now().Before(user.lockedOutUntil)
+}
+
+func (user *User) EnrollTotp(anIssuer string, anAlgorithm totp.Algorithm) (_ *TotpEnrollment, err error) {
+	defer ierrors.Wrap(&err, "user.EnrollTotp(%s, %v)", anIssuer, anAlgorithm)
+
+	if err := ierrors.NewArgumentFalseError(user.IsTotpEnabled(), "Multi-factor authentication is already enabled.").GetError(); err != nil {
+		return nil, err
+	}
+
+	totpAuthenticator, recoveryCodes, err := newTotpAuthenticator(anAlgorithm)
 	if err != nil {
+		return nil, err
+	}
+	user.totpAuthenticator = totpAuthenticator
+
+	generator := totpAuthenticator.totp()
+	return &TotpEnrollment{secret: generator.EncodedSecret(), provisioningUri: generator.ProvisioningUri(anIssuer, user.userName), recoveryCodes: recoveryCodes}, nil
+}
+
+func (user *User) ConfirmTotp(aCode string) (err error) {
+	defer ierrors.Wrap(&err, "user.ConfirmTotp()")
+
+	if err := ierrors.NewArgumentTrueErrorArguments(user.totpAuthenticator != nil, "Multi-factor authentication is not enrolled.").GetError(); err != nil {
 		return err
 	}
+	if err := ierrors.NewArgumentFalseError(user.totpAuthenticator.confirmed, "Multi-factor authentication is already enabled.").GetError(); err != nil {
+		return err
+	}
+	if err := ierrors.NewArgumentTrueErrorArguments(user.totpAuthenticator.verifyCode(aCode, time.Now()), "The authentication code is invalid.").WithKey(MESSAGE_KEY_AUTHENTICATION_CODE_INVALID).GetError(); err != nil {
+		return err
+	}
+
+	user.totpAuthenticator.confirmed = true
+	return nil
+}
+
+func (user *User) DisableTotp() {
+	user.totpAuthenticator = nil
+}
+
+func (user *User) IsTotpEnabled() bool {
+	return user.totpAuthenticator != nil && user.totpAuthenticator.confirmed
+}
+
+func (user *User) RemainingRecoveryCodes() int {
+	if !user.IsTotpEnabled() {
+		return 0
+	}
+	return user.totpAuthenticator.RemainingRecoveryCodes()
+}
+
+func (user *User) RegisterWebAuthnCredential(aRelyingParty *webauthn.RelyingParty, aChallenge []byte, aClientDataJson []byte, anAttestationObject []byte, aTransports []string) (_ *WebAuthnCredential, err error) {
+	defer ierrors.Wrap(&err, "user.RegisterWebAuthnCredential(%s, %v)", aRelyingParty.Id(), aTransports)
+
+	registration, err := aRelyingParty.VerifyRegistration(aChallenge, aClientDataJson, anAttestationObject)
+	if err != nil {
+		return nil, err
+	}
+	if err := ierrors.NewArgumentTrueErrorArguments(user.webAuthnCredential(registration.CredentialId()) == nil, "The credential is already registered.").GetError(); err != nil {
+		return nil, err
+	}
+
+	transports := make([]string, len(aTransports))
+	copy(transports, aTransports)
+	webAuthnCredential := &WebAuthnCredential{
+		credentialId: registration.CredentialId(),
+		publicKey:    registration.PublicKey(),
+		signCount:    registration.SignCount(),
+		transports:   transports,
+		registeredOn: time.Now(),
+	}
+	user.webAuthnCredentials = append(user.webAuthnCredentials, webAuthnCredential)
+	return webAuthnCredential, nil
+}
+
+func (user *User) VerifyWebAuthnAssertion(aRelyingParty *webauthn.RelyingParty, aCredentialId []byte, aChallenge []byte, aClientDataJson []byte, anAuthenticatorData []byte, aSignature []byte) (err error) {
+	defer ierrors.Wrap(&err, "user.VerifyWebAuthnAssertion(%s)", aRelyingParty.Id())
+
+	webAuthnCredential := user.webAuthnCredential(aCredentialId)
+	if err := ierrors.NewArgumentTrueErrorArguments(webAuthnCredential != nil, "The credential is not registered.").GetError(); err != nil {
+		return err
+	}
+
+	signCount, err := aRelyingParty.VerifyAssertion(aChallenge, aClientDataJson, anAuthenticatorData, aSignature, webAuthnCredential.publicKey, webAuthnCredential.signCount)
+	if err != nil {
+		return err
+	}
+
+	webAuthnCredential.signCount = signCount
+	webAuthnCredential.lastUsedOn = time.Now()
+	return nil
+}
+
+func (user *User) WebAuthnCredentials() []WebAuthnCredential {
+	webAuthnCredentials := make([]WebAuthnCredential, len(user.webAuthnCredentials))
+	for i, webAuthnCredential := range user.webAuthnCredentials {
+		webAuthnCredentials[i] = *webAuthnCredential
+	}
+	return webAuthnCredentials
+}
+
+func (user *User) RemoveWebAuthnCredential(aCredentialId []byte) {
+	for i, webAuthnCredential := range user.webAuthnCredentials {
+		if bytes.Equal(webAuthnCredential.credentialId, aCredentialId) {
+			user.webAuthnCredentials = append(user.webAuthnCredentials[:i], user.webAuthnCredentials[i+1:]...)
+			return
+		}
+	}
+}
 
-	user.password = string(bcryptedPassword)
+func (user *User) webAuthnCredential(aCredentialId []byte) *WebAuthnCredential {
+	for _, webAuthnCredential := range user.webAuthnCredentials {
+		if bytes.Equal(webAuthnCredential.credentialId, aCredentialId) {
+			return webAuthnCredential
+		}
+	}
 	return nil
 }
 
-func (user *User) assertPasswordNotSame(currentPassword string, changedPassword string) (err error) {
-	defer ierrors.Wrap(&err, "user.assertPasswordNotSame(%s, %s)", currentPassword, changedPassword)
-	if currentPassword == changedPassword {
-		return fmt.Errorf("The password is unchanged")
+func (user *User) isSecondFactorCorrect(aCode string) bool {
+	return user.IsTotpEnabled() && user.totpAuthenticator.verify(aCode, time.Now())
+}
+
+func (user *User) isPasswordCorrect(aPassword ierrors.Secret) bool {
+	return encryptionService.IsEncryptedValueEqual(aPassword.Reveal(), user.password)
+}
+
+func (user *User) recordFailedAuthentication(aLockoutPolicy LockoutPolicy) (lockedOut bool) {
+	if !user.lockedOutUntil.IsZero() && !user.IsLockedOut() {
+		// a previous lockout has elapsed, so counting starts over
+		user.resetFailedAuthentications()
+	}
+
+	user.failedAuthenticationCount++
+	if user.failedAuthenticationCount < aLockoutPolicy.maximumFailedAttempts {
+		return false
+	}
+
+	user.lockedOutUntil = time.Now().Add(aLockoutPolicy.lockoutDuration)
+	return true
+}
+
+// recordFailedRecoveryCode counts against both the failed attempts and the failed recovery codes of aLockoutPolicy.
+func (user *User) recordFailedRecoveryCode(aLockoutPolicy LockoutPolicy) (lockedOut bool) {
+	lockedOut = user.recordFailedAuthentication(aLockoutPolicy)
+	user.failedRecoveryCodeCount++
+	if lockedOut || user.failedRecoveryCodeCount < aLockoutPolicy.maximumFailedRecoveryCodes {
+		return lockedOut
+	}
+
+	user.lockedOutUntil = time.Now().Add(aLockoutPolicy.lockoutDuration)
+	return true
+}
+
+func (user *User) resetFailedAuthentications() {
+	user.failedAuthenticationCount = 0
+	user.failedRecoveryCodeCount = 0
+	user.lockedOutUntil = time.Time{}
+}
+
+func (user *User) toGroupMember() GroupMember {
+	return GroupMember{tenantId: user.tenantId, name: user.userName, memberType: GROUP_MEMBER_TYPE_USER}
+}
+
+func (user *User) protectPassword(currentPassword ierrors.Secret, changedPassword ierrors.Secret) error {
+	if err := user.assertPasswordNotSame(currentPassword, changedPassword); err != nil {
+		return ierrors.NewFieldError("password", err)
+	}
+
+	if err := user.assertPasswordNotWeak(changedPassword); err != nil {
+		return ierrors.NewFieldError("password", err)
+	}
+
+	if err := user.assertUsernamePasswordNotSame(changedPassword); err != nil {
+		return ierrors.NewFieldError("password", err)
+	}
+
+	encryptedPassword, err := encryptionService.EncryptedValue(changedPassword.Reveal())
+	if err != nil {
+		return err
 	}
+
+	user.password = encryptedPassword
 	return nil
 }
 
-func (user *User) assertPasswordNotWeak(changedPassword string) (err error) {
-	defer ierrors.Wrap(&err, "user.assertPasswordNotWeak(%s)", changedPassword)
+func (user *User) assertPasswordNotSame(currentPassword ierrors.Secret, changedPassword ierrors.Secret) (err error) {
+	defer ierrors.WrapFields(&err, "user.assertPasswordNotSame", ierrors.NewSensitiveField("currentPassword", currentPassword), ierrors.NewSensitiveField("changedPassword", changedPassword))
+	return ierrors.NewAssertionConcern("password").WithCause(ErrPasswordUnchanged).ArgumentTrue(currentPassword != changedPassword, ErrPasswordUnchanged.Error())
+}
+
+func (user *User) assertPasswordNotWeak(changedPassword ierrors.Secret) (err error) {
+	defer ierrors.WrapFields(&err, "user.assertPasswordNotWeak", ierrors.NewSensitiveField("changedPassword", changedPassword))
 
-	if changedPassword == "" {
-		return fmt.Errorf("The password must not be empty")
+	if changedPassword.IsEmpty() {
+		return ierrors.NewAssertionConcern("password").WithCause(ErrPasswordEmpty).ArgumentNotEmpty(changedPassword.Reveal(), ErrPasswordEmpty.Error())
 	}
 
 	strength := 0
 
-	length := len(changedPassword)
+	length := len(changedPassword.Reveal())
 
 	if length > 7 {
 		strength += 10
@@ -90,7 +413,7 @@ func (user *User) assertPasswordNotWeak(changedPassword string) (err error) {
 		strength += (length - 7)
 	}
 	digitCount, letterCount, lowerCount, upperCount, symbolCount := 0, 0, 0, 0, 0
-	for _, ch := range changedPassword {
+	for _, ch := range changedPassword.Reveal() {
 		if unicode.IsLetter(ch) {
 			letterCount++
 			if unicode.IsUpper(ch) {
@@ -112,19 +435,12 @@ func (user *User) assertPasswordNotWeak(changedPassword string) (err error) {
 		strength += (letterCount + digitCount)
 	}
 
-	if strength < STRONG_THRESHOL {
-		return fmt.Errorf("The password must be stronger.")
-	}
-
-	return nil
+	return ierrors.NewAssertionConcern("password").WithCause(&PasswordWeakError{score: strength}).ArgumentTrue(strength >= STRONG_THRESHOL, ErrPasswordWeak.Error())
 }
 
-func (user *User) assertUsernamePasswordNotSame(changedPassword string) (err error) {
-	defer ierrors.Wrap(&err, "user.assertUsernamePasswordNotSame(%s)", changedPassword)
-	if changedPassword == user.userName {
-		return fmt.Errorf("The username and password must not be the same.")
-	}
-	return nil
+func (user *User) assertUsernamePasswordNotSame(changedPassword ierrors.Secret) (err error) {
+	defer ierrors.WrapFields(&err, "user.assertUsernamePasswordNotSame", ierrors.NewSensitiveField("changedPassword", changedPassword))
+	return ierrors.NewAssertionConcern("password").WithCause(ErrPasswordEqualsUsername).ArgumentTrue(changedPassword.Reveal() != user.userName, ErrPasswordEqualsUsername.Error())
 }
 
 func (user *User) Equals(other User) bool {
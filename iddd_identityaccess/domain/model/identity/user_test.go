@@ -5,10 +5,13 @@ import (
 	"fmt"
 	"log"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/domain/model"
 	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/totp"
 	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/utils"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -25,6 +28,7 @@ var (
 	tenantId         *TenantId
 	bcryptedPassword []byte
 	enablement       *Enablement
+	person           *Person
 )
 
 func init() {
@@ -53,24 +57,34 @@ func init() {
 	if err != nil {
 		log.Fatal(err)
 	}
+	fullName, err := NewFullName("Zoe", "Doe")
+	if err != nil {
+		log.Fatal(err)
+	}
+	emailAddress, err := NewEmailAddress("zoe@saasovation.com")
+	if err != nil {
+		log.Fatal(err)
+	}
+	person = NewPerson(*tenantId, *fullName, *emailAddress)
 }
 
 var (
 	argumentLengthError   *ierrors.ArgumentLengthError
 	argumentNotEmptyError *ierrors.ArgumentNotEmptyError
+	assertionError        *ierrors.AssertionError
 )
 
 func TestNewUser(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
-		got, err := NewUser(*tenantId, userName, password, *enablement)
+		got, err := NewUser(*tenantId, userName, password, *enablement, *person, *DefaultNameLengthPolicy())
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		want := &User{tenantId: *tenantId, userName: userName, password: string(bcryptedPassword), enablement: *enablement}
+		want := &User{tenantId: *tenantId, userName: userName, password: string(bcryptedPassword), enablement: *enablement, person: *person}
 
 		opts := cmp.Options{
-			cmp.AllowUnexported(User{}, TenantId{}, Enablement{}),
+			cmp.AllowUnexported(model.ConcurrencySafeEntity{}, User{}, TenantId{}, Enablement{}, Person{}, FullName{}, EmailAddress{}),
 			cmpopts.IgnoreFields(User{}, "password"),
 		}
 		if diff := cmp.Diff(want, got, opts); diff != "" {
@@ -81,21 +95,32 @@ func TestNewUser(t *testing.T) {
 		}
 	})
 	t.Run("fail username is required.", func(t *testing.T) {
-		_, err := NewUser(*tenantId, "", password, *enablement)
+		_, err := NewUser(*tenantId, "", password, *enablement, *person, *DefaultNameLengthPolicy())
 		if !errors.As(err, &argumentNotEmptyError) {
 			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentNotEmptyError))
 		}
 	})
 	t.Run("fail username is lower than 3 characters.", func(t *testing.T) {
-		_, err := NewUser(*tenantId, "na", password, *enablement)
-		if !errors.As(err, &argumentLengthError) {
-			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentLengthError))
+		_, err := NewUser(*tenantId, "na", password, *enablement, *person, *DefaultNameLengthPolicy())
+		if !errors.As(err, &assertionError) || assertionError.Kind() != ierrors.ASSERTION_KIND_ARGUMENT_LENGTH {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&assertionError))
+		}
+	})
+	t.Run("success username of 3 Japanese characters.", func(t *testing.T) {
+		if _, err := NewUser(*tenantId, "山田太", password, *enablement, *person, *DefaultNameLengthPolicy()); err != nil {
+			t.Error(err)
+		}
+	})
+	t.Run("fail username of 2 Japanese characters.", func(t *testing.T) {
+		_, err := NewUser(*tenantId, "山田", password, *enablement, *person, *DefaultNameLengthPolicy())
+		if !errors.As(err, &assertionError) || assertionError.Kind() != ierrors.ASSERTION_KIND_ARGUMENT_LENGTH {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&assertionError))
 		}
 	})
 	t.Run("fail username is over than 250 characters.", func(t *testing.T) {
-		_, err := NewUser(*tenantId, utils.RandString(251), password, *enablement)
-		if !errors.As(err, &argumentLengthError) {
-			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentLengthError))
+		_, err := NewUser(*tenantId, utils.RandString(251), password, *enablement, *person, *DefaultNameLengthPolicy())
+		if !errors.As(err, &assertionError) || assertionError.Kind() != ierrors.ASSERTION_KIND_ARGUMENT_LENGTH {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&assertionError))
 		}
 	})
 }
@@ -103,18 +128,18 @@ func TestNewUser(t *testing.T) {
 func TestAssertPasswordNotSame(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		user := &User{tenantId: *tenantId, userName: userName, password: string(bcryptedPassword), enablement: *enablement}
-		changedPassword := "ASDFG#qwerty!"
+		changedPassword := ierrors.Secret("ASDFG#qwerty!")
 
-		if err := user.assertPasswordNotSame(password, changedPassword); err != nil {
+		if err := user.assertPasswordNotSame(ierrors.Secret(password), changedPassword); err != nil {
 			t.Error(err)
 		}
 	})
 	t.Run("fail", func(t *testing.T) {
 		user := &User{tenantId: *tenantId, userName: userName, password: string(bcryptedPassword), enablement: *enablement}
-		changedPassword := "qwerty!ASDFG#"
+		changedPassword := ierrors.Secret("qwerty!ASDFG#")
 
-		err := user.assertPasswordNotSame(password, changedPassword)
-		want := fmt.Sprintf("user.assertPasswordNotSame(%s, %s): The password is unchanged", password, changedPassword)
+		err := user.assertPasswordNotSame(ierrors.Secret(password), changedPassword)
+		want := "user.assertPasswordNotSame(currentPassword=[REDACTED], changedPassword=[REDACTED]): The password is unchanged"
 		if got := err.Error(); got != want {
 			t.Errorf("got %s, want %s", got, want)
 		}
@@ -124,7 +149,7 @@ func TestAssertPasswordNotSame(t *testing.T) {
 func TestAssertUsernamePasswordNotSame(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		user := &User{tenantId: *tenantId, userName: userName, password: string(bcryptedPassword), enablement: *enablement}
-		changedPassword := "qwerty!ASDFG#"
+		changedPassword := ierrors.Secret("qwerty!ASDFG#")
 
 		if err := user.assertUsernamePasswordNotSame(changedPassword); err != nil {
 			t.Error(err)
@@ -132,47 +157,109 @@ func TestAssertUsernamePasswordNotSame(t *testing.T) {
 	})
 	t.Run("fail", func(t *testing.T) {
 		user := &User{tenantId: *tenantId, userName: userName, password: string(bcryptedPassword), enablement: *enablement}
-		changedPassword := "userName"
+		changedPassword := ierrors.Secret("userName")
 
 		err := user.assertUsernamePasswordNotSame(changedPassword)
-		want := fmt.Sprintf("user.assertUsernamePasswordNotSame(%s): The username and password must not be the same.", changedPassword)
+		want := "user.assertUsernamePasswordNotSame(changedPassword=[REDACTED]): The username and password must not be the same."
 		if got := err.Error(); got != want {
 			t.Errorf("got %s, want %s", got, want)
 		}
 	})
 }
 
+func TestChangePasswordDoesNotLeakPasswords(t *testing.T) {
+	tests := []struct {
+		name            string
+		changedPassword string
+	}{
+		{name: "unchanged", changedPassword: password},
+		{name: "weak", changedPassword: "zoe123"},
+		{name: "same as username", changedPassword: userName},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := NewUser(*tenantId, userName, password, *enablement, *person, *DefaultNameLengthPolicy())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = user.ChangePassword(password, tt.changedPassword)
+			if err == nil {
+				t.Fatal("got nil, want an error")
+			}
+			for _, formatted := range []string{err.Error(), fmt.Sprintf("%v", err), fmt.Sprintf("%+v", err)} {
+				if strings.Contains(formatted, password) || strings.Contains(formatted, "zoe123") {
+					t.Errorf("error %q must not contain a password", formatted)
+				}
+			}
+		})
+	}
+}
+
 func TestAssertPasswordNotWeak(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		user := &User{tenantId: *tenantId, userName: userName, password: string(bcryptedPassword), enablement: *enablement}
-		changedPassword := "qwerty!ASDFG"
+		changedPassword := ierrors.Secret("qwerty!ASDFG")
 		if err := user.assertPasswordNotWeak(changedPassword); err != nil {
 			t.Error(err)
 		}
 	})
 	t.Run("fail password empty", func(t *testing.T) {
 		user := &User{tenantId: *tenantId, userName: userName, password: string(bcryptedPassword), enablement: *enablement}
-		changedPassword := ""
+		changedPassword := ierrors.Secret("")
 		err := user.assertPasswordNotWeak(changedPassword)
-		want := fmt.Sprintf("user.assertPasswordNotWeak(%s): The password must not be empty", changedPassword)
+		want := "user.assertPasswordNotWeak(changedPassword=[REDACTED]): The password must not be empty"
 		if got := err.Error(); got != want {
 			t.Errorf("got %s, want %s", got, want)
 		}
 	})
 	t.Run("fail password is weak", func(t *testing.T) {
 		user := &User{tenantId: *tenantId, userName: userName, password: string(bcryptedPassword), enablement: *enablement}
-		changedPassword := "123456"
+		changedPassword := ierrors.Secret("123456")
 		err := user.assertPasswordNotWeak(changedPassword)
-		want := fmt.Sprintf("user.assertPasswordNotWeak(%s): The password must be stronger.", changedPassword)
+		want := "user.assertPasswordNotWeak(changedPassword=[REDACTED]): The password must be stronger."
 		if got := err.Error(); got != want {
 			t.Errorf("got %s, want %s", got, want)
 		}
+		var passwordWeakError *PasswordWeakError
+		if !errors.As(err, &passwordWeakError) {
+			t.Fatalf("err type:%v, expect type: %v", reflect.TypeOf(err), reflect.TypeOf(passwordWeakError))
+		}
+		if passwordWeakError.Score() != 0 || passwordWeakError.Threshold() != STRONG_THRESHOL {
+			t.Errorf("got score %d of %d, want 0 of %d", passwordWeakError.Score(), passwordWeakError.Threshold(), STRONG_THRESHOL)
+		}
 	})
 }
 
+func TestChangePasswordErrors(t *testing.T) {
+	tests := []struct {
+		name            string
+		changedPassword string
+		want            error
+	}{
+		{name: "unchanged", changedPassword: password, want: ErrPasswordUnchanged},
+		{name: "weak", changedPassword: "zoe123", want: ErrPasswordWeak},
+		{name: "same as username", changedPassword: "qwerty.ASDFG-12345", want: ErrPasswordEqualsUsername},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := NewUser(*tenantId, "qwerty.ASDFG-12345", password, *enablement, *person, *DefaultNameLengthPolicy())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = user.ChangePassword(password, tt.changedPassword)
+			ierrors.Wrap(&err, "identityapplicationservice.ChangeUserPassword()")
+			if !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want it to match %v", err, tt.want)
+			}
+		})
+	}
+}
+
 func TestUserEquals(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
-		user, err := NewUser(*tenantId, userName, password, *enablement)
+		user, err := NewUser(*tenantId, userName, password, *enablement, *person, *DefaultNameLengthPolicy())
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -184,7 +271,7 @@ func TestUserEquals(t *testing.T) {
 		}
 	})
 	t.Run("fail tenantId is not equal", func(t *testing.T) {
-		user, err := NewUser(*tenantId, userName, password, *enablement)
+		user, err := NewUser(*tenantId, userName, password, *enablement, *person, *DefaultNameLengthPolicy())
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -206,3 +293,205 @@ func TestUserEquals(t *testing.T) {
 		}
 	})
 }
+
+func TestUserChangeAttribute(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		user := &User{tenantId: *tenantId, userName: userName, password: string(bcryptedPassword), enablement: *enablement}
+
+		if err := user.ChangeAttribute("department", "sales"); err != nil {
+			t.Fatal(err)
+		}
+
+		got, ok := user.Attribute("department")
+		if !ok || got != "sales" {
+			t.Errorf("got %s, want %s", got, "sales")
+		}
+
+		user.RemoveAttribute("department")
+		if _, ok := user.Attribute("department"); ok {
+			t.Errorf("attribute department must be removed")
+		}
+	})
+	t.Run("fail empty key", func(t *testing.T) {
+		user := &User{tenantId: *tenantId, userName: userName, password: string(bcryptedPassword), enablement: *enablement}
+
+		err := user.ChangeAttribute("", "sales")
+		if !errors.As(err, &argumentNotEmptyError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentNotEmptyError))
+		}
+	})
+}
+
+func TestRecordFailedAuthentication(t *testing.T) {
+	lockoutPolicy := LockoutPolicy{maximumFailedAttempts: 2, lockoutDuration: time.Minute}
+	user := &User{tenantId: *tenantId, userName: userName, password: string(bcryptedPassword), enablement: *enablement}
+
+	if user.recordFailedAuthentication(lockoutPolicy) {
+		t.Errorf("user must not be locked out after first failure")
+	}
+	if !user.recordFailedAuthentication(lockoutPolicy) {
+		t.Errorf("user must be locked out after second failure")
+	}
+	if !user.IsLockedOut() {
+		t.Errorf("user must be locked out until %v", user.LockedOutUntil())
+	}
+
+	user.resetFailedAuthentications()
+	if user.IsLockedOut() || user.FailedAuthenticationCount() != 0 {
+		t.Errorf("user must not be locked out after reset")
+	}
+}
+
+func TestRecordFailedRecoveryCode(t *testing.T) {
+	lockoutPolicy := LockoutPolicy{maximumFailedAttempts: 5, maximumFailedRecoveryCodes: 2, lockoutDuration: time.Minute}
+	user := &User{tenantId: *tenantId, userName: userName, password: string(bcryptedPassword), enablement: *enablement}
+
+	if user.recordFailedRecoveryCode(lockoutPolicy) {
+		t.Errorf("user must not be locked out after first failure")
+	}
+	if !user.recordFailedRecoveryCode(lockoutPolicy) {
+		t.Errorf("user must be locked out after second failed recovery code")
+	}
+	if user.FailedAuthenticationCount() != 2 || user.FailedRecoveryCodeCount() != 2 {
+		t.Errorf("got %d failed attempts and %d failed recovery codes, want 2 and 2", user.FailedAuthenticationCount(), user.FailedRecoveryCodeCount())
+	}
+
+	user.resetFailedAuthentications()
+	if user.IsLockedOut() || user.FailedRecoveryCodeCount() != 0 {
+		t.Errorf("user must not be locked out after reset")
+	}
+}
+
+func TestUserEnrollTotp(t *testing.T) {
+	useMinimumCostEncryption(t)
+
+	t.Run("success", func(t *testing.T) {
+		user := &User{tenantId: *tenantId, userName: userName, password: string(bcryptedPassword), enablement: *enablement}
+
+		totpEnrollment, err := user.EnrollTotp("SaaSOvation", totp.ALGORITHM_SHA1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if user.IsTotpEnabled() {
+			t.Fatalf("totp must not be enabled before confirmation")
+		}
+		if !strings.HasPrefix(totpEnrollment.ProvisioningUri(), "otpauth://totp/SaaSOvation:userName?") {
+			t.Errorf("unexpected provisioning uri %s", totpEnrollment.ProvisioningUri())
+		}
+
+		if err := user.ConfirmTotp(totpCodeAt(t, totpEnrollment, totp.ALGORITHM_SHA1, time.Now())); err != nil {
+			t.Fatal(err)
+		}
+		if !user.IsTotpEnabled() {
+			t.Errorf("totp must be enabled after confirmation")
+		}
+		if got := user.RemainingRecoveryCodes(); got != RECOVERY_CODE_COUNT {
+			t.Errorf("got %d remaining recovery codes, want %d", got, RECOVERY_CODE_COUNT)
+		}
+
+		_, err = user.EnrollTotp("SaaSOvation", totp.ALGORITHM_SHA1)
+		if !errors.As(err, &argumentFalseError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentFalseError))
+		}
+
+		user.DisableTotp()
+		if user.IsTotpEnabled() {
+			t.Errorf("totp must be disabled")
+		}
+	})
+	t.Run("fail confirm with wrong code", func(t *testing.T) {
+		user := &User{tenantId: *tenantId, userName: userName, password: string(bcryptedPassword), enablement: *enablement}
+		totpEnrollment, err := user.EnrollTotp("SaaSOvation", totp.ALGORITHM_SHA256)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = user.ConfirmTotp(totpCodeAt(t, totpEnrollment, totp.ALGORITHM_SHA256, time.Now().Add(-time.Hour)))
+		if !errors.As(err, &argumentTrueError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentTrueError))
+		}
+		if user.IsTotpEnabled() {
+			t.Errorf("totp must not be enabled")
+		}
+	})
+	t.Run("fail confirm without enrollment", func(t *testing.T) {
+		user := &User{tenantId: *tenantId, userName: userName, password: string(bcryptedPassword), enablement: *enablement}
+
+		err := user.ConfirmTotp("123456")
+		if !errors.As(err, &argumentTrueError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentTrueError))
+		}
+	})
+}
+
+func TestUserResetPassword(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		user := &User{tenantId: *tenantId, userName: userName, password: string(bcryptedPassword), enablement: *enablement, failedAuthenticationCount: 2}
+
+		if err := user.ResetPassword("ASDFG#qwerty!12"); err != nil {
+			t.Fatal(err)
+		}
+
+		if !user.isPasswordCorrect("ASDFG#qwerty!12") {
+			t.Errorf("new password must be correct")
+		}
+		if user.isPasswordCorrect(password) {
+			t.Errorf("old password must not be correct")
+		}
+		if user.FailedAuthenticationCount() != 0 {
+			t.Errorf("got %d failed authentications, want 0", user.FailedAuthenticationCount())
+		}
+	})
+	t.Run("fail weak password", func(t *testing.T) {
+		user := &User{tenantId: *tenantId, userName: userName, password: string(bcryptedPassword), enablement: *enablement}
+
+		if err := user.ResetPassword("weak"); err == nil {
+			t.Errorf("weak password must be rejected")
+		}
+		if !user.isPasswordCorrect(password) {
+			t.Errorf("password must be unchanged")
+		}
+	})
+	t.Run("fail password same as username", func(t *testing.T) {
+		user := &User{tenantId: *tenantId, userName: "zoe!ASDFG#12345", password: string(bcryptedPassword), enablement: *enablement}
+
+		if err := user.ResetPassword("zoe!ASDFG#12345"); err == nil {
+			t.Errorf("password same as username must be rejected")
+		}
+	})
+}
+
+func TestUserChangePassword(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		user := &User{tenantId: *tenantId, userName: userName, password: string(bcryptedPassword), enablement: *enablement}
+
+		if err := user.ChangePassword(password, "ASDFG#qwerty!12"); err != nil {
+			t.Fatal(err)
+		}
+
+		if !user.isPasswordCorrect("ASDFG#qwerty!12") {
+			t.Errorf("changed password must be correct")
+		}
+	})
+	t.Run("fail current password not confirmed", func(t *testing.T) {
+		user := &User{tenantId: *tenantId, userName: userName, password: string(bcryptedPassword), enablement: *enablement}
+
+		err := user.ChangePassword("wrong!ASDFG#12", "ASDFG#qwerty!12")
+		var fieldError *ierrors.FieldError
+		if !errors.As(err, &fieldError) || fieldError.Field() != "currentPassword" {
+			t.Errorf("got %v, want field error of currentPassword", err)
+		}
+		if !user.isPasswordCorrect(password) {
+			t.Errorf("password must be unchanged")
+		}
+	})
+	t.Run("fail unchanged password", func(t *testing.T) {
+		user := &User{tenantId: *tenantId, userName: userName, password: string(bcryptedPassword), enablement: *enablement}
+
+		err := user.ChangePassword(password, password)
+		var fieldError *ierrors.FieldError
+		if !errors.As(err, &fieldError) || fieldError.Field() != "password" {
+			t.Errorf("got %v, want field error of password", err)
+		}
+	})
+}
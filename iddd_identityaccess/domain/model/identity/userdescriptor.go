@@ -0,0 +1,92 @@
+package identity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/jwt"
+)
+
+type UserDescriptor struct {
+	tenantId     TenantId
+	username     string
+	emailAddress string
+}
+
+type userDescriptorClaims struct {
+	jwt.RegisteredClaims
+	TenantId     string `json:"tid"`
+	Username     string `json:"username"`
+	EmailAddress string `json:"email"`
+}
+
+func NewUserDescriptor(aTenantId TenantId, aUsername string, anEmailAddress string) *UserDescriptor {
+	return &UserDescriptor{tenantId: aTenantId, username: aUsername, emailAddress: anEmailAddress}
+}
+
+func NewUserDescriptorFromToken(aKeyring *jwt.Keyring, aToken string, anExpectation jwt.Expectation) (_ *UserDescriptor, err error) {
+	defer ierrors.Wrap(&err, "userdescriptor.NewUserDescriptorFromToken()")
+
+	claims := &userDescriptorClaims{}
+	if err := jwt.Verify(aKeyring, aToken, anExpectation, claims); err != nil {
+		return nil, err
+	}
+	tenantId, err := NewTenantId(claims.TenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewUserDescriptor(*tenantId, claims.Username, claims.EmailAddress), nil
+}
+
+func (userDescriptor *UserDescriptor) TenantId() TenantId {
+	return userDescriptor.tenantId
+}
+
+func (userDescriptor *UserDescriptor) Username() string {
+	return userDescriptor.username
+}
+
+func (userDescriptor *UserDescriptor) EmailAddress() string {
+	return userDescriptor.emailAddress
+}
+
+func (userDescriptor *UserDescriptor) SignedToken(aKeyring *jwt.Keyring, anIssuer string, anAudience string, aLifetime time.Duration) (_ string, err error) {
+	defer ierrors.Wrap(&err, "userdescriptor.SignedToken(%s, %s, %v)", anIssuer, anAudience, aLifetime)
+
+	if err := ierrors.NewArgumentNotEmptyError(anIssuer, "The token issuer is required.").GetError(); err != nil {
+		return "", err
+	}
+	if err := ierrors.NewArgumentNotEmptyError(anAudience, "The token audience is required.").GetError(); err != nil {
+		return "", err
+	}
+	if err := ierrors.NewArgumentTrueErrorArguments(aLifetime > 0, "The token lifetime must be positive.").GetError(); err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims := &userDescriptorClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    anIssuer,
+			Subject:   userDescriptor.tenantId.Id() + ":" + userDescriptor.username,
+			Audience:  anAudience,
+			ExpiresAt: now.Add(aLifetime).Unix(),
+			NotBefore: now.Unix(),
+			IssuedAt:  now.Unix(),
+		},
+		TenantId:     userDescriptor.tenantId.Id(),
+		Username:     userDescriptor.username,
+		EmailAddress: userDescriptor.emailAddress,
+	}
+
+	return jwt.Sign(aKeyring, claims)
+}
+
+func (userDescriptor *UserDescriptor) Equals(otherUserDescriptor *UserDescriptor) bool {
+	return *userDescriptor == *otherUserDescriptor
+}
+
+func (userDescriptor *UserDescriptor) String() string {
+	return fmt.Sprintf("UserDescriptor [tenantId=%s, username=%s, emailAddress=%s]", userDescriptor.tenantId.Id(), userDescriptor.username, userDescriptor.emailAddress)
+}
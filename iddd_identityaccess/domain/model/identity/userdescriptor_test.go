@@ -0,0 +1,58 @@
+package identity
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/jwt"
+)
+
+func TestUserDescriptorSignedToken(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := jwt.NewEd25519Key("key-1", privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyring, err := jwt.NewKeyring("key-1", key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	userDescriptor := NewUserDescriptor(*tenantId, userName, "zoe@saasovation.com")
+
+	t.Run("success", func(t *testing.T) {
+		token, err := userDescriptor.SignedToken(keyring, "iddd", "collaboration", time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := NewUserDescriptorFromToken(keyring, token, jwt.Expectation{Issuer: "iddd", Audience: "collaboration"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equals(userDescriptor) {
+			t.Errorf("got %v, want %v", got, userDescriptor)
+		}
+	})
+	t.Run("fail other audience", func(t *testing.T) {
+		token, err := userDescriptor.SignedToken(keyring, "iddd", "collaboration", time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = NewUserDescriptorFromToken(keyring, token, jwt.Expectation{Issuer: "iddd", Audience: "agilepm"})
+		if !errors.Is(err, jwt.ErrTokenAudienceInvalid) {
+			t.Errorf("got %v, want %v", err, jwt.ErrTokenAudienceInvalid)
+		}
+	})
+	t.Run("fail lifetime is not positive", func(t *testing.T) {
+		_, err := userDescriptor.SignedToken(keyring, "iddd", "collaboration", 0)
+		if !errors.As(err, &argumentTrueError) {
+			t.Errorf("got %v, want %T", err, argumentTrueError)
+		}
+	})
+}
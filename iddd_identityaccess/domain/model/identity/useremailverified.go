@@ -0,0 +1,35 @@
+package identity
+
+import "time"
+
+type UserEmailVerified struct {
+	eventVersion int
+	occurredOn   time.Time
+	tenantId     TenantId
+	username     string
+	emailAddress string
+}
+
+func NewUserEmailVerified(aTenantId TenantId, aUsername string, anEmailAddress string) *UserEmailVerified {
+	return &UserEmailVerified{eventVersion: 1, occurredOn: time.Now(), tenantId: aTenantId, username: aUsername, emailAddress: anEmailAddress}
+}
+
+func (userEmailVerified *UserEmailVerified) EventVersion() int {
+	return userEmailVerified.eventVersion
+}
+
+func (userEmailVerified *UserEmailVerified) OccurredOn() time.Time {
+	return userEmailVerified.occurredOn
+}
+
+func (userEmailVerified *UserEmailVerified) TenantId() TenantId {
+	return userEmailVerified.tenantId
+}
+
+func (userEmailVerified *UserEmailVerified) Username() string {
+	return userEmailVerified.username
+}
+
+func (userEmailVerified *UserEmailVerified) EmailAddress() string {
+	return userEmailVerified.emailAddress
+}
@@ -0,0 +1,35 @@
+package identity
+
+import "time"
+
+type UserEnablementChanged struct {
+	eventVersion int
+	occurredOn   time.Time
+	tenantId     TenantId
+	username     string
+	enablement   Enablement
+}
+
+func NewUserEnablementChanged(aTenantId TenantId, aUsername string, anEnablement Enablement) *UserEnablementChanged {
+	return &UserEnablementChanged{eventVersion: 1, occurredOn: time.Now(), tenantId: aTenantId, username: aUsername, enablement: anEnablement}
+}
+
+func (userEnablementChanged *UserEnablementChanged) EventVersion() int {
+	return userEnablementChanged.eventVersion
+}
+
+func (userEnablementChanged *UserEnablementChanged) OccurredOn() time.Time {
+	return userEnablementChanged.occurredOn
+}
+
+func (userEnablementChanged *UserEnablementChanged) TenantId() TenantId {
+	return userEnablementChanged.tenantId
+}
+
+func (userEnablementChanged *UserEnablementChanged) Username() string {
+	return userEnablementChanged.username
+}
+
+func (userEnablementChanged *UserEnablementChanged) Enablement() Enablement {
+	return userEnablementChanged.enablement
+}
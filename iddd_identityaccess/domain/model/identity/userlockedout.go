@@ -0,0 +1,35 @@
+package identity
+
+import "time"
+
+type UserLockedOut struct {
+	eventVersion   int
+	occurredOn     time.Time
+	tenantId       TenantId
+	username       string
+	lockedOutUntil time.Time
+}
+
+func NewUserLockedOut(aTenantId TenantId, aUsername string, aLockedOutUntil time.Time) *UserLockedOut {
+	return &UserLockedOut{eventVersion: 1, occurredOn: time.Now(), tenantId: aTenantId, username: aUsername, lockedOutUntil: aLockedOutUntil}
+}
+
+func (userLockedOut *UserLockedOut) EventVersion() int {
+	return userLockedOut.eventVersion
+}
+
+func (userLockedOut *UserLockedOut) OccurredOn() time.Time {
+	return userLockedOut.occurredOn
+}
+
+func (userLockedOut *UserLockedOut) TenantId() TenantId {
+	return userLockedOut.tenantId
+}
+
+func (userLockedOut *UserLockedOut) Username() string {
+	return userLockedOut.username
+}
+
+func (userLockedOut *UserLockedOut) LockedOutUntil() time.Time {
+	return userLockedOut.lockedOutUntil
+}
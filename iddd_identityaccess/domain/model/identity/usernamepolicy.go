@@ -0,0 +1,127 @@
+package identity
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+var DEFAULT_RESERVED_USERNAMES = []string{
+	"abuse", "admin", "administrator", "anonymous", "hostmaster", "noreply", "postmaster",
+	"root", "security", "superuser", "support", "system", "webmaster",
+}
+
+// USERNAME_POLICY applies to the usernames of every tenant.
+var USERNAME_POLICY = NewUsernamePolicy(DEFAULT_RESERVED_USERNAMES...)
+
+// confusables maps case folded characters of other scripts, and digits, to the Latin letters they are mistaken for (UTS #39).
+var confusables = map[rune]rune{
+	'\u0430': 'a', // CYRILLIC SMALL LETTER A
+	'\u0251': 'a', // LATIN SMALL LETTER ALPHA
+	'\u03b1': 'a', // GREEK SMALL LETTER ALPHA
+	'\u044c': 'b', // CYRILLIC SMALL LETTER SOFT SIGN
+	'\u0441': 'c', // CYRILLIC SMALL LETTER ES
+	'\u03f2': 'c', // GREEK LUNATE SIGMA SYMBOL
+	'\u0501': 'd', // CYRILLIC SMALL LETTER KOMI DE
+	'\u0435': 'e', // CYRILLIC SMALL LETTER IE
+	'\u0261': 'g', // LATIN SMALL LETTER SCRIPT G
+	'\u04bb': 'h', // CYRILLIC SMALL LETTER SHHA
+	'\u0570': 'h', // ARMENIAN SMALL LETTER HO
+	'\u0456': 'i', // CYRILLIC SMALL LETTER BYELORUSSIAN-UKRAINIAN I
+	'\u0131': 'i', // LATIN SMALL LETTER DOTLESS I
+	'\u03b9': 'i', // GREEK SMALL LETTER IOTA
+	'\u0458': 'j', // CYRILLIC SMALL LETTER JE
+	'\u03f3': 'j', // GREEK LETTER YOT
+	'\u04cf': 'l', // CYRILLIC SMALL LETTER PALOCHKA
+	'1':      'l',
+	'\u0578': 'n', // ARMENIAN SMALL LETTER VO
+	'\u03b7': 'n', // GREEK SMALL LETTER ETA
+	'\u043e': 'o', // CYRILLIC SMALL LETTER O
+	'\u03bf': 'o', // GREEK SMALL LETTER OMICRON
+	'\u0585': 'o', // ARMENIAN SMALL LETTER OH
+	'0':      'o',
+	'\u0440': 'p', // CYRILLIC SMALL LETTER ER
+	'\u03c1': 'p', // GREEK SMALL LETTER RHO
+	'\u051b': 'q', // CYRILLIC SMALL LETTER QA
+	'\u0455': 's', // CYRILLIC SMALL LETTER DZE
+	'\u057d': 'u', // ARMENIAN SMALL LETTER SEH
+	'\u03bd': 'v', // GREEK SMALL LETTER NU
+	'\u0475': 'v', // CYRILLIC SMALL LETTER IZHITSA
+	'\u051d': 'w', // CYRILLIC SMALL LETTER WE
+	'\u0445': 'x', // CYRILLIC SMALL LETTER HA
+	'\u03c7': 'x', // GREEK SMALL LETTER CHI
+	'\u0443': 'y', // CYRILLIC SMALL LETTER U
+	'\u03b3': 'y', // GREEK SMALL LETTER GAMMA
+}
+
+// confusableSequences maps the letter pairs that read as a single letter.
+var confusableSequences = strings.NewReplacer("rn", "m", "vv", "w")
+
+// UsernamePolicy decides which usernames are acceptable, and which two of them are the same name to a reader.
+type UsernamePolicy struct {
+	reservedSkeletons map[string]bool
+}
+
+func NewUsernamePolicy(aReservedUsernames ...string) *UsernamePolicy {
+	usernamePolicy := &UsernamePolicy{reservedSkeletons: make(map[string]bool)}
+	for _, reservedUsername := range aReservedUsernames {
+		usernamePolicy.reservedSkeletons[usernamePolicy.Skeleton(reservedUsername)] = true
+	}
+	return usernamePolicy
+}
+
+// Canonicalize is the form a username is stored in.
+func (usernamePolicy *UsernamePolicy) Canonicalize(aUsername string) string {
+	return norm.NFKC.String(aUsername)
+}
+
+// Fold is the key a username is looked up by. It is equal for two usernames that differ only in case or compatibility characters.
+func (usernamePolicy *UsernamePolicy) Fold(aUsername string) string {
+	return norm.NFKC.String(cases.Fold().String(usernamePolicy.Canonicalize(aUsername)))
+}
+
+// Skeleton is equal for two usernames a reader could mistake for each other.
+func (usernamePolicy *UsernamePolicy) Skeleton(aUsername string) string {
+	skeleton := strings.Map(func(aRune rune) rune {
+		if confusable, ok := confusables[aRune]; ok {
+			return confusable
+		}
+		return aRune
+	}, norm.NFD.String(usernamePolicy.Fold(aUsername)))
+	return norm.NFC.String(confusableSequences.Replace(skeleton))
+}
+
+func (usernamePolicy *UsernamePolicy) IsConfusable(aUsername string, anotherUsername string) bool {
+	return usernamePolicy.Skeleton(aUsername) == usernamePolicy.Skeleton(anotherUsername)
+}
+
+func (usernamePolicy *UsernamePolicy) IsReserved(aUsername string) bool {
+	return usernamePolicy.reservedSkeletons[usernamePolicy.Skeleton(aUsername)]
+}
+
+func (usernamePolicy *UsernamePolicy) validate(aUsername string) error {
+	canonical := usernamePolicy.Canonicalize(aUsername)
+	if err := ierrors.NewArgumentTrueErrorArguments(hasUsernameCharacters(canonical), "The username must start with a letter or digit and contain only letters, digits and . _ - @ +.").WithKey(MESSAGE_KEY_USERNAME_CHARACTERS).GetError(); err != nil {
+		return err
+	}
+	if err := ierrors.NewArgumentFalseError(usernamePolicy.IsReserved(canonical), "The username is reserved.").WithKey(MESSAGE_KEY_USERNAME_RESERVED).GetError(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func hasUsernameCharacters(aUsername string) bool {
+	for index, current := range aUsername {
+		switch {
+		case unicode.IsLetter(current), unicode.IsDigit(current):
+		case index > 0 && unicode.IsMark(current):
+		case index > 0 && strings.ContainsRune("._-@+", current):
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,82 @@
+package identity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+)
+
+func TestUsernamePolicyValidate(t *testing.T) {
+	usernamePolicy := NewUsernamePolicy("admin")
+
+	tests := []struct {
+		name     string
+		username string
+		wantErr  interface{}
+	}{
+		{name: "success letters and digits", username: "zoe2024"},
+		{name: "success Japanese", username: "山田太"},
+		{name: "success email address", username: "zoe.doe+iddd@saasovation.com"},
+		{name: "success combining mark", username: "zoe\u0301"},
+		{name: "fail leading punctuation", username: ".zoe", wantErr: new(*ierrors.ArgumentTrueError)},
+		{name: "fail space", username: "zoe doe", wantErr: new(*ierrors.ArgumentTrueError)},
+		{name: "fail symbol", username: "zoe!", wantErr: new(*ierrors.ArgumentTrueError)},
+		{name: "fail zero width joiner", username: "zo\u200de", wantErr: new(*ierrors.ArgumentTrueError)},
+		{name: "fail reserved", username: "admin", wantErr: new(*ierrors.ArgumentFalseError)},
+		{name: "fail reserved in upper case", username: "ADMIN", wantErr: new(*ierrors.ArgumentFalseError)},
+		{name: "fail reserved in fullwidth", username: "ａｄｍｉｎ", wantErr: new(*ierrors.ArgumentFalseError)},
+		{name: "fail reserved with Cyrillic a", username: "\u0430dmin", wantErr: new(*ierrors.ArgumentFalseError)},
+		{name: "fail reserved with rn", username: "adrnin", wantErr: new(*ierrors.ArgumentFalseError)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := usernamePolicy.validate(tt.username)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("got %v, want nil", err)
+				}
+				return
+			}
+			if !errors.As(err, tt.wantErr) {
+				t.Errorf("err type: %T, expect type: %T", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUsernamePolicyIsConfusable(t *testing.T) {
+	tests := []struct {
+		name            string
+		username        string
+		anotherUsername string
+		want            bool
+	}{
+		{name: "same", username: "zoe", anotherUsername: "zoe", want: true},
+		{name: "case", username: "Zoe", anotherUsername: "zoe", want: true},
+		{name: "fullwidth", username: "ｚｏｅ", anotherUsername: "zoe", want: true},
+		{name: "Cyrillic", username: "z\u043ee", anotherUsername: "zoe", want: true},
+		{name: "Greek", username: "z\u03bfe", anotherUsername: "zoe", want: true},
+		{name: "digit", username: "z0e", anotherUsername: "zoe", want: true},
+		{name: "letter pair", username: "rnary", anotherUsername: "mary", want: true},
+		{name: "accent", username: "zoé", anotherUsername: "zoe", want: false},
+		{name: "different", username: "zoe", anotherUsername: "joe", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := USERNAME_POLICY.IsConfusable(tt.username, tt.anotherUsername); got != tt.want {
+				t.Errorf("got %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewUserCanonicalizesUsername(t *testing.T) {
+	user, err := NewUser(*tenantId, "ｚｏｅusername", password, *enablement, *person, *DefaultNameLengthPolicy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := user.Username(), "zoeusername"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
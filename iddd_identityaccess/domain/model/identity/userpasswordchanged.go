@@ -0,0 +1,30 @@
+package identity
+
+import "time"
+
+type UserPasswordChanged struct {
+	eventVersion int
+	occurredOn   time.Time
+	tenantId     TenantId
+	username     string
+}
+
+func NewUserPasswordChanged(aTenantId TenantId, aUsername string) *UserPasswordChanged {
+	return &UserPasswordChanged{eventVersion: 1, occurredOn: time.Now(), tenantId: aTenantId, username: aUsername}
+}
+
+func (userPasswordChanged *UserPasswordChanged) EventVersion() int {
+	return userPasswordChanged.eventVersion
+}
+
+func (userPasswordChanged *UserPasswordChanged) OccurredOn() time.Time {
+	return userPasswordChanged.occurredOn
+}
+
+func (userPasswordChanged *UserPasswordChanged) TenantId() TenantId {
+	return userPasswordChanged.tenantId
+}
+
+func (userPasswordChanged *UserPasswordChanged) Username() string {
+	return userPasswordChanged.username
+}
@@ -0,0 +1,18 @@
+package identity
+
+import "github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+
+var (
+	ErrUserAlreadyExists  = ierrors.New(CODE_USER_ALREADY_EXISTS, MESSAGE_KEY_USER_ALREADY_EXISTS, "The user already exists.")
+	ErrUsernameConfusable = ierrors.New(CODE_USERNAME_CONFUSABLE, MESSAGE_KEY_USERNAME_CONFUSABLE, "The username can be mistaken for that of another user.")
+)
+
+// UserRepository keeps usernames unique within a tenant by their USERNAME_POLICY fold and skeleton, and looks them up by their fold.
+// Add reports ErrUserAlreadyExists or ErrUsernameConfusable for a user that would break that.
+type UserRepository interface {
+	Add(aUser *User) error
+	Remove(aUser *User) error
+	AllUsers(aTenantId TenantId) ([]*User, error)
+	UserWithUsername(aTenantId TenantId, aUsername string) (*User, error)
+	UserWithEmailAddress(aTenantId TenantId, anEmailAddress string) (*User, error)
+}
@@ -0,0 +1,42 @@
+package identity
+
+import (
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+)
+
+// UserValidator reports every invalid value a user would be registered with. NewUser only sees a constructed Person and Enablement, so
+// the values of those are validated here as well, under the field names their own constructors use.
+type UserValidator struct {
+	username         string
+	password         ierrors.Secret
+	firstName        string
+	lastName         string
+	emailAddress     string
+	startDate        time.Time
+	endDate          time.Time
+	nameLengthPolicy NameLengthPolicy
+}
+
+func NewUserValidator(aUsername string, aPassword string, aFirstName string, aLastName string, anEmailAddress string, aStartDate time.Time, anEndDate time.Time, aNameLengthPolicy NameLengthPolicy) *UserValidator {
+	return &UserValidator{username: aUsername, password: ierrors.Secret(aPassword), firstName: aFirstName, lastName: aLastName, emailAddress: anEmailAddress, startDate: aStartDate, endDate: anEndDate, nameLengthPolicy: aNameLengthPolicy}
+}
+
+func (userValidator *UserValidator) Validate(aValidationNotification *ierrors.ValidationNotification) {
+	aValidationNotification.Add("username", validateUsername(userValidator.username, userValidator.nameLengthPolicy))
+	aValidationNotification.Add("password", validatePassword(userValidator.username, userValidator.password))
+	aValidationNotification.Add("firstName", validateFirstName(userValidator.firstName))
+	aValidationNotification.Add("lastName", validateLastName(userValidator.lastName))
+	aValidationNotification.Add("emailAddress", validateEmailAddress(userValidator.emailAddress))
+	aValidationNotification.Add("endDate", validateEnablementPeriod(userValidator.startDate, userValidator.endDate))
+}
+
+// validatePassword applies the rules protectPassword applies to a new user, without encrypting the password.
+func validatePassword(aUsername string, aPassword ierrors.Secret) error {
+	user := &User{userName: aUsername}
+	if err := user.assertPasswordNotWeak(aPassword); err != nil {
+		return err
+	}
+	return user.assertUsernamePasswordNotSame(aPassword)
+}
@@ -0,0 +1,54 @@
+package identity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+)
+
+func TestUserValidator(t *testing.T) {
+	tests := []struct {
+		name       string
+		validator  *UserValidator
+		wantFields []string
+	}{
+		{
+			name:      "success",
+			validator: NewUserValidator(userName, password, "Zoe", "Doe", "zoe@saasovation.com", startDate, endDate, *DefaultNameLengthPolicy()),
+		},
+		{
+			name:       "fail every field",
+			validator:  NewUserValidator("", "weak", "", "", "zoe", endDate, startDate, *DefaultNameLengthPolicy()),
+			wantFields: []string{"username", "password", "firstName", "lastName", "emailAddress", "endDate"},
+		},
+		{
+			name:       "fail password equals username",
+			validator:  NewUserValidator("qwerty.ASDFG-12345", "qwerty.ASDFG-12345", "Zoe", "Doe", "zoe@saasovation.com", startDate, endDate, *DefaultNameLengthPolicy()),
+			wantFields: []string{"password"},
+		},
+		{
+			name:       "fail email address format",
+			validator:  NewUserValidator(userName, password, "Zoe", "Doe", "zoe@", startDate, endDate, *DefaultNameLengthPolicy()),
+			wantFields: []string{"emailAddress"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validationNotification := ierrors.NewValidationNotification()
+			tt.validator.Validate(validationNotification)
+
+			assertValidationFields(t, validationNotification, tt.wantFields)
+		})
+	}
+	t.Run("errors as", func(t *testing.T) {
+		validationNotification := ierrors.NewValidationNotification()
+		NewUserValidator(userName, password, "", "Doe", "zoe@saasovation.com", time.Now(), INDEFINITE_END_DATE, *DefaultNameLengthPolicy()).Validate(validationNotification)
+
+		var fieldError *ierrors.FieldError
+		if err := validationNotification.Err(); !errors.As(err, &argumentNotEmptyError) || !errors.As(err, &fieldError) || fieldError.Field() != "firstName" {
+			t.Errorf("got %v, want an empty firstName", err)
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package identity
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"time"
+)
+
+type WebAuthnCredential struct {
+	credentialId []byte
+	publicKey    []byte
+	signCount    uint32
+	transports   []string
+	registeredOn time.Time
+	lastUsedOn   time.Time
+}
+
+func (webAuthnCredential *WebAuthnCredential) CredentialId() []byte {
+	return append([]byte{}, webAuthnCredential.credentialId...)
+}
+
+// PublicKey is the COSE_Key the authenticator returned on registration.
+func (webAuthnCredential *WebAuthnCredential) PublicKey() []byte {
+	return append([]byte{}, webAuthnCredential.publicKey...)
+}
+
+func (webAuthnCredential *WebAuthnCredential) SignCount() uint32 {
+	return webAuthnCredential.signCount
+}
+
+func (webAuthnCredential *WebAuthnCredential) Transports() []string {
+	transports := make([]string, len(webAuthnCredential.transports))
+	copy(transports, webAuthnCredential.transports)
+	return transports
+}
+
+func (webAuthnCredential *WebAuthnCredential) RegisteredOn() time.Time {
+	return webAuthnCredential.registeredOn
+}
+
+func (webAuthnCredential *WebAuthnCredential) LastUsedOn() time.Time {
+	return webAuthnCredential.lastUsedOn
+}
+
+func (webAuthnCredential *WebAuthnCredential) Equals(otherWebAuthnCredential *WebAuthnCredential) bool {
+	return bytes.Equal(webAuthnCredential.credentialId, otherWebAuthnCredential.credentialId)
+}
+
+func (webAuthnCredential *WebAuthnCredential) String() string {
+	return fmt.Sprintf("WebAuthnCredential [credentialId=%s, signCount=%d, transports=%v]", base64.RawURLEncoding.EncodeToString(webAuthnCredential.credentialId), webAuthnCredential.signCount, webAuthnCredential.transports)
+}
@@ -0,0 +1,161 @@
+package identity
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/webauthn"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/webauthn/webauthntest"
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	rpId   = "saasovation.com"
+	origin = "https://saasovation.com"
+)
+
+func registerWebAuthnCredential(t *testing.T, aUser *User, aRelyingParty *webauthn.RelyingParty, anAlgorithm int64) *webauthntest.Authenticator {
+	t.Helper()
+
+	authenticator, err := webauthntest.NewAuthenticator(anAlgorithm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientDataJson, attestationObject, err := authenticator.Register(rpId, origin, challenge)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := aUser.RegisterWebAuthnCredential(aRelyingParty, challenge, clientDataJson, attestationObject, []string{"internal", "hybrid"}); err != nil {
+		t.Fatal(err)
+	}
+	return authenticator
+}
+
+func TestUserRegisterWebAuthnCredential(t *testing.T) {
+	relyingParty, err := webauthn.NewRelyingParty(rpId, []string{origin}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		user := &User{tenantId: *tenantId, userName: userName, password: string(bcryptedPassword), enablement: *enablement}
+
+		authenticator := registerWebAuthnCredential(t, user, relyingParty, webauthn.COSE_ALGORITHM_ES256)
+
+		webAuthnCredentials := user.WebAuthnCredentials()
+		if len(webAuthnCredentials) != 1 {
+			t.Fatalf("got %d credentials, want 1", len(webAuthnCredentials))
+		}
+		if diff := cmp.Diff(authenticator.CredentialId(), webAuthnCredentials[0].CredentialId()); diff != "" {
+			t.Errorf("mismatch (-want, +got):\n%s", diff)
+		}
+		if diff := cmp.Diff(authenticator.PublicKey(), webAuthnCredentials[0].PublicKey()); diff != "" {
+			t.Errorf("mismatch (-want, +got):\n%s", diff)
+		}
+		if diff := cmp.Diff([]string{"internal", "hybrid"}, webAuthnCredentials[0].Transports()); diff != "" {
+			t.Errorf("mismatch (-want, +got):\n%s", diff)
+		}
+
+		user.RemoveWebAuthnCredential(authenticator.CredentialId())
+		if len(user.WebAuthnCredentials()) != 0 {
+			t.Errorf("credential must be removed")
+		}
+	})
+	t.Run("fail already registered", func(t *testing.T) {
+		user := &User{tenantId: *tenantId, userName: userName, password: string(bcryptedPassword), enablement: *enablement}
+		authenticator := registerWebAuthnCredential(t, user, relyingParty, webauthn.COSE_ALGORITHM_EDDSA)
+
+		challenge, err := webauthn.NewChallenge()
+		if err != nil {
+			t.Fatal(err)
+		}
+		clientDataJson, attestationObject, err := authenticator.Register(rpId, origin, challenge)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = user.RegisterWebAuthnCredential(relyingParty, challenge, clientDataJson, attestationObject, nil)
+		if !errors.As(err, &argumentTrueError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentTrueError))
+		}
+	})
+	t.Run("fail challenge mismatch", func(t *testing.T) {
+		user := &User{tenantId: *tenantId, userName: userName, password: string(bcryptedPassword), enablement: *enablement}
+		authenticator, err := webauthntest.NewAuthenticator(webauthn.COSE_ALGORITHM_ES256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		clientDataJson, attestationObject, err := authenticator.Register(rpId, origin, []byte("issued challenge"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = user.RegisterWebAuthnCredential(relyingParty, []byte("other challenge"), clientDataJson, attestationObject, nil)
+		if !errors.Is(err, webauthn.ErrChallengeMismatch) {
+			t.Errorf("got %v, want %v", err, webauthn.ErrChallengeMismatch)
+		}
+		if len(user.WebAuthnCredentials()) != 0 {
+			t.Errorf("credential must not be registered")
+		}
+	})
+}
+
+func TestUserVerifyWebAuthnAssertion(t *testing.T) {
+	relyingParty, err := webauthn.NewRelyingParty(rpId, []string{origin}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		algorithm int64
+	}{
+		{name: "success ES256", algorithm: webauthn.COSE_ALGORITHM_ES256},
+		{name: "success EdDSA", algorithm: webauthn.COSE_ALGORITHM_EDDSA},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{tenantId: *tenantId, userName: userName, password: string(bcryptedPassword), enablement: *enablement}
+			authenticator := registerWebAuthnCredential(t, user, relyingParty, tt.algorithm)
+			authenticator.SignCount = 10
+
+			challenge, err := webauthn.NewChallenge()
+			if err != nil {
+				t.Fatal(err)
+			}
+			clientDataJson, authenticatorData, signature, err := authenticator.Assert(rpId, origin, challenge)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := user.VerifyWebAuthnAssertion(relyingParty, authenticator.CredentialId(), challenge, clientDataJson, authenticatorData, signature); err != nil {
+				t.Fatal(err)
+			}
+			webAuthnCredential := user.WebAuthnCredentials()[0]
+			if webAuthnCredential.SignCount() != 11 {
+				t.Errorf("got sign count %d, want 11", webAuthnCredential.SignCount())
+			}
+			if webAuthnCredential.LastUsedOn().IsZero() {
+				t.Errorf("last used on must be recorded")
+			}
+
+			err = user.VerifyWebAuthnAssertion(relyingParty, authenticator.CredentialId(), challenge, clientDataJson, authenticatorData, signature)
+			if !errors.Is(err, webauthn.ErrSignCountInvalid) {
+				t.Errorf("replayed assertion: got %v, want %v", err, webauthn.ErrSignCountInvalid)
+			}
+		})
+	}
+	t.Run("fail unknown credential", func(t *testing.T) {
+		user := &User{tenantId: *tenantId, userName: userName, password: string(bcryptedPassword), enablement: *enablement}
+
+		err := user.VerifyWebAuthnAssertion(relyingParty, []byte("unknown"), []byte("challenge"), nil, nil, nil)
+		if !errors.As(err, &argumentTrueError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentTrueError))
+		}
+	})
+}
@@ -0,0 +1,30 @@
+package notification
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/application"
+)
+
+type ConsoleNotifier struct {
+	mu     sync.Mutex
+	writer io.Writer
+}
+
+func NewConsoleNotifier(aWriter io.Writer) *ConsoleNotifier {
+	return &ConsoleNotifier{writer: aWriter}
+}
+
+func (consoleNotifier *ConsoleNotifier) Notify(aNotification *application.Notification) error {
+	consoleNotifier.mu.Lock()
+	defer consoleNotifier.mu.Unlock()
+
+	return writeNotification(consoleNotifier.writer, aNotification)
+}
+
+func writeNotification(aWriter io.Writer, aNotification *application.Notification) error {
+	_, err := fmt.Fprintf(aWriter, "To: %s\nSubject: %s\n\n%s\n", aNotification.Recipient(), aNotification.Subject(), aNotification.Body())
+	return err
+}
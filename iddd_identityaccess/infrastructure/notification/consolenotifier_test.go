@@ -0,0 +1,21 @@
+package notification
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/application"
+)
+
+func TestConsoleNotifier(t *testing.T) {
+	buffer := &bytes.Buffer{}
+
+	if err := NewConsoleNotifier(buffer).Notify(application.NewNotification("zoe@saasovation.com", "Password reset", "token")); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "To: zoe@saasovation.com\nSubject: Password reset\n\ntoken\n"
+	if got := buffer.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
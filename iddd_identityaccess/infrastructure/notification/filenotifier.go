@@ -0,0 +1,35 @@
+package notification
+
+import (
+	"os"
+	"sync"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/application"
+)
+
+// FileNotifier appends every notification to a file readable only by its owner, as notifications may carry tokens.
+type FileNotifier struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileNotifier(aPath string) *FileNotifier {
+	return &FileNotifier{path: aPath}
+}
+
+func (fileNotifier *FileNotifier) Notify(aNotification *application.Notification) (err error) {
+	fileNotifier.mu.Lock()
+	defer fileNotifier.mu.Unlock()
+
+	file, err := os.OpenFile(fileNotifier.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	return writeNotification(file, aNotification)
+}
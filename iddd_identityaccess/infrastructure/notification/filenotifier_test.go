@@ -0,0 +1,37 @@
+package notification
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/application"
+)
+
+func TestFileNotifier(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notifications.txt")
+	fileNotifier := NewFileNotifier(path)
+
+	if err := fileNotifier.Notify(application.NewNotification("zoe@saasovation.com", "Password reset", "first")); err != nil {
+		t.Fatal(err)
+	}
+	if err := fileNotifier.Notify(application.NewNotification("zoe@saasovation.com", "Password reset", "second")); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "To: zoe@saasovation.com\nSubject: Password reset\n\nfirst\nTo: zoe@saasovation.com\nSubject: Password reset\n\nsecond\n"
+	if string(got) != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("got mode %v, want %v", info.Mode().Perm(), os.FileMode(0600))
+	}
+}
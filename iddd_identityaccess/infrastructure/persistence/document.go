@@ -0,0 +1,328 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/totp"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/access"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+// The documents are the JSON the file snapshot and the SQL repositories store the aggregates as.
+
+type tenantDocument struct {
+	TenantId                          identity.TenantId                `json:"tenantId"`
+	Name                              string                           `json:"name"`
+	Active                            bool                             `json:"active"`
+	MultiFactorAuthenticationRequired bool                             `json:"multiFactorAuthenticationRequired,omitempty"`
+	VerifiedEmailAddressRequired      bool                             `json:"verifiedEmailAddressRequired,omitempty"`
+	RegistrationInvitations           []registrationInvitationDocument `json:"registrationInvitations,omitempty"`
+	ProvisioningTokenHash             string                           `json:"provisioningTokenHash,omitempty"`
+}
+
+type registrationInvitationDocument struct {
+	InvitationId string    `json:"invitationId"`
+	Description  string    `json:"description"`
+	StartingOn   time.Time `json:"startingOn"`
+	Until        time.Time `json:"until"`
+}
+
+func newTenantDocument(aTenant *identity.Tenant) tenantDocument {
+	state := aTenant.State()
+	document := tenantDocument{
+		TenantId:                          state.TenantId,
+		Name:                              state.Name,
+		Active:                            state.Active,
+		MultiFactorAuthenticationRequired: state.MultiFactorAuthenticationRequired,
+		VerifiedEmailAddressRequired:      state.VerifiedEmailAddressRequired,
+		ProvisioningTokenHash:             state.ProvisioningTokenHash,
+	}
+	for _, registrationInvitation := range state.RegistrationInvitations {
+		document.RegistrationInvitations = append(document.RegistrationInvitations, registrationInvitationDocument(registrationInvitation))
+	}
+	return document
+}
+
+func (document tenantDocument) tenant() *identity.Tenant {
+	state := identity.TenantState{
+		TenantId:                          document.TenantId,
+		Name:                              document.Name,
+		Active:                            document.Active,
+		MultiFactorAuthenticationRequired: document.MultiFactorAuthenticationRequired,
+		VerifiedEmailAddressRequired:      document.VerifiedEmailAddressRequired,
+		ProvisioningTokenHash:             document.ProvisioningTokenHash,
+	}
+	for _, registrationInvitation := range document.RegistrationInvitations {
+		state.RegistrationInvitations = append(state.RegistrationInvitations, identity.RegistrationInvitationState(registrationInvitation))
+	}
+	return identity.NewTenantFromState(state)
+}
+
+type userDocument struct {
+	ConcurrencyVersion        int                          `json:"concurrencyVersion"`
+	TenantId                  identity.TenantId            `json:"tenantId"`
+	Username                  string                       `json:"username"`
+	Password                  string                       `json:"password"`
+	Enablement                enablementDocument           `json:"enablement"`
+	Person                    personDocument               `json:"person"`
+	Attributes                map[string]string            `json:"attributes,omitempty"`
+	FailedAuthenticationCount int                          `json:"failedAuthenticationCount,omitempty"`
+	FailedRecoveryCodeCount   int                          `json:"failedRecoveryCodeCount,omitempty"`
+	LockedOutUntil            time.Time                    `json:"lockedOutUntil"`
+	TotpAuthenticator         *totpAuthenticatorDocument   `json:"totpAuthenticator,omitempty"`
+	WebAuthnCredentials       []webAuthnCredentialDocument `json:"webAuthnCredentials,omitempty"`
+}
+
+type enablementDocument struct {
+	Enabled   bool      `json:"enabled"`
+	StartDate time.Time `json:"startDate"`
+	EndDate   time.Time `json:"endDate"`
+}
+
+type personDocument struct {
+	FirstName            string `json:"firstName"`
+	LastName             string `json:"lastName"`
+	EmailAddress         string `json:"emailAddress"`
+	EmailAddressVerified bool   `json:"emailAddressVerified,omitempty"`
+}
+
+type totpAuthenticatorDocument struct {
+	Secret        []byte         `json:"secret"`
+	Algorithm     totp.Algorithm `json:"algorithm"`
+	Confirmed     bool           `json:"confirmed"`
+	LastUsedStep  int64          `json:"lastUsedStep"`
+	RecoveryCodes []string       `json:"recoveryCodes"`
+}
+
+type webAuthnCredentialDocument struct {
+	CredentialId []byte    `json:"credentialId"`
+	PublicKey    []byte    `json:"publicKey"`
+	SignCount    uint32    `json:"signCount"`
+	Transports   []string  `json:"transports,omitempty"`
+	RegisteredOn time.Time `json:"registeredOn"`
+	LastUsedOn   time.Time `json:"lastUsedOn"`
+}
+
+func newUserDocument(aUser *identity.User) userDocument {
+	state := aUser.State()
+	document := userDocument{
+		ConcurrencyVersion:        state.ConcurrencyVersion,
+		TenantId:                  state.TenantId,
+		Username:                  state.Username,
+		Password:                  state.Password,
+		Enablement:                enablementDocument(state.Enablement),
+		Person:                    personDocument(state.Person),
+		Attributes:                state.Attributes,
+		FailedAuthenticationCount: state.FailedAuthenticationCount,
+		FailedRecoveryCodeCount:   state.FailedRecoveryCodeCount,
+		LockedOutUntil:            state.LockedOutUntil,
+	}
+	if state.TotpAuthenticator != nil {
+		totpAuthenticator := totpAuthenticatorDocument(*state.TotpAuthenticator)
+		document.TotpAuthenticator = &totpAuthenticator
+	}
+	for _, webAuthnCredential := range state.WebAuthnCredentials {
+		document.WebAuthnCredentials = append(document.WebAuthnCredentials, webAuthnCredentialDocument(webAuthnCredential))
+	}
+	return document
+}
+
+func (document userDocument) user() *identity.User {
+	state := identity.UserState{
+		ConcurrencyVersion:        document.ConcurrencyVersion,
+		TenantId:                  document.TenantId,
+		Username:                  document.Username,
+		Password:                  document.Password,
+		Enablement:                identity.EnablementState(document.Enablement),
+		Person:                    identity.PersonState(document.Person),
+		Attributes:                document.Attributes,
+		FailedAuthenticationCount: document.FailedAuthenticationCount,
+		FailedRecoveryCodeCount:   document.FailedRecoveryCodeCount,
+		LockedOutUntil:            document.LockedOutUntil,
+	}
+	if document.TotpAuthenticator != nil {
+		totpAuthenticator := identity.TotpAuthenticatorState(*document.TotpAuthenticator)
+		state.TotpAuthenticator = &totpAuthenticator
+	}
+	for _, webAuthnCredential := range document.WebAuthnCredentials {
+		state.WebAuthnCredentials = append(state.WebAuthnCredentials, identity.WebAuthnCredentialState(webAuthnCredential))
+	}
+	return identity.NewUserFromState(state)
+}
+
+type groupDocument struct {
+	ConcurrencyVersion int                   `json:"concurrencyVersion"`
+	TenantId           identity.TenantId     `json:"tenantId"`
+	Name               string                `json:"name"`
+	Description        string                `json:"description"`
+	GroupMembers       []groupMemberDocument `json:"groupMembers,omitempty"`
+}
+
+type groupMemberDocument struct {
+	Name string                   `json:"name"`
+	Type identity.GroupMemberType `json:"type"`
+}
+
+func newGroupDocument(aGroup *identity.Group) groupDocument {
+	return newGroupDocumentOfState(aGroup.State())
+}
+
+func newGroupDocumentOfState(aState identity.GroupState) groupDocument {
+	document := groupDocument{ConcurrencyVersion: aState.ConcurrencyVersion, TenantId: aState.TenantId, Name: aState.Name, Description: aState.Description}
+	for _, groupMember := range aState.GroupMembers {
+		document.GroupMembers = append(document.GroupMembers, groupMemberDocument(groupMember))
+	}
+	return document
+}
+
+func (document groupDocument) group() *identity.Group {
+	state := identity.GroupState{ConcurrencyVersion: document.ConcurrencyVersion, TenantId: document.TenantId, Name: document.Name, Description: document.Description}
+	for _, groupMember := range document.GroupMembers {
+		state.GroupMembers = append(state.GroupMembers, identity.GroupMemberState(groupMember))
+	}
+	return identity.NewGroupFromState(state)
+}
+
+type roleDocument struct {
+	TenantId           identity.TenantId    `json:"tenantId"`
+	Name               string               `json:"name"`
+	Description        string               `json:"description"`
+	SupportsNesting    bool                 `json:"supportsNesting"`
+	ExclusiveRoleNames []string             `json:"exclusiveRoleNames,omitempty"`
+	Permissions        []permissionDocument `json:"permissions,omitempty"`
+	Group              groupDocument        `json:"group"`
+}
+
+type permissionDocument struct {
+	Resource  string                  `json:"resource"`
+	Action    string                  `json:"action"`
+	Effect    access.PermissionEffect `json:"effect"`
+	Condition *conditionDocument      `json:"condition,omitempty"`
+}
+
+type conditionDocument struct {
+	Name       access.ConditionName `json:"name"`
+	Arguments  []string             `json:"arguments,omitempty"`
+	Conditions []conditionDocument  `json:"conditions,omitempty"`
+}
+
+func newRoleDocument(aRole *access.Role) roleDocument {
+	state := aRole.State()
+	document := roleDocument{
+		TenantId:           state.TenantId,
+		Name:               state.Name,
+		Description:        state.Description,
+		SupportsNesting:    state.SupportsNesting,
+		ExclusiveRoleNames: state.ExclusiveRoleNames,
+		Group:              newGroupDocumentOfState(state.Group),
+	}
+	for _, permission := range state.Permissions {
+		permissionDocument := permissionDocument{Resource: permission.Resource(), Action: permission.Action(), Effect: permission.Effect()}
+		if condition := permission.Condition(); condition != nil {
+			conditionDocument := newConditionDocument(condition)
+			permissionDocument.Condition = &conditionDocument
+		}
+		document.Permissions = append(document.Permissions, permissionDocument)
+	}
+	return document
+}
+
+func (document roleDocument) role() (*access.Role, error) {
+	state := access.RoleState{
+		TenantId:           document.TenantId,
+		Name:               document.Name,
+		Description:        document.Description,
+		SupportsNesting:    document.SupportsNesting,
+		ExclusiveRoleNames: document.ExclusiveRoleNames,
+		Group:              document.Group.group().State(),
+	}
+	for _, permissionDocument := range document.Permissions {
+		var condition *access.Condition
+		if permissionDocument.Condition != nil {
+			var err error
+			if condition, err = permissionDocument.Condition.condition(); err != nil {
+				return nil, err
+			}
+		}
+		permission, err := access.NewPermission(permissionDocument.Resource, permissionDocument.Action, permissionDocument.Effect, condition)
+		if err != nil {
+			return nil, err
+		}
+		state.Permissions = append(state.Permissions, *permission)
+	}
+	return access.NewRoleFromState(state), nil
+}
+
+func newConditionDocument(aCondition *access.Condition) conditionDocument {
+	document := conditionDocument{Name: aCondition.Name(), Arguments: aCondition.Arguments()}
+	for _, condition := range aCondition.Conditions() {
+		document.Conditions = append(document.Conditions, newConditionDocument(condition))
+	}
+	return document
+}
+
+func (document conditionDocument) condition() (*access.Condition, error) {
+	conditions := []*access.Condition{}
+	for _, conditionDocument := range document.Conditions {
+		condition, err := conditionDocument.condition()
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+	return access.NewCondition(document.Name, document.Arguments, conditions)
+}
+
+type refreshTokenDocument struct {
+	TokenHash string            `json:"tokenHash"`
+	FamilyId  string            `json:"familyId"`
+	TenantId  identity.TenantId `json:"tenantId"`
+	Username  string            `json:"username"`
+	IssuedOn  time.Time         `json:"issuedOn"`
+	ExpiresOn time.Time         `json:"expiresOn"`
+	Rotated   bool              `json:"rotated,omitempty"`
+	Revoked   bool              `json:"revoked,omitempty"`
+}
+
+func newRefreshTokenDocument(aRefreshToken *identity.RefreshToken) refreshTokenDocument {
+	return refreshTokenDocument(aRefreshToken.State())
+}
+
+func (document refreshTokenDocument) refreshToken() *identity.RefreshToken {
+	return identity.NewRefreshTokenFromState(identity.RefreshTokenState(document))
+}
+
+type passwordResetTokenDocument struct {
+	TokenHash string            `json:"tokenHash"`
+	TenantId  identity.TenantId `json:"tenantId"`
+	Username  string            `json:"username"`
+	IssuedOn  time.Time         `json:"issuedOn"`
+	ExpiresOn time.Time         `json:"expiresOn"`
+	Used      bool              `json:"used,omitempty"`
+}
+
+func newPasswordResetTokenDocument(aPasswordResetToken *identity.PasswordResetToken) passwordResetTokenDocument {
+	return passwordResetTokenDocument(aPasswordResetToken.State())
+}
+
+func (document passwordResetTokenDocument) passwordResetToken() *identity.PasswordResetToken {
+	return identity.NewPasswordResetTokenFromState(identity.PasswordResetTokenState(document))
+}
+
+type emailVerificationTokenDocument struct {
+	TokenHash    string            `json:"tokenHash"`
+	TenantId     identity.TenantId `json:"tenantId"`
+	Username     string            `json:"username"`
+	EmailAddress string            `json:"emailAddress"`
+	IssuedOn     time.Time         `json:"issuedOn"`
+	ExpiresOn    time.Time         `json:"expiresOn"`
+	Used         bool              `json:"used,omitempty"`
+}
+
+func newEmailVerificationTokenDocument(anEmailVerificationToken *identity.EmailVerificationToken) emailVerificationTokenDocument {
+	return emailVerificationTokenDocument(anEmailVerificationToken.State())
+}
+
+func (document emailVerificationTokenDocument) emailVerificationToken() *identity.EmailVerificationToken {
+	return identity.NewEmailVerificationTokenFromState(identity.EmailVerificationTokenState(document))
+}
@@ -0,0 +1,161 @@
+package persistence
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/totp"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/access"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+	"github.com/google/go-cmp/cmp"
+)
+
+var stateComparers = cmp.Options{
+	cmp.Comparer(func(aTenantId identity.TenantId, otherTenantId identity.TenantId) bool {
+		return aTenantId.Equals(&otherTenantId)
+	}),
+	cmp.Comparer(func(aPermission access.Permission, otherPermission access.Permission) bool {
+		return aPermission.Equals(otherPermission)
+	}),
+}
+
+func roundTrip(t *testing.T, aDocument interface{}, aResult interface{}) {
+	t.Helper()
+
+	data, err := json.Marshal(aDocument)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, aResult); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTenantDocument(t *testing.T) {
+	startingOn := time.Now().Truncate(time.Second)
+	want := identity.TenantState{
+		TenantId:                          *tenantId,
+		Name:                              "TenantName",
+		Active:                            true,
+		MultiFactorAuthenticationRequired: true,
+		RegistrationInvitations:           []identity.RegistrationInvitationState{{InvitationId: "invitation-1", Description: "An invitation.", StartingOn: startingOn, Until: startingOn.AddDate(0, 0, 7)}},
+		ProvisioningTokenHash:             "provisioning-token-hash",
+	}
+
+	var got tenantDocument
+	roundTrip(t, newTenantDocument(identity.NewTenantFromState(want)), &got)
+
+	if diff := cmp.Diff(want, got.tenant().State(), stateComparers); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestUserDocument(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	want := identity.UserState{
+		ConcurrencyVersion:        3,
+		TenantId:                  *tenantId,
+		Username:                  "zoeusername",
+		Password:                  "$2a$10$hashed",
+		Enablement:                identity.EnablementState{Enabled: true, StartDate: now.AddDate(-1, 0, 0), EndDate: now.AddDate(1, 0, 0)},
+		Person:                    identity.PersonState{FirstName: "Zoe", LastName: "Doe", EmailAddress: "zoe@saasovation.com", EmailAddressVerified: true},
+		Attributes:                map[string]string{"department": "Sales"},
+		FailedAuthenticationCount: 2,
+		FailedRecoveryCodeCount:   1,
+		LockedOutUntil:            now,
+		TotpAuthenticator:         &identity.TotpAuthenticatorState{Secret: []byte("12345678901234567890"), Algorithm: totp.ALGORITHM_SHA1, Confirmed: true, LastUsedStep: 42, RecoveryCodes: []string{"hashed"}},
+		WebAuthnCredentials:       []identity.WebAuthnCredentialState{{CredentialId: []byte{1, 2, 3}, PublicKey: []byte{4, 5, 6}, SignCount: 7, Transports: []string{"usb"}, RegisteredOn: now}},
+	}
+	user := identity.NewUserFromState(want)
+
+	var got userDocument
+	roundTrip(t, newUserDocument(user), &got)
+
+	if diff := cmp.Diff(want, got.user().State(), stateComparers); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+
+	data, err := json.Marshal(user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), want.Password) {
+		t.Errorf("the user must not marshal its password: %s", data)
+	}
+}
+
+func TestGroupDocument(t *testing.T) {
+	want := identity.GroupState{
+		ConcurrencyVersion: 3,
+		TenantId:           *tenantId,
+		Name:               "GroupName",
+		Description:        "A group description.",
+		GroupMembers: []identity.GroupMemberState{
+			{Name: "zoeusername", Type: identity.GROUP_MEMBER_TYPE_USER},
+			{Name: "MemberGroupName", Type: identity.GROUP_MEMBER_TYPE_GROUP},
+		},
+	}
+
+	var got groupDocument
+	roundTrip(t, newGroupDocument(identity.NewGroupFromState(want)), &got)
+
+	if diff := cmp.Diff(want, got.group().State(), stateComparers); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestRoleDocument(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		role, err := access.NewRole(*tenantId, "Manager", "A manager.", true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		auditor, err := access.NewRole(*tenantId, "Auditor", "An auditor.", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := role.ExcludeRole(auditor); err != nil {
+			t.Fatal(err)
+		}
+		condition := access.AllOf(access.TenantIs(*tenantId), access.Not(access.UserAttributeEquals("department", "engineering")))
+		for _, condition := range []*access.Condition{nil, condition} {
+			permission, err := access.NewPermission("reports/*", "read", access.PERMISSION_EFFECT_ALLOW, condition)
+			if err != nil {
+				t.Fatal(err)
+			}
+			role.GrantPermission(*permission)
+		}
+		user := newUser(t, "zoeusername", "zoe@saasovation.com")
+		if err := access.NewRoleAssignmentService(NewInMemoryRoleRepository(), identity.NewGroupMemberService(NewInMemoryUserRepository(), NewInMemoryGroupRepository())).AssignUser(role, user); err != nil {
+			t.Fatal(err)
+		}
+
+		var document roleDocument
+		roundTrip(t, newRoleDocument(role), &document)
+		got, err := document.role()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(role.State(), got.State(), stateComparers); diff != "" {
+			t.Errorf("mismatch (-want, +got):\n%s", diff)
+		}
+	})
+	t.Run("fail unknown condition", func(t *testing.T) {
+		data := []byte(`{"tenantId":"` + tenantId.Id() + `","name":"Manager","permissions":[{"resource":"reports/*","action":"read","effect":1,"condition":{"name":"Always"}}]}`)
+		var document roleDocument
+		if err := json.Unmarshal(data, &document); err != nil {
+			t.Fatal(err)
+		}
+
+		var argumentTrueError *ierrors.ArgumentTrueError
+		if _, err := document.role(); !errors.As(err, &argumentTrueError) {
+			t.Errorf("err type:%v, expect type: %v", reflect.TypeOf(errors.Unwrap(err)), reflect.TypeOf(&argumentTrueError))
+		}
+	})
+}
@@ -0,0 +1,229 @@
+package persistence
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+// FileSnapshot keeps the in-memory repositories and writes them to a single JSON file, readable only by its owner as it holds password hashes.
+type FileSnapshot struct {
+	path             string
+	tenantRepository *InMemoryTenantRepository
+	userRepository   *InMemoryUserRepository
+	groupRepository  *InMemoryGroupRepository
+	roleRepository   *InMemoryRoleRepository
+
+	passwordResetTokenRepository     *InMemoryPasswordResetTokenRepository
+	emailVerificationTokenRepository *InMemoryEmailVerificationTokenRepository
+	refreshTokenRepository           *InMemoryRefreshTokenRepository
+}
+
+type snapshotDocument struct {
+	Tenants []tenantDocument `json:"tenants"`
+	Users   []userDocument   `json:"users"`
+	Groups  []groupDocument  `json:"groups"`
+	Roles   []roleDocument   `json:"roles"`
+
+	PasswordResetTokens         []passwordResetTokenDocument     `json:"passwordResetTokens,omitempty"`
+	EmailVerificationTokens     []emailVerificationTokenDocument `json:"emailVerificationTokens,omitempty"`
+	RefreshTokens               []refreshTokenDocument           `json:"refreshTokens,omitempty"`
+	RevokedRefreshTokenFamilies []string                         `json:"revokedRefreshTokenFamilies,omitempty"`
+}
+
+// LoadFileSnapshot starts from empty repositories when aPath does not exist yet.
+func LoadFileSnapshot(aPath string) (_ *FileSnapshot, err error) {
+	defer ierrors.Wrap(&err, "filesnapshot.LoadFileSnapshot(%s)", aPath)
+
+	fileSnapshot := &FileSnapshot{
+		path:             aPath,
+		tenantRepository: NewInMemoryTenantRepository(),
+		userRepository:   NewInMemoryUserRepository(),
+		groupRepository:  NewInMemoryGroupRepository(),
+		roleRepository:   NewInMemoryRoleRepository(),
+
+		passwordResetTokenRepository:     NewInMemoryPasswordResetTokenRepository(),
+		emailVerificationTokenRepository: NewInMemoryEmailVerificationTokenRepository(),
+		refreshTokenRepository:           NewInMemoryRefreshTokenRepository(),
+	}
+
+	data, err := os.ReadFile(aPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fileSnapshot, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var document snapshotDocument
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	// Loading bypasses Add, which would advance every concurrency version.
+	for _, tenantDocument := range document.Tenants {
+		tenant := tenantDocument.tenant()
+		fileSnapshot.tenantRepository.repository[tenant.TenantId()] = tenant
+	}
+	for _, userDocument := range document.Users {
+		fileSnapshot.userRepository.put(userDocument.user())
+	}
+	for _, groupDocument := range document.Groups {
+		group := groupDocument.group()
+		fileSnapshot.groupRepository.repository[groupKey{tenantId: group.TenantId(), name: group.Name()}] = group
+	}
+	for _, roleDocument := range document.Roles {
+		role, err := roleDocument.role()
+		if err != nil {
+			return nil, err
+		}
+		fileSnapshot.roleRepository.repository[roleKey{tenantId: role.TenantId(), name: role.Name()}] = role
+	}
+	for _, passwordResetTokenDocument := range document.PasswordResetTokens {
+		fileSnapshot.passwordResetTokenRepository.repository[passwordResetTokenDocument.TokenHash] = passwordResetTokenDocument.passwordResetToken()
+	}
+	for _, emailVerificationTokenDocument := range document.EmailVerificationTokens {
+		fileSnapshot.emailVerificationTokenRepository.repository[emailVerificationTokenDocument.TokenHash] = emailVerificationTokenDocument.emailVerificationToken()
+	}
+	for _, refreshTokenDocument := range document.RefreshTokens {
+		fileSnapshot.refreshTokenRepository.repository[refreshTokenDocument.TokenHash] = refreshTokenDocument.refreshToken()
+	}
+	for _, familyId := range document.RevokedRefreshTokenFamilies {
+		fileSnapshot.refreshTokenRepository.revokedFamilies[familyId] = true
+	}
+	return fileSnapshot, nil
+}
+
+func (fileSnapshot *FileSnapshot) TenantRepository() *InMemoryTenantRepository {
+	return fileSnapshot.tenantRepository
+}
+
+func (fileSnapshot *FileSnapshot) UserRepository() *InMemoryUserRepository {
+	return fileSnapshot.userRepository
+}
+
+func (fileSnapshot *FileSnapshot) GroupRepository() *InMemoryGroupRepository {
+	return fileSnapshot.groupRepository
+}
+
+func (fileSnapshot *FileSnapshot) RoleRepository() *InMemoryRoleRepository {
+	return fileSnapshot.roleRepository
+}
+
+func (fileSnapshot *FileSnapshot) PasswordResetTokenRepository() *InMemoryPasswordResetTokenRepository {
+	return fileSnapshot.passwordResetTokenRepository
+}
+
+func (fileSnapshot *FileSnapshot) EmailVerificationTokenRepository() *InMemoryEmailVerificationTokenRepository {
+	return fileSnapshot.emailVerificationTokenRepository
+}
+
+func (fileSnapshot *FileSnapshot) RefreshTokenRepository() *InMemoryRefreshTokenRepository {
+	return fileSnapshot.refreshTokenRepository
+}
+
+// Save replaces the file through a rename so that a failed write never leaves a truncated snapshot behind.
+func (fileSnapshot *FileSnapshot) Save() (err error) {
+	defer ierrors.Wrap(&err, "filesnapshot.Save(%s)", fileSnapshot.path)
+
+	data, err := json.MarshalIndent(fileSnapshot.document(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(fileSnapshot.path), filepath.Base(fileSnapshot.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), fileSnapshot.path)
+}
+
+func (fileSnapshot *FileSnapshot) document() snapshotDocument {
+	document := snapshotDocument{Tenants: []tenantDocument{}, Users: []userDocument{}, Groups: []groupDocument{}, Roles: []roleDocument{}}
+
+	fileSnapshot.tenantRepository.mu.RLock()
+	for _, tenant := range fileSnapshot.tenantRepository.repository {
+		document.Tenants = append(document.Tenants, newTenantDocument(tenant))
+	}
+	fileSnapshot.tenantRepository.mu.RUnlock()
+	sort.Slice(document.Tenants, func(i, j int) bool {
+		return isOrderedByTenantAndName(document.Tenants[i].TenantId, "", document.Tenants[j].TenantId, "")
+	})
+
+	fileSnapshot.userRepository.mu.RLock()
+	for _, user := range fileSnapshot.userRepository.repository {
+		document.Users = append(document.Users, newUserDocument(user))
+	}
+	fileSnapshot.userRepository.mu.RUnlock()
+	sort.Slice(document.Users, func(i, j int) bool {
+		return isOrderedByTenantAndName(document.Users[i].TenantId, document.Users[i].Username, document.Users[j].TenantId, document.Users[j].Username)
+	})
+
+	fileSnapshot.groupRepository.mu.RLock()
+	for _, group := range fileSnapshot.groupRepository.repository {
+		document.Groups = append(document.Groups, newGroupDocument(group))
+	}
+	fileSnapshot.groupRepository.mu.RUnlock()
+	sort.Slice(document.Groups, func(i, j int) bool {
+		return isOrderedByTenantAndName(document.Groups[i].TenantId, document.Groups[i].Name, document.Groups[j].TenantId, document.Groups[j].Name)
+	})
+
+	fileSnapshot.roleRepository.mu.RLock()
+	for _, role := range fileSnapshot.roleRepository.repository {
+		document.Roles = append(document.Roles, newRoleDocument(role))
+	}
+	fileSnapshot.roleRepository.mu.RUnlock()
+	sort.Slice(document.Roles, func(i, j int) bool {
+		return isOrderedByTenantAndName(document.Roles[i].TenantId, document.Roles[i].Name, document.Roles[j].TenantId, document.Roles[j].Name)
+	})
+
+	fileSnapshot.passwordResetTokenRepository.mu.RLock()
+	for _, passwordResetToken := range fileSnapshot.passwordResetTokenRepository.repository {
+		document.PasswordResetTokens = append(document.PasswordResetTokens, newPasswordResetTokenDocument(passwordResetToken))
+	}
+	fileSnapshot.passwordResetTokenRepository.mu.RUnlock()
+	sort.Slice(document.PasswordResetTokens, func(i, j int) bool {
+		return document.PasswordResetTokens[i].TokenHash < document.PasswordResetTokens[j].TokenHash
+	})
+
+	fileSnapshot.emailVerificationTokenRepository.mu.RLock()
+	for _, emailVerificationToken := range fileSnapshot.emailVerificationTokenRepository.repository {
+		document.EmailVerificationTokens = append(document.EmailVerificationTokens, newEmailVerificationTokenDocument(emailVerificationToken))
+	}
+	fileSnapshot.emailVerificationTokenRepository.mu.RUnlock()
+	sort.Slice(document.EmailVerificationTokens, func(i, j int) bool {
+		return document.EmailVerificationTokens[i].TokenHash < document.EmailVerificationTokens[j].TokenHash
+	})
+
+	fileSnapshot.refreshTokenRepository.mu.RLock()
+	for _, refreshToken := range fileSnapshot.refreshTokenRepository.repository {
+		document.RefreshTokens = append(document.RefreshTokens, newRefreshTokenDocument(refreshToken))
+	}
+	for familyId := range fileSnapshot.refreshTokenRepository.revokedFamilies {
+		document.RevokedRefreshTokenFamilies = append(document.RevokedRefreshTokenFamilies, familyId)
+	}
+	fileSnapshot.refreshTokenRepository.mu.RUnlock()
+	sort.Slice(document.RefreshTokens, func(i, j int) bool { return document.RefreshTokens[i].TokenHash < document.RefreshTokens[j].TokenHash })
+	sort.Strings(document.RevokedRefreshTokenFamilies)
+
+	return document
+}
+
+func isOrderedByTenantAndName(aTenantId identity.TenantId, aName string, otherTenantId identity.TenantId, otherName string) bool {
+	if aTenantId.Id() != otherTenantId.Id() {
+		return aTenantId.Id() < otherTenantId.Id()
+	}
+	return aName < otherName
+}
@@ -0,0 +1,129 @@
+package persistence
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/access"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+func TestFileSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+
+	fileSnapshot, err := LoadFileSnapshot(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenants, err := fileSnapshot.TenantRepository().AllTenants()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tenants) != 0 {
+		t.Errorf("got %v, want no tenants", tenants)
+	}
+
+	tenant, err := identity.NewTenant(*tenantId, "TenantName", true, *identity.DefaultNameLengthPolicy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := newUser(t, "zoeusername", "zoe@saasovation.com")
+	group, err := identity.NewGroup(*tenantId, "GroupName", "A group description.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := group.AddUser(user); err != nil {
+		t.Fatal(err)
+	}
+	role, err := access.NewRole(*tenantId, "Manager", "A manager role.", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := access.NewRoleAssignmentService(NewInMemoryRoleRepository(), identity.NewGroupMemberService(NewInMemoryUserRepository(), NewInMemoryGroupRepository())).AssignUser(role, user); err != nil {
+		t.Fatal(err)
+	}
+	fileSnapshot.TenantRepository().Add(tenant)
+	fileSnapshot.UserRepository().Add(user)
+	fileSnapshot.GroupRepository().Add(group)
+	fileSnapshot.RoleRepository().Add(role)
+	refreshTokenService := identity.NewRefreshTokenService(fileSnapshot.TenantRepository(), fileSnapshot.UserRepository(), fileSnapshot.RefreshTokenRepository(), time.Hour)
+	if _, err := refreshTokenService.Issue(user); err != nil {
+		t.Fatal(err)
+	}
+	passwordResetToken, err := identity.NewPasswordResetService(fileSnapshot.PasswordResetTokenRepository(), fileSnapshot.UserRepository(), time.Hour).Issue(user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	emailVerificationToken, err := identity.NewEmailVerificationService(fileSnapshot.EmailVerificationTokenRepository(), fileSnapshot.UserRepository(), time.Hour, 0).Issue(user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fileSnapshot.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fileInfo.Mode().Perm() != 0o600 {
+		t.Errorf("got %v, want %v", fileInfo.Mode().Perm(), os.FileMode(0o600))
+	}
+
+	reloaded, err := LoadFileSnapshot(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotTenant, err := reloaded.TenantRepository().TenantOfId(*tenantId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotTenant == nil || gotTenant.Name() != "TenantName" || !gotTenant.IsActive() {
+		t.Errorf("got %v, want %v", gotTenant, tenant)
+	}
+	gotUser, err := reloaded.UserRepository().UserWithUsername(*tenantId, "zoeusername")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotUser == nil || !gotUser.Equals(*user) || !gotUser.IsEnabled() {
+		t.Errorf("got %v, want %v", gotUser, user)
+	}
+	gotGroup, err := reloaded.GroupRepository().GroupNamed(*tenantId, "GroupName")
+	if err != nil {
+		t.Fatal(err)
+	}
+	groupMemberService := identity.NewGroupMemberService(reloaded.UserRepository(), reloaded.GroupRepository())
+	isMember, err := gotGroup.IsMember(gotUser, groupMemberService)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isMember {
+		t.Errorf("user %s must stay a member of %v", gotUser.Username(), gotGroup)
+	}
+	gotRole, err := reloaded.RoleRepository().RoleNamed(*tenantId, "Manager")
+	if err != nil {
+		t.Fatal(err)
+	}
+	isInRole, err := gotRole.IsInRole(gotUser, groupMemberService)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isInRole {
+		t.Errorf("user %s must stay in role %v", gotUser.Username(), gotRole)
+	}
+	gotRefreshTokens, err := reloaded.RefreshTokenRepository().AllRefreshTokensOfUser(*tenantId, "zoeusername")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gotRefreshTokens) != 1 || !gotRefreshTokens[0].IsUsable() {
+		t.Errorf("got %v, want a usable refresh token", gotRefreshTokens)
+	}
+	if _, err := identity.NewPasswordResetService(reloaded.PasswordResetTokenRepository(), reloaded.UserRepository(), time.Hour).ResetPassword(passwordResetToken, "ytrewq!GFDSA#"); err != nil {
+		t.Errorf("the password reset token must stay redeemable: %v", err)
+	}
+	if _, err := identity.NewEmailVerificationService(reloaded.EmailVerificationTokenRepository(), reloaded.UserRepository(), time.Hour, 0).Verify(emailVerificationToken); err != nil {
+		t.Errorf("the email verification token must stay redeemable: %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package persistence
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+type InMemoryEmailVerificationTokenRepository struct {
+	mu         sync.RWMutex
+	repository map[string]*identity.EmailVerificationToken
+}
+
+func NewInMemoryEmailVerificationTokenRepository() *InMemoryEmailVerificationTokenRepository {
+	return &InMemoryEmailVerificationTokenRepository{repository: map[string]*identity.EmailVerificationToken{}}
+}
+
+func (inMemoryEmailVerificationTokenRepository *InMemoryEmailVerificationTokenRepository) Add(anEmailVerificationToken *identity.EmailVerificationToken) error {
+	inMemoryEmailVerificationTokenRepository.mu.Lock()
+	defer inMemoryEmailVerificationTokenRepository.mu.Unlock()
+
+	inMemoryEmailVerificationTokenRepository.repository[anEmailVerificationToken.TokenHash()] = anEmailVerificationToken
+	return nil
+}
+
+func (inMemoryEmailVerificationTokenRepository *InMemoryEmailVerificationTokenRepository) EmailVerificationTokenOfHash(aTokenHash string) (*identity.EmailVerificationToken, error) {
+	inMemoryEmailVerificationTokenRepository.mu.RLock()
+	defer inMemoryEmailVerificationTokenRepository.mu.RUnlock()
+
+	return inMemoryEmailVerificationTokenRepository.repository[aTokenHash], nil
+}
+
+func (inMemoryEmailVerificationTokenRepository *InMemoryEmailVerificationTokenRepository) AllEmailVerificationTokensOfUser(aTenantId identity.TenantId, aUsername string) ([]*identity.EmailVerificationToken, error) {
+	inMemoryEmailVerificationTokenRepository.mu.RLock()
+	defer inMemoryEmailVerificationTokenRepository.mu.RUnlock()
+
+	emailVerificationTokens := []*identity.EmailVerificationToken{}
+	for _, emailVerificationToken := range inMemoryEmailVerificationTokenRepository.repository {
+		if emailVerificationToken.TenantId() == aTenantId && emailVerificationToken.Username() == aUsername {
+			emailVerificationTokens = append(emailVerificationTokens, emailVerificationToken)
+		}
+	}
+	sort.Slice(emailVerificationTokens, func(i, j int) bool {
+		return emailVerificationTokens[i].IssuedOn().Before(emailVerificationTokens[j].IssuedOn())
+	})
+	return emailVerificationTokens, nil
+}
@@ -0,0 +1,40 @@
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+func TestInMemoryEmailVerificationTokenRepository(t *testing.T) {
+	testEmailVerificationTokenRepository(t, NewInMemoryEmailVerificationTokenRepository())
+}
+
+func testEmailVerificationTokenRepository(t *testing.T, aEmailVerificationTokenRepository identity.EmailVerificationTokenRepository) {
+	t.Helper()
+
+	user := newUser(t, "zoeusername", "zoe@saasovation.com")
+	emailVerificationService := identity.NewEmailVerificationService(aEmailVerificationTokenRepository, NewInMemoryUserRepository(), time.Hour, 0)
+	if _, err := emailVerificationService.Issue(user); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := emailVerificationService.Issue(user); err != nil {
+		t.Fatal(err)
+	}
+
+	ofUser, err := aEmailVerificationTokenRepository.AllEmailVerificationTokensOfUser(*tenantId, "zoeusername")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ofUser) != 2 {
+		t.Fatalf("got %d email verification tokens, want 2", len(ofUser))
+	}
+	got, err := aEmailVerificationTokenRepository.EmailVerificationTokenOfHash(ofUser[1].TokenHash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == nil || got.TokenHash() != ofUser[1].TokenHash() {
+		t.Errorf("got %v, want %v", got, ofUser[1])
+	}
+}
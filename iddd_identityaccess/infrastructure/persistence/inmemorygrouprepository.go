@@ -0,0 +1,61 @@
+package persistence
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+type groupKey struct {
+	tenantId identity.TenantId
+	name     string
+}
+
+type InMemoryGroupRepository struct {
+	mu         sync.RWMutex
+	repository map[groupKey]*identity.Group
+}
+
+func NewInMemoryGroupRepository() *InMemoryGroupRepository {
+	return &InMemoryGroupRepository{repository: map[groupKey]*identity.Group{}}
+}
+
+// Add advances the concurrency version of the group, as a database would on every update.
+func (inMemoryGroupRepository *InMemoryGroupRepository) Add(aGroup *identity.Group) error {
+	inMemoryGroupRepository.mu.Lock()
+	defer inMemoryGroupRepository.mu.Unlock()
+
+	aGroup.SetConcurrencyVersion(aGroup.ConcurrencyVersion() + 1)
+	inMemoryGroupRepository.repository[groupKey{tenantId: aGroup.TenantId(), name: aGroup.Name()}] = aGroup
+	return nil
+}
+
+func (inMemoryGroupRepository *InMemoryGroupRepository) Remove(aGroup *identity.Group) error {
+	inMemoryGroupRepository.mu.Lock()
+	defer inMemoryGroupRepository.mu.Unlock()
+
+	delete(inMemoryGroupRepository.repository, groupKey{tenantId: aGroup.TenantId(), name: aGroup.Name()})
+	return nil
+}
+
+func (inMemoryGroupRepository *InMemoryGroupRepository) AllGroups(aTenantId identity.TenantId) ([]*identity.Group, error) {
+	inMemoryGroupRepository.mu.RLock()
+	defer inMemoryGroupRepository.mu.RUnlock()
+
+	groups := []*identity.Group{}
+	for key, group := range inMemoryGroupRepository.repository {
+		if key.tenantId == aTenantId {
+			groups = append(groups, group)
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name() < groups[j].Name() })
+	return groups, nil
+}
+
+func (inMemoryGroupRepository *InMemoryGroupRepository) GroupNamed(aTenantId identity.TenantId, aName string) (*identity.Group, error) {
+	inMemoryGroupRepository.mu.RLock()
+	defer inMemoryGroupRepository.mu.RUnlock()
+
+	return inMemoryGroupRepository.repository[groupKey{tenantId: aTenantId, name: aName}], nil
+}
@@ -0,0 +1,47 @@
+package persistence
+
+import (
+	"testing"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+func TestInMemoryGroupRepository(t *testing.T) {
+	group, err := identity.NewGroup(*tenantId, "GroupA", "Group A")
+	if err != nil {
+		t.Fatal(err)
+	}
+	groupRepository := NewInMemoryGroupRepository()
+	if err := groupRepository.Add(group); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := groupRepository.GroupNamed(*tenantId, "GroupA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != group {
+		t.Errorf("got %v, want %v", got, group)
+	}
+	if got.ConcurrencyVersion() != 1 {
+		t.Errorf("got version %d, want 1", got.ConcurrencyVersion())
+	}
+	groups, err := groupRepository.AllGroups(*tenantId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 1 || groups[0] != group {
+		t.Errorf("got %v, want [%v]", groups, group)
+	}
+
+	if err := groupRepository.Remove(group); err != nil {
+		t.Fatal(err)
+	}
+	got, err = groupRepository.GroupNamed(*tenantId, "GroupA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != nil {
+		t.Errorf("got %v, want nil", got)
+	}
+}
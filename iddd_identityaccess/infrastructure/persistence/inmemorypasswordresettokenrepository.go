@@ -0,0 +1,48 @@
+package persistence
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+type InMemoryPasswordResetTokenRepository struct {
+	mu         sync.RWMutex
+	repository map[string]*identity.PasswordResetToken
+}
+
+func NewInMemoryPasswordResetTokenRepository() *InMemoryPasswordResetTokenRepository {
+	return &InMemoryPasswordResetTokenRepository{repository: map[string]*identity.PasswordResetToken{}}
+}
+
+func (inMemoryPasswordResetTokenRepository *InMemoryPasswordResetTokenRepository) Add(aPasswordResetToken *identity.PasswordResetToken) error {
+	inMemoryPasswordResetTokenRepository.mu.Lock()
+	defer inMemoryPasswordResetTokenRepository.mu.Unlock()
+
+	inMemoryPasswordResetTokenRepository.repository[aPasswordResetToken.TokenHash()] = aPasswordResetToken
+	return nil
+}
+
+func (inMemoryPasswordResetTokenRepository *InMemoryPasswordResetTokenRepository) PasswordResetTokenOfHash(aTokenHash string) (*identity.PasswordResetToken, error) {
+	inMemoryPasswordResetTokenRepository.mu.RLock()
+	defer inMemoryPasswordResetTokenRepository.mu.RUnlock()
+
+	return inMemoryPasswordResetTokenRepository.repository[aTokenHash], nil
+}
+
+func (inMemoryPasswordResetTokenRepository *InMemoryPasswordResetTokenRepository) AllPasswordResetTokensOfUser(aTenantId identity.TenantId, aUsername string) ([]*identity.PasswordResetToken, error) {
+	inMemoryPasswordResetTokenRepository.mu.RLock()
+	defer inMemoryPasswordResetTokenRepository.mu.RUnlock()
+
+	passwordResetTokens := []*identity.PasswordResetToken{}
+	for _, passwordResetToken := range inMemoryPasswordResetTokenRepository.repository {
+		if passwordResetToken.TenantId() == aTenantId && passwordResetToken.Username() == aUsername {
+			passwordResetTokens = append(passwordResetTokens, passwordResetToken)
+		}
+	}
+	sort.Slice(passwordResetTokens, func(i, j int) bool {
+		return passwordResetTokens[i].IssuedOn().Before(passwordResetTokens[j].IssuedOn())
+	})
+	return passwordResetTokens, nil
+}
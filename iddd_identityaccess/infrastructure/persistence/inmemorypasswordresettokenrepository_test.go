@@ -0,0 +1,40 @@
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+func TestInMemoryPasswordResetTokenRepository(t *testing.T) {
+	testPasswordResetTokenRepository(t, NewInMemoryPasswordResetTokenRepository())
+}
+
+func testPasswordResetTokenRepository(t *testing.T, aPasswordResetTokenRepository identity.PasswordResetTokenRepository) {
+	t.Helper()
+
+	user := newUser(t, "zoeusername", "zoe@saasovation.com")
+	passwordResetService := identity.NewPasswordResetService(aPasswordResetTokenRepository, NewInMemoryUserRepository(), time.Hour)
+	if _, err := passwordResetService.Issue(user); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := passwordResetService.Issue(user); err != nil {
+		t.Fatal(err)
+	}
+
+	ofUser, err := aPasswordResetTokenRepository.AllPasswordResetTokensOfUser(*tenantId, "zoeusername")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ofUser) != 2 {
+		t.Fatalf("got %d password reset tokens, want 2", len(ofUser))
+	}
+	got, err := aPasswordResetTokenRepository.PasswordResetTokenOfHash(ofUser[1].TokenHash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == nil || got.TokenHash() != ofUser[1].TokenHash() {
+		t.Errorf("got %v, want %v", got, ofUser[1])
+	}
+}
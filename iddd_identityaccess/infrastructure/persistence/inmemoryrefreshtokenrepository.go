@@ -0,0 +1,103 @@
+package persistence
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+type InMemoryRefreshTokenRepository struct {
+	mu              sync.RWMutex
+	repository      map[string]*identity.RefreshToken
+	revokedFamilies map[string]bool
+}
+
+func NewInMemoryRefreshTokenRepository() *InMemoryRefreshTokenRepository {
+	return &InMemoryRefreshTokenRepository{repository: map[string]*identity.RefreshToken{}, revokedFamilies: map[string]bool{}}
+}
+
+func (inMemoryRefreshTokenRepository *InMemoryRefreshTokenRepository) Add(aRefreshToken *identity.RefreshToken) error {
+	inMemoryRefreshTokenRepository.mu.Lock()
+	defer inMemoryRefreshTokenRepository.mu.Unlock()
+
+	refreshToken := *aRefreshToken
+	inMemoryRefreshTokenRepository.repository[aRefreshToken.TokenHash()] = &refreshToken
+	return nil
+}
+
+func (inMemoryRefreshTokenRepository *InMemoryRefreshTokenRepository) RotateRefreshToken(aRotated *identity.RefreshToken, aSuccessor *identity.RefreshToken) (bool, error) {
+	inMemoryRefreshTokenRepository.mu.Lock()
+	defer inMemoryRefreshTokenRepository.mu.Unlock()
+
+	stored, ok := inMemoryRefreshTokenRepository.repository[aRotated.TokenHash()]
+	if !ok || stored.IsRotated() || stored.IsRevoked() || inMemoryRefreshTokenRepository.revokedFamilies[stored.FamilyId()] {
+		return false, nil
+	}
+	rotated := *aRotated
+	inMemoryRefreshTokenRepository.repository[aRotated.TokenHash()] = &rotated
+	successor := *aSuccessor
+	inMemoryRefreshTokenRepository.repository[aSuccessor.TokenHash()] = &successor
+	return true, nil
+}
+
+func (inMemoryRefreshTokenRepository *InMemoryRefreshTokenRepository) RevokeRefreshTokenFamily(aFamilyId string) error {
+	inMemoryRefreshTokenRepository.mu.Lock()
+	defer inMemoryRefreshTokenRepository.mu.Unlock()
+
+	inMemoryRefreshTokenRepository.revokedFamilies[aFamilyId] = true
+	for tokenHash, stored := range inMemoryRefreshTokenRepository.repository {
+		if stored.FamilyId() != aFamilyId || stored.IsRevoked() {
+			continue
+		}
+		state := stored.State()
+		state.Revoked = true
+		inMemoryRefreshTokenRepository.repository[tokenHash] = identity.NewRefreshTokenFromState(state)
+	}
+	return nil
+}
+
+func (inMemoryRefreshTokenRepository *InMemoryRefreshTokenRepository) RefreshTokenOfHash(aTokenHash string) (*identity.RefreshToken, error) {
+	inMemoryRefreshTokenRepository.mu.RLock()
+	defer inMemoryRefreshTokenRepository.mu.RUnlock()
+
+	stored, ok := inMemoryRefreshTokenRepository.repository[aTokenHash]
+	if !ok {
+		return nil, nil
+	}
+	refreshToken := *stored
+	return &refreshToken, nil
+}
+
+func (inMemoryRefreshTokenRepository *InMemoryRefreshTokenRepository) AllRefreshTokensOfFamily(aFamilyId string) ([]*identity.RefreshToken, error) {
+	return inMemoryRefreshTokenRepository.allRefreshTokens(func(aRefreshToken *identity.RefreshToken) bool {
+		return aRefreshToken.FamilyId() == aFamilyId
+	}), nil
+}
+
+func (inMemoryRefreshTokenRepository *InMemoryRefreshTokenRepository) AllRefreshTokensOfUser(aTenantId identity.TenantId, aUsername string) ([]*identity.RefreshToken, error) {
+	return inMemoryRefreshTokenRepository.allRefreshTokens(func(aRefreshToken *identity.RefreshToken) bool {
+		return aRefreshToken.TenantId() == aTenantId && aRefreshToken.Username() == aUsername
+	}), nil
+}
+
+func (inMemoryRefreshTokenRepository *InMemoryRefreshTokenRepository) AllRefreshTokensOfTenant(aTenantId identity.TenantId) ([]*identity.RefreshToken, error) {
+	return inMemoryRefreshTokenRepository.allRefreshTokens(func(aRefreshToken *identity.RefreshToken) bool {
+		return aRefreshToken.TenantId() == aTenantId
+	}), nil
+}
+
+func (inMemoryRefreshTokenRepository *InMemoryRefreshTokenRepository) allRefreshTokens(aFilter func(*identity.RefreshToken) bool) []*identity.RefreshToken {
+	inMemoryRefreshTokenRepository.mu.RLock()
+	defer inMemoryRefreshTokenRepository.mu.RUnlock()
+
+	refreshTokens := []*identity.RefreshToken{}
+	for _, refreshToken := range inMemoryRefreshTokenRepository.repository {
+		if aFilter(refreshToken) {
+			copied := *refreshToken
+			refreshTokens = append(refreshTokens, &copied)
+		}
+	}
+	sort.Slice(refreshTokens, func(i, j int) bool { return refreshTokens[i].IssuedOn().Before(refreshTokens[j].IssuedOn()) })
+	return refreshTokens
+}
@@ -0,0 +1,90 @@
+package persistence
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+func TestInMemoryRefreshTokenRepository(t *testing.T) {
+	testRefreshTokenRepository(t, NewInMemoryRefreshTokenRepository())
+}
+
+func testRefreshTokenRepository(t *testing.T, aRefreshTokenRepository identity.RefreshTokenRepository) {
+	t.Helper()
+
+	tenant, err := identity.NewTenant(*tenantId, "TenantName", true, *identity.DefaultNameLengthPolicy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenantRepository := NewInMemoryTenantRepository()
+	if err := tenantRepository.Add(tenant); err != nil {
+		t.Fatal(err)
+	}
+	user := newUser(t, "zoeusername", "zoe@saasovation.com")
+	userRepository := NewInMemoryUserRepository()
+	if err := userRepository.Add(user); err != nil {
+		t.Fatal(err)
+	}
+	refreshTokenService := identity.NewRefreshTokenService(tenantRepository, userRepository, aRefreshTokenRepository, time.Hour)
+	plainToken, err := refreshTokenService.Issue(user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := refreshTokenService.Issue(user); err != nil {
+		t.Fatal(err)
+	}
+
+	ofUser, err := aRefreshTokenRepository.AllRefreshTokensOfUser(*tenantId, "zoeusername")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ofUser) != 2 {
+		t.Fatalf("got %d refresh tokens, want 2", len(ofUser))
+	}
+	ofTenant, err := aRefreshTokenRepository.AllRefreshTokensOfTenant(*tenantId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ofTenant) != 2 {
+		t.Errorf("got %d refresh tokens, want 2", len(ofTenant))
+	}
+	ofFamily, err := aRefreshTokenRepository.AllRefreshTokensOfFamily(ofUser[0].FamilyId())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ofFamily) != 1 {
+		t.Errorf("got %d refresh tokens, want 1", len(ofFamily))
+	}
+	got, err := aRefreshTokenRepository.RefreshTokenOfHash(ofUser[0].TokenHash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == nil || got.TokenHash() != ofUser[0].TokenHash() {
+		t.Errorf("got %v, want %v", got, ofUser[0])
+	}
+
+	_, successor, err := refreshTokenService.Rotate(plainToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := refreshTokenService.Rotate(plainToken); !errors.Is(err, identity.ErrRefreshTokenReused) {
+		t.Fatalf("got %v, want %v", err, identity.ErrRefreshTokenReused)
+	}
+	ofFamily, err = aRefreshTokenRepository.AllRefreshTokensOfFamily(successor.FamilyId())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ofFamily) != 2 || !ofFamily[0].IsRotated() || !ofFamily[0].IsRevoked() || !ofFamily[1].IsRevoked() {
+		t.Errorf("got %v, want the rotated token and its successor revoked", ofFamily)
+	}
+	kept, err := aRefreshTokenRepository.RefreshTokenOfHash(ofUser[1].TokenHash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kept == nil || !kept.IsUsable() {
+		t.Errorf("got %v, want the token of another family kept", kept)
+	}
+}
@@ -0,0 +1,60 @@
+package persistence
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/access"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+type roleKey struct {
+	tenantId identity.TenantId
+	name     string
+}
+
+type InMemoryRoleRepository struct {
+	mu         sync.RWMutex
+	repository map[roleKey]*access.Role
+}
+
+func NewInMemoryRoleRepository() *InMemoryRoleRepository {
+	return &InMemoryRoleRepository{repository: map[roleKey]*access.Role{}}
+}
+
+func (inMemoryRoleRepository *InMemoryRoleRepository) Add(aRole *access.Role) error {
+	inMemoryRoleRepository.mu.Lock()
+	defer inMemoryRoleRepository.mu.Unlock()
+
+	inMemoryRoleRepository.repository[roleKey{tenantId: aRole.TenantId(), name: aRole.Name()}] = aRole
+	return nil
+}
+
+func (inMemoryRoleRepository *InMemoryRoleRepository) Remove(aRole *access.Role) error {
+	inMemoryRoleRepository.mu.Lock()
+	defer inMemoryRoleRepository.mu.Unlock()
+
+	delete(inMemoryRoleRepository.repository, roleKey{tenantId: aRole.TenantId(), name: aRole.Name()})
+	return nil
+}
+
+func (inMemoryRoleRepository *InMemoryRoleRepository) AllRoles(aTenantId identity.TenantId) ([]*access.Role, error) {
+	inMemoryRoleRepository.mu.RLock()
+	defer inMemoryRoleRepository.mu.RUnlock()
+
+	roles := []*access.Role{}
+	for key, role := range inMemoryRoleRepository.repository {
+		if key.tenantId == aTenantId {
+			roles = append(roles, role)
+		}
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].Name() < roles[j].Name() })
+	return roles, nil
+}
+
+func (inMemoryRoleRepository *InMemoryRoleRepository) RoleNamed(aTenantId identity.TenantId, aRoleName string) (*access.Role, error) {
+	inMemoryRoleRepository.mu.RLock()
+	defer inMemoryRoleRepository.mu.RUnlock()
+
+	return inMemoryRoleRepository.repository[roleKey{tenantId: aTenantId, name: aRoleName}], nil
+}
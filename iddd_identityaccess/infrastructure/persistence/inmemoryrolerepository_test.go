@@ -0,0 +1,45 @@
+package persistence
+
+import (
+	"testing"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/access"
+)
+
+func TestInMemoryRoleRepository(t *testing.T) {
+	role, err := access.NewRole(*tenantId, "Manager", "A manager role.", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roleRepository := NewInMemoryRoleRepository()
+	if err := roleRepository.Add(role); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := roleRepository.RoleNamed(*tenantId, "Manager")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != role {
+		t.Errorf("got %v, want %v", got, role)
+	}
+
+	roles, err := roleRepository.AllRoles(*tenantId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(roles) != 1 || roles[0] != role {
+		t.Errorf("got %v, want [%v]", roles, role)
+	}
+
+	if err := roleRepository.Remove(role); err != nil {
+		t.Fatal(err)
+	}
+	got, err = roleRepository.RoleNamed(*tenantId, "Manager")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != nil {
+		t.Errorf("got %v, want nil", got)
+	}
+}
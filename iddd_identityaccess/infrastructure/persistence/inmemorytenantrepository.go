@@ -0,0 +1,52 @@
+package persistence
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+type InMemoryTenantRepository struct {
+	mu         sync.RWMutex
+	repository map[identity.TenantId]*identity.Tenant
+}
+
+func NewInMemoryTenantRepository() *InMemoryTenantRepository {
+	return &InMemoryTenantRepository{repository: map[identity.TenantId]*identity.Tenant{}}
+}
+
+func (inMemoryTenantRepository *InMemoryTenantRepository) Add(aTenant *identity.Tenant) error {
+	inMemoryTenantRepository.mu.Lock()
+	defer inMemoryTenantRepository.mu.Unlock()
+
+	inMemoryTenantRepository.repository[aTenant.TenantId()] = aTenant
+	return nil
+}
+
+func (inMemoryTenantRepository *InMemoryTenantRepository) Remove(aTenant *identity.Tenant) error {
+	inMemoryTenantRepository.mu.Lock()
+	defer inMemoryTenantRepository.mu.Unlock()
+
+	delete(inMemoryTenantRepository.repository, aTenant.TenantId())
+	return nil
+}
+
+func (inMemoryTenantRepository *InMemoryTenantRepository) AllTenants() ([]*identity.Tenant, error) {
+	inMemoryTenantRepository.mu.RLock()
+	defer inMemoryTenantRepository.mu.RUnlock()
+
+	tenants := make([]*identity.Tenant, 0, len(inMemoryTenantRepository.repository))
+	for _, tenant := range inMemoryTenantRepository.repository {
+		tenants = append(tenants, tenant)
+	}
+	sort.Slice(tenants, func(i, j int) bool { return tenants[i].Name() < tenants[j].Name() })
+	return tenants, nil
+}
+
+func (inMemoryTenantRepository *InMemoryTenantRepository) TenantOfId(aTenantId identity.TenantId) (*identity.Tenant, error) {
+	inMemoryTenantRepository.mu.RLock()
+	defer inMemoryTenantRepository.mu.RUnlock()
+
+	return inMemoryTenantRepository.repository[aTenantId], nil
+}
@@ -0,0 +1,60 @@
+package persistence
+
+import (
+	"testing"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+	"github.com/google/uuid"
+)
+
+func TestInMemoryTenantRepository(t *testing.T) {
+	tenant, err := identity.NewTenant(*tenantId, "TenantName", true, *identity.DefaultNameLengthPolicy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenantRepository := NewInMemoryTenantRepository()
+	if err := tenantRepository.Add(tenant); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := tenantRepository.TenantOfId(*tenantId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != tenant {
+		t.Errorf("got %v, want %v", got, tenant)
+	}
+
+	otherTenantId, err := identity.NewTenantId(uuid.New().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherTenant, err := identity.NewTenant(*otherTenantId, "OtherTenantName", true, *identity.DefaultNameLengthPolicy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tenantRepository.Add(otherTenant); err != nil {
+		t.Fatal(err)
+	}
+	tenants, err := tenantRepository.AllTenants()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tenants) != 2 || tenants[0] != otherTenant || tenants[1] != tenant {
+		t.Errorf("got %v, want tenants sorted by name", tenants)
+	}
+	if err := tenantRepository.Remove(otherTenant); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tenantRepository.Remove(tenant); err != nil {
+		t.Fatal(err)
+	}
+	got, err = tenantRepository.TenantOfId(*tenantId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != nil {
+		t.Errorf("got %v, want nil", got)
+	}
+}
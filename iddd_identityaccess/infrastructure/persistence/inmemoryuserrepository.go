@@ -0,0 +1,104 @@
+package persistence
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+// userKey holds the fold or the skeleton of a username.
+type userKey struct {
+	tenantId identity.TenantId
+	username string
+}
+
+func foldedUserKey(aTenantId identity.TenantId, aUsername string) userKey {
+	return userKey{tenantId: aTenantId, username: identity.USERNAME_POLICY.Fold(aUsername)}
+}
+
+func skeletonUserKey(aTenantId identity.TenantId, aUsername string) userKey {
+	return userKey{tenantId: aTenantId, username: identity.USERNAME_POLICY.Skeleton(aUsername)}
+}
+
+type InMemoryUserRepository struct {
+	mu         sync.RWMutex
+	repository map[userKey]*identity.User
+	skeletons  map[userKey]string
+}
+
+func NewInMemoryUserRepository() *InMemoryUserRepository {
+	return &InMemoryUserRepository{repository: map[userKey]*identity.User{}, skeletons: map[userKey]string{}}
+}
+
+// Add advances the concurrency version of the user, as a database would on every update. A user that was never added
+// must not share the fold or the skeleton of its username with a stored user.
+func (inMemoryUserRepository *InMemoryUserRepository) Add(aUser *identity.User) error {
+	inMemoryUserRepository.mu.Lock()
+	defer inMemoryUserRepository.mu.Unlock()
+
+	key := foldedUserKey(aUser.TenantId(), aUser.Username())
+	if stored, ok := inMemoryUserRepository.repository[key]; ok && (aUser.ConcurrencyVersion() == 0 || stored.Username() != aUser.Username()) {
+		return identity.ErrUserAlreadyExists
+	}
+	if folded, ok := inMemoryUserRepository.skeletons[skeletonUserKey(aUser.TenantId(), aUser.Username())]; ok && folded != key.username {
+		return identity.ErrUsernameConfusable
+	}
+	aUser.SetConcurrencyVersion(aUser.ConcurrencyVersion() + 1)
+	inMemoryUserRepository.put(aUser)
+	return nil
+}
+
+func (inMemoryUserRepository *InMemoryUserRepository) put(aUser *identity.User) {
+	key := foldedUserKey(aUser.TenantId(), aUser.Username())
+	inMemoryUserRepository.repository[key] = aUser
+	inMemoryUserRepository.skeletons[skeletonUserKey(aUser.TenantId(), aUser.Username())] = key.username
+}
+
+func (inMemoryUserRepository *InMemoryUserRepository) Remove(aUser *identity.User) error {
+	inMemoryUserRepository.mu.Lock()
+	defer inMemoryUserRepository.mu.Unlock()
+
+	delete(inMemoryUserRepository.repository, foldedUserKey(aUser.TenantId(), aUser.Username()))
+	delete(inMemoryUserRepository.skeletons, skeletonUserKey(aUser.TenantId(), aUser.Username()))
+	return nil
+}
+
+func (inMemoryUserRepository *InMemoryUserRepository) AllUsers(aTenantId identity.TenantId) ([]*identity.User, error) {
+	inMemoryUserRepository.mu.RLock()
+	defer inMemoryUserRepository.mu.RUnlock()
+
+	users := []*identity.User{}
+	for key, user := range inMemoryUserRepository.repository {
+		if key.tenantId == aTenantId {
+			users = append(users, user)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Username() < users[j].Username() })
+	return users, nil
+}
+
+func (inMemoryUserRepository *InMemoryUserRepository) UserWithUsername(aTenantId identity.TenantId, aUsername string) (*identity.User, error) {
+	inMemoryUserRepository.mu.RLock()
+	defer inMemoryUserRepository.mu.RUnlock()
+
+	return inMemoryUserRepository.repository[foldedUserKey(aTenantId, aUsername)], nil
+}
+
+// UserWithEmailAddress compares addresses case-insensitively. Should several users share an address, the first by username is returned.
+func (inMemoryUserRepository *InMemoryUserRepository) UserWithEmailAddress(aTenantId identity.TenantId, anEmailAddress string) (*identity.User, error) {
+	inMemoryUserRepository.mu.RLock()
+	defer inMemoryUserRepository.mu.RUnlock()
+
+	var found *identity.User
+	for key, user := range inMemoryUserRepository.repository {
+		if key.tenantId != aTenantId || !strings.EqualFold(user.Person().EmailAddress().Address(), anEmailAddress) {
+			continue
+		}
+		if found == nil || user.Username() < found.Username() {
+			found = user
+		}
+	}
+	return found, nil
+}
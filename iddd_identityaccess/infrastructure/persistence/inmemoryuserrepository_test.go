@@ -0,0 +1,173 @@
+package persistence
+
+import (
+	"errors"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+	"github.com/google/uuid"
+)
+
+var tenantId *identity.TenantId
+
+func init() {
+	var err error
+	tenantId, err = identity.NewTenantId(uuid.New().String())
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func newUser(t *testing.T, aUsername string, anEmailAddress string) *identity.User {
+	t.Helper()
+
+	enablement, err := identity.NewEnablement(true, time.Now().AddDate(-1, 0, 0), time.Now().AddDate(1, 0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fullName, err := identity.NewFullName("Zoe", "Doe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	emailAddress, err := identity.NewEmailAddress(anEmailAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, err := identity.NewUser(*tenantId, aUsername, "qwerty!ASDFG#", *enablement, *identity.NewPerson(*tenantId, *fullName, *emailAddress), *identity.DefaultNameLengthPolicy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return user
+}
+
+func TestInMemoryUserRepository(t *testing.T) {
+	user := newUser(t, "zoeusername", "zoe@saasovation.com")
+	userRepository := NewInMemoryUserRepository()
+	if err := userRepository.Add(user); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := userRepository.UserWithUsername(*tenantId, "zoeusername")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != user {
+		t.Errorf("got %v, want %v", got, user)
+	}
+
+	if err := userRepository.Remove(user); err != nil {
+		t.Fatal(err)
+	}
+	got, err = userRepository.UserWithUsername(*tenantId, "zoeusername")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != nil {
+		t.Errorf("got %v, want nil", got)
+	}
+}
+
+func TestInMemoryUserRepositoryUserWithEmailAddress(t *testing.T) {
+	user := newUser(t, "zoeusername", "zoe@saasovation.com")
+	userRepository := NewInMemoryUserRepository()
+	if err := userRepository.Add(user); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := userRepository.UserWithEmailAddress(*tenantId, "Zoe@SaaSOvation.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != user {
+		t.Errorf("got %v, want %v", got, user)
+	}
+
+	otherTenantId, err := identity.NewTenantId(uuid.New().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err = userRepository.UserWithEmailAddress(*otherTenantId, "zoe@saasovation.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != nil {
+		t.Errorf("got %v, want nil", got)
+	}
+}
+
+func TestInMemoryUserRepositoryAllUsers(t *testing.T) {
+	userRepository := NewInMemoryUserRepository()
+	for _, username := range []string{"zoeusername", "janeusername"} {
+		if err := userRepository.Add(newUser(t, username, username+"@saasovation.com")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	users, err := userRepository.AllUsers(*tenantId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 2 || users[0].Username() != "janeusername" || users[1].Username() != "zoeusername" {
+		t.Errorf("got %v, want janeusername and zoeusername in order", users)
+	}
+}
+
+func TestInMemoryUserRepositoryConcurrencyVersion(t *testing.T) {
+	user := newUser(t, "zoeusername", "zoe@saasovation.com")
+	userRepository := NewInMemoryUserRepository()
+	for i := 0; i < 2; i++ {
+		if err := userRepository.Add(user); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := user.ConcurrencyVersion(); got != 2 {
+		t.Errorf("got %d, want 2", got)
+	}
+}
+
+func TestInMemoryUserRepositoryUniqueUsername(t *testing.T) {
+	testUniqueUsername(t, func() identity.UserRepository { return NewInMemoryUserRepository() })
+}
+
+func testUniqueUsername(t *testing.T, aNewUserRepository func() identity.UserRepository) {
+	t.Helper()
+
+	tests := []struct {
+		name     string
+		username string
+		want     error
+	}{
+		{name: "success other username", username: "janeusername", want: nil},
+		{name: "fail same username", username: "zoeusername", want: identity.ErrUserAlreadyExists},
+		{name: "fail other case", username: "ZoeUserName", want: identity.ErrUserAlreadyExists},
+		{name: "fail fullwidth", username: "ｚｏｅusername", want: identity.ErrUserAlreadyExists},
+		{name: "fail Cyrillic o", username: "z\u043eeusername", want: identity.ErrUsernameConfusable},
+		{name: "fail digit zero", username: "z0eusername", want: identity.ErrUsernameConfusable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepository := aNewUserRepository()
+			user := newUser(t, "zoeusername", "zoe@saasovation.com")
+			if err := userRepository.Add(user); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := userRepository.Add(newUser(t, tt.username, "other@saasovation.com")); !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+			if err := userRepository.Add(user); err != nil {
+				t.Errorf("got %v, want the stored user to be updated", err)
+			}
+			got, err := userRepository.UserWithUsername(*tenantId, "ZOEUSERNAME")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got == nil || got.Username() != "zoeusername" {
+				t.Errorf("got %v, want zoeusername", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package persistence
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+type SqlEmailVerificationTokenRepository struct {
+	db *sql.DB
+}
+
+func NewSqlEmailVerificationTokenRepository(aDb *sql.DB) *SqlEmailVerificationTokenRepository {
+	return &SqlEmailVerificationTokenRepository{db: aDb}
+}
+
+func (sqlEmailVerificationTokenRepository *SqlEmailVerificationTokenRepository) Add(anEmailVerificationToken *identity.EmailVerificationToken) (err error) {
+	tenantId := anEmailVerificationToken.TenantId()
+	defer ierrors.Wrap(&err, "sqlemailverificationtokenrepository.Add(%s, %s)", tenantId.Id(), anEmailVerificationToken.Username())
+
+	document, err := json.Marshal(newEmailVerificationTokenDocument(anEmailVerificationToken))
+	if err != nil {
+		return err
+	}
+	return replaceRow(
+		sqlEmailVerificationTokenRepository.db,
+		"DELETE FROM identity_email_verification_tokens WHERE token_hash = ?", []interface{}{anEmailVerificationToken.TokenHash()},
+		"INSERT INTO identity_email_verification_tokens (token_hash, tenant_id, username, document) VALUES (?, ?, ?, ?)", []interface{}{anEmailVerificationToken.TokenHash(), tenantId.Id(), anEmailVerificationToken.Username(), string(document)},
+	)
+}
+
+func (sqlEmailVerificationTokenRepository *SqlEmailVerificationTokenRepository) EmailVerificationTokenOfHash(aTokenHash string) (_ *identity.EmailVerificationToken, err error) {
+	defer ierrors.Wrap(&err, "sqlemailverificationtokenrepository.EmailVerificationTokenOfHash()")
+
+	var document string
+	err = sqlEmailVerificationTokenRepository.db.QueryRow("SELECT document FROM identity_email_verification_tokens WHERE token_hash = ?", aTokenHash).Scan(&document)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var stored emailVerificationTokenDocument
+	if err := json.Unmarshal([]byte(document), &stored); err != nil {
+		return nil, err
+	}
+	return stored.emailVerificationToken(), nil
+}
+
+func (sqlEmailVerificationTokenRepository *SqlEmailVerificationTokenRepository) AllEmailVerificationTokensOfUser(aTenantId identity.TenantId, aUsername string) (_ []*identity.EmailVerificationToken, err error) {
+	defer ierrors.Wrap(&err, "sqlemailverificationtokenrepository.AllEmailVerificationTokensOfUser(%s, %s)", aTenantId.Id(), aUsername)
+
+	rows, err := sqlEmailVerificationTokenRepository.db.Query("SELECT document FROM identity_email_verification_tokens WHERE tenant_id = ? AND username = ?", aTenantId.Id(), aUsername)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	emailVerificationTokens := []*identity.EmailVerificationToken{}
+	for rows.Next() {
+		var document string
+		if err := rows.Scan(&document); err != nil {
+			return nil, err
+		}
+		var stored emailVerificationTokenDocument
+		if err := json.Unmarshal([]byte(document), &stored); err != nil {
+			return nil, err
+		}
+		emailVerificationTokens = append(emailVerificationTokens, stored.emailVerificationToken())
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	sort.Slice(emailVerificationTokens, func(i, j int) bool {
+		return emailVerificationTokens[i].IssuedOn().Before(emailVerificationTokens[j].IssuedOn())
+	})
+	return emailVerificationTokens, nil
+}
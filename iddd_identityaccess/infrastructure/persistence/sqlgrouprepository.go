@@ -0,0 +1,87 @@
+package persistence
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+type SqlGroupRepository struct {
+	db *sql.DB
+}
+
+func NewSqlGroupRepository(aDb *sql.DB) *SqlGroupRepository {
+	return &SqlGroupRepository{db: aDb}
+}
+
+// Add advances the concurrency version of the group, which is stored within the document.
+func (sqlGroupRepository *SqlGroupRepository) Add(aGroup *identity.Group) (err error) {
+	tenantId := aGroup.TenantId()
+	defer ierrors.Wrap(&err, "sqlgrouprepository.Add(%s, %s)", tenantId.Id(), aGroup.Name())
+
+	aGroup.SetConcurrencyVersion(aGroup.ConcurrencyVersion() + 1)
+	document, err := json.Marshal(newGroupDocument(aGroup))
+	if err != nil {
+		return err
+	}
+	return replaceRow(
+		sqlGroupRepository.db,
+		"DELETE FROM identity_groups WHERE tenant_id = ? AND name = ?", []interface{}{tenantId.Id(), aGroup.Name()},
+		"INSERT INTO identity_groups (tenant_id, name, document) VALUES (?, ?, ?)", []interface{}{tenantId.Id(), aGroup.Name(), string(document)},
+	)
+}
+
+func (sqlGroupRepository *SqlGroupRepository) Remove(aGroup *identity.Group) (err error) {
+	tenantId := aGroup.TenantId()
+	defer ierrors.Wrap(&err, "sqlgrouprepository.Remove(%s, %s)", tenantId.Id(), aGroup.Name())
+
+	_, err = sqlGroupRepository.db.Exec("DELETE FROM identity_groups WHERE tenant_id = ? AND name = ?", tenantId.Id(), aGroup.Name())
+	return err
+}
+
+func (sqlGroupRepository *SqlGroupRepository) AllGroups(aTenantId identity.TenantId) (_ []*identity.Group, err error) {
+	defer ierrors.Wrap(&err, "sqlgrouprepository.AllGroups(%s)", aTenantId.Id())
+
+	rows, err := sqlGroupRepository.db.Query("SELECT document FROM identity_groups WHERE tenant_id = ? ORDER BY name", aTenantId.Id())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := []*identity.Group{}
+	for rows.Next() {
+		var document string
+		if err := rows.Scan(&document); err != nil {
+			return nil, err
+		}
+		var stored groupDocument
+		if err := json.Unmarshal([]byte(document), &stored); err != nil {
+			return nil, err
+		}
+		group := stored.group()
+		groups = append(groups, group)
+	}
+	return groups, rows.Err()
+}
+
+func (sqlGroupRepository *SqlGroupRepository) GroupNamed(aTenantId identity.TenantId, aName string) (_ *identity.Group, err error) {
+	defer ierrors.Wrap(&err, "sqlgrouprepository.GroupNamed(%s, %s)", aTenantId.Id(), aName)
+
+	var document string
+	err = sqlGroupRepository.db.QueryRow("SELECT document FROM identity_groups WHERE tenant_id = ? AND name = ?", aTenantId.Id(), aName).Scan(&document)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var stored groupDocument
+	if err := json.Unmarshal([]byte(document), &stored); err != nil {
+		return nil, err
+	}
+	group := stored.group()
+	return group, nil
+}
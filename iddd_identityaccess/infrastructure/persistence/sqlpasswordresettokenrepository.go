@@ -0,0 +1,82 @@
+package persistence
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+type SqlPasswordResetTokenRepository struct {
+	db *sql.DB
+}
+
+func NewSqlPasswordResetTokenRepository(aDb *sql.DB) *SqlPasswordResetTokenRepository {
+	return &SqlPasswordResetTokenRepository{db: aDb}
+}
+
+func (sqlPasswordResetTokenRepository *SqlPasswordResetTokenRepository) Add(aPasswordResetToken *identity.PasswordResetToken) (err error) {
+	tenantId := aPasswordResetToken.TenantId()
+	defer ierrors.Wrap(&err, "sqlpasswordresettokenrepository.Add(%s, %s)", tenantId.Id(), aPasswordResetToken.Username())
+
+	document, err := json.Marshal(newPasswordResetTokenDocument(aPasswordResetToken))
+	if err != nil {
+		return err
+	}
+	return replaceRow(
+		sqlPasswordResetTokenRepository.db,
+		"DELETE FROM identity_password_reset_tokens WHERE token_hash = ?", []interface{}{aPasswordResetToken.TokenHash()},
+		"INSERT INTO identity_password_reset_tokens (token_hash, tenant_id, username, document) VALUES (?, ?, ?, ?)", []interface{}{aPasswordResetToken.TokenHash(), tenantId.Id(), aPasswordResetToken.Username(), string(document)},
+	)
+}
+
+func (sqlPasswordResetTokenRepository *SqlPasswordResetTokenRepository) PasswordResetTokenOfHash(aTokenHash string) (_ *identity.PasswordResetToken, err error) {
+	defer ierrors.Wrap(&err, "sqlpasswordresettokenrepository.PasswordResetTokenOfHash()")
+
+	var document string
+	err = sqlPasswordResetTokenRepository.db.QueryRow("SELECT document FROM identity_password_reset_tokens WHERE token_hash = ?", aTokenHash).Scan(&document)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var stored passwordResetTokenDocument
+	if err := json.Unmarshal([]byte(document), &stored); err != nil {
+		return nil, err
+	}
+	return stored.passwordResetToken(), nil
+}
+
+func (sqlPasswordResetTokenRepository *SqlPasswordResetTokenRepository) AllPasswordResetTokensOfUser(aTenantId identity.TenantId, aUsername string) (_ []*identity.PasswordResetToken, err error) {
+	defer ierrors.Wrap(&err, "sqlpasswordresettokenrepository.AllPasswordResetTokensOfUser(%s, %s)", aTenantId.Id(), aUsername)
+
+	rows, err := sqlPasswordResetTokenRepository.db.Query("SELECT document FROM identity_password_reset_tokens WHERE tenant_id = ? AND username = ?", aTenantId.Id(), aUsername)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	passwordResetTokens := []*identity.PasswordResetToken{}
+	for rows.Next() {
+		var document string
+		if err := rows.Scan(&document); err != nil {
+			return nil, err
+		}
+		var stored passwordResetTokenDocument
+		if err := json.Unmarshal([]byte(document), &stored); err != nil {
+			return nil, err
+		}
+		passwordResetTokens = append(passwordResetTokens, stored.passwordResetToken())
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	sort.Slice(passwordResetTokens, func(i, j int) bool {
+		return passwordResetTokens[i].IssuedOn().Before(passwordResetTokens[j].IssuedOn())
+	})
+	return passwordResetTokens, nil
+}
@@ -0,0 +1,176 @@
+package persistence
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+type SqlRefreshTokenRepository struct {
+	db *sql.DB
+}
+
+func NewSqlRefreshTokenRepository(aDb *sql.DB) *SqlRefreshTokenRepository {
+	return &SqlRefreshTokenRepository{db: aDb}
+}
+
+func (sqlRefreshTokenRepository *SqlRefreshTokenRepository) Add(aRefreshToken *identity.RefreshToken) (err error) {
+	defer ierrors.Wrap(&err, "sqlrefreshtokenrepository.Add(%s)", aRefreshToken.FamilyId())
+
+	tx, err := sqlRefreshTokenRepository.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+	if _, err = tx.Exec("DELETE FROM identity_refresh_tokens WHERE token_hash = ?", aRefreshToken.TokenHash()); err != nil {
+		return err
+	}
+	if err = insertRefreshToken(tx, aRefreshToken); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (sqlRefreshTokenRepository *SqlRefreshTokenRepository) RotateRefreshToken(aRotated *identity.RefreshToken, aSuccessor *identity.RefreshToken) (_ bool, err error) {
+	defer ierrors.Wrap(&err, "sqlrefreshtokenrepository.RotateRefreshToken(%s)", aRotated.FamilyId())
+
+	tx, err := sqlRefreshTokenRepository.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+	result, err := tx.Exec(
+		`UPDATE identity_refresh_tokens SET rotated = ? WHERE token_hash = ? AND rotated = ? AND revoked = ?
+			AND family_id NOT IN (SELECT family_id FROM identity_revoked_refresh_token_families)`,
+		true, aRotated.TokenHash(), false, false,
+	)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if rowsAffected == 0 {
+		return false, tx.Rollback()
+	}
+	if err = insertRefreshToken(tx, aSuccessor); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+func (sqlRefreshTokenRepository *SqlRefreshTokenRepository) RevokeRefreshTokenFamily(aFamilyId string) (err error) {
+	defer ierrors.Wrap(&err, "sqlrefreshtokenrepository.RevokeRefreshTokenFamily(%s)", aFamilyId)
+
+	tx, err := sqlRefreshTokenRepository.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+	if _, err = tx.Exec("DELETE FROM identity_revoked_refresh_token_families WHERE family_id = ?", aFamilyId); err != nil {
+		return err
+	}
+	if _, err = tx.Exec("INSERT INTO identity_revoked_refresh_token_families (family_id) VALUES (?)", aFamilyId); err != nil {
+		return err
+	}
+	if _, err = tx.Exec("UPDATE identity_refresh_tokens SET revoked = ? WHERE family_id = ?", true, aFamilyId); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (sqlRefreshTokenRepository *SqlRefreshTokenRepository) RefreshTokenOfHash(aTokenHash string) (_ *identity.RefreshToken, err error) {
+	defer ierrors.Wrap(&err, "sqlrefreshtokenrepository.RefreshTokenOfHash()")
+
+	refreshToken, err := scanRefreshToken(sqlRefreshTokenRepository.db.QueryRow("SELECT rotated, revoked, document FROM identity_refresh_tokens WHERE token_hash = ?", aTokenHash))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return refreshToken, err
+}
+
+func (sqlRefreshTokenRepository *SqlRefreshTokenRepository) AllRefreshTokensOfFamily(aFamilyId string) (_ []*identity.RefreshToken, err error) {
+	defer ierrors.Wrap(&err, "sqlrefreshtokenrepository.AllRefreshTokensOfFamily(%s)", aFamilyId)
+
+	return sqlRefreshTokenRepository.allRefreshTokens("family_id = ?", aFamilyId)
+}
+
+func (sqlRefreshTokenRepository *SqlRefreshTokenRepository) AllRefreshTokensOfUser(aTenantId identity.TenantId, aUsername string) (_ []*identity.RefreshToken, err error) {
+	defer ierrors.Wrap(&err, "sqlrefreshtokenrepository.AllRefreshTokensOfUser(%s, %s)", aTenantId.Id(), aUsername)
+
+	return sqlRefreshTokenRepository.allRefreshTokens("tenant_id = ? AND username = ?", aTenantId.Id(), aUsername)
+}
+
+func (sqlRefreshTokenRepository *SqlRefreshTokenRepository) AllRefreshTokensOfTenant(aTenantId identity.TenantId) (_ []*identity.RefreshToken, err error) {
+	defer ierrors.Wrap(&err, "sqlrefreshtokenrepository.AllRefreshTokensOfTenant(%s)", aTenantId.Id())
+
+	return sqlRefreshTokenRepository.allRefreshTokens("tenant_id = ?", aTenantId.Id())
+}
+
+// allRefreshTokens orders the tokens as they were issued, as the in-memory repository does.
+func (sqlRefreshTokenRepository *SqlRefreshTokenRepository) allRefreshTokens(aCondition string, anArgs ...interface{}) ([]*identity.RefreshToken, error) {
+	rows, err := sqlRefreshTokenRepository.db.Query("SELECT rotated, revoked, document FROM identity_refresh_tokens WHERE "+aCondition, anArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	refreshTokens := []*identity.RefreshToken{}
+	for rows.Next() {
+		refreshToken, err := scanRefreshToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		refreshTokens = append(refreshTokens, refreshToken)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	sort.Slice(refreshTokens, func(i, j int) bool { return refreshTokens[i].IssuedOn().Before(refreshTokens[j].IssuedOn()) })
+	return refreshTokens, nil
+}
+
+func insertRefreshToken(aTx *sql.Tx, aRefreshToken *identity.RefreshToken) error {
+	document, err := json.Marshal(newRefreshTokenDocument(aRefreshToken))
+	if err != nil {
+		return err
+	}
+	tenantId := aRefreshToken.TenantId()
+	_, err = aTx.Exec(
+		"INSERT INTO identity_refresh_tokens (token_hash, family_id, tenant_id, username, rotated, revoked, document) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		aRefreshToken.TokenHash(), aRefreshToken.FamilyId(), tenantId.Id(), aRefreshToken.Username(), aRefreshToken.IsRotated(), aRefreshToken.IsRevoked(), string(document),
+	)
+	return err
+}
+
+// scanRefreshToken takes rotated and revoked from their columns, which are updated without the document.
+func scanRefreshToken(aRow interface{ Scan(...interface{}) error }) (*identity.RefreshToken, error) {
+	var rotated, revoked bool
+	var document string
+	if err := aRow.Scan(&rotated, &revoked, &document); err != nil {
+		return nil, err
+	}
+	var stored refreshTokenDocument
+	if err := json.Unmarshal([]byte(document), &stored); err != nil {
+		return nil, err
+	}
+	stored.Rotated, stored.Revoked = rotated, revoked
+	return stored.refreshToken(), nil
+}
@@ -0,0 +1,231 @@
+package persistence
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/access"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func newSqlDb(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "identityaccess.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := CreateSqlSchema(db); err != nil {
+		t.Fatal(err)
+	}
+	if err := CreateSqlSchema(db); err != nil {
+		t.Fatalf("the schema must be creatable twice: %v", err)
+	}
+	return db
+}
+
+func TestSqlTenantRepository(t *testing.T) {
+	tenantRepository := NewSqlTenantRepository(newSqlDb(t))
+	tenant, err := identity.NewTenant(*tenantId, "TenantName", true, *identity.DefaultNameLengthPolicy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tenantRepository.Add(tenant); err != nil {
+		t.Fatal(err)
+	}
+	if err := tenant.Deactivate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tenantRepository.Add(tenant); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := tenantRepository.TenantOfId(*tenantId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == nil || got.Name() != "TenantName" || got.IsActive() {
+		t.Errorf("got %v, want %v", got, tenant)
+	}
+	tenants, err := tenantRepository.AllTenants()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tenants) != 1 {
+		t.Errorf("got %v, want one tenant", tenants)
+	}
+
+	if err := tenantRepository.Remove(tenant); err != nil {
+		t.Fatal(err)
+	}
+	got, err = tenantRepository.TenantOfId(*tenantId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != nil {
+		t.Errorf("got %v, want nil", got)
+	}
+}
+
+func TestSqlUserRepository(t *testing.T) {
+	userRepository := NewSqlUserRepository(newSqlDb(t))
+	user := newUser(t, "zoeusername", "zoe@saasovation.com")
+	if err := userRepository.Add(user); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := userRepository.UserWithUsername(*tenantId, "zoeusername")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == nil || !got.Equals(*user) || got.ConcurrencyVersion() != 1 {
+		t.Errorf("got %v, want %v at version 1", got, user)
+	}
+	users, err := userRepository.AllUsers(*tenantId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 1 || !users[0].Equals(*user) {
+		t.Errorf("got %v, want [%v]", users, user)
+	}
+	got, err = userRepository.UserWithEmailAddress(*tenantId, "Zoe@SaaSOvation.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == nil || !got.Equals(*user) {
+		t.Errorf("got %v, want %v", got, user)
+	}
+
+	if err := userRepository.Remove(user); err != nil {
+		t.Fatal(err)
+	}
+	got, err = userRepository.UserWithUsername(*tenantId, "zoeusername")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != nil {
+		t.Errorf("got %v, want nil", got)
+	}
+}
+
+func TestSqlUserRepositoryUniqueUsername(t *testing.T) {
+	testUniqueUsername(t, func() identity.UserRepository { return NewSqlUserRepository(newSqlDb(t)) })
+}
+
+func TestSqlPasswordResetTokenRepository(t *testing.T) {
+	testPasswordResetTokenRepository(t, NewSqlPasswordResetTokenRepository(newSqlDb(t)))
+}
+
+func TestSqlEmailVerificationTokenRepository(t *testing.T) {
+	testEmailVerificationTokenRepository(t, NewSqlEmailVerificationTokenRepository(newSqlDb(t)))
+}
+
+func TestSqlRefreshTokenRepository(t *testing.T) {
+	testRefreshTokenRepository(t, NewSqlRefreshTokenRepository(newSqlDb(t)))
+}
+
+func TestSqlGroupRepository(t *testing.T) {
+	groupRepository := NewSqlGroupRepository(newSqlDb(t))
+	group, err := identity.NewGroup(*tenantId, "GroupName", "A group description.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := group.AddUser(newUser(t, "zoeusername", "zoe@saasovation.com")); err != nil {
+		t.Fatal(err)
+	}
+	if err := groupRepository.Add(group); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := groupRepository.GroupNamed(*tenantId, "GroupName")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == nil || !got.Equals(group) || len(got.GroupMembers()) != 1 || got.ConcurrencyVersion() != 1 {
+		t.Errorf("got %v, want %v at version 1", got, group)
+	}
+	groups, err := groupRepository.AllGroups(*tenantId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 1 || !groups[0].Equals(group) {
+		t.Errorf("got %v, want [%v]", groups, group)
+	}
+
+	if err := groupRepository.Remove(group); err != nil {
+		t.Fatal(err)
+	}
+	got, err = groupRepository.GroupNamed(*tenantId, "GroupName")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != nil {
+		t.Errorf("got %v, want nil", got)
+	}
+}
+
+func TestSqlRoleRepository(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		roleRepository := NewSqlRoleRepository(newSqlDb(t))
+		role, err := access.NewRole(*tenantId, "Manager", "A manager role.", true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := roleRepository.Add(role); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := roleRepository.RoleNamed(*tenantId, "Manager")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got == nil || got.Name() != "Manager" || !got.SupportsNesting() {
+			t.Errorf("got %v, want %v", got, role)
+		}
+		roles, err := roleRepository.AllRoles(*tenantId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(roles) != 1 {
+			t.Errorf("got %v, want one role", roles)
+		}
+
+		if err := roleRepository.Remove(role); err != nil {
+			t.Fatal(err)
+		}
+		got, err = roleRepository.RoleNamed(*tenantId, "Manager")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != nil {
+			t.Errorf("got %v, want nil", got)
+		}
+	})
+	t.Run("success conditional permission", func(t *testing.T) {
+		roleRepository := NewSqlRoleRepository(newSqlDb(t))
+		role, err := access.NewRole(*tenantId, "Manager", "A manager role.", true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		permission, err := access.NewPermission("reports/*", "read", access.PERMISSION_EFFECT_ALLOW, access.TenantIs(*tenantId))
+		if err != nil {
+			t.Fatal(err)
+		}
+		role.GrantPermission(*permission)
+
+		if err := roleRepository.Add(role); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := roleRepository.RoleNamed(*tenantId, "Manager")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if permissions := got.Permissions(); len(permissions) != 1 || !permissions[0].Equals(*permission) {
+			t.Errorf("got %v, want %v", permissions, permission)
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package persistence
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/access"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+type SqlRoleRepository struct {
+	db *sql.DB
+}
+
+func NewSqlRoleRepository(aDb *sql.DB) *SqlRoleRepository {
+	return &SqlRoleRepository{db: aDb}
+}
+
+func (sqlRoleRepository *SqlRoleRepository) Add(aRole *access.Role) (err error) {
+	tenantId := aRole.TenantId()
+	defer ierrors.Wrap(&err, "sqlrolerepository.Add(%s, %s)", tenantId.Id(), aRole.Name())
+
+	document, err := json.Marshal(newRoleDocument(aRole))
+	if err != nil {
+		return err
+	}
+	return replaceRow(
+		sqlRoleRepository.db,
+		"DELETE FROM access_roles WHERE tenant_id = ? AND name = ?", []interface{}{tenantId.Id(), aRole.Name()},
+		"INSERT INTO access_roles (tenant_id, name, document) VALUES (?, ?, ?)", []interface{}{tenantId.Id(), aRole.Name(), string(document)},
+	)
+}
+
+func (sqlRoleRepository *SqlRoleRepository) Remove(aRole *access.Role) (err error) {
+	tenantId := aRole.TenantId()
+	defer ierrors.Wrap(&err, "sqlrolerepository.Remove(%s, %s)", tenantId.Id(), aRole.Name())
+
+	_, err = sqlRoleRepository.db.Exec("DELETE FROM access_roles WHERE tenant_id = ? AND name = ?", tenantId.Id(), aRole.Name())
+	return err
+}
+
+func (sqlRoleRepository *SqlRoleRepository) AllRoles(aTenantId identity.TenantId) (_ []*access.Role, err error) {
+	defer ierrors.Wrap(&err, "sqlrolerepository.AllRoles(%s)", aTenantId.Id())
+
+	rows, err := sqlRoleRepository.db.Query("SELECT document FROM access_roles WHERE tenant_id = ? ORDER BY name", aTenantId.Id())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := []*access.Role{}
+	for rows.Next() {
+		var document string
+		if err := rows.Scan(&document); err != nil {
+			return nil, err
+		}
+		var stored roleDocument
+		if err := json.Unmarshal([]byte(document), &stored); err != nil {
+			return nil, err
+		}
+		role, err := stored.role()
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	return roles, rows.Err()
+}
+
+func (sqlRoleRepository *SqlRoleRepository) RoleNamed(aTenantId identity.TenantId, aRoleName string) (_ *access.Role, err error) {
+	defer ierrors.Wrap(&err, "sqlrolerepository.RoleNamed(%s, %s)", aTenantId.Id(), aRoleName)
+
+	var document string
+	err = sqlRoleRepository.db.QueryRow("SELECT document FROM access_roles WHERE tenant_id = ? AND name = ?", aTenantId.Id(), aRoleName).Scan(&document)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var stored roleDocument
+	if err := json.Unmarshal([]byte(document), &stored); err != nil {
+		return nil, err
+	}
+	role, err := stored.role()
+	if err != nil {
+		return nil, err
+	}
+	return role, nil
+}
@@ -0,0 +1,91 @@
+package persistence
+
+import (
+	"database/sql"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+)
+
+// The SQL repositories keep each aggregate as a JSON document next to the columns it is looked up by. Queries use ? placeholders.
+var sqlSchema = []string{
+	`CREATE TABLE IF NOT EXISTS identity_tenants (
+		tenant_id VARCHAR(36) NOT NULL PRIMARY KEY,
+		name VARCHAR(100) NOT NULL,
+		document TEXT NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS identity_users (
+		tenant_id VARCHAR(36) NOT NULL,
+		username VARCHAR(250) NOT NULL,
+		username_fold VARCHAR(250) NOT NULL,
+		username_skeleton VARCHAR(250) NOT NULL,
+		email_address VARCHAR(250) NOT NULL,
+		document TEXT NOT NULL,
+		PRIMARY KEY (tenant_id, username_fold),
+		UNIQUE (tenant_id, username_skeleton)
+	)`,
+	`CREATE TABLE IF NOT EXISTS identity_groups (
+		tenant_id VARCHAR(36) NOT NULL,
+		name VARCHAR(100) NOT NULL,
+		document TEXT NOT NULL,
+		PRIMARY KEY (tenant_id, name)
+	)`,
+	`CREATE TABLE IF NOT EXISTS identity_password_reset_tokens (
+		token_hash VARCHAR(64) NOT NULL PRIMARY KEY,
+		tenant_id VARCHAR(36) NOT NULL,
+		username VARCHAR(250) NOT NULL,
+		document TEXT NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS identity_email_verification_tokens (
+		token_hash VARCHAR(64) NOT NULL PRIMARY KEY,
+		tenant_id VARCHAR(36) NOT NULL,
+		username VARCHAR(250) NOT NULL,
+		document TEXT NOT NULL
+	)`,
+	// Refresh tokens keep rotated and revoked in columns of their own, as rotation and revocation update them conditionally.
+	`CREATE TABLE IF NOT EXISTS identity_refresh_tokens (
+		token_hash VARCHAR(64) NOT NULL PRIMARY KEY,
+		family_id VARCHAR(36) NOT NULL,
+		tenant_id VARCHAR(36) NOT NULL,
+		username VARCHAR(250) NOT NULL,
+		rotated BOOLEAN NOT NULL,
+		revoked BOOLEAN NOT NULL,
+		document TEXT NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS identity_revoked_refresh_token_families (
+		family_id VARCHAR(36) NOT NULL PRIMARY KEY
+	)`,
+	`CREATE TABLE IF NOT EXISTS access_roles (
+		tenant_id VARCHAR(36) NOT NULL,
+		name VARCHAR(100) NOT NULL,
+		document TEXT NOT NULL,
+		PRIMARY KEY (tenant_id, name)
+	)`,
+}
+
+func CreateSqlSchema(aDb *sql.DB) (err error) {
+	defer ierrors.Wrap(&err, "sqlschema.CreateSqlSchema()")
+
+	for _, statement := range sqlSchema {
+		if _, err := aDb.Exec(statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replaceRow deletes then inserts within one transaction, as upserts are spelt differently by every database.
+func replaceRow(aDb *sql.DB, aDeleteStatement string, aKey []interface{}, anInsertStatement string, aRow []interface{}) error {
+	tx, err := aDb.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(aDeleteStatement, aKey...); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.Exec(anInsertStatement, aRow...); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
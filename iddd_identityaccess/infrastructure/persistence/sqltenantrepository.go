@@ -0,0 +1,85 @@
+package persistence
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+type SqlTenantRepository struct {
+	db *sql.DB
+}
+
+func NewSqlTenantRepository(aDb *sql.DB) *SqlTenantRepository {
+	return &SqlTenantRepository{db: aDb}
+}
+
+func (sqlTenantRepository *SqlTenantRepository) Add(aTenant *identity.Tenant) (err error) {
+	tenantId := aTenant.TenantId()
+	defer ierrors.Wrap(&err, "sqltenantrepository.Add(%s)", tenantId.Id())
+
+	document, err := json.Marshal(newTenantDocument(aTenant))
+	if err != nil {
+		return err
+	}
+	return replaceRow(
+		sqlTenantRepository.db,
+		"DELETE FROM identity_tenants WHERE tenant_id = ?", []interface{}{tenantId.Id()},
+		"INSERT INTO identity_tenants (tenant_id, name, document) VALUES (?, ?, ?)", []interface{}{tenantId.Id(), aTenant.Name(), string(document)},
+	)
+}
+
+func (sqlTenantRepository *SqlTenantRepository) Remove(aTenant *identity.Tenant) (err error) {
+	tenantId := aTenant.TenantId()
+	defer ierrors.Wrap(&err, "sqltenantrepository.Remove(%s)", tenantId.Id())
+
+	_, err = sqlTenantRepository.db.Exec("DELETE FROM identity_tenants WHERE tenant_id = ?", tenantId.Id())
+	return err
+}
+
+func (sqlTenantRepository *SqlTenantRepository) AllTenants() (_ []*identity.Tenant, err error) {
+	defer ierrors.Wrap(&err, "sqltenantrepository.AllTenants()")
+
+	rows, err := sqlTenantRepository.db.Query("SELECT document FROM identity_tenants ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tenants := []*identity.Tenant{}
+	for rows.Next() {
+		var document string
+		if err := rows.Scan(&document); err != nil {
+			return nil, err
+		}
+		var stored tenantDocument
+		if err := json.Unmarshal([]byte(document), &stored); err != nil {
+			return nil, err
+		}
+		tenant := stored.tenant()
+		tenants = append(tenants, tenant)
+	}
+	return tenants, rows.Err()
+}
+
+func (sqlTenantRepository *SqlTenantRepository) TenantOfId(aTenantId identity.TenantId) (_ *identity.Tenant, err error) {
+	defer ierrors.Wrap(&err, "sqltenantrepository.TenantOfId(%s)", aTenantId.Id())
+
+	var document string
+	err = sqlTenantRepository.db.QueryRow("SELECT document FROM identity_tenants WHERE tenant_id = ?", aTenantId.Id()).Scan(&document)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var stored tenantDocument
+	if err := json.Unmarshal([]byte(document), &stored); err != nil {
+		return nil, err
+	}
+	tenant := stored.tenant()
+	return tenant, nil
+}
@@ -0,0 +1,143 @@
+package persistence
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+type SqlUserRepository struct {
+	db *sql.DB
+}
+
+func NewSqlUserRepository(aDb *sql.DB) *SqlUserRepository {
+	return &SqlUserRepository{db: aDb}
+}
+
+// Add advances the concurrency version of the user, which is stored within the document. A user that was never added
+// must not share the fold or the skeleton of its username with a stored user.
+func (sqlUserRepository *SqlUserRepository) Add(aUser *identity.User) (err error) {
+	tenantId := aUser.TenantId()
+	defer ierrors.Wrap(&err, "sqluserrepository.Add(%s, %s)", tenantId.Id(), aUser.Username())
+
+	tx, err := sqlUserRepository.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+	fold, skeleton := identity.USERNAME_POLICY.Fold(aUser.Username()), identity.USERNAME_POLICY.Skeleton(aUser.Username())
+	if err := assertUsernameAvailable(tx, aUser, fold, skeleton); err != nil {
+		return err
+	}
+
+	aUser.SetConcurrencyVersion(aUser.ConcurrencyVersion() + 1)
+	document, err := json.Marshal(newUserDocument(aUser))
+	if err != nil {
+		return err
+	}
+	emailAddress := aUser.Person().EmailAddress()
+	if _, err = tx.Exec("DELETE FROM identity_users WHERE tenant_id = ? AND username_fold = ?", tenantId.Id(), fold); err != nil {
+		return err
+	}
+	if _, err = tx.Exec(
+		"INSERT INTO identity_users (tenant_id, username, username_fold, username_skeleton, email_address, document) VALUES (?, ?, ?, ?, ?, ?)",
+		tenantId.Id(), aUser.Username(), fold, skeleton, strings.ToLower(emailAddress.Address()), string(document),
+	); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func assertUsernameAvailable(aTx *sql.Tx, aUser *identity.User, aFold string, aSkeleton string) error {
+	tenantId := aUser.TenantId()
+	rows, err := aTx.Query("SELECT username, username_fold FROM identity_users WHERE tenant_id = ? AND (username_fold = ? OR username_skeleton = ?)", tenantId.Id(), aFold, aSkeleton)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var username, fold string
+		if err := rows.Scan(&username, &fold); err != nil {
+			return err
+		}
+		switch {
+		case fold != aFold:
+			return identity.ErrUsernameConfusable
+		case aUser.ConcurrencyVersion() == 0 || username != aUser.Username():
+			return identity.ErrUserAlreadyExists
+		}
+	}
+	return rows.Err()
+}
+
+func (sqlUserRepository *SqlUserRepository) Remove(aUser *identity.User) (err error) {
+	tenantId := aUser.TenantId()
+	defer ierrors.Wrap(&err, "sqluserrepository.Remove(%s, %s)", tenantId.Id(), aUser.Username())
+
+	_, err = sqlUserRepository.db.Exec("DELETE FROM identity_users WHERE tenant_id = ? AND username_fold = ?", tenantId.Id(), identity.USERNAME_POLICY.Fold(aUser.Username()))
+	return err
+}
+
+func (sqlUserRepository *SqlUserRepository) AllUsers(aTenantId identity.TenantId) (_ []*identity.User, err error) {
+	defer ierrors.Wrap(&err, "sqluserrepository.AllUsers(%s)", aTenantId.Id())
+
+	rows, err := sqlUserRepository.db.Query("SELECT document FROM identity_users WHERE tenant_id = ? ORDER BY username", aTenantId.Id())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []*identity.User{}
+	for rows.Next() {
+		var document string
+		if err := rows.Scan(&document); err != nil {
+			return nil, err
+		}
+		var stored userDocument
+		if err := json.Unmarshal([]byte(document), &stored); err != nil {
+			return nil, err
+		}
+		user := stored.user()
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+func (sqlUserRepository *SqlUserRepository) UserWithUsername(aTenantId identity.TenantId, aUsername string) (_ *identity.User, err error) {
+	defer ierrors.Wrap(&err, "sqluserrepository.UserWithUsername(%s, %s)", aTenantId.Id(), aUsername)
+
+	return sqlUserRepository.userOfRow(sqlUserRepository.db.QueryRow("SELECT document FROM identity_users WHERE tenant_id = ? AND username_fold = ?", aTenantId.Id(), identity.USERNAME_POLICY.Fold(aUsername)))
+}
+
+// UserWithEmailAddress compares addresses case-insensitively. Should several users share an address, the first by username is returned.
+func (sqlUserRepository *SqlUserRepository) UserWithEmailAddress(aTenantId identity.TenantId, anEmailAddress string) (_ *identity.User, err error) {
+	defer ierrors.Wrap(&err, "sqluserrepository.UserWithEmailAddress(%s, %s)", aTenantId.Id(), anEmailAddress)
+
+	return sqlUserRepository.userOfRow(sqlUserRepository.db.QueryRow("SELECT document FROM identity_users WHERE tenant_id = ? AND email_address = ? ORDER BY username LIMIT 1", aTenantId.Id(), strings.ToLower(anEmailAddress)))
+}
+
+func (sqlUserRepository *SqlUserRepository) userOfRow(aRow *sql.Row) (*identity.User, error) {
+	var document string
+	err := aRow.Scan(&document)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var stored userDocument
+	if err := json.Unmarshal([]byte(document), &stored); err != nil {
+		return nil, err
+	}
+	user := stored.user()
+	return user, nil
+}
@@ -0,0 +1,110 @@
+package resource
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+type groupRepresentation struct {
+	TenantId     string                      `json:"tenantId"`
+	Name         string                      `json:"name"`
+	Description  string                      `json:"description"`
+	GroupMembers []groupMemberRepresentation `json:"groupMembers"`
+}
+
+type groupMemberRepresentation struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+func newGroupRepresentation(aGroup *identity.Group) groupRepresentation {
+	tenantId := aGroup.TenantId()
+	groupMembers := aGroup.GroupMembers()
+	representation := groupRepresentation{TenantId: tenantId.Id(), Name: aGroup.Name(), Description: aGroup.Description(), GroupMembers: make([]groupMemberRepresentation, len(groupMembers))}
+	for i, groupMember := range groupMembers {
+		representation.GroupMembers[i] = groupMemberRepresentation{Name: groupMember.Name(), Type: groupMember.Type().String()}
+	}
+	return representation
+}
+
+type groupCommand struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type groupMembershipRepresentation struct {
+	TenantId  string `json:"tenantId"`
+	GroupName string `json:"groupName"`
+	Username  string `json:"username"`
+	Member    bool   `json:"member"`
+}
+
+func (resource *Resource) provisionGroup(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	var command groupCommand
+	if err := readJson(aResponseWriter, aRequest, &command); err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+
+	group, err := resource.identityApplicationService.ProvisionGroup(aRequest.PathValue("tenantId"), command.Name, command.Description)
+	if err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	representation := newGroupRepresentation(group)
+	aResponseWriter.Header().Set("Location", "/tenants/"+representation.TenantId+"/groups/"+url.PathEscape(representation.Name))
+	writeJson(aResponseWriter, http.StatusCreated, representation)
+}
+
+func (resource *Resource) group(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	group, err := resource.identityApplicationService.Group(aRequest.PathValue("tenantId"), aRequest.PathValue("groupName"))
+	if err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	writeJson(aResponseWriter, http.StatusOK, newGroupRepresentation(group))
+}
+
+func (resource *Resource) groupMember(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	tenantId, groupName, username := aRequest.PathValue("tenantId"), aRequest.PathValue("groupName"), aRequest.PathValue("username")
+	member, err := resource.identityApplicationService.IsGroupMember(tenantId, groupName, username)
+	if err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	writeJson(aResponseWriter, http.StatusOK, groupMembershipRepresentation{TenantId: tenantId, GroupName: groupName, Username: username, Member: member})
+}
+
+func (resource *Resource) addUserToGroup(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	if err := resource.identityApplicationService.AddUserToGroup(aRequest.PathValue("tenantId"), aRequest.PathValue("groupName"), aRequest.PathValue("username")); err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	aResponseWriter.WriteHeader(http.StatusNoContent)
+}
+
+func (resource *Resource) removeUserFromGroup(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	if err := resource.identityApplicationService.RemoveUserFromGroup(aRequest.PathValue("tenantId"), aRequest.PathValue("groupName"), aRequest.PathValue("username")); err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	aResponseWriter.WriteHeader(http.StatusNoContent)
+}
+
+func (resource *Resource) addGroupToGroup(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	if err := resource.identityApplicationService.AddGroupToGroup(aRequest.PathValue("tenantId"), aRequest.PathValue("groupName"), aRequest.PathValue("memberGroupName")); err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	aResponseWriter.WriteHeader(http.StatusNoContent)
+}
+
+func (resource *Resource) removeGroupFromGroup(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	if err := resource.identityApplicationService.RemoveGroupFromGroup(aRequest.PathValue("tenantId"), aRequest.PathValue("groupName"), aRequest.PathValue("memberGroupName")); err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	aResponseWriter.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,75 @@
+package resource
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/problem"
+)
+
+func TestGroupResource(t *testing.T) {
+	t.Run("membership", func(t *testing.T) {
+		resource := newTestResource(t)
+		tenant := provisionTenant(t, resource)
+		registerUser(t, resource, tenant.TenantId, "zoeusername")
+		path := fmt.Sprintf("/tenants/%s/groups", tenant.TenantId)
+		decode(t, serve(t, resource, http.MethodPost, path, groupCommand{Name: "Staff", Description: "All staff."}), http.StatusCreated, nil)
+		decode(t, serve(t, resource, http.MethodPost, path, groupCommand{Name: "Engineers", Description: "All engineers."}), http.StatusCreated, nil)
+
+		decode(t, serve(t, resource, http.MethodPut, path+"/Staff/groups/Engineers", nil), http.StatusNoContent, nil)
+		decode(t, serve(t, resource, http.MethodPut, path+"/Engineers/users/zoeusername", nil), http.StatusNoContent, nil)
+
+		var membership groupMembershipRepresentation
+		decode(t, serve(t, resource, http.MethodGet, path+"/Staff/users/zoeusername", nil), http.StatusOK, &membership)
+		if !membership.Member {
+			t.Errorf("user must be a member of the nested group")
+		}
+		var group groupRepresentation
+		decode(t, serve(t, resource, http.MethodGet, path+"/Staff", nil), http.StatusOK, &group)
+		if len(group.GroupMembers) != 1 || group.GroupMembers[0] != (groupMemberRepresentation{Name: "Engineers", Type: "Group"}) {
+			t.Errorf("got %v, want the Engineers group as the only member", group.GroupMembers)
+		}
+
+		decode(t, serve(t, resource, http.MethodDelete, path+"/Engineers/users/zoeusername", nil), http.StatusNoContent, nil)
+		decode(t, serve(t, resource, http.MethodGet, path+"/Staff/users/zoeusername", nil), http.StatusOK, &membership)
+		if membership.Member {
+			t.Errorf("user must not be a member after removal")
+		}
+
+		decode(t, serve(t, resource, http.MethodDelete, path+"/Staff/groups/Engineers", nil), http.StatusNoContent, nil)
+	})
+	t.Run("fail group recursion", func(t *testing.T) {
+		resource := newTestResource(t)
+		tenant := provisionTenant(t, resource)
+		path := fmt.Sprintf("/tenants/%s/groups", tenant.TenantId)
+		decode(t, serve(t, resource, http.MethodPost, path, groupCommand{Name: "Staff", Description: "All staff."}), http.StatusCreated, nil)
+
+		decode(t, serve(t, resource, http.MethodPut, path+"/Staff/groups/Staff", nil), http.StatusBadRequest, nil)
+	})
+	t.Run("fail validation", func(t *testing.T) {
+		resource := newTestResource(t)
+		tenant := provisionTenant(t, resource)
+
+		var document problem.Problem
+		decode(t, serve(t, resource, http.MethodPost, fmt.Sprintf("/tenants/%s/groups", tenant.TenantId), groupCommand{Name: "Staff", Description: strings.Repeat("a", 251)}), http.StatusBadRequest, &document)
+		if len(document.InvalidParams) != 1 || document.InvalidParams[0].Name != "description" {
+			t.Errorf("got %v, want an invalid param of description", document)
+		}
+	})
+	t.Run("fail group already exists", func(t *testing.T) {
+		resource := newTestResource(t)
+		tenant := provisionTenant(t, resource)
+		path := fmt.Sprintf("/tenants/%s/groups", tenant.TenantId)
+		decode(t, serve(t, resource, http.MethodPost, path, groupCommand{Name: "Staff", Description: "All staff."}), http.StatusCreated, nil)
+
+		decode(t, serve(t, resource, http.MethodPost, path, groupCommand{Name: "Staff", Description: "All staff."}), http.StatusConflict, nil)
+	})
+	t.Run("fail unknown group", func(t *testing.T) {
+		resource := newTestResource(t)
+		tenant := provisionTenant(t, resource)
+
+		decode(t, serve(t, resource, http.MethodGet, fmt.Sprintf("/tenants/%s/groups/Unknown", tenant.TenantId), nil), http.StatusNotFound, nil)
+	})
+}
@@ -0,0 +1,224 @@
+// Package resource serves the identity and access REST API to callers bearing a token signed by its keyring. Callers act
+// on their own tenant only, and only its administrators may change it; administrators of the administration tenant may
+// act on every tenant.
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/jwt"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/problem"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/application"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+const (
+	MAXIMUM_REQUEST_BODY_BYTES = 1 << 20
+
+	ADMINISTRATOR_ROLE_NAME = "Administrator"
+
+	TYPE_REQUEST_MALFORMED = "urn:iddd:problem:request-malformed"
+	TYPE_UNAUTHENTICATED   = "urn:iddd:problem:unauthenticated"
+	TYPE_FORBIDDEN         = "urn:iddd:problem:forbidden"
+	TYPE_NOT_FOUND         = "urn:iddd:problem:not-found"
+	TYPE_ALREADY_EXISTS    = "urn:iddd:problem:already-exists"
+)
+
+var (
+	errRequestMalformed = errors.New("The request body is malformed.")
+	errUnauthenticated  = errors.New("The request is not authenticated.")
+	errForbidden        = errors.New("The caller may not make the request.")
+)
+
+type userDescriptorKey struct{}
+
+type Resource struct {
+	identityApplicationService *application.IdentityApplicationService
+	accessApplicationService   *application.AccessApplicationService
+	administrationTenantId     identity.TenantId
+	keyring                    *jwt.Keyring
+	expectation                jwt.Expectation
+	translator                 *problem.Translator
+	mux                        *http.ServeMux
+}
+
+func NewResource(anIdentityApplicationService *application.IdentityApplicationService, anAccessApplicationService *application.AccessApplicationService, anAdministrationTenantId identity.TenantId, aKeyring *jwt.Keyring, anExpectation jwt.Expectation) *Resource {
+	resource := &Resource{identityApplicationService: anIdentityApplicationService, accessApplicationService: anAccessApplicationService, administrationTenantId: anAdministrationTenantId, keyring: aKeyring, expectation: anExpectation, translator: newTranslator(), mux: http.NewServeMux()}
+
+	resource.mux.HandleFunc("POST /tenants", resource.forSystemAdministrator(resource.provisionTenant))
+	resource.mux.HandleFunc("GET /tenants", resource.forSystemAdministrator(resource.allTenants))
+	resource.mux.HandleFunc("GET /tenants/{tenantId}", resource.forTenantMember(resource.tenant))
+	resource.mux.HandleFunc("PUT /tenants/{tenantId}", resource.forTenantAdministrator(resource.changeTenantName))
+	resource.mux.HandleFunc("DELETE /tenants/{tenantId}", resource.forTenantAdministrator(resource.removeTenant))
+	resource.mux.HandleFunc("POST /tenants/{tenantId}/activate", resource.forTenantAdministrator(resource.activateTenant))
+	resource.mux.HandleFunc("POST /tenants/{tenantId}/deactivate", resource.forTenantAdministrator(resource.deactivateTenant))
+	resource.mux.HandleFunc("POST /tenants/{tenantId}/invitations", resource.forTenantAdministrator(resource.offerRegistrationInvitation))
+	resource.mux.HandleFunc("GET /tenants/{tenantId}/invitations", resource.forTenantMember(resource.availableRegistrationInvitations))
+	resource.mux.HandleFunc("DELETE /tenants/{tenantId}/invitations/{invitationId}", resource.forTenantAdministrator(resource.withdrawRegistrationInvitation))
+
+	resource.mux.HandleFunc("POST /tenants/{tenantId}/users", resource.forTenantMember(resource.registerUser))
+	resource.mux.HandleFunc("GET /tenants/{tenantId}/users/{username}", resource.forTenantMember(resource.user))
+	resource.mux.HandleFunc("PUT /tenants/{tenantId}/users/{username}/password", resource.forTenantMember(resource.changeUserPassword))
+	resource.mux.HandleFunc("GET /tenants/{tenantId}/users/{username}/inRole/{roleName}", resource.forTenantMember(resource.userInRole))
+
+	resource.mux.HandleFunc("POST /tenants/{tenantId}/groups", resource.forTenantAdministrator(resource.provisionGroup))
+	resource.mux.HandleFunc("GET /tenants/{tenantId}/groups/{groupName}", resource.forTenantMember(resource.group))
+	resource.mux.HandleFunc("GET /tenants/{tenantId}/groups/{groupName}/users/{username}", resource.forTenantMember(resource.groupMember))
+	resource.mux.HandleFunc("PUT /tenants/{tenantId}/groups/{groupName}/users/{username}", resource.forTenantAdministrator(resource.addUserToGroup))
+	resource.mux.HandleFunc("DELETE /tenants/{tenantId}/groups/{groupName}/users/{username}", resource.forTenantAdministrator(resource.removeUserFromGroup))
+	resource.mux.HandleFunc("PUT /tenants/{tenantId}/groups/{groupName}/groups/{memberGroupName}", resource.forTenantAdministrator(resource.addGroupToGroup))
+	resource.mux.HandleFunc("DELETE /tenants/{tenantId}/groups/{groupName}/groups/{memberGroupName}", resource.forTenantAdministrator(resource.removeGroupFromGroup))
+
+	resource.mux.HandleFunc("POST /tenants/{tenantId}/roles", resource.forTenantAdministrator(resource.provisionRole))
+	resource.mux.HandleFunc("GET /tenants/{tenantId}/roles/{roleName}", resource.forTenantMember(resource.role))
+	resource.mux.HandleFunc("PUT /tenants/{tenantId}/roles/{roleName}/users/{username}", resource.forTenantAdministrator(resource.assignUserToRole))
+	resource.mux.HandleFunc("PUT /tenants/{tenantId}/roles/{roleName}/groups/{groupName}", resource.forTenantAdministrator(resource.assignGroupToRole))
+
+	return resource
+}
+
+func (resource *Resource) ServeHTTP(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	userDescriptor, err := resource.authenticate(aRequest)
+	if err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	resource.mux.ServeHTTP(aResponseWriter, aRequest.WithContext(context.WithValue(aRequest.Context(), userDescriptorKey{}, userDescriptor)))
+}
+
+// authenticate does not tell the caller why a token is rejected.
+func (resource *Resource) authenticate(aRequest *http.Request) (*identity.UserDescriptor, error) {
+	plainToken, ok := strings.CutPrefix(aRequest.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return nil, errUnauthenticated
+	}
+	userDescriptor, err := identity.NewUserDescriptorFromToken(resource.keyring, plainToken, resource.expectation)
+	if err != nil {
+		return nil, errUnauthenticated
+	}
+	return userDescriptor, nil
+}
+
+// The handlers below authorize the caller once the mux has matched the path, as they depend on the tenant in it.
+
+func (resource *Resource) forSystemAdministrator(aHandler http.HandlerFunc) http.HandlerFunc {
+	return resource.authorized(aHandler, func(aUserDescriptor *identity.UserDescriptor, aRequest *http.Request) error {
+		isSystemAdministrator, err := resource.isSystemAdministrator(aUserDescriptor)
+		if err != nil {
+			return err
+		}
+		if !isSystemAdministrator {
+			return errForbidden
+		}
+		return nil
+	})
+}
+
+func (resource *Resource) forTenantMember(aHandler http.HandlerFunc) http.HandlerFunc {
+	return resource.authorized(aHandler, func(aUserDescriptor *identity.UserDescriptor, aRequest *http.Request) error {
+		return resource.authorizeTenant(aUserDescriptor, aRequest.PathValue("tenantId"), false)
+	})
+}
+
+func (resource *Resource) forTenantAdministrator(aHandler http.HandlerFunc) http.HandlerFunc {
+	return resource.authorized(aHandler, func(aUserDescriptor *identity.UserDescriptor, aRequest *http.Request) error {
+		return resource.authorizeTenant(aUserDescriptor, aRequest.PathValue("tenantId"), true)
+	})
+}
+
+func (resource *Resource) authorized(aHandler http.HandlerFunc, anAuthorize func(*identity.UserDescriptor, *http.Request) error) http.HandlerFunc {
+	return func(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+		userDescriptor, _ := aRequest.Context().Value(userDescriptorKey{}).(*identity.UserDescriptor)
+		if userDescriptor == nil {
+			resource.writeError(aResponseWriter, aRequest, errUnauthenticated)
+			return
+		}
+		if err := anAuthorize(userDescriptor, aRequest); err != nil {
+			resource.writeError(aResponseWriter, aRequest, err)
+			return
+		}
+		aHandler(aResponseWriter, aRequest)
+	}
+}
+
+func (resource *Resource) authorizeTenant(aUserDescriptor *identity.UserDescriptor, aTenantId string, anAdministratorOnly bool) error {
+	isSystemAdministrator, err := resource.isSystemAdministrator(aUserDescriptor)
+	if err != nil || isSystemAdministrator {
+		return err
+	}
+	tenantId, err := identity.NewTenantId(aTenantId)
+	if err != nil || *tenantId != aUserDescriptor.TenantId() {
+		return errForbidden
+	}
+	if !anAdministratorOnly {
+		return nil
+	}
+	isAdministrator, err := resource.isAdministrator(aUserDescriptor)
+	if err != nil {
+		return err
+	}
+	if !isAdministrator {
+		return errForbidden
+	}
+	return nil
+}
+
+func (resource *Resource) isSystemAdministrator(aUserDescriptor *identity.UserDescriptor) (bool, error) {
+	if aUserDescriptor.TenantId() != resource.administrationTenantId {
+		return false, nil
+	}
+	return resource.isAdministrator(aUserDescriptor)
+}
+
+func (resource *Resource) isAdministrator(aUserDescriptor *identity.UserDescriptor) (bool, error) {
+	tenantId := aUserDescriptor.TenantId()
+	return resource.accessApplicationService.IsUserInRole(tenantId.Id(), aUserDescriptor.Username(), ADMINISTRATOR_ROLE_NAME)
+}
+
+func readJson(aResponseWriter http.ResponseWriter, aRequest *http.Request, aValue interface{}) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(aResponseWriter, aRequest.Body, MAXIMUM_REQUEST_BODY_BYTES))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(aValue); err != nil {
+		return errRequestMalformed
+	}
+	return nil
+}
+
+func writeJson(aResponseWriter http.ResponseWriter, aStatus int, aValue interface{}) {
+	aResponseWriter.Header().Set("Content-Type", "application/json")
+	aResponseWriter.WriteHeader(aStatus)
+	if err := json.NewEncoder(aResponseWriter).Encode(aValue); err != nil {
+		log.Printf("resource.writeJson(): %v", err)
+	}
+}
+
+func (resource *Resource) writeError(aResponseWriter http.ResponseWriter, aRequest *http.Request, anError error) {
+	document := resource.translator.TranslateIn(anError, aRequest.Header.Get("Accept-Language"))
+	if document.Status == http.StatusInternalServerError {
+		log.Printf("resource.writeError(%s): %v", aRequest.URL.Path, anError)
+	}
+	if document.Status == http.StatusUnauthorized {
+		aResponseWriter.Header().Set("WWW-Authenticate", `Bearer realm="iddd"`)
+	}
+	document.Instance = aRequest.URL.Path
+	document.Write(aResponseWriter)
+}
+
+func newTranslator() *problem.Translator {
+	translator := problem.NewTranslator()
+	translator.Register(errRequestMalformed, http.StatusBadRequest, TYPE_REQUEST_MALFORMED, "The request body is malformed.")
+	translator.Register(errUnauthenticated, http.StatusUnauthorized, TYPE_UNAUTHENTICATED, "The request is not authenticated.")
+	translator.Register(errForbidden, http.StatusForbidden, TYPE_FORBIDDEN, "The caller may not make the request.")
+	for _, err := range []error{application.ErrTenantNotFound, application.ErrUserNotFound, application.ErrGroupNotFound, application.ErrRoleNotFound} {
+		translator.Register(err, http.StatusNotFound, TYPE_NOT_FOUND, "The resource is not found.")
+	}
+	for _, err := range []error{application.ErrUserAlreadyExists, application.ErrUsernameConfusable, application.ErrGroupAlreadyExists, application.ErrRoleAlreadyExists} {
+		translator.Register(err, http.StatusConflict, TYPE_ALREADY_EXISTS, "The resource already exists.")
+	}
+	return translator
+}
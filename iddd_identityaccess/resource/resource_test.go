@@ -0,0 +1,345 @@
+package resource
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/jwt"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/problem"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/application"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/access"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/infrastructure/persistence"
+	"github.com/google/go-cmp/cmp"
+)
+
+const password = "qwerty!ASDFG#"
+
+var expectation = jwt.Expectation{Issuer: "iddd", Audience: "identityaccess"}
+
+// administrationTenantId is the tenant of the administrator whom bearerToken is signed for.
+const administrationTenantId = "5a4f7b9e-3c1d-4e8f-9a2b-6c7d8e9f0a1b"
+
+// bearerToken is signed with keyring by newTestResource.
+var (
+	keyring     *jwt.Keyring
+	bearerToken string
+)
+
+type discardNotifier struct{}
+
+func (discardNotifier) Notify(aNotification *application.Notification) error {
+	return nil
+}
+
+func newTestResource(t *testing.T) *Resource {
+	t.Helper()
+
+	tenantRepository := persistence.NewInMemoryTenantRepository()
+	userRepository := persistence.NewInMemoryUserRepository()
+	groupRepository := persistence.NewInMemoryGroupRepository()
+	roleRepository := persistence.NewInMemoryRoleRepository()
+
+	lockoutPolicy, err := identity.NewLockoutPolicy(3, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	passwordResetService := identity.NewPasswordResetService(persistence.NewInMemoryPasswordResetTokenRepository(), userRepository, time.Hour)
+	emailVerificationService := identity.NewEmailVerificationService(persistence.NewInMemoryEmailVerificationTokenRepository(), userRepository, time.Hour, time.Minute)
+	roleAssignmentService := access.NewRoleAssignmentService(roleRepository, identity.NewGroupMemberService(userRepository, groupRepository))
+	identityApplicationService := application.NewIdentityApplicationService(tenantRepository, userRepository, groupRepository, roleAssignmentService, *identity.DefaultNameLengthPolicy(), passwordResetService, emailVerificationService, discardNotifier{})
+
+	authenticationService := identity.NewAuthenticationService(tenantRepository, userRepository, *lockoutPolicy)
+	authorizationService := access.NewAuthorizationService(userRepository, groupRepository, roleRepository)
+	refreshTokenService := identity.NewRefreshTokenService(tenantRepository, userRepository, persistence.NewInMemoryRefreshTokenRepository(), time.Hour)
+	accessApplicationService := application.NewAccessApplicationService(authenticationService, authorizationService, roleAssignmentService, refreshTokenService, tenantRepository, userRepository, groupRepository, roleRepository)
+
+	tenantId, err := identity.NewTenantId(administrationTenantId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenant, err := identity.NewTenant(*tenantId, "Administration", true, *identity.DefaultNameLengthPolicy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tenantRepository.Add(tenant); err != nil {
+		t.Fatal(err)
+	}
+	administrator, err := tenant.ProvisionUser("operatorusername", password, *identity.NewIndefiniteEnablement(true), *newPerson(t, *tenantId), *identity.DefaultNameLengthPolicy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := userRepository.Add(administrator); err != nil {
+		t.Fatal(err)
+	}
+	role, err := access.NewRole(*tenantId, ADMINISTRATOR_ROLE_NAME, "Administers every tenant.", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := roleAssignmentService.AssignUser(role, administrator); err != nil {
+		t.Fatal(err)
+	}
+	if err := roleRepository.Add(role); err != nil {
+		t.Fatal(err)
+	}
+
+	newKeyring(t)
+	return NewResource(identityApplicationService, accessApplicationService, *tenantId, keyring, expectation)
+}
+
+func newPerson(t *testing.T, aTenantId identity.TenantId) *identity.Person {
+	t.Helper()
+
+	fullName, err := identity.NewFullName("Zoe", "Doe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	emailAddress, err := identity.NewEmailAddress("zoe@saasovation.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return identity.NewPerson(aTenantId, *fullName, *emailAddress)
+}
+
+func newKeyring(t *testing.T) {
+	t.Helper()
+
+	_, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := jwt.NewEd25519Key("key-1", privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyring, err = jwt.NewKeyring("key-1", key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bearerToken = signedToken(t, expectation, time.Minute)
+}
+
+func signedToken(t *testing.T, anExpectation jwt.Expectation, aLifetime time.Duration) string {
+	t.Helper()
+
+	return signedTokenFor(t, administrationTenantId, "operatorusername", anExpectation, aLifetime)
+}
+
+func signedTokenFor(t *testing.T, aTenantId string, aUsername string, anExpectation jwt.Expectation, aLifetime time.Duration) string {
+	t.Helper()
+
+	tenantId, err := identity.NewTenantId(aTenantId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := identity.NewUserDescriptor(*tenantId, aUsername, aUsername+"@saasovation.com").SignedToken(keyring, anExpectation.Issuer, anExpectation.Audience, aLifetime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func newRequest(aMethod string, aPath string, aBody io.Reader) *http.Request {
+	request := httptest.NewRequest(aMethod, aPath, aBody)
+	request.Header.Set("Authorization", "Bearer "+bearerToken)
+	return request
+}
+
+func serve(t *testing.T, aResource *Resource, aMethod string, aPath string, aBody interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+
+	return serveAs(t, aResource, bearerToken, aMethod, aPath, aBody)
+}
+
+func serveAs(t *testing.T, aResource *Resource, aBearerToken string, aMethod string, aPath string, aBody interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var body bytes.Buffer
+	if aBody != nil {
+		if err := json.NewEncoder(&body).Encode(aBody); err != nil {
+			t.Fatal(err)
+		}
+	}
+	request := newRequest(aMethod, aPath, &body)
+	request.Header.Set("Authorization", "Bearer "+aBearerToken)
+	responseRecorder := httptest.NewRecorder()
+	aResource.ServeHTTP(responseRecorder, request)
+	return responseRecorder
+}
+
+func decode(t *testing.T, aResponseRecorder *httptest.ResponseRecorder, aStatus int, aValue interface{}) {
+	t.Helper()
+
+	if aResponseRecorder.Code != aStatus {
+		t.Fatalf("got status %d, want %d: %s", aResponseRecorder.Code, aStatus, aResponseRecorder.Body.String())
+	}
+	if aValue == nil {
+		return
+	}
+	if err := json.NewDecoder(aResponseRecorder.Body).Decode(aValue); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func provisionTenant(t *testing.T, aResource *Resource) tenantRepresentation {
+	t.Helper()
+
+	var tenant tenantRepresentation
+	decode(t, serve(t, aResource, http.MethodPost, "/tenants", tenantCommand{Name: "TenantName"}), http.StatusCreated, &tenant)
+	return tenant
+}
+
+func registerUser(t *testing.T, aResource *Resource, aTenantId string, aUsername string) userRepresentation {
+	t.Helper()
+
+	var registrationInvitation registrationInvitationRepresentation
+	decode(t, serve(t, aResource, http.MethodPost, fmt.Sprintf("/tenants/%s/invitations", aTenantId), registrationInvitationCommand{Description: "Invitation for " + aUsername}), http.StatusCreated, &registrationInvitation)
+
+	var user userRepresentation
+	decode(t, serve(t, aResource, http.MethodPost, fmt.Sprintf("/tenants/%s/users", aTenantId), newRegisterUserCommand(registrationInvitation.InvitationId, aUsername)), http.StatusCreated, &user)
+	return user
+}
+
+func newRegisterUserCommand(anInvitationId string, aUsername string) registerUserCommand {
+	return registerUserCommand{
+		InvitationId: anInvitationId,
+		Username:     aUsername,
+		Password:     password,
+		FirstName:    "Zoe",
+		LastName:     "Doe",
+		EmailAddress: "zoe@saasovation.com",
+		Enablement:   enablementCommand{Enabled: true, StartDate: time.Now().AddDate(-1, 0, 0), EndDate: time.Now().AddDate(1, 0, 0)},
+	}
+}
+
+func TestMalformedRequestBody(t *testing.T) {
+	resource := newTestResource(t)
+
+	responseRecorder := httptest.NewRecorder()
+	resource.ServeHTTP(responseRecorder, newRequest(http.MethodPost, "/tenants", strings.NewReader(`{"name": "TenantName", "unknown": true}`)))
+
+	if got := responseRecorder.Header().Get("Content-Type"); got != problem.CONTENT_TYPE {
+		t.Errorf("got content type %s, want %s", got, problem.CONTENT_TYPE)
+	}
+	var document problem.Problem
+	decode(t, responseRecorder, http.StatusBadRequest, &document)
+	want := problem.Problem{Type: TYPE_REQUEST_MALFORMED, Title: "The request body is malformed.", Status: http.StatusBadRequest, Detail: errRequestMalformed.Error(), Instance: "/tenants"}
+	if diff := cmp.Diff(want, document); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestWriteErrorHidesUnexpectedError(t *testing.T) {
+	resource := newTestResource(t)
+	responseRecorder := httptest.NewRecorder()
+
+	resource.writeError(responseRecorder, httptest.NewRequest(http.MethodGet, "/tenants", nil), errors.New("connection refused to 10.0.0.1"))
+
+	var document problem.Problem
+	decode(t, responseRecorder, http.StatusInternalServerError, &document)
+	if document.Detail != "" || strings.Contains(document.Title, "10.0.0.1") {
+		t.Errorf("unexpected error must not be exposed: %v", document)
+	}
+}
+
+func TestWriteErrorLocalizesDetail(t *testing.T) {
+	resource := newTestResource(t)
+
+	request := newRequest(http.MethodPost, "/tenants", strings.NewReader(`{"name": ""}`))
+	request.Header.Set("Accept-Language", "ja-JP,ja;q=0.9,en;q=0.8")
+	responseRecorder := httptest.NewRecorder()
+	resource.ServeHTTP(responseRecorder, request)
+
+	var document problem.Problem
+	decode(t, responseRecorder, http.StatusBadRequest, &document)
+	if want := "テナント名を入力してください。"; document.Detail != want {
+		t.Errorf("got %s, want %s", document.Detail, want)
+	}
+}
+
+func TestUnauthorizedRequest(t *testing.T) {
+	resource := newTestResource(t)
+	tenant := provisionTenant(t, resource)
+	otherTenant := provisionTenant(t, resource)
+	registerUser(t, resource, tenant.TenantId, "zoeusername")
+	registerUser(t, resource, tenant.TenantId, "johnusername")
+	decode(t, serve(t, resource, http.MethodPost, fmt.Sprintf("/tenants/%s/roles", tenant.TenantId), roleCommand{Name: ADMINISTRATOR_ROLE_NAME, Description: "Administers the tenant."}), http.StatusCreated, nil)
+	decode(t, serve(t, resource, http.MethodPut, fmt.Sprintf("/tenants/%s/roles/%s/users/johnusername", tenant.TenantId, ADMINISTRATOR_ROLE_NAME), nil), http.StatusNoContent, nil)
+	member := signedTokenFor(t, tenant.TenantId, "zoeusername", expectation, time.Minute)
+	administrator := signedTokenFor(t, tenant.TenantId, "johnusername", expectation, time.Minute)
+
+	tests := []struct {
+		name        string
+		bearerToken string
+		method      string
+		path        string
+		want        int
+	}{
+		{name: "success member reads own tenant", bearerToken: member, method: http.MethodGet, path: "/tenants/" + tenant.TenantId, want: http.StatusOK},
+		{name: "fail member reads other tenant", bearerToken: member, method: http.MethodGet, path: "/tenants/" + otherTenant.TenantId, want: http.StatusForbidden},
+		{name: "fail administrator deactivates other tenant", bearerToken: administrator, method: http.MethodPost, path: fmt.Sprintf("/tenants/%s/deactivate", otherTenant.TenantId), want: http.StatusForbidden},
+		{name: "fail administrator removes other tenant", bearerToken: administrator, method: http.MethodDelete, path: "/tenants/" + otherTenant.TenantId, want: http.StatusForbidden},
+		{name: "fail administrator lists tenants", bearerToken: administrator, method: http.MethodGet, path: "/tenants", want: http.StatusForbidden},
+		{name: "fail administrator provisions tenant", bearerToken: administrator, method: http.MethodPost, path: "/tenants", want: http.StatusForbidden},
+		{name: "fail member deactivates own tenant", bearerToken: member, method: http.MethodPost, path: fmt.Sprintf("/tenants/%s/deactivate", tenant.TenantId), want: http.StatusForbidden},
+		{name: "fail member removes own tenant", bearerToken: member, method: http.MethodDelete, path: "/tenants/" + tenant.TenantId, want: http.StatusForbidden},
+		{name: "fail member provisions role", bearerToken: member, method: http.MethodPost, path: fmt.Sprintf("/tenants/%s/roles", tenant.TenantId), want: http.StatusForbidden},
+		{name: "fail member assigns role", bearerToken: member, method: http.MethodPut, path: fmt.Sprintf("/tenants/%s/roles/%s/users/zoeusername", tenant.TenantId, ADMINISTRATOR_ROLE_NAME), want: http.StatusForbidden},
+		// last, as it deactivates the tenant
+		{name: "success administrator deactivates own tenant", bearerToken: administrator, method: http.MethodPost, path: fmt.Sprintf("/tenants/%s/deactivate", tenant.TenantId), want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body interface{}
+			if tt.method == http.MethodPost && strings.HasSuffix(tt.path, "/roles") {
+				body = roleCommand{Name: "Requester", Description: "Requests."}
+			}
+			responseRecorder := serveAs(t, resource, tt.bearerToken, tt.method, tt.path, body)
+			if responseRecorder.Code != tt.want {
+				t.Errorf("got status %d, want %d: %s", responseRecorder.Code, tt.want, responseRecorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestUnauthenticatedRequest(t *testing.T) {
+	resource := newTestResource(t)
+
+	tests := []struct {
+		name          string
+		authorization string
+	}{
+		{name: "fail no token", authorization: ""},
+		{name: "fail not bearer", authorization: "Basic YWRtaW46YWRtaW4="},
+		{name: "fail malformed token", authorization: "Bearer not.a.token"},
+		{name: "fail other audience", authorization: "Bearer " + signedToken(t, jwt.Expectation{Issuer: "iddd", Audience: "collaboration"}, time.Minute)},
+		{name: "fail expired", authorization: "Bearer " + signedToken(t, expectation, time.Nanosecond)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/tenants", nil)
+			request.Header.Set("Authorization", tt.authorization)
+			responseRecorder := httptest.NewRecorder()
+			resource.ServeHTTP(responseRecorder, request)
+
+			if got := responseRecorder.Header().Get("WWW-Authenticate"); got != `Bearer realm="iddd"` {
+				t.Errorf("got WWW-Authenticate %s", got)
+			}
+			var document problem.Problem
+			decode(t, responseRecorder, http.StatusUnauthorized, &document)
+			want := problem.Problem{Type: TYPE_UNAUTHENTICATED, Title: "The request is not authenticated.", Status: http.StatusUnauthorized, Detail: errUnauthenticated.Error(), Instance: "/tenants"}
+			if diff := cmp.Diff(want, document); diff != "" {
+				t.Errorf("mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package resource
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/access"
+)
+
+type roleRepresentation struct {
+	TenantId        string `json:"tenantId"`
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	SupportsNesting bool   `json:"supportsNesting"`
+}
+
+func newRoleRepresentation(aRole *access.Role) roleRepresentation {
+	tenantId := aRole.TenantId()
+	return roleRepresentation{TenantId: tenantId.Id(), Name: aRole.Name(), Description: aRole.Description(), SupportsNesting: aRole.SupportsNesting()}
+}
+
+type roleCommand struct {
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	SupportsNesting bool   `json:"supportsNesting"`
+}
+
+func (resource *Resource) provisionRole(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	var command roleCommand
+	if err := readJson(aResponseWriter, aRequest, &command); err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+
+	role, err := resource.accessApplicationService.ProvisionRole(aRequest.PathValue("tenantId"), command.Name, command.Description, command.SupportsNesting)
+	if err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	representation := newRoleRepresentation(role)
+	aResponseWriter.Header().Set("Location", "/tenants/"+representation.TenantId+"/roles/"+url.PathEscape(representation.Name))
+	writeJson(aResponseWriter, http.StatusCreated, representation)
+}
+
+func (resource *Resource) role(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	role, err := resource.accessApplicationService.Role(aRequest.PathValue("tenantId"), aRequest.PathValue("roleName"))
+	if err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	writeJson(aResponseWriter, http.StatusOK, newRoleRepresentation(role))
+}
+
+func (resource *Resource) assignUserToRole(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	if err := resource.accessApplicationService.AssignUserToRole(aRequest.PathValue("tenantId"), aRequest.PathValue("roleName"), aRequest.PathValue("username")); err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	aResponseWriter.WriteHeader(http.StatusNoContent)
+}
+
+func (resource *Resource) assignGroupToRole(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	if err := resource.accessApplicationService.AssignGroupToRole(aRequest.PathValue("tenantId"), aRequest.PathValue("roleName"), aRequest.PathValue("groupName")); err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	aResponseWriter.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,67 @@
+package resource
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/problem"
+)
+
+func TestRoleResource(t *testing.T) {
+	t.Run("user in role", func(t *testing.T) {
+		resource := newTestResource(t)
+		tenant := provisionTenant(t, resource)
+		registerUser(t, resource, tenant.TenantId, "zoeusername")
+		inRolePath := fmt.Sprintf("/tenants/%s/users/zoeusername/inRole/Manager", tenant.TenantId)
+
+		var role roleRepresentation
+		decode(t, serve(t, resource, http.MethodPost, fmt.Sprintf("/tenants/%s/roles", tenant.TenantId), roleCommand{Name: "Manager", Description: "A manager role.", SupportsNesting: true}), http.StatusCreated, &role)
+
+		var userInRole userInRoleRepresentation
+		decode(t, serve(t, resource, http.MethodGet, inRolePath, nil), http.StatusOK, &userInRole)
+		if userInRole.InRole {
+			t.Errorf("user must not be in role %v before assignment", role)
+		}
+
+		decode(t, serve(t, resource, http.MethodPut, fmt.Sprintf("/tenants/%s/roles/Manager/users/zoeusername", tenant.TenantId), nil), http.StatusNoContent, nil)
+		decode(t, serve(t, resource, http.MethodGet, inRolePath, nil), http.StatusOK, &userInRole)
+		if !userInRole.InRole {
+			t.Errorf("user must be in role %v", role)
+		}
+	})
+	t.Run("group in role", func(t *testing.T) {
+		resource := newTestResource(t)
+		tenant := provisionTenant(t, resource)
+		registerUser(t, resource, tenant.TenantId, "zoeusername")
+		decode(t, serve(t, resource, http.MethodPost, fmt.Sprintf("/tenants/%s/roles", tenant.TenantId), roleCommand{Name: "Manager", Description: "A manager role.", SupportsNesting: true}), http.StatusCreated, nil)
+		decode(t, serve(t, resource, http.MethodPost, fmt.Sprintf("/tenants/%s/groups", tenant.TenantId), groupCommand{Name: "Managers", Description: "All managers."}), http.StatusCreated, nil)
+		decode(t, serve(t, resource, http.MethodPut, fmt.Sprintf("/tenants/%s/groups/Managers/users/zoeusername", tenant.TenantId), nil), http.StatusNoContent, nil)
+
+		decode(t, serve(t, resource, http.MethodPut, fmt.Sprintf("/tenants/%s/roles/Manager/groups/Managers", tenant.TenantId), nil), http.StatusNoContent, nil)
+
+		var userInRole userInRoleRepresentation
+		decode(t, serve(t, resource, http.MethodGet, fmt.Sprintf("/tenants/%s/users/zoeusername/inRole/Manager", tenant.TenantId), nil), http.StatusOK, &userInRole)
+		if !userInRole.InRole {
+			t.Errorf("user must be in role through group Managers")
+		}
+	})
+	t.Run("fail validation", func(t *testing.T) {
+		resource := newTestResource(t)
+		tenant := provisionTenant(t, resource)
+
+		var document problem.Problem
+		decode(t, serve(t, resource, http.MethodPost, fmt.Sprintf("/tenants/%s/roles", tenant.TenantId), roleCommand{Name: "", Description: "A manager role."}), http.StatusBadRequest, &document)
+		if len(document.InvalidParams) != 1 || document.InvalidParams[0].Name != "name" {
+			t.Errorf("got %v, want an invalid param of name", document)
+		}
+	})
+	t.Run("fail unknown role", func(t *testing.T) {
+		resource := newTestResource(t)
+		tenant := provisionTenant(t, resource)
+		registerUser(t, resource, tenant.TenantId, "zoeusername")
+
+		decode(t, serve(t, resource, http.MethodGet, fmt.Sprintf("/tenants/%s/roles/Unknown", tenant.TenantId), nil), http.StatusNotFound, nil)
+		decode(t, serve(t, resource, http.MethodPut, fmt.Sprintf("/tenants/%s/roles/Unknown/users/zoeusername", tenant.TenantId), nil), http.StatusNotFound, nil)
+	})
+}
@@ -0,0 +1,164 @@
+package resource
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+type tenantRepresentation struct {
+	TenantId string `json:"tenantId"`
+	Name     string `json:"name"`
+	Active   bool   `json:"active"`
+}
+
+func newTenantRepresentation(aTenant *identity.Tenant) tenantRepresentation {
+	tenantId := aTenant.TenantId()
+	return tenantRepresentation{TenantId: tenantId.Id(), Name: aTenant.Name(), Active: aTenant.IsActive()}
+}
+
+type tenantCommand struct {
+	Name string `json:"name"`
+}
+
+type registrationInvitationRepresentation struct {
+	InvitationId string     `json:"invitationId"`
+	Description  string     `json:"description"`
+	StartingOn   *time.Time `json:"startingOn,omitempty"`
+	Until        *time.Time `json:"until,omitempty"`
+}
+
+func newRegistrationInvitationRepresentation(aRegistrationInvitation *identity.RegistrationInvitation) registrationInvitationRepresentation {
+	representation := registrationInvitationRepresentation{InvitationId: aRegistrationInvitation.InvitationId(), Description: aRegistrationInvitation.Description()}
+	if startingOn := aRegistrationInvitation.StartingOn(); !startingOn.IsZero() {
+		representation.StartingOn = &startingOn
+	}
+	if until := aRegistrationInvitation.Until(); !until.IsZero() {
+		representation.Until = &until
+	}
+	return representation
+}
+
+type registrationInvitationCommand struct {
+	Description string    `json:"description"`
+	StartingOn  time.Time `json:"startingOn"`
+	Until       time.Time `json:"until"`
+}
+
+func (resource *Resource) provisionTenant(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	var command tenantCommand
+	if err := readJson(aResponseWriter, aRequest, &command); err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+
+	tenant, err := resource.identityApplicationService.ProvisionTenant(command.Name)
+	if err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	representation := newTenantRepresentation(tenant)
+	aResponseWriter.Header().Set("Location", "/tenants/"+representation.TenantId)
+	writeJson(aResponseWriter, http.StatusCreated, representation)
+}
+
+func (resource *Resource) allTenants(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	tenants, err := resource.identityApplicationService.AllTenants()
+	if err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	representations := make([]tenantRepresentation, len(tenants))
+	for i, tenant := range tenants {
+		representations[i] = newTenantRepresentation(tenant)
+	}
+	writeJson(aResponseWriter, http.StatusOK, representations)
+}
+
+func (resource *Resource) tenant(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	resource.writeTenant(aResponseWriter, aRequest)
+}
+
+func (resource *Resource) changeTenantName(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	var command tenantCommand
+	if err := readJson(aResponseWriter, aRequest, &command); err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+
+	if err := resource.identityApplicationService.ChangeTenantName(aRequest.PathValue("tenantId"), command.Name); err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	resource.writeTenant(aResponseWriter, aRequest)
+}
+
+func (resource *Resource) removeTenant(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	if err := resource.identityApplicationService.RemoveTenant(aRequest.PathValue("tenantId")); err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	aResponseWriter.WriteHeader(http.StatusNoContent)
+}
+
+func (resource *Resource) activateTenant(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	if err := resource.identityApplicationService.ActivateTenant(aRequest.PathValue("tenantId")); err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	resource.writeTenant(aResponseWriter, aRequest)
+}
+
+func (resource *Resource) deactivateTenant(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	if err := resource.identityApplicationService.DeactivateTenant(aRequest.PathValue("tenantId")); err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	resource.writeTenant(aResponseWriter, aRequest)
+}
+
+func (resource *Resource) offerRegistrationInvitation(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	var command registrationInvitationCommand
+	if err := readJson(aResponseWriter, aRequest, &command); err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+
+	registrationInvitation, err := resource.identityApplicationService.OfferRegistrationInvitation(aRequest.PathValue("tenantId"), command.Description, command.StartingOn, command.Until)
+	if err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	writeJson(aResponseWriter, http.StatusCreated, newRegistrationInvitationRepresentation(registrationInvitation))
+}
+
+func (resource *Resource) availableRegistrationInvitations(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	registrationInvitations, err := resource.identityApplicationService.AvailableRegistrationInvitations(aRequest.PathValue("tenantId"))
+	if err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	representations := make([]registrationInvitationRepresentation, len(registrationInvitations))
+	for i := range registrationInvitations {
+		representations[i] = newRegistrationInvitationRepresentation(&registrationInvitations[i])
+	}
+	writeJson(aResponseWriter, http.StatusOK, representations)
+}
+
+func (resource *Resource) withdrawRegistrationInvitation(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	if err := resource.identityApplicationService.WithdrawRegistrationInvitation(aRequest.PathValue("tenantId"), aRequest.PathValue("invitationId")); err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	aResponseWriter.WriteHeader(http.StatusNoContent)
+}
+
+func (resource *Resource) writeTenant(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	tenant, err := resource.identityApplicationService.Tenant(aRequest.PathValue("tenantId"))
+	if err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	writeJson(aResponseWriter, http.StatusOK, newTenantRepresentation(tenant))
+}
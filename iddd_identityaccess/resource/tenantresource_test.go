@@ -0,0 +1,122 @@
+package resource
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/problem"
+)
+
+func containsTenant(aTenants []tenantRepresentation, aTenantId string) bool {
+	for _, tenant := range aTenants {
+		if tenant.TenantId == aTenantId {
+			return true
+		}
+	}
+	return false
+}
+
+func TestTenantResource(t *testing.T) {
+	t.Run("crud", func(t *testing.T) {
+		resource := newTestResource(t)
+		tenant := provisionTenant(t, resource)
+		path := fmt.Sprintf("/tenants/%s", tenant.TenantId)
+
+		var got tenantRepresentation
+		decode(t, serve(t, resource, http.MethodGet, path, nil), http.StatusOK, &got)
+		if got != tenant {
+			t.Errorf("got %v, want %v", got, tenant)
+		}
+
+		decode(t, serve(t, resource, http.MethodPut, path, tenantCommand{Name: "OtherName"}), http.StatusOK, &got)
+		if got.Name != "OtherName" {
+			t.Errorf("got %s, want %s", got.Name, "OtherName")
+		}
+
+		var tenants []tenantRepresentation
+		decode(t, serve(t, resource, http.MethodGet, "/tenants", nil), http.StatusOK, &tenants)
+		if len(tenants) != 2 || !containsTenant(tenants, tenant.TenantId) {
+			t.Errorf("got %v, want the administration tenant and %v", tenants, got)
+		}
+
+		decode(t, serve(t, resource, http.MethodDelete, path, nil), http.StatusNoContent, nil)
+		decode(t, serve(t, resource, http.MethodGet, path, nil), http.StatusNotFound, nil)
+	})
+	t.Run("activate and deactivate", func(t *testing.T) {
+		resource := newTestResource(t)
+		tenant := provisionTenant(t, resource)
+
+		var got tenantRepresentation
+		decode(t, serve(t, resource, http.MethodPost, fmt.Sprintf("/tenants/%s/deactivate", tenant.TenantId), nil), http.StatusOK, &got)
+		if got.Active {
+			t.Errorf("tenant %v must be inactive", got)
+		}
+		decode(t, serve(t, resource, http.MethodPost, fmt.Sprintf("/tenants/%s/activate", tenant.TenantId), nil), http.StatusOK, &got)
+		if !got.Active {
+			t.Errorf("tenant %v must be active", got)
+		}
+	})
+	t.Run("fail validation", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			command tenantCommand
+		}{
+			{name: "empty name", command: tenantCommand{Name: ""}},
+			{name: "over 100 characters name", command: tenantCommand{Name: strings.Repeat("a", 101)}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				resource := newTestResource(t)
+
+				var document problem.Problem
+				decode(t, serve(t, resource, http.MethodPost, "/tenants", tt.command), http.StatusBadRequest, &document)
+				if len(document.InvalidParams) != 1 || document.InvalidParams[0].Name != "name" {
+					t.Errorf("got %v, want an invalid param of name", document)
+				}
+			})
+		}
+	})
+	t.Run("fail unknown tenant", func(t *testing.T) {
+		resource := newTestResource(t)
+
+		decode(t, serve(t, resource, http.MethodGet, "/tenants/unknown", nil), http.StatusNotFound, nil)
+		decode(t, serve(t, resource, http.MethodPost, "/tenants/unknown/activate", nil), http.StatusNotFound, nil)
+	})
+}
+
+func TestRegistrationInvitationResource(t *testing.T) {
+	t.Run("offer and withdraw", func(t *testing.T) {
+		resource := newTestResource(t)
+		tenant := provisionTenant(t, resource)
+		path := fmt.Sprintf("/tenants/%s/invitations", tenant.TenantId)
+
+		var registrationInvitation registrationInvitationRepresentation
+		decode(t, serve(t, resource, http.MethodPost, path, registrationInvitationCommand{Description: "Today-and-Tomorrow"}), http.StatusCreated, &registrationInvitation)
+
+		var registrationInvitations []registrationInvitationRepresentation
+		decode(t, serve(t, resource, http.MethodGet, path, nil), http.StatusOK, &registrationInvitations)
+		if len(registrationInvitations) != 1 || registrationInvitations[0].InvitationId != registrationInvitation.InvitationId {
+			t.Errorf("got %v, want [%v]", registrationInvitations, registrationInvitation)
+		}
+
+		decode(t, serve(t, resource, http.MethodDelete, path+"/"+registrationInvitation.InvitationId, nil), http.StatusNoContent, nil)
+		decode(t, serve(t, resource, http.MethodGet, path, nil), http.StatusOK, &registrationInvitations)
+		if len(registrationInvitations) != 0 {
+			t.Errorf("got %v, want none", registrationInvitations)
+		}
+	})
+	t.Run("fail until before starting on", func(t *testing.T) {
+		resource := newTestResource(t)
+		tenant := provisionTenant(t, resource)
+		command := registrationInvitationCommand{Description: "Today-and-Tomorrow", StartingOn: time.Now(), Until: time.Now().Add(-time.Hour)}
+
+		var document problem.Problem
+		decode(t, serve(t, resource, http.MethodPost, fmt.Sprintf("/tenants/%s/invitations", tenant.TenantId), command), http.StatusBadRequest, &document)
+		if len(document.InvalidParams) != 1 || document.InvalidParams[0].Name != "until" {
+			t.Errorf("got %v, want an invalid param of until", document)
+		}
+	})
+}
@@ -0,0 +1,128 @@
+package resource
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/application"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+type userRepresentation struct {
+	TenantId             string `json:"tenantId"`
+	Username             string `json:"username"`
+	FirstName            string `json:"firstName"`
+	LastName             string `json:"lastName"`
+	EmailAddress         string `json:"emailAddress"`
+	EmailAddressVerified bool   `json:"emailAddressVerified"`
+	Enabled              bool   `json:"enabled"`
+}
+
+func newUserRepresentation(aUser *identity.User) userRepresentation {
+	tenantId := aUser.TenantId()
+	person := aUser.Person()
+	return userRepresentation{
+		TenantId:             tenantId.Id(),
+		Username:             aUser.Username(),
+		FirstName:            person.Name().FirstName(),
+		LastName:             person.Name().LastName(),
+		EmailAddress:         person.EmailAddress().Address(),
+		EmailAddressVerified: aUser.IsEmailAddressVerified(),
+		Enabled:              aUser.IsEnabled(),
+	}
+}
+
+type registerUserCommand struct {
+	InvitationId string            `json:"invitationId"`
+	Username     string            `json:"username"`
+	Password     string            `json:"password"`
+	FirstName    string            `json:"firstName"`
+	LastName     string            `json:"lastName"`
+	EmailAddress string            `json:"emailAddress"`
+	Enablement   enablementCommand `json:"enablement"`
+}
+
+type enablementCommand struct {
+	Enabled   bool      `json:"enabled"`
+	StartDate time.Time `json:"startDate"`
+	EndDate   time.Time `json:"endDate"`
+}
+
+type changePasswordCommand struct {
+	CurrentPassword string `json:"currentPassword"`
+	ChangedPassword string `json:"changedPassword"`
+}
+
+type userInRoleRepresentation struct {
+	TenantId string `json:"tenantId"`
+	Username string `json:"username"`
+	RoleName string `json:"roleName"`
+	InRole   bool   `json:"inRole"`
+}
+
+func (resource *Resource) registerUser(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	var command registerUserCommand
+	if err := readJson(aResponseWriter, aRequest, &command); err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+
+	user, err := resource.identityApplicationService.RegisterUser(application.RegisterUserCommand{
+		TenantId:             aRequest.PathValue("tenantId"),
+		InvitationIdentifier: command.InvitationId,
+		Username:             command.Username,
+		Password:             command.Password,
+		FirstName:            command.FirstName,
+		LastName:             command.LastName,
+		EmailAddress:         command.EmailAddress,
+		Enabled:              command.Enablement.Enabled,
+		StartDate:            command.Enablement.StartDate,
+		EndDate:              command.Enablement.EndDate,
+	})
+	if err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	representation := newUserRepresentation(user)
+	aResponseWriter.Header().Set("Location", "/tenants/"+representation.TenantId+"/users/"+url.PathEscape(representation.Username))
+	writeJson(aResponseWriter, http.StatusCreated, representation)
+}
+
+func (resource *Resource) user(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	user, err := resource.identityApplicationService.User(aRequest.PathValue("tenantId"), aRequest.PathValue("username"))
+	if err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	writeJson(aResponseWriter, http.StatusOK, newUserRepresentation(user))
+}
+
+func (resource *Resource) changeUserPassword(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	var command changePasswordCommand
+	if err := readJson(aResponseWriter, aRequest, &command); err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+
+	if err := resource.identityApplicationService.ChangeUserPassword(aRequest.PathValue("tenantId"), aRequest.PathValue("username"), command.CurrentPassword, command.ChangedPassword); err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	aResponseWriter.WriteHeader(http.StatusNoContent)
+}
+
+func (resource *Resource) userInRole(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	user, err := resource.identityApplicationService.User(aRequest.PathValue("tenantId"), aRequest.PathValue("username"))
+	if err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	tenantId := user.TenantId()
+	inRole, err := resource.accessApplicationService.IsUserInRole(tenantId.Id(), user.Username(), aRequest.PathValue("roleName"))
+	if err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	writeJson(aResponseWriter, http.StatusOK, userInRoleRepresentation{TenantId: tenantId.Id(), Username: user.Username(), RoleName: aRequest.PathValue("roleName"), InRole: inRole})
+}
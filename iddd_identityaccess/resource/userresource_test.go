@@ -0,0 +1,110 @@
+package resource
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/problem"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+func TestUserResourceRegisterUser(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		resource := newTestResource(t)
+		tenant := provisionTenant(t, resource)
+		user := registerUser(t, resource, tenant.TenantId, "zoeusername")
+
+		var got userRepresentation
+		decode(t, serve(t, resource, http.MethodGet, fmt.Sprintf("/tenants/%s/users/zoeusername", tenant.TenantId), nil), http.StatusOK, &got)
+		if got != user {
+			t.Errorf("got %v, want %v", got, user)
+		}
+	})
+	t.Run("fail user already exists", func(t *testing.T) {
+		resource := newTestResource(t)
+		tenant := provisionTenant(t, resource)
+		registerUser(t, resource, tenant.TenantId, "zoeusername")
+
+		decode(t, serve(t, resource, http.MethodPost, fmt.Sprintf("/tenants/%s/users", tenant.TenantId), newRegisterUserCommand("Invitation for zoeusername", "zoeusername")), http.StatusConflict, nil)
+	})
+	t.Run("fail validation", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			field string
+			edit  func(command *registerUserCommand)
+		}{
+			{name: "unknown invitation", field: "invitationId", edit: func(command *registerUserCommand) { command.InvitationId = "unknown" }},
+			{name: "short username", field: "username", edit: func(command *registerUserCommand) { command.Username = "zo" }},
+			{name: "weak password", field: "password", edit: func(command *registerUserCommand) { command.Password = "weak" }},
+			{name: "empty first name", field: "firstName", edit: func(command *registerUserCommand) { command.FirstName = "" }},
+			{name: "invalid email address", field: "emailAddress", edit: func(command *registerUserCommand) { command.EmailAddress = "zoe" }},
+			{name: "end date before start date", field: "endDate", edit: func(command *registerUserCommand) {
+				command.Enablement.EndDate = command.Enablement.StartDate.AddDate(0, 0, -1)
+			}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				resource := newTestResource(t)
+				tenant := provisionTenant(t, resource)
+				decode(t, serve(t, resource, http.MethodPost, fmt.Sprintf("/tenants/%s/invitations", tenant.TenantId), registrationInvitationCommand{Description: "Today-and-Tomorrow"}), http.StatusCreated, nil)
+				command := newRegisterUserCommand("Today-and-Tomorrow", "zoeusername")
+				tt.edit(&command)
+
+				var document problem.Problem
+				decode(t, serve(t, resource, http.MethodPost, fmt.Sprintf("/tenants/%s/users", tenant.TenantId), command), http.StatusBadRequest, &document)
+				if len(document.InvalidParams) != 1 || document.InvalidParams[0].Name != tt.field {
+					t.Errorf("got %v, want an invalid param of %s", document, tt.field)
+				}
+			})
+		}
+	})
+	t.Run("fail inactive tenant", func(t *testing.T) {
+		resource := newTestResource(t)
+		tenant := provisionTenant(t, resource)
+		decode(t, serve(t, resource, http.MethodPost, fmt.Sprintf("/tenants/%s/invitations", tenant.TenantId), registrationInvitationCommand{Description: "Today-and-Tomorrow"}), http.StatusCreated, nil)
+		decode(t, serve(t, resource, http.MethodPost, fmt.Sprintf("/tenants/%s/deactivate", tenant.TenantId), nil), http.StatusOK, nil)
+
+		decode(t, serve(t, resource, http.MethodPost, fmt.Sprintf("/tenants/%s/users", tenant.TenantId), newRegisterUserCommand("Today-and-Tomorrow", "zoeusername")), http.StatusConflict, nil)
+	})
+}
+
+func TestUserResourceChangePassword(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		resource := newTestResource(t)
+		tenant := provisionTenant(t, resource)
+		registerUser(t, resource, tenant.TenantId, "zoeusername")
+		path := fmt.Sprintf("/tenants/%s/users/zoeusername/password", tenant.TenantId)
+
+		decode(t, serve(t, resource, http.MethodPut, path, changePasswordCommand{CurrentPassword: password, ChangedPassword: "ASDFG#qwerty!12"}), http.StatusNoContent, nil)
+		decode(t, serve(t, resource, http.MethodPut, path, changePasswordCommand{CurrentPassword: password, ChangedPassword: "ZXCVB#qwerty!34"}), http.StatusBadRequest, nil)
+	})
+	t.Run("fail current password not confirmed", func(t *testing.T) {
+		resource := newTestResource(t)
+		tenant := provisionTenant(t, resource)
+		registerUser(t, resource, tenant.TenantId, "zoeusername")
+
+		var document problem.Problem
+		decode(t, serve(t, resource, http.MethodPut, fmt.Sprintf("/tenants/%s/users/zoeusername/password", tenant.TenantId), changePasswordCommand{CurrentPassword: "wrong", ChangedPassword: "ASDFG#qwerty!12"}), http.StatusBadRequest, &document)
+		if len(document.InvalidParams) != 1 || document.InvalidParams[0].Name != "currentPassword" {
+			t.Errorf("got %v, want an invalid param of currentPassword", document)
+		}
+	})
+	t.Run("fail weak password", func(t *testing.T) {
+		resource := newTestResource(t)
+		tenant := provisionTenant(t, resource)
+		registerUser(t, resource, tenant.TenantId, "zoeusername")
+
+		var document problem.Problem
+		decode(t, serve(t, resource, http.MethodPut, fmt.Sprintf("/tenants/%s/users/zoeusername/password", tenant.TenantId), changePasswordCommand{CurrentPassword: password, ChangedPassword: "zoe123"}), http.StatusBadRequest, &document)
+		if len(document.InvalidParams) != 1 || document.InvalidParams[0].Name != "password" || document.InvalidParams[0].Code != string(identity.CODE_PASSWORD_WEAK) {
+			t.Errorf("got %v, want an invalid param of password with code %s", document, identity.CODE_PASSWORD_WEAK)
+		}
+	})
+	t.Run("fail unknown user", func(t *testing.T) {
+		resource := newTestResource(t)
+		tenant := provisionTenant(t, resource)
+
+		decode(t, serve(t, resource, http.MethodPut, fmt.Sprintf("/tenants/%s/users/unknown/password", tenant.TenantId), changePasswordCommand{CurrentPassword: password, ChangedPassword: "ASDFG#qwerty!12"}), http.StatusNotFound, nil)
+	})
+}
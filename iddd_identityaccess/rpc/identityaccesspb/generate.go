@@ -0,0 +1,3 @@
+package identityaccesspb
+
+//go:generate buf generate --template buf.gen.yaml
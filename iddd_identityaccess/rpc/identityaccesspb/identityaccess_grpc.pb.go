@@ -0,0 +1,311 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: identityaccess.proto
+
+package identityaccesspb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	IdentityAccessService_ProvisionTenant_FullMethodName             = "/iddd.identityaccess.v1.IdentityAccessService/ProvisionTenant"
+	IdentityAccessService_OfferRegistrationInvitation_FullMethodName = "/iddd.identityaccess.v1.IdentityAccessService/OfferRegistrationInvitation"
+	IdentityAccessService_RegisterUser_FullMethodName                = "/iddd.identityaccess.v1.IdentityAccessService/RegisterUser"
+	IdentityAccessService_Authenticate_FullMethodName                = "/iddd.identityaccess.v1.IdentityAccessService/Authenticate"
+	IdentityAccessService_IsUserInRole_FullMethodName                = "/iddd.identityaccess.v1.IdentityAccessService/IsUserInRole"
+	IdentityAccessService_IsMember_FullMethodName                    = "/iddd.identityaccess.v1.IdentityAccessService/IsMember"
+)
+
+// IdentityAccessServiceClient is the client API for IdentityAccessService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type IdentityAccessServiceClient interface {
+	ProvisionTenant(ctx context.Context, in *ProvisionTenantRequest, opts ...grpc.CallOption) (*Tenant, error)
+	OfferRegistrationInvitation(ctx context.Context, in *OfferRegistrationInvitationRequest, opts ...grpc.CallOption) (*RegistrationInvitation, error)
+	RegisterUser(ctx context.Context, in *RegisterUserRequest, opts ...grpc.CallOption) (*User, error)
+	Authenticate(ctx context.Context, in *AuthenticateRequest, opts ...grpc.CallOption) (*UserDescriptor, error)
+	IsUserInRole(ctx context.Context, in *IsUserInRoleRequest, opts ...grpc.CallOption) (*IsUserInRoleResponse, error)
+	IsMember(ctx context.Context, in *IsMemberRequest, opts ...grpc.CallOption) (*IsMemberResponse, error)
+}
+
+type identityAccessServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewIdentityAccessServiceClient(cc grpc.ClientConnInterface) IdentityAccessServiceClient {
+	return &identityAccessServiceClient{cc}
+}
+
+func (c *identityAccessServiceClient) ProvisionTenant(ctx context.Context, in *ProvisionTenantRequest, opts ...grpc.CallOption) (*Tenant, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tenant)
+	err := c.cc.Invoke(ctx, IdentityAccessService_ProvisionTenant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *identityAccessServiceClient) OfferRegistrationInvitation(ctx context.Context, in *OfferRegistrationInvitationRequest, opts ...grpc.CallOption) (*RegistrationInvitation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegistrationInvitation)
+	err := c.cc.Invoke(ctx, IdentityAccessService_OfferRegistrationInvitation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *identityAccessServiceClient) RegisterUser(ctx context.Context, in *RegisterUserRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, IdentityAccessService_RegisterUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *identityAccessServiceClient) Authenticate(ctx context.Context, in *AuthenticateRequest, opts ...grpc.CallOption) (*UserDescriptor, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserDescriptor)
+	err := c.cc.Invoke(ctx, IdentityAccessService_Authenticate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *identityAccessServiceClient) IsUserInRole(ctx context.Context, in *IsUserInRoleRequest, opts ...grpc.CallOption) (*IsUserInRoleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IsUserInRoleResponse)
+	err := c.cc.Invoke(ctx, IdentityAccessService_IsUserInRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *identityAccessServiceClient) IsMember(ctx context.Context, in *IsMemberRequest, opts ...grpc.CallOption) (*IsMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IsMemberResponse)
+	err := c.cc.Invoke(ctx, IdentityAccessService_IsMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IdentityAccessServiceServer is the server API for IdentityAccessService service.
+// All implementations must embed UnimplementedIdentityAccessServiceServer
+// for forward compatibility.
+type IdentityAccessServiceServer interface {
+	ProvisionTenant(context.Context, *ProvisionTenantRequest) (*Tenant, error)
+	OfferRegistrationInvitation(context.Context, *OfferRegistrationInvitationRequest) (*RegistrationInvitation, error)
+	RegisterUser(context.Context, *RegisterUserRequest) (*User, error)
+	Authenticate(context.Context, *AuthenticateRequest) (*UserDescriptor, error)
+	IsUserInRole(context.Context, *IsUserInRoleRequest) (*IsUserInRoleResponse, error)
+	IsMember(context.Context, *IsMemberRequest) (*IsMemberResponse, error)
+	mustEmbedUnimplementedIdentityAccessServiceServer()
+}
+
+// UnimplementedIdentityAccessServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedIdentityAccessServiceServer struct{}
+
+func (UnimplementedIdentityAccessServiceServer) ProvisionTenant(context.Context, *ProvisionTenantRequest) (*Tenant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProvisionTenant not implemented")
+}
+func (UnimplementedIdentityAccessServiceServer) OfferRegistrationInvitation(context.Context, *OfferRegistrationInvitationRequest) (*RegistrationInvitation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OfferRegistrationInvitation not implemented")
+}
+func (UnimplementedIdentityAccessServiceServer) RegisterUser(context.Context, *RegisterUserRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterUser not implemented")
+}
+func (UnimplementedIdentityAccessServiceServer) Authenticate(context.Context, *AuthenticateRequest) (*UserDescriptor, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Authenticate not implemented")
+}
+func (UnimplementedIdentityAccessServiceServer) IsUserInRole(context.Context, *IsUserInRoleRequest) (*IsUserInRoleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsUserInRole not implemented")
+}
+func (UnimplementedIdentityAccessServiceServer) IsMember(context.Context, *IsMemberRequest) (*IsMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsMember not implemented")
+}
+func (UnimplementedIdentityAccessServiceServer) mustEmbedUnimplementedIdentityAccessServiceServer() {}
+func (UnimplementedIdentityAccessServiceServer) testEmbeddedByValue()                               {}
+
+// UnsafeIdentityAccessServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to IdentityAccessServiceServer will
+// result in compilation errors.
+type UnsafeIdentityAccessServiceServer interface {
+	mustEmbedUnimplementedIdentityAccessServiceServer()
+}
+
+func RegisterIdentityAccessServiceServer(s grpc.ServiceRegistrar, srv IdentityAccessServiceServer) {
+	// If the following call pancis, it indicates UnimplementedIdentityAccessServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&IdentityAccessService_ServiceDesc, srv)
+}
+
+func _IdentityAccessService_ProvisionTenant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProvisionTenantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IdentityAccessServiceServer).ProvisionTenant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IdentityAccessService_ProvisionTenant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IdentityAccessServiceServer).ProvisionTenant(ctx, req.(*ProvisionTenantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IdentityAccessService_OfferRegistrationInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OfferRegistrationInvitationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IdentityAccessServiceServer).OfferRegistrationInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IdentityAccessService_OfferRegistrationInvitation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IdentityAccessServiceServer).OfferRegistrationInvitation(ctx, req.(*OfferRegistrationInvitationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IdentityAccessService_RegisterUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IdentityAccessServiceServer).RegisterUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IdentityAccessService_RegisterUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IdentityAccessServiceServer).RegisterUser(ctx, req.(*RegisterUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IdentityAccessService_Authenticate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthenticateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IdentityAccessServiceServer).Authenticate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IdentityAccessService_Authenticate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IdentityAccessServiceServer).Authenticate(ctx, req.(*AuthenticateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IdentityAccessService_IsUserInRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsUserInRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IdentityAccessServiceServer).IsUserInRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IdentityAccessService_IsUserInRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IdentityAccessServiceServer).IsUserInRole(ctx, req.(*IsUserInRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IdentityAccessService_IsMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IdentityAccessServiceServer).IsMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IdentityAccessService_IsMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IdentityAccessServiceServer).IsMember(ctx, req.(*IsMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// IdentityAccessService_ServiceDesc is the grpc.ServiceDesc for IdentityAccessService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var IdentityAccessService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "iddd.identityaccess.v1.IdentityAccessService",
+	HandlerType: (*IdentityAccessServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ProvisionTenant",
+			Handler:    _IdentityAccessService_ProvisionTenant_Handler,
+		},
+		{
+			MethodName: "OfferRegistrationInvitation",
+			Handler:    _IdentityAccessService_OfferRegistrationInvitation_Handler,
+		},
+		{
+			MethodName: "RegisterUser",
+			Handler:    _IdentityAccessService_RegisterUser_Handler,
+		},
+		{
+			MethodName: "Authenticate",
+			Handler:    _IdentityAccessService_Authenticate_Handler,
+		},
+		{
+			MethodName: "IsUserInRole",
+			Handler:    _IdentityAccessService_IsUserInRole_Handler,
+		},
+		{
+			MethodName: "IsMember",
+			Handler:    _IdentityAccessService_IsMember_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "identityaccess.proto",
+}
@@ -0,0 +1,122 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/application"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/rpc/identityaccesspb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type Server struct {
+	identityaccesspb.UnimplementedIdentityAccessServiceServer
+	identityApplicationService *application.IdentityApplicationService
+	accessApplicationService   *application.AccessApplicationService
+}
+
+func NewServer(anIdentityApplicationService *application.IdentityApplicationService, anAccessApplicationService *application.AccessApplicationService) *Server {
+	return &Server{identityApplicationService: anIdentityApplicationService, accessApplicationService: anAccessApplicationService}
+}
+
+func (server *Server) ProvisionTenant(aContext context.Context, aRequest *identityaccesspb.ProvisionTenantRequest) (*identityaccesspb.Tenant, error) {
+	tenant, err := server.identityApplicationService.ProvisionTenant(aRequest.GetName())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	tenantId := tenant.TenantId()
+	return &identityaccesspb.Tenant{TenantId: tenantId.Id(), Name: tenant.Name(), Active: tenant.IsActive()}, nil
+}
+
+func (server *Server) OfferRegistrationInvitation(aContext context.Context, aRequest *identityaccesspb.OfferRegistrationInvitationRequest) (*identityaccesspb.RegistrationInvitation, error) {
+	registrationInvitation, err := server.identityApplicationService.OfferRegistrationInvitation(aRequest.GetTenantId(), aRequest.GetDescription(), timeOf(aRequest.GetStartingOn()), timeOf(aRequest.GetUntil()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &identityaccesspb.RegistrationInvitation{
+		InvitationId: registrationInvitation.InvitationId(),
+		Description:  registrationInvitation.Description(),
+		StartingOn:   timestampOf(registrationInvitation.StartingOn()),
+		Until:        timestampOf(registrationInvitation.Until()),
+	}, nil
+}
+
+func (server *Server) RegisterUser(aContext context.Context, aRequest *identityaccesspb.RegisterUserRequest) (*identityaccesspb.User, error) {
+	user, err := server.identityApplicationService.RegisterUser(application.RegisterUserCommand{
+		TenantId:             aRequest.GetTenantId(),
+		InvitationIdentifier: aRequest.GetInvitationId(),
+		Username:             aRequest.GetUsername(),
+		Password:             aRequest.GetPassword(),
+		FirstName:            aRequest.GetFirstName(),
+		LastName:             aRequest.GetLastName(),
+		EmailAddress:         aRequest.GetEmailAddress(),
+		Enabled:              aRequest.GetEnablement().GetEnabled(),
+		StartDate:            timeOf(aRequest.GetEnablement().GetStartDate()),
+		EndDate:              timeOf(aRequest.GetEnablement().GetEndDate()),
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return newUser(user), nil
+}
+
+func (server *Server) Authenticate(aContext context.Context, aRequest *identityaccesspb.AuthenticateRequest) (*identityaccesspb.UserDescriptor, error) {
+	var userDescriptor *identity.UserDescriptor
+	var err error
+	if aRequest.GetSecondFactorCode() == "" {
+		userDescriptor, err = server.accessApplicationService.Authenticate(aRequest.GetTenantId(), aRequest.GetUsername(), aRequest.GetPassword())
+	} else {
+		userDescriptor, err = server.accessApplicationService.AuthenticateWithSecondFactor(aRequest.GetTenantId(), aRequest.GetUsername(), aRequest.GetPassword(), aRequest.GetSecondFactorCode())
+	}
+	if err != nil {
+		return nil, statusError(err)
+	}
+	tenantId := userDescriptor.TenantId()
+	return &identityaccesspb.UserDescriptor{TenantId: tenantId.Id(), Username: userDescriptor.Username(), EmailAddress: userDescriptor.EmailAddress()}, nil
+}
+
+func (server *Server) IsUserInRole(aContext context.Context, aRequest *identityaccesspb.IsUserInRoleRequest) (*identityaccesspb.IsUserInRoleResponse, error) {
+	inRole, err := server.accessApplicationService.IsUserInRole(aRequest.GetTenantId(), aRequest.GetUsername(), aRequest.GetRoleName())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &identityaccesspb.IsUserInRoleResponse{InRole: inRole}, nil
+}
+
+func (server *Server) IsMember(aContext context.Context, aRequest *identityaccesspb.IsMemberRequest) (*identityaccesspb.IsMemberResponse, error) {
+	member, err := server.identityApplicationService.IsGroupMember(aRequest.GetTenantId(), aRequest.GetGroupName(), aRequest.GetUsername())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &identityaccesspb.IsMemberResponse{Member: member}, nil
+}
+
+func newUser(aUser *identity.User) *identityaccesspb.User {
+	tenantId := aUser.TenantId()
+	person := aUser.Person()
+	return &identityaccesspb.User{
+		TenantId:             tenantId.Id(),
+		Username:             aUser.Username(),
+		FirstName:            person.Name().FirstName(),
+		LastName:             person.Name().LastName(),
+		EmailAddress:         person.EmailAddress().Address(),
+		EmailAddressVerified: aUser.IsEmailAddressVerified(),
+		Enabled:              aUser.IsEnabled(),
+	}
+}
+
+// timeOf maps an absent timestamp to the zero time rather than the Unix epoch that AsTime would return.
+func timeOf(aTimestamp *timestamppb.Timestamp) time.Time {
+	if aTimestamp == nil {
+		return time.Time{}
+	}
+	return aTimestamp.AsTime()
+}
+
+func timestampOf(aTime time.Time) *timestamppb.Timestamp {
+	if aTime.IsZero() {
+		return nil
+	}
+	return timestamppb.New(aTime)
+}
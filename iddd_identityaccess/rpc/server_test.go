@@ -0,0 +1,214 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/application"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/access"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/infrastructure/persistence"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/rpc/identityaccesspb"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const password = "qwerty!ASDFG#"
+
+type discardNotifier struct{}
+
+func (discardNotifier) Notify(aNotification *application.Notification) error {
+	return nil
+}
+
+type fixture struct {
+	identityApplicationService *application.IdentityApplicationService
+	accessApplicationService   *application.AccessApplicationService
+	client                     identityaccesspb.IdentityAccessServiceClient
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	tenantRepository := persistence.NewInMemoryTenantRepository()
+	userRepository := persistence.NewInMemoryUserRepository()
+	groupRepository := persistence.NewInMemoryGroupRepository()
+	roleRepository := persistence.NewInMemoryRoleRepository()
+
+	lockoutPolicy, err := identity.NewLockoutPolicy(3, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	passwordResetService := identity.NewPasswordResetService(persistence.NewInMemoryPasswordResetTokenRepository(), userRepository, time.Hour)
+	emailVerificationService := identity.NewEmailVerificationService(persistence.NewInMemoryEmailVerificationTokenRepository(), userRepository, time.Hour, time.Minute)
+	roleAssignmentService := access.NewRoleAssignmentService(roleRepository, identity.NewGroupMemberService(userRepository, groupRepository))
+	identityApplicationService := application.NewIdentityApplicationService(tenantRepository, userRepository, groupRepository, roleAssignmentService, *identity.DefaultNameLengthPolicy(), passwordResetService, emailVerificationService, discardNotifier{})
+
+	authenticationService := identity.NewAuthenticationService(tenantRepository, userRepository, *lockoutPolicy)
+	authorizationService := access.NewAuthorizationService(userRepository, groupRepository, roleRepository)
+	refreshTokenService := identity.NewRefreshTokenService(tenantRepository, userRepository, persistence.NewInMemoryRefreshTokenRepository(), time.Hour)
+	accessApplicationService := application.NewAccessApplicationService(authenticationService, authorizationService, roleAssignmentService, refreshTokenService, tenantRepository, userRepository, groupRepository, roleRepository)
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	identityaccesspb.RegisterIdentityAccessServiceServer(server, NewServer(identityApplicationService, accessApplicationService))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	clientConn, err := grpc.NewClient(
+		"passthrough:///bufconn",
+		grpc.WithContextDialer(func(aContext context.Context, anAddress string) (net.Conn, error) {
+			return listener.DialContext(aContext)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { clientConn.Close() })
+
+	return &fixture{identityApplicationService: identityApplicationService, accessApplicationService: accessApplicationService, client: identityaccesspb.NewIdentityAccessServiceClient(clientConn)}
+}
+
+func (fixture *fixture) provisionTenant(t *testing.T) *identityaccesspb.Tenant {
+	t.Helper()
+
+	tenant, err := fixture.client.ProvisionTenant(context.Background(), &identityaccesspb.ProvisionTenantRequest{Name: "TenantName"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tenant
+}
+
+func (fixture *fixture) registerUser(t *testing.T, aTenantId string, aUsername string) *identityaccesspb.User {
+	t.Helper()
+
+	registrationInvitation, err := fixture.client.OfferRegistrationInvitation(context.Background(), &identityaccesspb.OfferRegistrationInvitationRequest{TenantId: aTenantId, Description: "Invitation for " + aUsername})
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, err := fixture.client.RegisterUser(context.Background(), newRegisterUserRequest(aTenantId, registrationInvitation.GetInvitationId(), aUsername))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return user
+}
+
+func newRegisterUserRequest(aTenantId string, anInvitationId string, aUsername string) *identityaccesspb.RegisterUserRequest {
+	return &identityaccesspb.RegisterUserRequest{
+		TenantId:     aTenantId,
+		InvitationId: anInvitationId,
+		Username:     aUsername,
+		Password:     password,
+		FirstName:    "Zoe",
+		LastName:     "Doe",
+		EmailAddress: "zoe@saasovation.com",
+		Enablement:   &identityaccesspb.Enablement{Enabled: true, StartDate: timestamppb.New(time.Now().AddDate(-1, 0, 0)), EndDate: timestamppb.New(time.Now().AddDate(1, 0, 0))},
+	}
+}
+
+func TestServerRegisterUserAndAuthenticate(t *testing.T) {
+	fixture := newFixture(t)
+	tenant := fixture.provisionTenant(t)
+	if !tenant.GetActive() {
+		t.Errorf("tenant %v must be active", tenant)
+	}
+
+	user := fixture.registerUser(t, tenant.GetTenantId(), "zoeusername")
+	want := &identityaccesspb.User{TenantId: tenant.GetTenantId(), Username: "zoeusername", FirstName: "Zoe", LastName: "Doe", EmailAddress: "zoe@saasovation.com", Enabled: true}
+	if diff := cmp.Diff(want, user, protocmp.Transform()); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		userDescriptor, err := fixture.client.Authenticate(context.Background(), &identityaccesspb.AuthenticateRequest{TenantId: tenant.GetTenantId(), Username: "zoeusername", Password: password})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &identityaccesspb.UserDescriptor{TenantId: tenant.GetTenantId(), Username: "zoeusername", EmailAddress: "zoe@saasovation.com"}
+		if diff := cmp.Diff(want, userDescriptor, protocmp.Transform()); diff != "" {
+			t.Errorf("mismatch (-want, +got):\n%s", diff)
+		}
+	})
+	t.Run("fail", func(t *testing.T) {
+		_, err := fixture.client.Authenticate(context.Background(), &identityaccesspb.AuthenticateRequest{TenantId: tenant.GetTenantId(), Username: "zoeusername", Password: "wrong"})
+		if status.Code(err) != codes.Unauthenticated {
+			t.Errorf("got %v, want %v", err, codes.Unauthenticated)
+		}
+	})
+}
+
+func TestServerRegisterUserInvalidArgument(t *testing.T) {
+	fixture := newFixture(t)
+	tenant := fixture.provisionTenant(t)
+	registrationInvitation, err := fixture.client.OfferRegistrationInvitation(context.Background(), &identityaccesspb.OfferRegistrationInvitationRequest{TenantId: tenant.GetTenantId(), Description: "Open invitation"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	request := newRegisterUserRequest(tenant.GetTenantId(), registrationInvitation.GetInvitationId(), "zoeusername")
+	request.FirstName = ""
+
+	_, err = fixture.client.RegisterUser(context.Background(), request)
+
+	errorStatus := status.Convert(err)
+	if errorStatus.Code() != codes.InvalidArgument {
+		t.Fatalf("got %v, want %v", err, codes.InvalidArgument)
+	}
+	var badRequest *errdetails.BadRequest
+	for _, detail := range errorStatus.Details() {
+		if b, ok := detail.(*errdetails.BadRequest); ok {
+			badRequest = b
+		}
+	}
+	if len(badRequest.GetFieldViolations()) != 1 || badRequest.GetFieldViolations()[0].GetField() != "firstName" {
+		t.Errorf("got %v, want a field violation of firstName", errorStatus.Details())
+	}
+}
+
+func TestServerIsUserInRoleAndIsMember(t *testing.T) {
+	fixture := newFixture(t)
+	tenant := fixture.provisionTenant(t)
+	fixture.registerUser(t, tenant.GetTenantId(), "zoeusername")
+	if _, err := fixture.accessApplicationService.ProvisionRole(tenant.GetTenantId(), "Manager", "A manager role.", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := fixture.accessApplicationService.AssignUserToRole(tenant.GetTenantId(), "Manager", "zoeusername"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fixture.identityApplicationService.ProvisionGroup(tenant.GetTenantId(), "Staff", "All staff."); err != nil {
+		t.Fatal(err)
+	}
+	if err := fixture.identityApplicationService.AddUserToGroup(tenant.GetTenantId(), "Staff", "zoeusername"); err != nil {
+		t.Fatal(err)
+	}
+
+	isUserInRoleResponse, err := fixture.client.IsUserInRole(context.Background(), &identityaccesspb.IsUserInRoleRequest{TenantId: tenant.GetTenantId(), Username: "zoeusername", RoleName: "Manager"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isUserInRoleResponse.GetInRole() {
+		t.Errorf("zoeusername must be in role Manager")
+	}
+
+	isMemberResponse, err := fixture.client.IsMember(context.Background(), &identityaccesspb.IsMemberRequest{TenantId: tenant.GetTenantId(), GroupName: "Staff", Username: "zoeusername"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isMemberResponse.GetMember() {
+		t.Errorf("zoeusername must be a member of Staff")
+	}
+
+	_, err = fixture.client.IsMember(context.Background(), &identityaccesspb.IsMemberRequest{TenantId: tenant.GetTenantId(), GroupName: "Unknown", Username: "zoeusername"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("got %v, want %v", err, codes.NotFound)
+	}
+}
@@ -0,0 +1,143 @@
+package rpc
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/application"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+const ERROR_DOMAIN = "iddd.identityaccess"
+
+// mapping gives the status code of an error made with ierrors.New, whose own code is reported as the reason.
+type mapping struct {
+	target error
+	code   codes.Code
+}
+
+var mappings = []mapping{
+	{target: application.ErrTenantNotFound, code: codes.NotFound},
+	{target: application.ErrUserNotFound, code: codes.NotFound},
+	{target: application.ErrGroupNotFound, code: codes.NotFound},
+	{target: application.ErrRoleNotFound, code: codes.NotFound},
+	{target: application.ErrUserAlreadyExists, code: codes.AlreadyExists},
+	{target: application.ErrUsernameConfusable, code: codes.AlreadyExists},
+	{target: application.ErrGroupAlreadyExists, code: codes.AlreadyExists},
+	{target: application.ErrRoleAlreadyExists, code: codes.AlreadyExists},
+	{target: identity.ErrAuthenticationFailed, code: codes.Unauthenticated},
+	{target: identity.ErrSecondFactorRequired, code: codes.Unauthenticated},
+	{target: identity.ErrSecondFactorEnrollmentRequired, code: codes.FailedPrecondition},
+	{target: identity.ErrEmailAddressNotVerified, code: codes.FailedPrecondition},
+}
+
+// statusError reports only the innermost message, since the operations added by ierrors.Wrap may carry arguments such as passwords.
+func statusError(anError error) error {
+	for _, mapping := range mappings {
+		if errors.Is(anError, mapping.target) {
+			return newStatusError(mapping.code, rootMessage(anError), &errdetails.ErrorInfo{Reason: string(ierrors.CodeOf(mapping.target)), Domain: ERROR_DOMAIN})
+		}
+	}
+
+	var exclusiveConstraintError *ierrors.ExclusiveConstraintError
+	if errors.As(anError, &exclusiveConstraintError) {
+		arguments := exclusiveConstraintError.GetArguments()
+		return newStatusError(codes.FailedPrecondition, exclusiveConstraintError.Error(), &errdetails.ErrorInfo{
+			Reason:   string(exclusiveConstraintError.Code()),
+			Domain:   ERROR_DOMAIN,
+			Metadata: map[string]string{"held": arguments.Held, "requested": arguments.Requested},
+		})
+	}
+
+	// A single failure reads the same whether or not it was collected by a ValidationNotification.
+	var validationError *ierrors.ValidationError
+	if errors.As(anError, &validationError) && len(validationError.FieldErrors()) > 1 {
+		badRequest := &errdetails.BadRequest{}
+		descriptions := []string{}
+		for _, fieldError := range validationError.FieldErrors() {
+			_, description := invalidArgument(fieldError)
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{Field: fieldError.Field(), Description: description})
+			descriptions = append(descriptions, description)
+		}
+		return newStatusError(codes.InvalidArgument, strings.Join(descriptions, " "), &errdetails.ErrorInfo{Reason: string(ierrors.CODE_VALIDATION_FAILED), Domain: ERROR_DOMAIN}, badRequest)
+	}
+
+	var assertionError *ierrors.AssertionError
+	if errors.As(anError, &assertionError) && assertionError.Kind().IsState() {
+		return newStatusError(codes.FailedPrecondition, assertionError.Error(), &errdetails.ErrorInfo{Reason: string(assertionError.Code()), Domain: ERROR_DOMAIN})
+	}
+
+	if errorInfo, description := invalidArgument(anError); errorInfo != nil {
+		fieldViolation := &errdetails.BadRequest_FieldViolation{Description: description}
+		var fieldError *ierrors.FieldError
+		if errors.As(anError, &assertionError) {
+			fieldViolation.Field = assertionError.Field()
+		}
+		if fieldViolation.Field == "" && errors.As(anError, &fieldError) {
+			fieldViolation.Field = fieldError.Field()
+		}
+		return newStatusError(codes.InvalidArgument, description, errorInfo, &errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{fieldViolation}})
+	}
+
+	log.Printf("rpc.statusError(): %v", anError)
+	return status.Error(codes.Internal, "An unexpected error occurred.")
+}
+
+func invalidArgument(anError error) (*errdetails.ErrorInfo, string) {
+	var argumentNotEmptyError *ierrors.ArgumentNotEmptyError
+	var argumentLengthError *ierrors.ArgumentLengthError
+	var argumentTrueError *ierrors.ArgumentTrueError
+	var argumentFalseError *ierrors.ArgumentFalseError
+	var assertionError *ierrors.AssertionError
+
+	switch {
+	case errors.As(anError, &assertionError):
+		errorInfo := &errdetails.ErrorInfo{Reason: string(assertionError.Code()), Domain: ERROR_DOMAIN}
+		if constraint := assertionError.Constraint(); constraint != "" {
+			errorInfo.Metadata = map[string]string{"constraint": constraint}
+		}
+		return errorInfo, assertionError.Error()
+	case errors.As(anError, &argumentNotEmptyError):
+		return &errdetails.ErrorInfo{Reason: string(argumentNotEmptyError.Code()), Domain: ERROR_DOMAIN}, argumentNotEmptyError.GetArguments().Message
+	case errors.As(anError, &argumentLengthError):
+		arguments := argumentLengthError.GetArguments()
+		return &errdetails.ErrorInfo{
+			Reason:   string(argumentLengthError.Code()),
+			Domain:   ERROR_DOMAIN,
+			Metadata: map[string]string{"minimum": strconv.Itoa(arguments.Minimum), "maximum": strconv.Itoa(arguments.Maximum)},
+		}, arguments.Message
+	case errors.As(anError, &argumentTrueError):
+		return &errdetails.ErrorInfo{Reason: string(argumentTrueError.Code()), Domain: ERROR_DOMAIN}, argumentTrueError.GetArguments().Message
+	case errors.As(anError, &argumentFalseError):
+		return &errdetails.ErrorInfo{Reason: string(argumentFalseError.Code()), Domain: ERROR_DOMAIN}, argumentFalseError.Error()
+	}
+
+	var fieldError *ierrors.FieldError
+	if errors.As(anError, &fieldError) {
+		return &errdetails.ErrorInfo{Reason: "INVALID_ARGUMENT", Domain: ERROR_DOMAIN}, rootMessage(fieldError)
+	}
+	return nil, ""
+}
+
+func newStatusError(aCode codes.Code, aMessage string, aDetails ...protoadapt.MessageV1) error {
+	statusWithDetails, err := status.New(aCode, aMessage).WithDetails(aDetails...)
+	if err != nil {
+		log.Printf("rpc.newStatusError(): %v", err)
+		return status.Error(aCode, aMessage)
+	}
+	return statusWithDetails.Err()
+}
+
+func rootMessage(anError error) string {
+	for errors.Unwrap(anError) != nil {
+		anError = errors.Unwrap(anError)
+	}
+	return anError.Error()
+}
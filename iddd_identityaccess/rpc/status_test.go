@@ -0,0 +1,111 @@
+package rpc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/ierrors"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/application"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func wrapped(anError error) error {
+	err := anError
+	ierrors.Wrap(&err, "identityapplicationservice.RegisterUser(%s)", "secret")
+	return err
+}
+
+func newValidationError() error {
+	validationNotification := ierrors.NewValidationNotification()
+	validationNotification.Add("firstName", ierrors.NewArgumentNotEmptyError("", "First name is required.").GetError())
+	validationNotification.Add("lastName", ierrors.NewArgumentNotEmptyError("", "Last name is required.").GetError())
+	return validationNotification.Err()
+}
+
+func TestStatusError(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantCode    codes.Code
+		wantMessage string
+		wantDetails []protoadapt.MessageV1
+	}{
+		{
+			name:        "not found",
+			err:         wrapped(application.ErrTenantNotFound),
+			wantCode:    codes.NotFound,
+			wantMessage: "The tenant does not exist.",
+			wantDetails: []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: "TENANT_NOT_FOUND", Domain: ERROR_DOMAIN}},
+		},
+		{
+			name:        "argument length error with field",
+			err:         wrapped(ierrors.NewFieldError("username", ierrors.NewArgumentLengthError("ab", 3, 250, "The username must be 3 to 250 characters.").GetError())),
+			wantCode:    codes.InvalidArgument,
+			wantMessage: "The username must be 3 to 250 characters.",
+			wantDetails: []protoadapt.MessageV1{
+				&errdetails.ErrorInfo{Reason: "ARGUMENT_LENGTH", Domain: ERROR_DOMAIN, Metadata: map[string]string{"minimum": "3", "maximum": "250"}},
+				&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "username", Description: "The username must be 3 to 250 characters."}}},
+			},
+		},
+		{
+			name:        "assertion error",
+			err:         wrapped(ierrors.NewAssertionConcern("username").ArgumentLength("ab", 3, 250, "The username must be 3 to 250 characters.")),
+			wantCode:    codes.InvalidArgument,
+			wantMessage: "The username must be 3 to 250 characters.",
+			wantDetails: []protoadapt.MessageV1{
+				&errdetails.ErrorInfo{Reason: "ARGUMENT_LENGTH", Domain: ERROR_DOMAIN, Metadata: map[string]string{"constraint": "3..250"}},
+				&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "username", Description: "The username must be 3 to 250 characters."}}},
+			},
+		},
+		{
+			name:        "state assertion error",
+			err:         wrapped(ierrors.NewAssertionConcern("").StateTrue(false, "Tenant is not active.")),
+			wantCode:    codes.FailedPrecondition,
+			wantMessage: "Tenant is not active.",
+			wantDetails: []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: "STATE_TRUE", Domain: ERROR_DOMAIN}},
+		},
+		{
+			name:        "validation error",
+			err:         wrapped(newValidationError()),
+			wantCode:    codes.InvalidArgument,
+			wantMessage: "First name is required. Last name is required.",
+			wantDetails: []protoadapt.MessageV1{
+				&errdetails.ErrorInfo{Reason: "VALIDATION_FAILED", Domain: ERROR_DOMAIN},
+				&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "firstName", Description: "First name is required."}, {Field: "lastName", Description: "Last name is required."}}},
+			},
+		},
+		{
+			name:        "exclusive constraint error",
+			err:         wrapped(ierrors.NewExclusiveConstraintError(true, "Requester", "Approver", "The roles are mutually exclusive.").GetError()),
+			wantCode:    codes.FailedPrecondition,
+			wantMessage: "The roles are mutually exclusive.",
+			wantDetails: []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: "EXCLUSIVE_CONSTRAINT", Domain: ERROR_DOMAIN, Metadata: map[string]string{"held": "Requester", "requested": "Approver"}}},
+		},
+		{
+			name:        "unknown error",
+			err:         wrapped(errors.New("dial tcp 10.0.0.1:5432: connection refused")),
+			wantCode:    codes.Internal,
+			wantMessage: "An unexpected error occurred.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errorStatus := status.Convert(statusError(tt.err))
+			if errorStatus.Code() != tt.wantCode || errorStatus.Message() != tt.wantMessage {
+				t.Errorf("got %v %q, want %v %q", errorStatus.Code(), errorStatus.Message(), tt.wantCode, tt.wantMessage)
+			}
+			var gotDetails []protoadapt.MessageV1
+			for _, detail := range errorStatus.Details() {
+				gotDetails = append(gotDetails, detail.(protoadapt.MessageV1))
+			}
+			if diff := cmp.Diff(tt.wantDetails, gotDetails, protocmp.Transform()); diff != "" {
+				t.Errorf("mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
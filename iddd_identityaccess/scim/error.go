@@ -0,0 +1,102 @@
+package scim
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/application"
+)
+
+const (
+	SCIM_TYPE_INVALID_FILTER = "invalidFilter"
+	SCIM_TYPE_INVALID_PATH   = "invalidPath"
+	SCIM_TYPE_INVALID_SYNTAX = "invalidSyntax"
+	SCIM_TYPE_INVALID_VALUE  = "invalidValue"
+	SCIM_TYPE_MUTABILITY     = "mutability"
+	SCIM_TYPE_NO_TARGET      = "noTarget"
+	SCIM_TYPE_UNIQUENESS     = "uniqueness"
+)
+
+// scimError is a failure of the protocol itself rather than of the domain, such as an unparsable filter.
+type scimError struct {
+	status   int
+	scimType string
+	detail   string
+}
+
+func newScimError(aStatus int, aScimType string, aDetail string) *scimError {
+	return &scimError{status: aStatus, scimType: aScimType, detail: aDetail}
+}
+
+func (scimError *scimError) Error() string {
+	return scimError.detail
+}
+
+type errorRepresentation struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+type mapping struct {
+	target   error
+	status   int
+	scimType string
+}
+
+var mappings = []mapping{
+	{target: application.ErrProvisioningTokenInvalid, status: http.StatusUnauthorized},
+	{target: application.ErrTenantNotFound, status: http.StatusNotFound},
+	{target: application.ErrUserNotFound, status: http.StatusNotFound},
+	{target: application.ErrGroupNotFound, status: http.StatusNotFound},
+	{target: application.ErrUserAlreadyExists, status: http.StatusConflict, scimType: SCIM_TYPE_UNIQUENESS},
+	{target: application.ErrUsernameConfusable, status: http.StatusConflict, scimType: SCIM_TYPE_UNIQUENESS},
+	{target: application.ErrGroupAlreadyExists, status: http.StatusConflict, scimType: SCIM_TYPE_UNIQUENESS},
+}
+
+// writeError leaves domain errors to the problem translator, so that SCIM and the REST API agree on their status codes.
+func (resource *Resource) writeError(aResponseWriter http.ResponseWriter, aRequest *http.Request, anError error) {
+	representation := errorRepresentation{Schemas: []string{SCHEMA_ERROR}}
+	status := http.StatusInternalServerError
+
+	var scimError *scimError
+	if errors.As(anError, &scimError) {
+		status, representation.ScimType, representation.Detail = scimError.status, scimError.scimType, scimError.detail
+	} else if mapping, ok := mappingOf(anError); ok {
+		status, representation.ScimType, representation.Detail = mapping.status, mapping.scimType, rootMessage(anError)
+	} else {
+		document := resource.translator.Translate(anError)
+		status, representation.Detail = document.Status, document.Detail
+		if status == http.StatusBadRequest {
+			representation.ScimType = SCIM_TYPE_INVALID_VALUE
+		}
+	}
+
+	if status == http.StatusInternalServerError {
+		log.Printf("scim.writeError(%s): %v", aRequest.URL.Path, anError)
+	}
+	if status == http.StatusUnauthorized {
+		aResponseWriter.Header().Set("WWW-Authenticate", `Bearer realm="scim"`)
+	}
+	representation.Status = strconv.Itoa(status)
+	writeScim(aResponseWriter, status, representation)
+}
+
+func mappingOf(anError error) (mapping, bool) {
+	for _, mapping := range mappings {
+		if errors.Is(anError, mapping.target) {
+			return mapping, true
+		}
+	}
+	return mapping{}, false
+}
+
+func rootMessage(anError error) string {
+	for errors.Unwrap(anError) != nil {
+		anError = errors.Unwrap(anError)
+	}
+	return anError.Error()
+}
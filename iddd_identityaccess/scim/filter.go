@@ -0,0 +1,29 @@
+package scim
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// parseFilter understands the single comparison identity providers send to look a resource up, such as userName eq "bjensen".
+func parseFilter(aFilter string, anAttributes ...string) (attribute string, value string, err error) {
+	invalidFilter := newScimError(http.StatusBadRequest, SCIM_TYPE_INVALID_FILTER, "The filter must compare one of "+strings.Join(anAttributes, ", ")+" with eq.")
+
+	fields := strings.SplitN(strings.TrimSpace(aFilter), " ", 3)
+	if len(fields) != 3 || !strings.EqualFold(fields[1], "eq") {
+		return "", "", invalidFilter
+	}
+	for _, candidate := range anAttributes {
+		if strings.EqualFold(fields[0], candidate) {
+			attribute = candidate
+		}
+	}
+	if attribute == "" {
+		return "", "", invalidFilter
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(fields[2])), &value); err != nil {
+		return "", "", invalidFilter
+	}
+	return attribute, value, nil
+}
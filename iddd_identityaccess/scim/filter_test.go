@@ -0,0 +1,44 @@
+package scim
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		name          string
+		filter        string
+		wantAttribute string
+		wantValue     string
+		wantErr       bool
+	}{
+		{name: "success", filter: `userName eq "bjensen"`, wantAttribute: "userName", wantValue: "bjensen"},
+		{name: "success case insensitive", filter: `USERNAME EQ "bjensen"`, wantAttribute: "userName", wantValue: "bjensen"},
+		{name: "success escaped quote", filter: `userName eq "b\"jensen"`, wantAttribute: "userName", wantValue: `b"jensen`},
+		{name: "success value with spaces", filter: `userName eq "b jensen"`, wantAttribute: "userName", wantValue: "b jensen"},
+		{name: "fail unknown attribute", filter: `displayName eq "bjensen"`, wantErr: true},
+		{name: "fail unsupported operator", filter: `userName co "jensen"`, wantErr: true},
+		{name: "fail unquoted value", filter: `userName eq bjensen`, wantErr: true},
+		{name: "fail logical expression", filter: `userName eq "bjensen" and active eq true`, wantErr: true},
+		{name: "fail missing value", filter: `userName eq`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attribute, value, err := parseFilter(tt.filter, "userName")
+			if tt.wantErr {
+				var scimError *scimError
+				if !errors.As(err, &scimError) || scimError.scimType != SCIM_TYPE_INVALID_FILTER {
+					t.Errorf("got %v, want an %s error", err, SCIM_TYPE_INVALID_FILTER)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if attribute != tt.wantAttribute || value != tt.wantValue {
+				t.Errorf("got %s and %s, want %s and %s", attribute, value, tt.wantAttribute, tt.wantValue)
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package scim
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/application"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+const (
+	RESOURCE_TYPE_GROUP = "Group"
+	GROUP_DESCRIPTION   = "Provisioned through SCIM."
+)
+
+type groupRepresentation struct {
+	Schemas     []string               `json:"schemas"`
+	Id          string                 `json:"id,omitempty"`
+	DisplayName string                 `json:"displayName"`
+	Members     []memberRepresentation `json:"members"`
+	Meta        *metaRepresentation    `json:"meta,omitempty"`
+}
+
+type memberRepresentation struct {
+	Value string `json:"value"`
+	Type  string `json:"type,omitempty"`
+}
+
+func newGroupRepresentation(aRequest *http.Request, aGroup *identity.Group) groupRepresentation {
+	groupMembers := aGroup.GroupMembers()
+	representation := groupRepresentation{
+		Schemas:     []string{SCHEMA_GROUP},
+		Id:          aGroup.Name(),
+		DisplayName: aGroup.Name(),
+		Members:     make([]memberRepresentation, len(groupMembers)),
+		Meta:        &metaRepresentation{ResourceType: RESOURCE_TYPE_GROUP, Version: entityTag(aGroup.ConcurrencyVersion()), Location: location(aRequest, "Groups", aGroup.Name())},
+	}
+	for i, groupMember := range groupMembers {
+		representation.Members[i] = memberRepresentation{Value: groupMember.Name(), Type: groupMember.Type().String()}
+	}
+	return representation
+}
+
+func (groupRepresentation *groupRepresentation) removeMember(aValue string) {
+	members := []memberRepresentation{}
+	for _, member := range groupRepresentation.Members {
+		if member.Value != aValue {
+			members = append(members, member)
+		}
+	}
+	groupRepresentation.Members = members
+}
+
+func (resource *Resource) allGroups(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	var displayName string
+	if filter := aRequest.URL.Query().Get("filter"); filter != "" {
+		_, value, err := parseFilter(filter, "displayName")
+		if err != nil {
+			resource.writeError(aResponseWriter, aRequest, err)
+			return
+		}
+		displayName = value
+	}
+
+	groups, err := resource.identityApplicationService.AllGroups(aRequest.PathValue("tenantId"))
+	if err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	representations := []interface{}{}
+	for _, group := range groups {
+		if !group.IsInternalGroup() && (displayName == "" || strings.EqualFold(group.Name(), displayName)) {
+			representations = append(representations, newGroupRepresentation(aRequest, group))
+		}
+	}
+	writeScim(aResponseWriter, http.StatusOK, newListResponse(aRequest, representations))
+}
+
+func (resource *Resource) createGroup(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	var representation groupRepresentation
+	if err := readScim(aResponseWriter, aRequest, &representation); err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	tenantId := aRequest.PathValue("tenantId")
+	members, err := resource.resolveMembers(tenantId, representation.Members)
+	if err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+
+	group, err := resource.identityApplicationService.ProvisionGroup(tenantId, representation.DisplayName, GROUP_DESCRIPTION)
+	if err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	if err := resource.changeMembers(tenantId, group, members); err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	resource.writeGroup(aResponseWriter, aRequest, http.StatusCreated, group)
+}
+
+func (resource *Resource) group(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	group, err := resource.existingGroup(aRequest)
+	if err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	if !resource.checkPreconditions(aResponseWriter, aRequest, group.ConcurrencyVersion()) {
+		return
+	}
+	resource.writeGroup(aResponseWriter, aRequest, http.StatusOK, group)
+}
+
+func (resource *Resource) replaceGroup(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	var representation groupRepresentation
+	if err := readScim(aResponseWriter, aRequest, &representation); err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	resource.changeGroup(aResponseWriter, aRequest, func(groupRepresentation *groupRepresentation) error {
+		*groupRepresentation = representation
+		return nil
+	})
+}
+
+func (resource *Resource) patchGroup(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	var patchRequest patchRequest
+	if err := readPatchRequest(aResponseWriter, aRequest, &patchRequest); err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	resource.changeGroup(aResponseWriter, aRequest, func(groupRepresentation *groupRepresentation) error {
+		for _, operation := range patchRequest.Operations {
+			if err := operation.applyToGroup(groupRepresentation); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// changeGroup lets aChange edit the current representation and then applies the difference in members through the application service.
+func (resource *Resource) changeGroup(aResponseWriter http.ResponseWriter, aRequest *http.Request, aChange func(*groupRepresentation) error) {
+	tenantId := aRequest.PathValue("tenantId")
+	group, err := resource.existingGroup(aRequest)
+	if err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	if !resource.checkPreconditions(aResponseWriter, aRequest, group.ConcurrencyVersion()) {
+		return
+	}
+
+	representation := newGroupRepresentation(aRequest, group)
+	if err := aChange(&representation); err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	if representation.DisplayName != "" && representation.DisplayName != group.Name() {
+		resource.writeError(aResponseWriter, aRequest, newScimError(http.StatusBadRequest, SCIM_TYPE_MUTABILITY, "The displayName must not change."))
+		return
+	}
+	members, err := resource.resolveMembers(tenantId, representation.Members)
+	if err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	if err := resource.changeMembers(tenantId, group, members); err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	resource.writeGroup(aResponseWriter, aRequest, http.StatusOK, group)
+}
+
+// existingGroup hides the internal groups of roles, which are managed through their roles only.
+func (resource *Resource) existingGroup(aRequest *http.Request) (*identity.Group, error) {
+	group, err := resource.identityApplicationService.Group(aRequest.PathValue("tenantId"), aRequest.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+	if group.IsInternalGroup() {
+		return nil, application.ErrGroupNotFound
+	}
+	return group, nil
+}
+
+// resolveMembers looks up every member so that no change is made for a member that does not exist.
+// A member without a type is a user if one has its name and a group otherwise.
+func (resource *Resource) resolveMembers(aTenantId string, aMembers []memberRepresentation) ([]memberRepresentation, error) {
+	members := make([]memberRepresentation, 0, len(aMembers))
+	for _, member := range aMembers {
+		switch {
+		case strings.EqualFold(member.Type, RESOURCE_TYPE_USER):
+			if _, err := resource.identityApplicationService.User(aTenantId, member.Value); err != nil {
+				return nil, err
+			}
+			member.Type = RESOURCE_TYPE_USER
+		case strings.EqualFold(member.Type, RESOURCE_TYPE_GROUP):
+			if _, err := resource.identityApplicationService.Group(aTenantId, member.Value); err != nil {
+				return nil, err
+			}
+			member.Type = RESOURCE_TYPE_GROUP
+		case member.Type == "":
+			_, err := resource.identityApplicationService.User(aTenantId, member.Value)
+			member.Type = RESOURCE_TYPE_USER
+			if errors.Is(err, application.ErrUserNotFound) {
+				_, err = resource.identityApplicationService.Group(aTenantId, member.Value)
+				member.Type = RESOURCE_TYPE_GROUP
+			}
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, newScimError(http.StatusBadRequest, SCIM_TYPE_INVALID_VALUE, "The member type "+member.Type+" is unknown.")
+		}
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+func (resource *Resource) changeMembers(aTenantId string, aGroup *identity.Group, aMembers []memberRepresentation) error {
+	current := map[memberRepresentation]bool{}
+	groupMembers := aGroup.GroupMembers()
+	for _, groupMember := range groupMembers {
+		current[memberRepresentation{Value: groupMember.Name(), Type: groupMember.Type().String()}] = true
+	}
+
+	wanted := map[memberRepresentation]bool{}
+	for _, member := range aMembers {
+		if wanted[member] {
+			continue
+		}
+		wanted[member] = true
+		if current[member] {
+			continue
+		}
+		var err error
+		if member.Type == RESOURCE_TYPE_GROUP {
+			err = resource.identityApplicationService.AddGroupToGroup(aTenantId, aGroup.Name(), member.Value)
+		} else {
+			err = resource.identityApplicationService.AddUserToGroup(aTenantId, aGroup.Name(), member.Value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, groupMember := range groupMembers {
+		if wanted[memberRepresentation{Value: groupMember.Name(), Type: groupMember.Type().String()}] {
+			continue
+		}
+		var err error
+		if groupMember.IsGroup() {
+			err = resource.identityApplicationService.RemoveGroupFromGroup(aTenantId, aGroup.Name(), groupMember.Name())
+		} else {
+			err = resource.identityApplicationService.RemoveUserFromGroup(aTenantId, aGroup.Name(), groupMember.Name())
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (resource *Resource) writeGroup(aResponseWriter http.ResponseWriter, aRequest *http.Request, aStatus int, aGroup *identity.Group) {
+	group, err := resource.identityApplicationService.Group(aRequest.PathValue("tenantId"), aGroup.Name())
+	if err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	representation := newGroupRepresentation(aRequest, group)
+	aResponseWriter.Header().Set("ETag", representation.Meta.Version)
+	if aStatus == http.StatusCreated {
+		aResponseWriter.Header().Set("Location", representation.Meta.Location)
+	}
+	writeScim(aResponseWriter, aStatus, representation)
+}
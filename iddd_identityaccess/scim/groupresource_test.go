@@ -0,0 +1,132 @@
+package scim
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func (fixture *fixture) createGroup(t *testing.T, aDisplayName string, aMembers ...memberRepresentation) groupRepresentation {
+	t.Helper()
+
+	var group groupRepresentation
+	decode(t, fixture.serve(t, http.MethodPost, "Groups", groupRepresentation{Schemas: []string{SCHEMA_GROUP}, DisplayName: aDisplayName, Members: aMembers}), http.StatusCreated, &group)
+	return group
+}
+
+func TestGroupResource(t *testing.T) {
+	t.Run("create and get", func(t *testing.T) {
+		fixture := newFixture(t)
+		fixture.createUser(t, "zoeusername")
+		fixture.createGroup(t, "Engineers")
+
+		created := fixture.createGroup(t, "Staff", memberRepresentation{Value: "zoeusername"}, memberRepresentation{Value: "Engineers", Type: "group"})
+		want := []memberRepresentation{{Value: "zoeusername", Type: RESOURCE_TYPE_USER}, {Value: "Engineers", Type: RESOURCE_TYPE_GROUP}}
+		if diff := cmp.Diff(want, created.Members); diff != "" {
+			t.Errorf("mismatch (-want, +got):\n%s", diff)
+		}
+
+		var got groupRepresentation
+		decode(t, fixture.serve(t, http.MethodGet, "Groups/Staff", nil), http.StatusOK, &got)
+		if diff := cmp.Diff(created, got); diff != "" {
+			t.Errorf("mismatch (-want, +got):\n%s", diff)
+		}
+		group, err := fixture.identityApplicationService.Group(fixture.tenantId, "Staff")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if group.Description() != GROUP_DESCRIPTION {
+			t.Errorf("got %s, want %s", group.Description(), GROUP_DESCRIPTION)
+		}
+	})
+	t.Run("filter", func(t *testing.T) {
+		fixture := newFixture(t)
+		fixture.createGroup(t, "Staff")
+		fixture.createGroup(t, "Engineers")
+
+		var got listResponseRepresentation
+		decode(t, fixture.serve(t, http.MethodGet, "Groups?filter="+url.QueryEscape(`displayName eq "staff"`), nil), http.StatusOK, &got)
+		if got.TotalResults != 1 || got.Resources[0].(map[string]interface{})["id"] != "Staff" {
+			t.Errorf("got %v, want Staff only", got)
+		}
+	})
+	t.Run("replace", func(t *testing.T) {
+		fixture := newFixture(t)
+		fixture.createUser(t, "zoeusername")
+		fixture.createUser(t, "amyusername")
+		created := fixture.createGroup(t, "Staff", memberRepresentation{Value: "zoeusername"})
+
+		var got groupRepresentation
+		request := groupRepresentation{Schemas: []string{SCHEMA_GROUP}, DisplayName: "Staff", Members: []memberRepresentation{{Value: "amyusername", Type: "User"}}}
+		decode(t, fixture.serve(t, http.MethodPut, "Groups/Staff", request, "If-Match", created.Meta.Version), http.StatusOK, &got)
+		if diff := cmp.Diff([]memberRepresentation{{Value: "amyusername", Type: RESOURCE_TYPE_USER}}, got.Members); diff != "" {
+			t.Errorf("mismatch (-want, +got):\n%s", diff)
+		}
+	})
+	t.Run("patch", func(t *testing.T) {
+		fixture := newFixture(t)
+		fixture.createUser(t, "zoeusername")
+		fixture.createUser(t, "amyusername")
+		fixture.createGroup(t, "Staff", memberRepresentation{Value: "zoeusername"})
+
+		var got groupRepresentation
+		decode(t, fixture.serve(t, http.MethodPatch, "Groups/Staff", patchRequest{
+			Schemas: []string{SCHEMA_PATCH_OP},
+			Operations: []patchOperation{
+				{Op: "add", Path: "members", Value: []byte(`[{"value": "amyusername"}]`)},
+				{Op: "remove", Path: `members[value eq "zoeusername"]`},
+			},
+		}), http.StatusOK, &got)
+		if diff := cmp.Diff([]memberRepresentation{{Value: "amyusername", Type: RESOURCE_TYPE_USER}}, got.Members); diff != "" {
+			t.Errorf("mismatch (-want, +got):\n%s", diff)
+		}
+
+		decode(t, fixture.serve(t, http.MethodPatch, "Groups/Staff", patchRequest{
+			Schemas:    []string{SCHEMA_PATCH_OP},
+			Operations: []patchOperation{{Op: "remove", Path: "members"}},
+		}), http.StatusOK, &got)
+		if len(got.Members) != 0 {
+			t.Errorf("got %v, want no members", got.Members)
+		}
+	})
+	t.Run("fail", func(t *testing.T) {
+		tests := []struct {
+			name         string
+			method       string
+			path         string
+			body         interface{}
+			header       []string
+			wantStatus   int
+			wantScimType string
+		}{
+			{name: "duplicate", method: http.MethodPost, path: "Groups", body: groupRepresentation{DisplayName: "Staff"}, wantStatus: http.StatusConflict, wantScimType: SCIM_TYPE_UNIQUENESS},
+			{name: "unknown member", method: http.MethodPost, path: "Groups", body: groupRepresentation{DisplayName: "Engineers", Members: []memberRepresentation{{Value: "unknown"}}}, wantStatus: http.StatusNotFound},
+			{name: "unknown member type", method: http.MethodPost, path: "Groups", body: groupRepresentation{DisplayName: "Engineers", Members: []memberRepresentation{{Value: "zoeusername", Type: "Device"}}}, wantStatus: http.StatusBadRequest, wantScimType: SCIM_TYPE_INVALID_VALUE},
+			{name: "rename", method: http.MethodPatch, path: "Groups/Staff", body: patchRequest{Schemas: []string{SCHEMA_PATCH_OP}, Operations: []patchOperation{{Op: "replace", Path: "displayName", Value: []byte(`"Engineers"`)}}}, wantStatus: http.StatusBadRequest, wantScimType: SCIM_TYPE_MUTABILITY},
+			{name: "add selected member", method: http.MethodPatch, path: "Groups/Staff", body: patchRequest{Schemas: []string{SCHEMA_PATCH_OP}, Operations: []patchOperation{{Op: "add", Path: `members[value eq "zoeusername"]`}}}, wantStatus: http.StatusBadRequest, wantScimType: SCIM_TYPE_INVALID_PATH},
+			{name: "stale version", method: http.MethodPatch, path: "Groups/Staff", body: patchRequest{Schemas: []string{SCHEMA_PATCH_OP}, Operations: []patchOperation{{Op: "remove", Path: "members"}}}, header: []string{"If-Match", `W/"0"`}, wantStatus: http.StatusPreconditionFailed},
+			{name: "not found", method: http.MethodGet, path: "Groups/unknown", wantStatus: http.StatusNotFound},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				fixture := newFixture(t)
+				fixture.createUser(t, "zoeusername")
+				fixture.createGroup(t, "Staff", memberRepresentation{Value: "zoeusername"})
+
+				var got errorRepresentation
+				decode(t, fixture.serve(t, tt.method, tt.path, tt.body, tt.header...), tt.wantStatus, &got)
+				if got.ScimType != tt.wantScimType || got.Detail == "" {
+					t.Errorf("got %v, want scimType %q with a detail", got, tt.wantScimType)
+				}
+
+				var group groupRepresentation
+				decode(t, fixture.serve(t, http.MethodGet, "Groups/Staff", nil), http.StatusOK, &group)
+				if diff := cmp.Diff([]memberRepresentation{{Value: "zoeusername", Type: RESOURCE_TYPE_USER}}, group.Members); diff != "" {
+					t.Errorf("a failed request must leave the group unchanged (-want, +got):\n%s", diff)
+				}
+			})
+		}
+	})
+}
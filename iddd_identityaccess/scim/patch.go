@@ -0,0 +1,215 @@
+package scim
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+)
+
+const (
+	PATCH_OP_ADD     = "add"
+	PATCH_OP_REPLACE = "replace"
+	PATCH_OP_REMOVE  = "remove"
+)
+
+type patchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []patchOperation `json:"Operations"`
+}
+
+// patchOperation ignores the attributes the aggregates do not hold, as identity providers patch them regardless.
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+func readPatchRequest(aResponseWriter http.ResponseWriter, aRequest *http.Request, aPatchRequest *patchRequest) error {
+	if err := readScim(aResponseWriter, aRequest, aPatchRequest); err != nil {
+		return err
+	}
+	if !slices.Contains(aPatchRequest.Schemas, SCHEMA_PATCH_OP) {
+		return newScimError(http.StatusBadRequest, SCIM_TYPE_INVALID_SYNTAX, "The request must use the PatchOp schema.")
+	}
+	for i, operation := range aPatchRequest.Operations {
+		// Some identity providers capitalize the operation.
+		aPatchRequest.Operations[i].Op = strings.ToLower(operation.Op)
+		switch aPatchRequest.Operations[i].Op {
+		case PATCH_OP_ADD, PATCH_OP_REPLACE:
+		case PATCH_OP_REMOVE:
+			if operation.Path == "" {
+				return newScimError(http.StatusBadRequest, SCIM_TYPE_NO_TARGET, "The remove operation requires a path.")
+			}
+		default:
+			return newScimError(http.StatusBadRequest, SCIM_TYPE_INVALID_SYNTAX, "The operation "+operation.Op+" is unknown.")
+		}
+	}
+	return nil
+}
+
+// attributesOf splits an operation without a path into one operation per attribute of its value.
+func attributesOf(anOperation patchOperation) ([]patchOperation, error) {
+	if anOperation.Path != "" {
+		return []patchOperation{anOperation}, nil
+	}
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(anOperation.Value, &values); err != nil {
+		return nil, newScimError(http.StatusBadRequest, SCIM_TYPE_INVALID_VALUE, "The value of an operation without a path must be an object.")
+	}
+	paths := make([]string, 0, len(values))
+	for path := range values {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	operations := make([]patchOperation, len(paths))
+	for i, path := range paths {
+		operations[i] = patchOperation{Op: anOperation.Op, Path: path, Value: values[path]}
+	}
+	return operations, nil
+}
+
+func (patchOperation patchOperation) applyToUser(aUserRepresentation *userRepresentation) error {
+	operations, err := attributesOf(patchOperation)
+	if err != nil {
+		return err
+	}
+	for _, operation := range operations {
+		if err := operation.applyAttributeToUser(aUserRepresentation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (patchOperation patchOperation) applyAttributeToUser(aUserRepresentation *userRepresentation) error {
+	path := strings.ToLower(patchOperation.Path)
+	if patchOperation.Op == PATCH_OP_REMOVE {
+		switch {
+		case path == "active":
+			active := false
+			aUserRepresentation.Active = &active
+		case path == "username" || path == "password" || strings.HasPrefix(path, "name") || strings.HasPrefix(path, "emails"):
+			return newScimError(http.StatusBadRequest, SCIM_TYPE_MUTABILITY, "The attribute "+patchOperation.Path+" is required.")
+		}
+		return nil
+	}
+
+	switch {
+	case path == "active":
+		active, err := patchOperation.booleanValue()
+		if err != nil {
+			return err
+		}
+		aUserRepresentation.Active = &active
+	case path == "username":
+		return patchOperation.decodeValue(&aUserRepresentation.UserName)
+	case path == "password":
+		return patchOperation.decodeValue(&aUserRepresentation.Password)
+	case path == "name":
+		var name nameRepresentation
+		if err := patchOperation.decodeValue(&name); err != nil {
+			return err
+		}
+		if name.GivenName != "" {
+			aUserRepresentation.Name.GivenName = name.GivenName
+		}
+		if name.FamilyName != "" {
+			aUserRepresentation.Name.FamilyName = name.FamilyName
+		}
+	case path == "name.givenname":
+		return patchOperation.decodeValue(&aUserRepresentation.Name.GivenName)
+	case path == "name.familyname":
+		return patchOperation.decodeValue(&aUserRepresentation.Name.FamilyName)
+	case path == "emails":
+		return patchOperation.decodeValue(&aUserRepresentation.Emails)
+	case strings.HasPrefix(path, "emails[") && strings.HasSuffix(path, "].value"):
+		// The person holds a single address, so whichever email is selected becomes it.
+		var address string
+		if err := patchOperation.decodeValue(&address); err != nil {
+			return err
+		}
+		aUserRepresentation.Emails = []emailRepresentation{{Value: address, Type: "work", Primary: true}}
+	}
+	return nil
+}
+
+func (patchOperation patchOperation) applyToGroup(aGroupRepresentation *groupRepresentation) error {
+	operations, err := attributesOf(patchOperation)
+	if err != nil {
+		return err
+	}
+	for _, operation := range operations {
+		if err := operation.applyAttributeToGroup(aGroupRepresentation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (patchOperation patchOperation) applyAttributeToGroup(aGroupRepresentation *groupRepresentation) error {
+	path := strings.ToLower(patchOperation.Path)
+	switch {
+	case path == "displayname":
+		if patchOperation.Op == PATCH_OP_REMOVE {
+			return newScimError(http.StatusBadRequest, SCIM_TYPE_MUTABILITY, "The attribute displayName is required.")
+		}
+		return patchOperation.decodeValue(&aGroupRepresentation.DisplayName)
+	case path == "members":
+		var members []memberRepresentation
+		if len(patchOperation.Value) > 0 && string(patchOperation.Value) != "null" {
+			if err := patchOperation.decodeValue(&members); err != nil {
+				return err
+			}
+		}
+		switch patchOperation.Op {
+		case PATCH_OP_ADD:
+			aGroupRepresentation.Members = append(aGroupRepresentation.Members, members...)
+		case PATCH_OP_REPLACE:
+			aGroupRepresentation.Members = members
+		case PATCH_OP_REMOVE:
+			if members == nil {
+				aGroupRepresentation.Members = nil
+			}
+			for _, member := range members {
+				aGroupRepresentation.removeMember(member.Value)
+			}
+		}
+	case strings.HasPrefix(path, "members[") && strings.HasSuffix(path, "]"):
+		if patchOperation.Op != PATCH_OP_REMOVE {
+			return newScimError(http.StatusBadRequest, SCIM_TYPE_INVALID_PATH, "Only the remove operation may select members.")
+		}
+		_, value, err := parseFilter(patchOperation.Path[len("members["):len(patchOperation.Path)-1], "value")
+		if err != nil {
+			return newScimError(http.StatusBadRequest, SCIM_TYPE_INVALID_PATH, "The members must be selected with value eq.")
+		}
+		aGroupRepresentation.removeMember(value)
+	}
+	return nil
+}
+
+func (patchOperation patchOperation) decodeValue(aValue interface{}) error {
+	if err := json.Unmarshal(patchOperation.Value, aValue); err != nil {
+		return newScimError(http.StatusBadRequest, SCIM_TYPE_INVALID_VALUE, "The value of "+patchOperation.Path+" is invalid.")
+	}
+	return nil
+}
+
+// booleanValue also accepts the strings "True" and "False", which some identity providers send for active.
+func (patchOperation patchOperation) booleanValue() (bool, error) {
+	var value bool
+	if err := json.Unmarshal(patchOperation.Value, &value); err == nil {
+		return value, nil
+	}
+	var text string
+	if err := json.Unmarshal(patchOperation.Value, &text); err == nil {
+		switch strings.ToLower(text) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+	}
+	return false, newScimError(http.StatusBadRequest, SCIM_TYPE_INVALID_VALUE, "The value of "+patchOperation.Path+" must be a boolean.")
+}
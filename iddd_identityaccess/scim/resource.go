@@ -0,0 +1,199 @@
+package scim
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_common/problem"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/application"
+)
+
+const (
+	CONTENT_TYPE               = "application/scim+json"
+	MAXIMUM_REQUEST_BODY_BYTES = 1 << 20
+	MAXIMUM_RESULTS            = 100
+
+	SCHEMA_USER                    = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SCHEMA_GROUP                   = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SCHEMA_SERVICE_PROVIDER_CONFIG = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	SCHEMA_LIST_RESPONSE           = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SCHEMA_PATCH_OP                = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SCHEMA_ERROR                   = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// Resource serves SCIM 2.0 below /scim/v2/{tenantId}, so that every tenant has its own base path authenticated by its provisioning token.
+type Resource struct {
+	identityApplicationService *application.IdentityApplicationService
+	translator                 *problem.Translator
+	mux                        *http.ServeMux
+}
+
+func NewResource(anIdentityApplicationService *application.IdentityApplicationService) *Resource {
+	resource := &Resource{identityApplicationService: anIdentityApplicationService, translator: problem.NewTranslator(), mux: http.NewServeMux()}
+
+	resource.mux.HandleFunc("GET /scim/v2/{tenantId}/ServiceProviderConfig", resource.serviceProviderConfig)
+
+	resource.mux.HandleFunc("GET /scim/v2/{tenantId}/Users", resource.authenticated(resource.allUsers))
+	resource.mux.HandleFunc("POST /scim/v2/{tenantId}/Users", resource.authenticated(resource.createUser))
+	resource.mux.HandleFunc("GET /scim/v2/{tenantId}/Users/{id}", resource.authenticated(resource.user))
+	resource.mux.HandleFunc("PUT /scim/v2/{tenantId}/Users/{id}", resource.authenticated(resource.replaceUser))
+	resource.mux.HandleFunc("PATCH /scim/v2/{tenantId}/Users/{id}", resource.authenticated(resource.patchUser))
+
+	resource.mux.HandleFunc("GET /scim/v2/{tenantId}/Groups", resource.authenticated(resource.allGroups))
+	resource.mux.HandleFunc("POST /scim/v2/{tenantId}/Groups", resource.authenticated(resource.createGroup))
+	resource.mux.HandleFunc("GET /scim/v2/{tenantId}/Groups/{id}", resource.authenticated(resource.group))
+	resource.mux.HandleFunc("PUT /scim/v2/{tenantId}/Groups/{id}", resource.authenticated(resource.replaceGroup))
+	resource.mux.HandleFunc("PATCH /scim/v2/{tenantId}/Groups/{id}", resource.authenticated(resource.patchGroup))
+
+	return resource
+}
+
+func (resource *Resource) ServeHTTP(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	resource.mux.ServeHTTP(aResponseWriter, aRequest)
+}
+
+func (resource *Resource) authenticated(aHandler http.HandlerFunc) http.HandlerFunc {
+	return func(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+		plainToken, ok := strings.CutPrefix(aRequest.Header.Get("Authorization"), "Bearer ")
+		if !ok {
+			resource.writeError(aResponseWriter, aRequest, application.ErrProvisioningTokenInvalid)
+			return
+		}
+		if err := resource.identityApplicationService.AuthenticateProvisioning(aRequest.PathValue("tenantId"), plainToken); err != nil {
+			resource.writeError(aResponseWriter, aRequest, err)
+			return
+		}
+		aHandler(aResponseWriter, aRequest)
+	}
+}
+
+type serviceProviderConfigRepresentation struct {
+	Schemas               []string                             `json:"schemas"`
+	Patch                 supportedRepresentation              `json:"patch"`
+	Bulk                  supportedRepresentation              `json:"bulk"`
+	Filter                filterSupportedRepresentation        `json:"filter"`
+	ChangePassword        supportedRepresentation              `json:"changePassword"`
+	Sort                  supportedRepresentation              `json:"sort"`
+	Etag                  supportedRepresentation              `json:"etag"`
+	AuthenticationSchemes []authenticationSchemeRepresentation `json:"authenticationSchemes"`
+}
+
+type supportedRepresentation struct {
+	Supported bool `json:"supported"`
+}
+
+type filterSupportedRepresentation struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults"`
+}
+
+type authenticationSchemeRepresentation struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func (resource *Resource) serviceProviderConfig(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	writeScim(aResponseWriter, http.StatusOK, serviceProviderConfigRepresentation{
+		Schemas: []string{SCHEMA_SERVICE_PROVIDER_CONFIG},
+		Patch:   supportedRepresentation{Supported: true},
+		Filter:  filterSupportedRepresentation{Supported: true, MaxResults: MAXIMUM_RESULTS},
+		Etag:    supportedRepresentation{Supported: true},
+		AuthenticationSchemes: []authenticationSchemeRepresentation{
+			{Type: "oauthbearertoken", Name: "Provisioning Token", Description: "The provisioning token issued to the tenant."},
+		},
+	})
+}
+
+type metaRepresentation struct {
+	ResourceType string `json:"resourceType"`
+	Version      string `json:"version"`
+	Location     string `json:"location"`
+}
+
+type listResponseRepresentation struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults int           `json:"totalResults"`
+	StartIndex   int           `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+// newListResponse pages through aResources with the 1-based startIndex and the count of the request.
+func newListResponse(aRequest *http.Request, aResources []interface{}) listResponseRepresentation {
+	startIndex, err := strconv.Atoi(aRequest.URL.Query().Get("startIndex"))
+	if err != nil || startIndex < 1 {
+		startIndex = 1
+	}
+	count, err := strconv.Atoi(aRequest.URL.Query().Get("count"))
+	if err != nil || count > MAXIMUM_RESULTS {
+		count = MAXIMUM_RESULTS
+	}
+	if count < 0 {
+		count = 0
+	}
+
+	page := []interface{}{}
+	if start := startIndex - 1; start < len(aResources) {
+		page = aResources[start:min(start+count, len(aResources))]
+	}
+	return listResponseRepresentation{Schemas: []string{SCHEMA_LIST_RESPONSE}, TotalResults: len(aResources), StartIndex: startIndex, ItemsPerPage: len(page), Resources: page}
+}
+
+func entityTag(aConcurrencyVersion int) string {
+	return `W/"` + strconv.Itoa(aConcurrencyVersion) + `"`
+}
+
+// checkPreconditions answers If-Match on writes and If-None-Match on reads, returning false once it has written the response.
+func (resource *Resource) checkPreconditions(aResponseWriter http.ResponseWriter, aRequest *http.Request, aConcurrencyVersion int) bool {
+	entityTag := entityTag(aConcurrencyVersion)
+	if ifMatch := aRequest.Header.Get("If-Match"); ifMatch != "" && ifMatch != "*" && !containsEntityTag(ifMatch, entityTag) {
+		resource.writeError(aResponseWriter, aRequest, newScimError(http.StatusPreconditionFailed, "", "The resource has been modified since it was read."))
+		return false
+	}
+	if ifNoneMatch := aRequest.Header.Get("If-None-Match"); ifNoneMatch != "" && aRequest.Method == http.MethodGet && containsEntityTag(ifNoneMatch, entityTag) {
+		aResponseWriter.Header().Set("ETag", entityTag)
+		aResponseWriter.WriteHeader(http.StatusNotModified)
+		return false
+	}
+	return true
+}
+
+// containsEntityTag compares weakly, as every version this resource hands out is a weak validator.
+func containsEntityTag(aHeader string, anEntityTag string) bool {
+	for _, candidate := range strings.Split(aHeader, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(anEntityTag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+func location(aRequest *http.Request, aResourceType string, anId string) string {
+	scheme := "http"
+	if aRequest.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + aRequest.Host + "/scim/v2/" + url.PathEscape(aRequest.PathValue("tenantId")) + "/" + aResourceType + "/" + url.PathEscape(anId)
+}
+
+// readScim accepts attributes it does not know, as identity providers send many the aggregates do not hold.
+func readScim(aResponseWriter http.ResponseWriter, aRequest *http.Request, aValue interface{}) error {
+	if err := json.NewDecoder(http.MaxBytesReader(aResponseWriter, aRequest.Body, MAXIMUM_REQUEST_BODY_BYTES)).Decode(aValue); err != nil {
+		return newScimError(http.StatusBadRequest, SCIM_TYPE_INVALID_SYNTAX, "The request body is malformed.")
+	}
+	return nil
+}
+
+func writeScim(aResponseWriter http.ResponseWriter, aStatus int, aValue interface{}) {
+	aResponseWriter.Header().Set("Content-Type", CONTENT_TYPE)
+	aResponseWriter.WriteHeader(aStatus)
+	if err := json.NewEncoder(aResponseWriter).Encode(aValue); err != nil {
+		log.Printf("scim.writeScim(): %v", err)
+	}
+}
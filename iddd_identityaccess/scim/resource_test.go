@@ -0,0 +1,207 @@
+package scim
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/application"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/access"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/infrastructure/persistence"
+	"github.com/google/go-cmp/cmp"
+)
+
+type discardNotifier struct{}
+
+func (discardNotifier) Notify(aNotification *application.Notification) error {
+	return nil
+}
+
+type fixture struct {
+	resource                   *Resource
+	identityApplicationService *application.IdentityApplicationService
+	tenantId                   string
+	token                      string
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	userRepository := persistence.NewInMemoryUserRepository()
+	passwordResetService := identity.NewPasswordResetService(persistence.NewInMemoryPasswordResetTokenRepository(), userRepository, time.Hour)
+	emailVerificationService := identity.NewEmailVerificationService(persistence.NewInMemoryEmailVerificationTokenRepository(), userRepository, time.Hour, time.Minute)
+	groupRepository := persistence.NewInMemoryGroupRepository()
+	roleAssignmentService := access.NewRoleAssignmentService(persistence.NewInMemoryRoleRepository(), identity.NewGroupMemberService(userRepository, groupRepository))
+	identityApplicationService := application.NewIdentityApplicationService(persistence.NewInMemoryTenantRepository(), userRepository, groupRepository, roleAssignmentService, *identity.DefaultNameLengthPolicy(), passwordResetService, emailVerificationService, discardNotifier{})
+
+	tenant, err := identityApplicationService.ProvisionTenant("TenantName")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenantId := tenant.TenantId()
+	token, err := identityApplicationService.IssueProvisioningToken(tenantId.Id())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &fixture{resource: NewResource(identityApplicationService), identityApplicationService: identityApplicationService, tenantId: tenantId.Id(), token: token}
+}
+
+func (fixture *fixture) path(aResourcePath string) string {
+	return "/scim/v2/" + fixture.tenantId + "/" + aResourcePath
+}
+
+// serve authenticates with the tenant's token and sends aHeader as name and value pairs.
+func (fixture *fixture) serve(t *testing.T, aMethod string, aResourcePath string, aBody interface{}, aHeader ...string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var body bytes.Buffer
+	if aBody != nil {
+		if err := json.NewEncoder(&body).Encode(aBody); err != nil {
+			t.Fatal(err)
+		}
+	}
+	request := httptest.NewRequest(aMethod, fixture.path(aResourcePath), &body)
+	request.Header.Set("Authorization", "Bearer "+fixture.token)
+	for i := 0; i+1 < len(aHeader); i += 2 {
+		request.Header.Set(aHeader[i], aHeader[i+1])
+	}
+	responseRecorder := httptest.NewRecorder()
+	fixture.resource.ServeHTTP(responseRecorder, request)
+	return responseRecorder
+}
+
+func (fixture *fixture) createUser(t *testing.T, aUsername string) userRepresentation {
+	t.Helper()
+
+	var user userRepresentation
+	decode(t, fixture.serve(t, http.MethodPost, "Users", newUserRequest(aUsername)), http.StatusCreated, &user)
+	return user
+}
+
+func newUserRequest(aUsername string) userRepresentation {
+	return userRepresentation{
+		Schemas:  []string{SCHEMA_USER},
+		UserName: aUsername,
+		Name:     nameRepresentation{GivenName: "Zoe", FamilyName: "Doe"},
+		Emails:   []emailRepresentation{{Value: aUsername + "@saasovation.com", Type: "work", Primary: true}},
+	}
+}
+
+func decode(t *testing.T, aResponseRecorder *httptest.ResponseRecorder, aStatus int, aValue interface{}) {
+	t.Helper()
+
+	if aResponseRecorder.Code != aStatus {
+		t.Fatalf("got status %d, want %d: %s", aResponseRecorder.Code, aStatus, aResponseRecorder.Body.String())
+	}
+	if aValue == nil {
+		return
+	}
+	if got := aResponseRecorder.Header().Get("Content-Type"); got != CONTENT_TYPE {
+		t.Errorf("got content type %s, want %s", got, CONTENT_TYPE)
+	}
+	if err := json.NewDecoder(aResponseRecorder.Body).Decode(aValue); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	fixture := newFixture(t)
+	other := newFixture(t)
+
+	tests := []struct {
+		name          string
+		authorization string
+		tenantId      string
+	}{
+		{name: "missing token", authorization: "", tenantId: fixture.tenantId},
+		{name: "basic authentication", authorization: "Basic " + fixture.token, tenantId: fixture.tenantId},
+		{name: "wrong token", authorization: "Bearer wrong", tenantId: fixture.tenantId},
+		{name: "token of another tenant", authorization: "Bearer " + other.token, tenantId: fixture.tenantId},
+		{name: "unknown tenant", authorization: "Bearer " + fixture.token, tenantId: "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/scim/v2/"+tt.tenantId+"/Users", nil)
+			if tt.authorization != "" {
+				request.Header.Set("Authorization", tt.authorization)
+			}
+			responseRecorder := httptest.NewRecorder()
+			fixture.resource.ServeHTTP(responseRecorder, request)
+
+			var got errorRepresentation
+			decode(t, responseRecorder, http.StatusUnauthorized, &got)
+			want := errorRepresentation{Schemas: []string{SCHEMA_ERROR}, Status: "401", Detail: application.ErrProvisioningTokenInvalid.Error()}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("mismatch (-want, +got):\n%s", diff)
+			}
+			if responseRecorder.Header().Get("WWW-Authenticate") == "" {
+				t.Errorf("WWW-Authenticate must name the bearer scheme")
+			}
+		})
+	}
+	t.Run("revoked token", func(t *testing.T) {
+		fixture := newFixture(t)
+		if err := fixture.identityApplicationService.RevokeProvisioningToken(fixture.tenantId); err != nil {
+			t.Fatal(err)
+		}
+		decode(t, fixture.serve(t, http.MethodGet, "Users", nil), http.StatusUnauthorized, nil)
+	})
+}
+
+func TestServiceProviderConfig(t *testing.T) {
+	fixture := newFixture(t)
+	responseRecorder := httptest.NewRecorder()
+	fixture.resource.ServeHTTP(responseRecorder, httptest.NewRequest(http.MethodGet, fixture.path("ServiceProviderConfig"), nil))
+
+	var got serviceProviderConfigRepresentation
+	decode(t, responseRecorder, http.StatusOK, &got)
+	if !got.Patch.Supported || !got.Filter.Supported || !got.Etag.Supported || got.Bulk.Supported {
+		t.Errorf("got %v, want patch, filter and etag support only", got)
+	}
+}
+
+func TestListPagination(t *testing.T) {
+	fixture := newFixture(t)
+	for _, username := range []string{"amyusername", "bobusername", "zoeusername"} {
+		fixture.createUser(t, username)
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		wantTotal int
+		wantStart int
+		wantIds   []string
+	}{
+		{name: "all", query: "", wantTotal: 3, wantStart: 1, wantIds: []string{"amyusername", "bobusername", "zoeusername"}},
+		{name: "second page", query: "?startIndex=2&count=1", wantTotal: 3, wantStart: 2, wantIds: []string{"bobusername"}},
+		{name: "beyond the end", query: "?startIndex=5", wantTotal: 3, wantStart: 5, wantIds: []string{}},
+		{name: "count only", query: "?count=0", wantTotal: 3, wantStart: 1, wantIds: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got struct {
+				Schemas      []string             `json:"schemas"`
+				TotalResults int                  `json:"totalResults"`
+				StartIndex   int                  `json:"startIndex"`
+				ItemsPerPage int                  `json:"itemsPerPage"`
+				Resources    []userRepresentation `json:"Resources"`
+			}
+			decode(t, fixture.serve(t, http.MethodGet, "Users"+tt.query, nil), http.StatusOK, &got)
+			ids := []string{}
+			for _, user := range got.Resources {
+				ids = append(ids, user.Id)
+			}
+			if got.TotalResults != tt.wantTotal || got.StartIndex != tt.wantStart || got.ItemsPerPage != len(tt.wantIds) {
+				t.Errorf("got total %d, start %d and %d items, want %d, %d and %d", got.TotalResults, got.StartIndex, got.ItemsPerPage, tt.wantTotal, tt.wantStart, len(tt.wantIds))
+			}
+			if diff := cmp.Diff(tt.wantIds, ids); diff != "" {
+				t.Errorf("mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
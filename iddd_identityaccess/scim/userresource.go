@@ -0,0 +1,254 @@
+package scim
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/application"
+	"github.com/Msksgm/go-IDDD-05-entity/iddd_identityaccess/domain/model/identity"
+)
+
+const RESOURCE_TYPE_USER = "User"
+
+// userRepresentation is read from and written to identity providers alike; the password is never written.
+type userRepresentation struct {
+	Schemas  []string              `json:"schemas"`
+	Id       string                `json:"id,omitempty"`
+	UserName string                `json:"userName"`
+	Name     nameRepresentation    `json:"name"`
+	Emails   []emailRepresentation `json:"emails"`
+	Active   *bool                 `json:"active,omitempty"`
+	Password string                `json:"password,omitempty"`
+	Meta     *metaRepresentation   `json:"meta,omitempty"`
+}
+
+type nameRepresentation struct {
+	GivenName  string `json:"givenName"`
+	FamilyName string `json:"familyName"`
+}
+
+type emailRepresentation struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+func newUserRepresentation(aRequest *http.Request, aUser *identity.User) userRepresentation {
+	person := aUser.Person()
+	active := aUser.IsEnabled()
+	return userRepresentation{
+		Schemas:  []string{SCHEMA_USER},
+		Id:       aUser.Username(),
+		UserName: aUser.Username(),
+		Name:     nameRepresentation{GivenName: person.Name().FirstName(), FamilyName: person.Name().LastName()},
+		Emails:   []emailRepresentation{{Value: person.EmailAddress().Address(), Type: "work", Primary: true}},
+		Active:   &active,
+		Meta:     &metaRepresentation{ResourceType: RESOURCE_TYPE_USER, Version: entityTag(aUser.ConcurrencyVersion()), Location: location(aRequest, "Users", aUser.Username())},
+	}
+}
+
+// primaryEmailAddress falls back to the first address, as the person holds only one.
+func (userRepresentation *userRepresentation) primaryEmailAddress() string {
+	for _, email := range userRepresentation.Emails {
+		if email.Primary {
+			return email.Value
+		}
+	}
+	if len(userRepresentation.Emails) > 0 {
+		return userRepresentation.Emails[0].Value
+	}
+	return ""
+}
+
+func (resource *Resource) allUsers(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	var username string
+	if filter := aRequest.URL.Query().Get("filter"); filter != "" {
+		_, value, err := parseFilter(filter, "userName")
+		if err != nil {
+			resource.writeError(aResponseWriter, aRequest, err)
+			return
+		}
+		username = value
+	}
+
+	users, err := resource.identityApplicationService.AllUsers(aRequest.PathValue("tenantId"))
+	if err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	representations := []interface{}{}
+	for _, user := range users {
+		// userName is not case exact in SCIM.
+		if username == "" || strings.EqualFold(user.Username(), username) {
+			representations = append(representations, newUserRepresentation(aRequest, user))
+		}
+	}
+	writeScim(aResponseWriter, http.StatusOK, newListResponse(aRequest, representations))
+}
+
+// createUser generates a password the user never learns when the identity provider sends none, since such users sign in through the provider.
+func (resource *Resource) createUser(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	var representation userRepresentation
+	if err := readScim(aResponseWriter, aRequest, &representation); err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	password := representation.Password
+	if password == "" {
+		var err error
+		if password, err = randomPassword(); err != nil {
+			resource.writeError(aResponseWriter, aRequest, err)
+			return
+		}
+	}
+
+	user, err := resource.identityApplicationService.ProvisionUser(application.ProvisionUserCommand{
+		TenantId:     aRequest.PathValue("tenantId"),
+		Username:     representation.UserName,
+		Password:     password,
+		FirstName:    representation.Name.GivenName,
+		LastName:     representation.Name.FamilyName,
+		EmailAddress: representation.primaryEmailAddress(),
+		Enabled:      representation.Active == nil || *representation.Active,
+	})
+	if err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	resource.writeUser(aResponseWriter, aRequest, http.StatusCreated, user)
+}
+
+func (resource *Resource) user(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	user, err := resource.identityApplicationService.User(aRequest.PathValue("tenantId"), aRequest.PathValue("id"))
+	if err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	if !resource.checkPreconditions(aResponseWriter, aRequest, user.ConcurrencyVersion()) {
+		return
+	}
+	resource.writeUser(aResponseWriter, aRequest, http.StatusOK, user)
+}
+
+func (resource *Resource) replaceUser(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	var representation userRepresentation
+	if err := readScim(aResponseWriter, aRequest, &representation); err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	resource.changeUser(aResponseWriter, aRequest, func(userRepresentation *userRepresentation) error {
+		*userRepresentation = representation
+		return nil
+	})
+}
+
+func (resource *Resource) patchUser(aResponseWriter http.ResponseWriter, aRequest *http.Request) {
+	var patchRequest patchRequest
+	if err := readPatchRequest(aResponseWriter, aRequest, &patchRequest); err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	resource.changeUser(aResponseWriter, aRequest, func(userRepresentation *userRepresentation) error {
+		for _, operation := range patchRequest.Operations {
+			if err := operation.applyToUser(userRepresentation); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// changeUser lets aChange edit the current representation and then applies whatever differs through the application service.
+func (resource *Resource) changeUser(aResponseWriter http.ResponseWriter, aRequest *http.Request, aChange func(*userRepresentation) error) {
+	tenantId := aRequest.PathValue("tenantId")
+	user, err := resource.identityApplicationService.User(tenantId, aRequest.PathValue("id"))
+	if err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	if !resource.checkPreconditions(aResponseWriter, aRequest, user.ConcurrencyVersion()) {
+		return
+	}
+
+	representation := newUserRepresentation(aRequest, user)
+	if err := aChange(&representation); err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	if err := resource.applyUser(tenantId, user, representation); err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+
+	user, err = resource.identityApplicationService.User(tenantId, user.Username())
+	if err != nil {
+		resource.writeError(aResponseWriter, aRequest, err)
+		return
+	}
+	resource.writeUser(aResponseWriter, aRequest, http.StatusOK, user)
+}
+
+// applyUser validates the whole representation before it changes anything, so that a rejected request leaves the user untouched.
+func (resource *Resource) applyUser(aTenantId string, aUser *identity.User, aRepresentation userRepresentation) error {
+	if aRepresentation.UserName != "" && aRepresentation.UserName != aUser.Username() {
+		return newScimError(http.StatusBadRequest, SCIM_TYPE_MUTABILITY, "The userName must not change.")
+	}
+	fullName, err := identity.NewFullName(aRepresentation.Name.GivenName, aRepresentation.Name.FamilyName)
+	if err != nil {
+		return err
+	}
+	emailAddress, err := identity.NewEmailAddress(aRepresentation.primaryEmailAddress())
+	if err != nil {
+		return err
+	}
+
+	username := aUser.Username()
+	person := aUser.Person()
+	if !fullName.Equals(person.Name()) {
+		if err := resource.identityApplicationService.ChangeUserPersonalName(aTenantId, username, fullName.FirstName(), fullName.LastName()); err != nil {
+			return err
+		}
+	}
+	if emailAddress.Address() != person.EmailAddress().Address() {
+		if err := resource.identityApplicationService.ChangeUserEmailAddress(aTenantId, username, emailAddress.Address()); err != nil {
+			return err
+		}
+	}
+	if aRepresentation.Password != "" {
+		if err := resource.identityApplicationService.ResetUserPassword(aTenantId, username, aRepresentation.Password); err != nil {
+			return err
+		}
+	}
+	if aRepresentation.Active != nil && *aRepresentation.Active != aUser.IsEnabled() {
+		// Deactivating keeps the enablement period, whereas activating starts an indefinite one.
+		enablement := aUser.Enablement()
+		startDate, endDate := enablement.StartDate(), enablement.EndDate()
+		if *aRepresentation.Active {
+			startDate, endDate = time.Now(), identity.INDEFINITE_END_DATE
+		}
+		if err := resource.identityApplicationService.DefineUserEnablement(aTenantId, username, *aRepresentation.Active, startDate, endDate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (resource *Resource) writeUser(aResponseWriter http.ResponseWriter, aRequest *http.Request, aStatus int, aUser *identity.User) {
+	representation := newUserRepresentation(aRequest, aUser)
+	aResponseWriter.Header().Set("ETag", representation.Meta.Version)
+	if aStatus == http.StatusCreated {
+		aResponseWriter.Header().Set("Location", representation.Meta.Location)
+	}
+	writeScim(aResponseWriter, aStatus, representation)
+}
+
+func randomPassword() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
@@ -0,0 +1,154 @@
+package scim
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestUserResource(t *testing.T) {
+	t.Run("create and get", func(t *testing.T) {
+		fixture := newFixture(t)
+		responseRecorder := fixture.serve(t, http.MethodPost, "Users", newUserRequest("zoeusername"))
+
+		var created userRepresentation
+		decode(t, responseRecorder, http.StatusCreated, &created)
+		active := true
+		want := userRepresentation{
+			Schemas:  []string{SCHEMA_USER},
+			Id:       "zoeusername",
+			UserName: "zoeusername",
+			Name:     nameRepresentation{GivenName: "Zoe", FamilyName: "Doe"},
+			Emails:   []emailRepresentation{{Value: "zoeusername@saasovation.com", Type: "work", Primary: true}},
+			Active:   &active,
+			Meta:     &metaRepresentation{ResourceType: RESOURCE_TYPE_USER, Version: `W/"1"`, Location: "http://example.com" + fixture.path("Users/zoeusername")},
+		}
+		if diff := cmp.Diff(want, created); diff != "" {
+			t.Errorf("mismatch (-want, +got):\n%s", diff)
+		}
+		if responseRecorder.Header().Get("Location") != want.Meta.Location || responseRecorder.Header().Get("ETag") != `W/"1"` {
+			t.Errorf("got headers %v, want the location and version of the user", responseRecorder.Header())
+		}
+
+		var got userRepresentation
+		decode(t, fixture.serve(t, http.MethodGet, "Users/zoeusername", nil), http.StatusOK, &got)
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("mismatch (-want, +got):\n%s", diff)
+		}
+		decode(t, fixture.serve(t, http.MethodGet, "Users/zoeusername", nil, "If-None-Match", `W/"1"`), http.StatusNotModified, nil)
+	})
+	t.Run("create inactive", func(t *testing.T) {
+		fixture := newFixture(t)
+		request := newUserRequest("zoeusername")
+		active := false
+		request.Active = &active
+
+		var got userRepresentation
+		decode(t, fixture.serve(t, http.MethodPost, "Users", request), http.StatusCreated, &got)
+		if *got.Active {
+			t.Errorf("user must be inactive")
+		}
+	})
+	t.Run("filter", func(t *testing.T) {
+		fixture := newFixture(t)
+		fixture.createUser(t, "zoeusername")
+		fixture.createUser(t, "amyusername")
+
+		var got listResponseRepresentation
+		decode(t, fixture.serve(t, http.MethodGet, "Users?filter="+url.QueryEscape(`userName eq "ZoeUsername"`), nil), http.StatusOK, &got)
+		if got.TotalResults != 1 || got.Resources[0].(map[string]interface{})["id"] != "zoeusername" {
+			t.Errorf("got %v, want zoeusername only", got)
+		}
+
+		var document errorRepresentation
+		decode(t, fixture.serve(t, http.MethodGet, "Users?filter="+url.QueryEscape(`userName sw "zoe"`), nil), http.StatusBadRequest, &document)
+		if document.ScimType != SCIM_TYPE_INVALID_FILTER {
+			t.Errorf("got %v, want an %s error", document, SCIM_TYPE_INVALID_FILTER)
+		}
+	})
+	t.Run("replace", func(t *testing.T) {
+		fixture := newFixture(t)
+		fixture.createUser(t, "zoeusername")
+		request := newUserRequest("zoeusername")
+		request.Name = nameRepresentation{GivenName: "Zoey", FamilyName: "Roe"}
+		request.Emails = []emailRepresentation{{Value: "other@saasovation.com"}, {Value: "zoey@saasovation.com", Primary: true}}
+
+		var got userRepresentation
+		decode(t, fixture.serve(t, http.MethodPut, "Users/zoeusername", request, "If-Match", `W/"1"`), http.StatusOK, &got)
+		if got.Name != (nameRepresentation{GivenName: "Zoey", FamilyName: "Roe"}) || got.Emails[0].Value != "zoey@saasovation.com" {
+			t.Errorf("got %v, want the replaced name and primary email", got)
+		}
+		if got.Meta.Version == `W/"1"` {
+			t.Errorf("version must advance on change")
+		}
+	})
+	t.Run("patch", func(t *testing.T) {
+		fixture := newFixture(t)
+		fixture.createUser(t, "zoeusername")
+
+		var got userRepresentation
+		decode(t, fixture.serve(t, http.MethodPatch, "Users/zoeusername", patchRequest{
+			Schemas: []string{SCHEMA_PATCH_OP},
+			Operations: []patchOperation{
+				{Op: "Replace", Path: "active", Value: []byte(`"False"`)},
+				{Op: "replace", Path: "name.givenName", Value: []byte(`"Zoey"`)},
+				{Op: "replace", Path: `emails[type eq "work"].value`, Value: []byte(`"zoey@saasovation.com"`)},
+				{Op: "add", Path: "title", Value: []byte(`"Engineer"`)},
+			},
+		}), http.StatusOK, &got)
+		if *got.Active || got.Name.GivenName != "Zoey" || got.Emails[0].Value != "zoey@saasovation.com" {
+			t.Errorf("got %v, want an inactive Zoey at zoey@saasovation.com", got)
+		}
+
+		decode(t, fixture.serve(t, http.MethodPatch, "Users/zoeusername", patchRequest{
+			Schemas:    []string{SCHEMA_PATCH_OP},
+			Operations: []patchOperation{{Op: "replace", Value: []byte(`{"active": true, "name": {"familyName": "Roe"}}`)}},
+		}), http.StatusOK, &got)
+		if !*got.Active || got.Name != (nameRepresentation{GivenName: "Zoey", FamilyName: "Roe"}) {
+			t.Errorf("got %v, want an active Zoey Roe", got)
+		}
+	})
+	t.Run("fail", func(t *testing.T) {
+		tests := []struct {
+			name         string
+			method       string
+			path         string
+			body         interface{}
+			header       []string
+			wantStatus   int
+			wantScimType string
+		}{
+			{name: "duplicate", method: http.MethodPost, path: "Users", body: newUserRequest("zoeusername"), wantStatus: http.StatusConflict, wantScimType: SCIM_TYPE_UNIQUENESS},
+			{name: "invalid email address", method: http.MethodPost, path: "Users", body: userRepresentation{UserName: "amyusername", Name: nameRepresentation{GivenName: "Amy", FamilyName: "Doe"}}, wantStatus: http.StatusBadRequest, wantScimType: SCIM_TYPE_INVALID_VALUE},
+			{name: "not found", method: http.MethodGet, path: "Users/unknown", wantStatus: http.StatusNotFound},
+			{name: "stale version", method: http.MethodPut, path: "Users/zoeusername", body: newUserRequest("zoeusername"), header: []string{"If-Match", `W/"0"`}, wantStatus: http.StatusPreconditionFailed},
+			{name: "rename", method: http.MethodPut, path: "Users/zoeusername", body: newUserRequest("amyusername"), wantStatus: http.StatusBadRequest, wantScimType: SCIM_TYPE_MUTABILITY},
+			{name: "patch without schema", method: http.MethodPatch, path: "Users/zoeusername", body: patchRequest{Operations: []patchOperation{{Op: "replace", Path: "active", Value: []byte(`false`)}}}, wantStatus: http.StatusBadRequest, wantScimType: SCIM_TYPE_INVALID_SYNTAX},
+			{name: "patch unknown operation", method: http.MethodPatch, path: "Users/zoeusername", body: patchRequest{Schemas: []string{SCHEMA_PATCH_OP}, Operations: []patchOperation{{Op: "move", Path: "active"}}}, wantStatus: http.StatusBadRequest, wantScimType: SCIM_TYPE_INVALID_SYNTAX},
+			{name: "patch remove without path", method: http.MethodPatch, path: "Users/zoeusername", body: patchRequest{Schemas: []string{SCHEMA_PATCH_OP}, Operations: []patchOperation{{Op: "remove"}}}, wantStatus: http.StatusBadRequest, wantScimType: SCIM_TYPE_NO_TARGET},
+			{name: "patch remove required", method: http.MethodPatch, path: "Users/zoeusername", body: patchRequest{Schemas: []string{SCHEMA_PATCH_OP}, Operations: []patchOperation{{Op: "remove", Path: "name.familyName"}}}, wantStatus: http.StatusBadRequest, wantScimType: SCIM_TYPE_MUTABILITY},
+			{name: "patch invalid active", method: http.MethodPatch, path: "Users/zoeusername", body: patchRequest{Schemas: []string{SCHEMA_PATCH_OP}, Operations: []patchOperation{{Op: "replace", Path: "active", Value: []byte(`"maybe"`)}}}, wantStatus: http.StatusBadRequest, wantScimType: SCIM_TYPE_INVALID_VALUE},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				fixture := newFixture(t)
+				fixture.createUser(t, "zoeusername")
+
+				var got errorRepresentation
+				decode(t, fixture.serve(t, tt.method, tt.path, tt.body, tt.header...), tt.wantStatus, &got)
+				if got.ScimType != tt.wantScimType || got.Detail == "" {
+					t.Errorf("got %v, want scimType %q with a detail", got, tt.wantScimType)
+				}
+
+				var user userRepresentation
+				decode(t, fixture.serve(t, http.MethodGet, "Users/zoeusername", nil), http.StatusOK, &user)
+				if diff := cmp.Diff(newUserRequest("zoeusername"), user, cmpopts.IgnoreFields(userRepresentation{}, "Id", "Active", "Meta")); diff != "" {
+					t.Errorf("a failed request must leave the user unchanged (-want, +got):\n%s", diff)
+				}
+			})
+		}
+	})
+}